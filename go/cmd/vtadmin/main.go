@@ -48,6 +48,8 @@ var (
 	defaultClusterConfig  cluster.Config
 	enableDynamicClusters bool
 
+	schemaMigrationsStreamInterval time.Duration
+
 	rbacConfigPath string
 	enableRBAC     bool
 	disableRBAC    bool
@@ -149,10 +151,11 @@ func run(cmd *cobra.Command, args []string) {
 		fatal(err)
 	}
 	s := vtadmin.NewAPI(env, clusters, vtadmin.Options{
-		GRPCOpts:              opts,
-		HTTPOpts:              httpOpts,
-		RBAC:                  rbacConfig,
-		EnableDynamicClusters: enableDynamicClusters,
+		GRPCOpts:                       opts,
+		HTTPOpts:                       httpOpts,
+		RBAC:                           rbacConfig,
+		EnableDynamicClusters:          enableDynamicClusters,
+		SchemaMigrationsStreamInterval: schemaMigrationsStreamInterval,
 	})
 	bootSpan.Finish()
 
@@ -172,6 +175,7 @@ func registerFlags() {
 	rootCmd.Flags().Var(&clusterFileConfig, "cluster-config", "path to a yaml cluster configuration. see clusters.example.yaml") // (TODO:@amason) provide example config.
 	rootCmd.Flags().Var(&defaultClusterConfig, "cluster-defaults", "default options for all clusters")
 	rootCmd.Flags().BoolVar(&enableDynamicClusters, "enable-dynamic-clusters", false, "whether to enable dynamic clusters that are set by request header cookies or gRPC metadata")
+	rootCmd.Flags().DurationVar(&schemaMigrationsStreamInterval, "schema-migrations-stream-interval", time.Second, "how often each schema migrations stream polls the clusters for progress changes")
 
 	// Tracing flags
 	trace.RegisterFlags(rootCmd.Flags()) // defined in go/vt/trace
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyQueryDenylist makes an ApplyQueryDenylist gRPC call to a vtctld.
+	ApplyQueryDenylist = &cobra.Command{
+		Use:   "ApplyQueryDenylist {--rules RULES | --rules-file RULES_FILE} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Applies the provided vtgate query denylist.",
+		Long: `Applies the provided vtgate query denylist, replacing the current one.
+
+vtgate matches queries against the denylist by the fingerprint of their normalized text without comments,
+as shown in its /queryz and /querylogz pages. Each rule has one of the following modes:
+  FAIL               Reject matching queries.
+  LIMIT_CONCURRENCY  Limit the number of matching queries executing concurrently in each vtgate to max_concurrency.
+  DRY_RUN            Only count and log matching queries.
+
+Example:
+  ApplyQueryDenylist --rules '{"rules": [{"fingerprint": "4f3a0b8e2c6d1a97", "mode": "FAIL", "description": "full table scan"}]}'`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyQueryDenylist,
+	}
+	// GetQueryDenylist makes a GetQueryDenylist gRPC call to a vtctld.
+	GetQueryDenylist = &cobra.Command{
+		Use:                   "GetQueryDenylist",
+		Short:                 "Displays the vtgate query denylist as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetQueryDenylist,
+	}
+)
+
+var applyQueryDenylistOptions = struct {
+	Rules         string
+	RulesFilePath string
+	Cells         []string
+	SkipRebuild   bool
+	DryRun        bool
+}{}
+
+func commandApplyQueryDenylist(cmd *cobra.Command, args []string) error {
+	if applyQueryDenylistOptions.Rules != "" && applyQueryDenylistOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyQueryDenylistOptions.Rules, applyQueryDenylistOptions.RulesFilePath)
+	}
+
+	if applyQueryDenylistOptions.Rules == "" && applyQueryDenylistOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules or --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var rulesBytes []byte
+	if applyQueryDenylistOptions.RulesFilePath != "" {
+		data, err := os.ReadFile(applyQueryDenylistOptions.RulesFilePath)
+		if err != nil {
+			return err
+		}
+
+		rulesBytes = data
+	} else {
+		rulesBytes = []byte(applyQueryDenylistOptions.Rules)
+	}
+
+	qd := &vschemapb.QueryDenylist{}
+	if err := json2.UnmarshalPB(rulesBytes, qd); err != nil {
+		return err
+	}
+	if err := querydenylist.Validate(qd); err != nil {
+		return err
+	}
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(qd)
+	if err != nil {
+		return err
+	}
+
+	if applyQueryDenylistOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have saved new QueryDenylist object:\n%s\n", data)
+
+		if applyQueryDenylistOptions.SkipRebuild {
+			fmt.Println("[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+		} else {
+			fmt.Print("[DRY RUN] Would have rebuilt the VSchema graph")
+			if len(applyQueryDenylistOptions.Cells) == 0 {
+				fmt.Print(" in all cells\n")
+			} else {
+				fmt.Printf(" in the following cells: %s.\n", strings.Join(applyQueryDenylistOptions.Cells, ", "))
+			}
+		}
+
+		return nil
+	}
+
+	_, err = client.ApplyQueryDenylist(commandCtx, &vtctldatapb.ApplyQueryDenylistRequest{
+		QueryDenylist: qd,
+		SkipRebuild:   applyQueryDenylistOptions.SkipRebuild,
+		RebuildCells:  applyQueryDenylistOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New QueryDenylist object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyQueryDenylistOptions.SkipRebuild {
+		fmt.Println("Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func commandGetQueryDenylist(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetQueryDenylist(commandCtx, &vtctldatapb.GetQueryDenylistRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.QueryDenylist)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyQueryDenylist.Flags().StringVarP(&applyQueryDenylistOptions.Rules, "rules", "r", "", "Query denylist, specified as a string")
+	ApplyQueryDenylist.Flags().StringVarP(&applyQueryDenylistOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing the query denylist specified as JSON")
+	ApplyQueryDenylist.Flags().StringSliceVarP(&applyQueryDenylistOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyQueryDenylist.Flags().BoolVar(&applyQueryDenylistOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyQueryDenylist.Flags().BoolVarP(&applyQueryDenylistOptions.DryRun, "dry-run", "d", false, "Validate the specified query denylist and note actions that would be taken, but do not actually apply it to the topo.")
+	Root.AddCommand(ApplyQueryDenylist)
+
+	Root.AddCommand(GetQueryDenylist)
+}
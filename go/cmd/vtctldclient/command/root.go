@@ -53,6 +53,7 @@ import (
 	// These imports register the topo factories to use when --server=internal.
 	_ "vitess.io/vitess/go/vt/topo/consultopo"
 	_ "vitess.io/vitess/go/vt/topo/etcd2topo"
+	_ "vitess.io/vitess/go/vt/topo/rafttopo"
 	_ "vitess.io/vitess/go/vt/topo/zk2topo"
 )
 
@@ -207,9 +208,9 @@ func getClientForCommand(cmd *cobra.Command) (vtctldclient.VtctldClient, error)
 	}
 
 	if server == useInternalVtctld {
-		ts, err := topo.OpenServer(topoOptions.implementation, strings.Join(topoOptions.globalServerAddresses, ","), topoOptions.globalRoot)
+		ts, err := openTopoServer()
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to the topology server: %v", err)
+			return nil, err
 		}
 		onTerm = append(onTerm, ts.Close)
 
@@ -222,6 +223,16 @@ func getClientForCommand(cmd *cobra.Command) (vtctldclient.VtctldClient, error)
 	return vtctldclient.New(cmd.Context(), VtctldClientProtocol, server)
 }
 
+// openTopoServer connects to the global topology server set by the --topo-*
+// flags.
+func openTopoServer() (*topo.Server, error) {
+	ts, err := topo.OpenServer(topoOptions.implementation, strings.Join(topoOptions.globalServerAddresses, ","), topoOptions.globalRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the topology server: %v", err)
+	}
+	return ts, nil
+}
+
 func init() {
 	Root.PersistentFlags().StringVar(&server, "server", "", "server to use for the connection (required)")
 	utils.SetFlagDurationVar(Root.PersistentFlags(), &actionTimeout, "action-timeout", time.Hour, "timeout to use for the command")
@@ -41,7 +41,7 @@ import (
 var (
 	// ApplySchema makes an ApplySchema gRPC call to a vtctld.
 	ApplySchema = &cobra.Command{
-		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] {--sql-file <file> | --sql <sql>} <keyspace>",
+		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] [--lint [--lint-config <file>]] {--sql-file <file> | --sql <sql>} <keyspace>",
 		Short: "Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.",
 		Long: `Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.
 
@@ -49,6 +49,7 @@ If --allow-long-unavailability is set, schema changes affecting a large number o
 --ddl-strategy is used to instruct migrations via vreplication, mysql or direct with optional parameters.
 --migration-context allows the user to specify a custom migration context for online DDL migrations.
 If --skip-preflight, SQL goes directly to shards without going through sanity checks.
+If --lint is set, the schema changes are checked against schema lint rules (e.g. every table has a primary key, utf8mb4 only), and rejected if they violate any rule. --lint-config optionally points to a JSON file configuring the rules.
 
 The --uuid and --sql flags are repeatable, so they can be passed multiple times to build a list of values.
 For --uuid, this is used like "--uuid $first_uuid --uuid $second_uuid".
@@ -75,6 +76,29 @@ For --sql, semi-colons and repeated values may be mixed, for example:
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetSchema,
 	}
+	// GetSchemaDrift makes a GetSchemaDrift gRPC call to a vtctld.
+	GetSchemaDrift = &cobra.Command{
+		Use:   "GetSchemaDrift [--reference-shard <shard>] [--include-views] <keyspace>",
+		Short: "Compares the schema of every shard in the keyspace against the canonical schema of the keyspace, and displays the diffs of drifted shards.",
+		Long: `Compares the schema of every shard in the keyspace against the canonical schema of the keyspace, and displays the diffs of drifted shards.
+
+The canonical schema is the schema of --reference-shard, if given. Otherwise it is the schema shared by most shards.
+Each diff is a statement that takes the shard's schema towards the canonical schema.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetSchemaDrift,
+	}
+	// ReconcileSchemaDrift makes a ReconcileSchemaDrift gRPC call to a vtctld.
+	ReconcileSchemaDrift = &cobra.Command{
+		Use:   "ReconcileSchemaDrift [--ddl-strategy <strategy>] [--reference-shard <shard>] [--include-views] [--caller-id <caller_id>] [--dry-run] <keyspace/shard>",
+		Short: "Submits online DDL migrations that take a drifted shard's schema into the canonical schema of its keyspace.",
+		Long: `Submits online DDL migrations that take a drifted shard's schema into the canonical schema of its keyspace.
+
+The migrations only run on the given shard. With --dry-run, the statements are displayed but not submitted.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandReconcileSchemaDrift,
+	}
 	// ReloadSchema makes a ReloadSchema gRPC call to a vtctld.
 	ReloadSchema = &cobra.Command{
 		Use:                   "ReloadSchema <tablet_alias>",
@@ -133,6 +157,8 @@ type ApplySchemaOptions struct {
 	SkipPreflight           bool
 	CallerID                string
 	BatchSize               int64
+	Lint                    bool
+	LintConfigFile          string
 }
 
 // CallerIDProto returns a *vtrpcpb.CallerID constructed from this options
@@ -168,6 +194,20 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var lintConfig string
+	if applySchemaOptions.LintConfigFile != "" {
+		if !applySchemaOptions.Lint {
+			return errors.New("--lint-config requires --lint.")
+		}
+
+		data, err := os.ReadFile(applySchemaOptions.LintConfigFile)
+		if err != nil {
+			return err
+		}
+
+		lintConfig = string(data)
+	}
+
 	cli.FinishedParsing(cmd)
 
 	cid := applySchemaOptions.CallerIDProto()
@@ -183,6 +223,8 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 		WaitReplicasTimeout: protoutil.DurationToProto(applySchemaOptions.WaitReplicasTimeout),
 		CallerId:            cid,
 		BatchSize:           applySchemaOptions.BatchSize,
+		Lint:                applySchemaOptions.Lint,
+		LintConfig:          lintConfig,
 	})
 	if err != nil {
 		return err
@@ -303,6 +345,77 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var getSchemaDriftOptions = struct {
+	ReferenceShard string
+	IncludeViews   bool
+}{}
+
+func commandGetSchemaDrift(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetSchemaDrift(commandCtx, &vtctldatapb.GetSchemaDriftRequest{
+		Keyspace:       cmd.Flags().Arg(0),
+		ReferenceShard: getSchemaDriftOptions.ReferenceShard,
+		IncludeViews:   getSchemaDriftOptions.IncludeViews,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var reconcileSchemaDriftOptions = struct {
+	DDLStrategy    string
+	ReferenceShard string
+	IncludeViews   bool
+	CallerID       string
+	DryRun         bool
+}{}
+
+func commandReconcileSchemaDrift(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var cid *vtrpcpb.CallerID
+	if reconcileSchemaDriftOptions.CallerID != "" {
+		cid = &vtrpcpb.CallerID{Principal: reconcileSchemaDriftOptions.CallerID}
+	}
+
+	resp, err := client.ReconcileSchemaDrift(commandCtx, &vtctldatapb.ReconcileSchemaDriftRequest{
+		Keyspace:       keyspace,
+		Shard:          shard,
+		ReferenceShard: reconcileSchemaDriftOptions.ReferenceShard,
+		IncludeViews:   reconcileSchemaDriftOptions.IncludeViews,
+		DdlStrategy:    reconcileSchemaDriftOptions.DDLStrategy,
+		DryRun:         reconcileSchemaDriftOptions.DryRun,
+		CallerId:       cid,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func commandReloadSchema(cmd *cobra.Command, args []string) error {
 	tabletAlias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -449,6 +562,8 @@ func init() {
 	ApplySchema.Flags().StringArrayVar(&applySchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().StringVar(&applySchemaOptions.SQLFile, "sql-file", "", "Path to a file containing semicolon-delimited SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().Int64Var(&applySchemaOptions.BatchSize, "batch-size", 0, "How many queries to batch together. Only applicable when all queries are CREATE TABLE|VIEW")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.Lint, "lint", false, "Check the schema changes against schema lint rules, and reject them if they violate any rule.")
+	ApplySchema.Flags().StringVar(&applySchemaOptions.LintConfigFile, "lint-config", "", "Path to a JSON file configuring the schema lint rules. Requires --lint. By default all built-in rules apply.")
 	Root.AddCommand(ApplySchema)
 
 	CopySchemaShard.Flags().StringSliceVar(&copySchemaShardOptions.tables, "tables", nil, "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
@@ -466,6 +581,17 @@ func init() {
 	GetSchema.Flags().BoolVarP(&getSchemaOptions.TableSchemaOnly, "table-schema-only", "", false, "Skip introspecting columns and fields metadata.")
 	Root.AddCommand(GetSchema)
 
+	GetSchemaDrift.Flags().StringVar(&getSchemaDriftOptions.ReferenceShard, "reference-shard", "", "Shard whose schema is canonical. By default, the schema shared by most shards is canonical.")
+	GetSchemaDrift.Flags().BoolVar(&getSchemaDriftOptions.IncludeViews, "include-views", false, "Includes views in compared schemas.")
+	Root.AddCommand(GetSchemaDrift)
+
+	utils.SetFlagStringVar(ReconcileSchemaDrift.Flags(), &reconcileSchemaDriftOptions.DDLStrategy, "ddl-strategy", string(schema.DDLStrategyVitess), "Online DDL strategy for the reconciling migrations (examples: 'vitess', 'vitess --postpone-completion'). The direct strategy is not allowed.")
+	ReconcileSchemaDrift.Flags().StringVar(&reconcileSchemaDriftOptions.ReferenceShard, "reference-shard", "", "Shard whose schema is canonical. By default, the schema shared by most shards is canonical.")
+	ReconcileSchemaDrift.Flags().BoolVar(&reconcileSchemaDriftOptions.IncludeViews, "include-views", false, "Includes views in compared schemas.")
+	ReconcileSchemaDrift.Flags().StringVar(&reconcileSchemaDriftOptions.CallerID, "caller-id", "", "Effective caller ID used for the operation and should map to an ACL name which grants this identity the necessary permissions to perform the operation (this is only necessary when strict table ACLs are used).")
+	ReconcileSchemaDrift.Flags().BoolVar(&reconcileSchemaDriftOptions.DryRun, "dry-run", false, "Display the reconciling statements without submitting them.")
+	Root.AddCommand(ReconcileSchemaDrift)
+
 	Root.AddCommand(ReloadSchema)
 
 	ReloadSchemaKeyspace.Flags().Int32Var(&reloadSchemaKeyspaceOptions.Concurrency, "concurrency", 10, "Number of tablets to reload in parallel. Set to zero for unbounded concurrency.")
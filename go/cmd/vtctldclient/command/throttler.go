@@ -38,7 +38,7 @@ import (
 var (
 	// UpdateThrottlerConfig makes a UpdateThrottlerConfig gRPC call to a vtctld.
 	UpdateThrottlerConfig = &cobra.Command{
-		Use:                   "UpdateThrottlerConfig [--enable|--disable] [--metric-name=<name>] [--threshold=<float64>] [--custom-query=<query>] [--throttle-app|unthrottle-app=<name>] [--throttle-app-ratio=<float, range [0..1]>] [--throttle-app-duration=<duration>] [--throttle-app-exempt=<bool>] [--app-name=<name> --app-metrics=<metrics>] <keyspace>",
+		Use:                   "UpdateThrottlerConfig [--enable|--disable] [--metric-name=<name>] [--threshold=<float64>] [--custom-query=<query>] [--throttle-app|unthrottle-app=<name>] [--throttle-app-ratio=<float, range [0..1]>] [--throttle-app-duration=<duration>] [--throttle-app-exempt=<bool>] [--app-name=<name> --app-metrics=<metrics>] [--prometheus-url=<url> --prometheus-metric=<name> [--prometheus-labels=<labels>] [--prometheus-aggregation=<aggregation>] [--prometheus-rate]] <keyspace>",
 		Short:                 "Update the tablet throttler configuration for all tablets in the given keyspace (across all cells)",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
@@ -69,6 +69,8 @@ var (
 	throttledAppRule             topodatapb.ThrottledAppRule
 	unthrottledAppRule           topodatapb.ThrottledAppRule
 	throttledAppDuration         time.Duration
+	prometheusMetric             topodatapb.ThrottlerConfig_PrometheusMetric
+	prometheusLabels             string
 
 	checkThrottlerOptions vtctldatapb.CheckThrottlerRequest
 	requestHeartbeats     bool
@@ -81,6 +83,21 @@ func validateUpdateThrottlerConfig(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("app-name") && updateThrottlerConfigOptions.AppName == "" {
 		return errors.New("--app-name must not be empty")
 	}
+	if prometheusMetric.Url != "" && prometheusMetric.Metric == "" {
+		return errors.New("--prometheus-url flag requires --prometheus-metric flag")
+	}
+	if !cmd.Flags().Changed("prometheus-url") {
+		for _, flag := range []string{"prometheus-metric", "prometheus-labels", "prometheus-aggregation", "prometheus-rate"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--%s flag requires --prometheus-url flag", flag)
+			}
+		}
+	}
+	if prometheusMetric.Url != "" {
+		if err := base.ValidatePrometheusURL(prometheusMetric.Url); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -92,6 +109,18 @@ func commandUpdateThrottlerConfig(cmd *cobra.Command, args []string) error {
 	updateThrottlerConfigOptions.CustomQuerySet = cmd.Flags().Changed("custom-query")
 	updateThrottlerConfigOptions.Keyspace = keyspace
 
+	if cmd.Flags().Changed("prometheus-url") {
+		// The prometheus flags replace the whole prometheus metric configuration.
+		labels, err := base.ParsePrometheusLabels(prometheusLabels)
+		if err != nil {
+			return err
+		}
+		if len(labels) > 0 {
+			prometheusMetric.Labels = labels
+		}
+		updateThrottlerConfigOptions.PrometheusMetric = &prometheusMetric
+	}
+
 	if throttledAppRule.Name != "" {
 		throttledAppRule.ExpiresAt = protoutil.TimeToProto(time.Now().Add(throttledAppDuration))
 		updateThrottlerConfigOptions.ThrottledApp = &throttledAppRule
@@ -178,6 +207,11 @@ func init() {
 	UpdateThrottlerConfig.Flags().BoolVar(&throttledAppRule.Exempt, "throttle-app-exempt", throttledAppRule.Exempt, "exempt this app from being at all throttled. WARNING: use with extreme care, as this is likely to push metrics beyond the throttler's threshold, and starve other apps")
 	UpdateThrottlerConfig.Flags().StringVar(&updateThrottlerConfigOptions.AppName, "app-name", "", "app name for which to assign metrics (requires --app-metrics)")
 	UpdateThrottlerConfig.Flags().StringSliceVar(&updateThrottlerConfigOptions.AppCheckedMetrics, "app-metrics", nil, "metrics to be used when checking the throttler for the app (requires --app-name). Empty to restore to default metrics. Example: --app-metrics=lag,custom,shard/loadavg")
+	UpdateThrottlerConfig.Flags().StringVar(&prometheusMetric.Url, "prometheus-url", "", "URL of a local HTTP endpoint exposing metrics in prometheus text format, read by each tablet for the 'prometheus' metric (requires --prometheus-metric). Empty to remove the prometheus metric configuration. Example: --prometheus-url=http://localhost:9100/metrics")
+	UpdateThrottlerConfig.Flags().StringVar(&prometheusMetric.Metric, "prometheus-metric", "", "name of the prometheus metric read for the 'prometheus' metric. Example: --prometheus-metric=node_pressure_io_waiting_seconds_total")
+	UpdateThrottlerConfig.Flags().StringVar(&prometheusLabels, "prometheus-labels", "", "comma separated name=value label selectors, limiting the series read for the 'prometheus' metric. Example: --prometheus-labels=device=nvme0n1")
+	UpdateThrottlerConfig.Flags().StringVar(&prometheusMetric.Aggregation, "prometheus-aggregation", "sum", "aggregation applied to all series read for the 'prometheus' metric. One of: sum, avg, min, max")
+	UpdateThrottlerConfig.Flags().BoolVar(&prometheusMetric.Rate, "prometheus-rate", false, "when true, the 'prometheus' metric is the per-second rate of increase of the aggregated value, suitable for counters")
 	UpdateThrottlerConfig.MarkFlagsMutuallyExclusive("unthrottle-app", "throttle-app")
 	UpdateThrottlerConfig.MarkFlagsRequiredTogether("app-name", "app-metrics")
 
@@ -19,13 +19,13 @@ package command
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/helpers"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 )
 
@@ -64,8 +64,64 @@ var (
 		},
 		RunE: commandWriteTopologyPath,
 	}
+
+	// Topo is the parent command of the topology archive commands.
+	Topo = &cobra.Command{
+		Use:                   "Topo [command] [command-flags]",
+		Short:                 "Backs up, restores and compares the whole topology server contents.",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"topo"},
+		Args:                  cobra.ExactArgs(1),
+	}
+
+	// TopoBackup exports the global and cell topologies to an archive file.
+	TopoBackup = &cobra.Command{
+		Use:                   "Backup --server=internal <file>",
+		Short:                 "Writes the contents of the global and of all the cell topology servers to a versioned archive file.",
+		Example:               "vtctldclient --server=internal --topo-implementation etcd2 --topo-global-server-address localhost:2379 --topo-global-root /vitess/global Topo Backup /tmp/topo.json.gz",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"backup"},
+		Args:                  cobra.ExactArgs(1),
+		PreRunE:               checkInternalServer,
+		RunE:                  commandTopoBackup,
+	}
+
+	// TopoRestore writes the contents of an archive file to the topology.
+	TopoRestore = &cobra.Command{
+		Use:   "Restore --server=internal [--prune] <file>",
+		Short: "Restores the global and cell topology servers from an archive file written by Topo Backup.",
+		Long: `Restores the global and cell topology servers from an archive file written by Topo Backup.
+The global topology is restored first, so the cells it defines can be restored next.
+Files that are not in the archive are only deleted with --prune. Locks and elections are never
+backed up nor restored.`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"restore"},
+		Args:                  cobra.ExactArgs(1),
+		PreRunE:               checkInternalServer,
+		RunE:                  commandTopoRestore,
+	}
+
+	// TopoDiff compares an archive file with the topology.
+	TopoDiff = &cobra.Command{
+		Use:                   "Diff --server=internal <file>",
+		Short:                 "Compares an archive file written by Topo Backup with the global and cell topology servers, and fails if they differ.",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"diff"},
+		Args:                  cobra.ExactArgs(1),
+		PreRunE:               checkInternalServer,
+		RunE:                  commandTopoDiff,
+	}
 )
 
+// checkInternalServer makes sure the command talks to the topology
+// server directly.
+func checkInternalServer(cmd *cobra.Command, args []string) error {
+	if VtctldClientProtocol != "local" {
+		return fmt.Errorf("The %s command can only be used with --server=%s", cmd.CommandPath(), useInternalVtctld)
+	}
+	return nil
+}
+
 var getTopologyPathOptions = struct {
 	// The version of the key/path to get. If not specified, the latest/current
 	// version is returned.
@@ -147,9 +203,9 @@ var writeTopologyPathOptions = struct {
 func commandWriteTopologyPath(cmd *cobra.Command, args []string) error {
 	path := cmd.Flags().Arg(0)
 	file := cmd.Flags().Arg(1)
-	ts, err := topo.OpenServer(topoOptions.implementation, strings.Join(topoOptions.globalServerAddresses, ","), topoOptions.globalRoot)
+	ts, err := openTopoServer()
 	if err != nil {
-		return fmt.Errorf("failed to connect to the topology server: %v", err)
+		return err
 	}
 	cli.FinishedParsing(cmd)
 
@@ -169,6 +225,105 @@ func commandWriteTopologyPath(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandTopoBackup(cmd *cobra.Command, args []string) error {
+	file := cmd.Flags().Arg(0)
+	ts, err := openTopoServer()
+	if err != nil {
+		return err
+	}
+	defer ts.Close()
+	cli.FinishedParsing(cmd)
+
+	archive, err := helpers.BackupTopo(cmd.Context(), ts)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := helpers.WriteTopoArchive(f, archive); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write archive %s: %v", file, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	files := 0
+	for _, cellFiles := range archive.Cells {
+		files += len(cellFiles)
+	}
+	fmt.Printf("Backed up %d files from %d cells (including %s) to %s.\n", files, len(archive.Cells), topo.GlobalCell, file)
+	return nil
+}
+
+var topoRestoreOptions = struct {
+	Prune bool
+}{}
+
+func commandTopoRestore(cmd *cobra.Command, args []string) error {
+	archive, err := readTopoArchive(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+	ts, err := openTopoServer()
+	if err != nil {
+		return err
+	}
+	defer ts.Close()
+	cli.FinishedParsing(cmd)
+
+	restored, err := helpers.RestoreTopo(cmd.Context(), ts, archive, topoRestoreOptions.Prune)
+	if len(restored) > 0 {
+		data, merr := cli.MarshalJSON(restored)
+		if merr != nil {
+			return merr
+		}
+		fmt.Printf("%s\n", data)
+	}
+	return err
+}
+
+func commandTopoDiff(cmd *cobra.Command, args []string) error {
+	archive, err := readTopoArchive(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+	ts, err := openTopoServer()
+	if err != nil {
+		return err
+	}
+	defer ts.Close()
+	cli.FinishedParsing(cmd)
+
+	diffs, err := helpers.DiffTopoArchive(cmd.Context(), ts, archive)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Printf("The topology matches the archive taken at %v.\n", archive.CreatedAt)
+		return nil
+	}
+
+	data, err := cli.MarshalJSON(diffs)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+	return fmt.Errorf("the topology differs from the archive taken at %v in %d files", archive.CreatedAt, len(diffs))
+}
+
+func readTopoArchive(file string) (*helpers.TopoArchive, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return helpers.ReadTopoArchive(f)
+}
+
 func init() {
 	GetTopologyPath.Flags().Int64Var(&getTopologyPathOptions.version, "version", getTopologyPathOptions.version, "The version of the path's key to get. If not specified, the latest version is returned.")
 	GetTopologyPath.Flags().BoolVar(&getTopologyPathOptions.dataAsJSON, "data-as-json", getTopologyPathOptions.dataAsJSON, "If true, only the data is output and it is in JSON format rather than prototext.")
@@ -180,4 +335,10 @@ func init() {
 
 	WriteTopologyPath.Flags().StringVar(&writeTopologyPathOptions.cell, "cell", topo.GlobalCell, "Topology server cell to copy the file to.")
 	Root.AddCommand(WriteTopologyPath)
+
+	Topo.AddCommand(TopoBackup)
+	TopoRestore.Flags().BoolVar(&topoRestoreOptions.Prune, "prune", false, "Delete the files that are in the topology but not in the archive.")
+	Topo.AddCommand(TopoRestore)
+	Topo.AddCommand(TopoDiff)
+	Root.AddCommand(Topo)
 }
@@ -48,6 +48,13 @@ func GetSwitchTrafficCommand(opts *SubCommandsOpts) *cobra.Command {
 					topodatapb.TabletType_REPLICA,
 					topodatapb.TabletType_RDONLY,
 				}
+				if SwitchTrafficOptions.Percent > 0 && SwitchTrafficOptions.Percent < 100 {
+					// Only reads can be switched gradually.
+					SwitchTrafficOptions.TabletTypes = []topodatapb.TabletType{
+						topodatapb.TabletType_REPLICA,
+						topodatapb.TabletType_RDONLY,
+					}
+				}
 			}
 			if SwitchTrafficOptions.Timeout.Seconds() < 1 {
 				return errors.New("timeout value must be at least 1 second")
@@ -103,6 +110,8 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 		EnableReverseReplication:  SwitchTrafficOptions.EnableReverseReplication,
 		InitializeTargetSequences: SwitchTrafficOptions.InitializeTargetSequences,
 		Direction:                 int32(SwitchTrafficOptions.Direction),
+		Percent:                   SwitchTrafficOptions.Percent,
+		MaxErrorPercent:           SwitchTrafficOptions.MaxErrorPercent,
 	}
 	resp, err := GetClient().WorkflowSwitchTraffic(GetCommandCtx(), req)
 	if err != nil {
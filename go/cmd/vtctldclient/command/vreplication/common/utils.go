@@ -349,6 +349,8 @@ var SwitchTrafficOptions = struct {
 	InitializeTargetSequences bool
 	Shards                    []string
 	Force                     bool
+	Percent                   float32
+	MaxErrorPercent           float32
 }{}
 
 func AddCommonSwitchTrafficFlags(cmd *cobra.Command, initializeTargetSequences bool) {
@@ -29,7 +29,9 @@ import (
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -99,6 +101,15 @@ var (
 		Keyspace string
 	}{}
 
+	verifyOptions = struct {
+		Keyspace                     string
+		Cells                        []string
+		TabletTypes                  []topodatapb.TabletType
+		TabletTypesInPreferenceOrder bool
+		Repair                       bool
+		MaxSampleRows                int64
+	}{}
+
 	parseAndValidateCreate = func(cmd *cobra.Command, args []string) error {
 		if createOptions.ParamsFile != "" {
 			if createOptions.TableOwner != "" {
@@ -342,6 +353,18 @@ var (
 		RunE:                  commandInternalize,
 	}
 
+	// verify makes a LookupVindexVerify call to a vtctld.
+	verify = &cobra.Command{
+		Use:                   "verify",
+		Short:                 "Verify that the lookup table of an owned Lookup Vindex matches its owner table, reporting orphaned, missing and mismatched rows and optionally repairing them.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer verify --keyspace customer --repair`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Verify"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandVerify,
+	}
+
 	// show makes a GetWorkflows call to a vtctld.
 	show = &cobra.Command{
 		Use:                   "show",
@@ -500,6 +523,42 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandVerify(cmd *cobra.Command, args []string) error {
+	if verifyOptions.Keyspace == "" {
+		verifyOptions.Keyspace = baseOptions.TableKeyspace
+	}
+	tsp := tabletmanagerdatapb.TabletSelectionPreference_ANY
+	if verifyOptions.TabletTypesInPreferenceOrder {
+		tsp = tabletmanagerdatapb.TabletSelectionPreference_INORDER
+	}
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().LookupVindexVerify(common.GetCommandCtx(), &vtctldatapb.LookupVindexVerifyRequest{
+		Keyspace:                  verifyOptions.Keyspace,
+		Name:                      baseOptions.Name,
+		Cells:                     verifyOptions.Cells,
+		TabletTypes:               verifyOptions.TabletTypes,
+		TabletSelectionPreference: tsp,
+		Repair:                    verifyOptions.Repair,
+		MaxSampleRows:             verifyOptions.MaxSampleRows,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+
+	if resp.OrphanedRows+resp.MissingRows+resp.MismatchedRows > 0 && !verifyOptions.Repair {
+		return fmt.Errorf("the lookup table of LookupVindex %s does not match its owner table", baseOptions.Name)
+	}
+
+	return nil
+}
+
 func registerCommands(root *cobra.Command) {
 	base.PersistentFlags().StringVar(&baseOptions.Name, "name", "", "The name of the Lookup Vindex to create. This will also be the name of the VReplication workflow created to backfill the Lookup Vindex. This will be used only for the workflow name if params-file is used.")
 	base.MarkPersistentFlagRequired("name")
@@ -542,6 +601,14 @@ func registerCommands(root *cobra.Command) {
 	complete.Flags().StringVar(&completeOptions.Keyspace, "keyspace", "", "The keyspace containing the Lookup Vindex. If no value is specified then the table-keyspace will be used.")
 	base.AddCommand(complete)
 
+	verify.Flags().StringVar(&verifyOptions.Keyspace, "keyspace", "", "The keyspace containing the Lookup Vindex and its owner table. If no value is specified then the table-keyspace will be used.")
+	verify.Flags().StringSliceVar(&verifyOptions.Cells, "cells", nil, "Cells to look in for tablets to stream the owner and lookup tables from.")
+	verify.Flags().Var((*topoprotopb.TabletTypeListFlag)(&verifyOptions.TabletTypes), "tablet-types", "Tablet types to stream the owner and lookup tables from. If no value is specified then replica, rdonly and primary tablets are used, in that order.")
+	verify.Flags().BoolVar(&verifyOptions.TabletTypesInPreferenceOrder, "tablet-types-in-preference-order", true, "When performing tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
+	verify.Flags().BoolVar(&verifyOptions.Repair, "repair", false, "Repair the lookup table by deleting orphaned rows, inserting missing rows and updating mismatched rows on the primary tablets.")
+	verify.Flags().Int64Var(&verifyOptions.MaxSampleRows, "max-sample-rows", workflow.DefaultLookupVindexVerifyMaxSampleRows, "The maximum number of sample rows to report for each kind of difference.")
+	base.AddCommand(verify)
+
 	// The cancel command deletes the VReplication workflow used
 	// to backfill the lookup vindex. It ends up making a
 	// WorkflowDelete VtctldServer call.
@@ -89,6 +89,8 @@ func registerCommands(root *cobra.Command) {
 	switchTrafficCommand := common.GetSwitchTrafficCommand(opts)
 	common.AddCommonSwitchTrafficFlags(switchTrafficCommand, true)
 	common.AddShardSubsetFlag(switchTrafficCommand, &common.SwitchTrafficOptions.Shards)
+	switchTrafficCommand.Flags().Float32Var(&common.SwitchTrafficOptions.Percent, "percent", 0, "Percentage of the REPLICA and RDONLY reads to route to the target keyspace, to switch them gradually. The reads are switched all at once when not set or 100.")
+	switchTrafficCommand.Flags().Float32Var(&common.SwitchTrafficOptions.MaxErrorPercent, "max-error-percent", 0, "With --percent, the error rate of the reads routed to the target keyspace above which vtgate routes them back to the source keyspace. 0 disables the check.")
 	base.AddCommand(switchTrafficCommand)
 
 	reverseTrafficCommand := common.GetReverseTrafficCommand(opts)
@@ -50,6 +50,8 @@ func registerReshardCommands(root *cobra.Command) {
 
 	switchTrafficCommand := common.GetSwitchTrafficCommand(opts)
 	common.AddCommonSwitchTrafficFlags(switchTrafficCommand, false)
+	switchTrafficCommand.Flags().Float32Var(&common.SwitchTrafficOptions.Percent, "percent", 0, "Percentage of the REPLICA and RDONLY reads to route to the target shards, to switch them gradually. The reads are switched all at once when not set or 100.")
+	switchTrafficCommand.Flags().Float32Var(&common.SwitchTrafficOptions.MaxErrorPercent, "max-error-percent", 0, "With --percent, the error rate of the reads routed to the target shards above which vtgate routes them back to the source shards. 0 disables the check.")
 	reshard.AddCommand(switchTrafficCommand)
 
 	reverseTrafficCommand := common.GetReverseTrafficCommand(opts)
@@ -3,3 +3,4 @@ package config
 const DefaultSQLMode = "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"
 const DefaultMySQLVersion = "8.4.6"
 const LegacyMySQLVersion = "5.7.31"
+const DefaultBlockEncryptionMode = "aes-128-ecb"
@@ -62,12 +62,7 @@ func (jp *Path) format(b *strings.Builder) {
 	case jpDocumentRoot:
 		b.WriteByte('$')
 	case jpMember:
-		if jpIsIdentifier(jp.name) {
-			b.WriteByte('.')
-			b.WriteString(jp.name)
-		} else {
-			_, _ = fmt.Fprintf(b, ".%q", jp.name)
-		}
+		formatMember(b, jp.name)
 	case jpMemberAny:
 		b.WriteString(".*")
 	case jpArrayLocation:
@@ -95,6 +90,15 @@ func (jp *Path) format(b *strings.Builder) {
 	}
 }
 
+func formatMember(b *strings.Builder, name string) {
+	if jpIsIdentifier(name) {
+		b.WriteByte('.')
+		b.WriteString(name)
+	} else {
+		_, _ = fmt.Fprintf(b, ".%q", name)
+	}
+}
+
 func (jp *Path) String() string {
 	var b strings.Builder
 	for jp != nil {
@@ -217,20 +221,25 @@ func (jp *Path) Match(doc *Value, wrap bool, match func(value *Value)) {
 	m.value(jp, doc)
 }
 
-func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
+// transform walks the path down to the parent of its last leg and calls t with the last
+// leg, the parent value and a function that replaces the parent inside its own container.
+// Following MySQL's auto-wrapping rules, an array location of 0 or 'last' evaluated against
+// a value that is not an array refers to the value itself.
+func (jp *Path) transform(v *Value, replace func(*Value), t func(pp *Path, vv *Value, replace func(*Value))) {
 	if v == nil {
 		return
 	}
 	if jp.next == nil {
-		t(jp, v)
+		t(jp, v, replace)
 		return
 	}
 	switch jp.kind {
 	case jpDocumentRoot:
-		jp.next.transform(v, t)
+		jp.next.transform(v, replace, t)
 	case jpMember:
 		if obj, ok := v.Object(); ok {
-			jp.next.transform(obj.Get(jp.name), t)
+			name := jp.name
+			jp.next.transform(obj.Get(name), func(nv *Value) { obj.Set(name, nv, Set) }, t)
 		}
 	case jpArrayLocation:
 		if ary, ok := v.Array(); ok {
@@ -239,7 +248,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				panic("range in transformation path expression")
 			}
 			if from >= 0 && from < len(ary) {
-				jp.next.transform(ary[from], t)
+				jp.next.transform(ary[from], func(nv *Value) { ary[from] = nv }, t)
 			}
 		} else if jp.offset0 == 0 || jp.offset0 == -1 {
 			/*
@@ -247,13 +256,18 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				the result of the evaluation is the same as if the value had been
 				wrapped in a single-element array:
 			*/
-			jp.next.transform(v, t)
+			jp.next.transform(v, replace, t)
 		}
 	case jpMemberAny, jpArrayLocationAny, jpAny:
 		panic("wildcard in transformation path expression")
 	}
 }
 
+// IsRoot returns whether this path only refers to the document root, i.e. '$'.
+func (jp *Path) IsRoot() bool {
+	return jp.kind == jpDocumentRoot && jp.next == nil
+}
+
 type Transformation int
 
 const (
@@ -261,40 +275,99 @@ const (
 	Insert
 	Replace
 	Remove
+	ArrayAppend
 )
 
-func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
+var errVacuousPath = vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "The path expression '$' is not allowed in this context.")
+
+// ApplyTransform applies the given transformation to doc for every path, in order, with the
+// matching value from values. The paths cannot contain wildcards or array ranges. The document
+// and the values are modified in place, so callers must pass values they own (see Value.Clone).
+// The resulting document is returned, because transforming the document root replaces it.
+func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) (*Value, error) {
 	if t != Remove && len(paths) != len(values) {
 		panic("missing Values for transformation")
 	}
 	for i, p := range paths {
-		transform := func(pp *Path, vv *Value) {
+		if p.IsRoot() {
+			switch t {
+			case Set, Replace:
+				doc = values[i]
+			case Remove:
+				return nil, errVacuousPath
+			case ArrayAppend:
+				doc = arrayAppend(doc, values[i])
+			}
+			continue
+		}
+
+		var value *Value
+		if t != Remove {
+			value = values[i]
+		}
+
+		p.transform(doc, func(nv *Value) { doc = nv }, func(pp *Path, vv *Value, replace func(*Value)) {
 			switch pp.kind {
 			case jpArrayLocation:
-				if ary, ok := vv.Array(); ok {
-					from, to := pp.arrayOffsets(ary)
-					if from != to {
-						return
+				ary, ok := vv.Array()
+				if !ok {
+					// The parent is auto-wrapped into an array: the first element refers to the
+					// parent itself and any other position is past the end of the array.
+					switch {
+					case pp.offset0 == 0 || pp.offset0 == -1:
+						switch t {
+						case Set, Replace:
+							replace(value)
+						case ArrayAppend:
+							replace(arrayAppend(vv, value))
+						}
+					case pp.offset0 > 0 && (t == Set || t == Insert):
+						replace(NewArray([]*Value{vv, value}))
 					}
-					if t == Remove {
-						vv.DelArrayItem(from)
-					} else {
-						vv.SetArrayItem(from, values[i], t)
+					return
+				}
+
+				from, to := pp.arrayOffsets(ary)
+				if from != to || from < 0 {
+					return
+				}
+				switch t {
+				case Remove:
+					vv.DelArrayItem(from)
+				case ArrayAppend:
+					if from < len(ary) {
+						ary[from] = arrayAppend(ary[from], value)
 					}
+				default:
+					vv.SetArrayItem(from, value, t)
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
-					if t == Remove {
+					switch t {
+					case Remove:
 						obj.Del(pp.name)
-					} else {
-						obj.Set(pp.name, values[i], t)
+					case ArrayAppend:
+						if cur := obj.Get(pp.name); cur != nil {
+							obj.Set(pp.name, arrayAppend(cur, value), Set)
+						}
+					default:
+						obj.Set(pp.name, value, t)
 					}
 				}
 			}
-		}
-		p.transform(doc, transform)
+		})
 	}
-	return nil
+	return doc, nil
+}
+
+// arrayAppend appends value to the array v. If v is not an array, it is
+// wrapped into an array before appending, as JSON_ARRAY_APPEND does.
+func arrayAppend(v, value *Value) *Value {
+	if v.t == TypeArray {
+		v.a = append(v.a, value)
+		return v
+	}
+	return NewArray([]*Value{v, value})
 }
 
 func MatchPath(rawJSON, rawPath []byte, match func(value *Value)) error {
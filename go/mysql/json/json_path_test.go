@@ -171,6 +171,48 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$[2][10]`, `$[0][1]`, `$[1].b[0][0]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 2], {"b": [3, false]}, [10, 20, 1]]`,
+		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$`},
+			Values:   []string{`{"a": 1}`},
+			Expected: `{"a": 1}`,
+		},
+		{
+			T:        Insert,
+			Document: `{"a": 1}`,
+			Paths:    []string{`$.a`, `$.b`, `$.a[1]`, `$[0]`},
+			Values:   []string{"10", "20", "30", "40"},
+			Expected: `{"a": [1, 30], "b": 20}`,
+		},
+		{
+			T:        Replace,
+			Document: `{"a": 1}`,
+			Paths:    []string{`$.a`, `$.b`, `$.a[1]`, `$[0].a`},
+			Values:   []string{"10", "20", "30", "40"},
+			Expected: `{"a": 40}`,
+		},
+		{
+			T:        ArrayAppend,
+			Document: `["a", ["b", "c"], "d"]`,
+			Paths:    []string{`$[1]`, `$[0]`, `$[3]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 2], ["b", "c", 1], "d"]`,
+		},
+		{
+			T:        ArrayAppend,
+			Document: `{"a": 1, "b": [2, 3], "c": 4}`,
+			Paths:    []string{`$.b`, `$.c`, `$`},
+			Values:   []string{`"x"`, `"y"`, `"z"`},
+			Expected: `[{"a": 1, "b": [2, 3, "x"], "c": [4, "y"]}, "z"]`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -186,7 +228,7 @@ func TestTransformations(t *testing.T) {
 			values = append(values, json(t, v))
 		}
 
-		err := ApplyTransform(tc.T, doc, paths, values)
+		doc, err := ApplyTransform(tc.T, doc, paths, values)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -197,3 +239,38 @@ func TestTransformations(t *testing.T) {
 		}
 	}
 }
+
+func TestSearch(t *testing.T) {
+	const Document = `["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]`
+
+	cases := []struct {
+		Document string
+		Search   string
+		One      bool
+		Paths    []string
+		Expected []string
+	}{
+		{Document, "abc", true, nil, []string{`$[0]`}},
+		{Document, "abc", false, nil, []string{`$[0]`, `$[2].x`}},
+		{Document, "ghi", false, nil, nil},
+		{Document, "10", false, nil, []string{`$[1][0].k`}},
+		{Document, "10", false, []string{`$`}, []string{`$[1][0].k`}},
+		{Document, "10", false, []string{`$[*][0].k`}, []string{`$[1][0].k`}},
+		{Document, "10", false, []string{`$**.k`}, []string{`$[1][0].k`}},
+		{Document, "abc", false, []string{`$[2]`, `$[0]`, `$[*]`}, []string{`$[2].x`, `$[0]`}},
+		{`{"a b": "abc"}`, "abc", false, nil, []string{`$."a b"`}},
+	}
+
+	for _, tc := range cases {
+		var paths []*Path
+		for _, p := range tc.Paths {
+			paths = append(paths, path(t, p))
+		}
+		got := Search(json(t, tc.Document), paths, tc.One, func(s string) bool {
+			return s == tc.Search
+		})
+		if !slices.Equal(tc.Expected, got) {
+			t.Errorf("Search(%s, %q, %v) = %v (expected %v)", tc.Document, tc.Search, tc.Paths, got, tc.Expected)
+		}
+	}
+}
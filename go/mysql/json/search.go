@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Search looks for the string scalars in doc for which match returns true and
+// returns the paths to them, as used by JSON_SEARCH. If paths are given, only
+// the values under those paths are searched, otherwise the whole document is.
+// The results are returned in document order for every path and do not contain
+// duplicates. If one is true, the search stops after the first match.
+func Search(doc *Value, paths []*Path, one bool, match func(s string) bool) []string {
+	s := searcher{
+		seen:  make(map[*Value]struct{}),
+		one:   one,
+		match: match,
+	}
+
+	if len(paths) == 0 {
+		s.search(doc, "$")
+		return s.results
+	}
+
+	// Resolve the concrete location of every value in the document, so that
+	// the results for wildcard paths can be reported with their actual path.
+	locations := make(map[*Value]string)
+	walkPaths(doc, "$", func(v *Value, path string) {
+		if _, ok := locations[v]; !ok {
+			locations[v] = path
+		}
+	})
+
+	for _, p := range paths {
+		p.Match(doc, true, func(v *Value) {
+			if s.done() {
+				return
+			}
+			if loc, ok := locations[v]; ok {
+				s.search(v, loc)
+			}
+		})
+		if s.done() {
+			break
+		}
+	}
+	return s.results
+}
+
+type searcher struct {
+	seen    map[*Value]struct{}
+	results []string
+	one     bool
+	match   func(s string) bool
+}
+
+func (s *searcher) done() bool {
+	return s.one && len(s.results) > 0
+}
+
+func (s *searcher) search(v *Value, path string) {
+	walkPaths(v, path, func(v *Value, path string) {
+		if s.done() || v.Type() != TypeString {
+			return
+		}
+		if _, seen := s.seen[v]; seen {
+			return
+		}
+		s.seen[v] = struct{}{}
+		if s.match(v.s) {
+			s.results = append(s.results, path)
+		}
+	})
+}
+
+// walkPaths calls f for v and all its nested values, in document order,
+// together with the canonical path that points to each of them.
+func walkPaths(v *Value, path string, f func(v *Value, path string)) {
+	f(v, path)
+	switch v.t {
+	case TypeObject:
+		for _, kv := range v.o.kvs {
+			var b strings.Builder
+			b.WriteString(path)
+			formatMember(&b, kv.k)
+			walkPaths(kv.v, b.String(), f)
+		}
+	case TypeArray:
+		for i, item := range v.a {
+			walkPaths(item, path+"["+strconv.Itoa(i)+"]", f)
+		}
+	}
+}
@@ -64,25 +64,21 @@ func (o *Object) Set(key string, value *Value, t Transformation) {
 	}
 }
 
-// SetArrayItem sets the value in the array v at idx position.
+// SetArrayItem sets the value in the array v at idx position. Like in MySQL, setting or
+// inserting a value past the end of the array appends it to the array.
 //
 // The value must be unchanged during v lifetime.
 func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	if v == nil || v.t != TypeArray || idx < 0 {
 		return
 	}
-	switch t {
-	case Insert:
-		if idx < len(v.a) {
-			return
-		}
-		fallthrough
-	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
+	if idx >= len(v.a) {
+		if t == Set || t == Insert {
+			v.a = append(v.a, value)
 		}
+		return
 	}
-	if idx < len(v.a) {
+	if t != Insert {
 		v.a[idx] = value
 	}
 }
@@ -96,3 +92,81 @@ func (v *Value) DelArrayItem(n int) {
 	}
 	v.a = append(v.a[:n], v.a[n+1:]...)
 }
+
+// Clone returns a deep copy of v, which can be modified without affecting v.
+func (v *Value) Clone() *Value {
+	switch v.t {
+	case TypeObject:
+		c := &Value{t: TypeObject}
+		c.o.kvs = make([]kv, len(v.o.kvs))
+		for i, kv := range v.o.kvs {
+			c.o.kvs[i].k = kv.k
+			c.o.kvs[i].v = kv.v.Clone()
+		}
+		return c
+	case TypeArray:
+		a := make([]*Value, len(v.a))
+		for i, item := range v.a {
+			a[i] = item.Clone()
+		}
+		return NewArray(a)
+	case TypeNull, TypeBoolean:
+		// null, true and false are singletons and are compared by identity
+		return v
+	default:
+		c := *v
+		return &c
+	}
+}
+
+// MergePreserve merges b into a following the semantics of JSON_MERGE_PRESERVE:
+// adjacent arrays are concatenated, adjacent objects are merged recursively and
+// scalars are wrapped into arrays before merging. Both a and b may be modified.
+func MergePreserve(a, b *Value) *Value {
+	if a.t == TypeObject && b.t == TypeObject {
+		for _, kv := range b.o.kvs {
+			if cur := a.o.Get(kv.k); cur != nil {
+				a.o.Set(kv.k, MergePreserve(cur, kv.v), Set)
+			} else {
+				a.o.Set(kv.k, kv.v, Set)
+			}
+		}
+		return a
+	}
+
+	var merged []*Value
+	if a.t == TypeArray {
+		merged = a.a
+	} else {
+		merged = []*Value{a}
+	}
+	if b.t == TypeArray {
+		merged = append(merged, b.a...)
+	} else {
+		merged = append(merged, b)
+	}
+	return NewArray(merged)
+}
+
+// MergePatch applies patch to target as described in RFC 7396, which is the
+// behavior of JSON_MERGE_PATCH. Both target and patch may be modified.
+func MergePatch(target, patch *Value) *Value {
+	if patch.t != TypeObject {
+		return patch
+	}
+	if target.t != TypeObject {
+		target = NewObject(Object{})
+	}
+	for _, kv := range patch.o.kvs {
+		if kv.v.t == TypeNull {
+			target.o.Del(kv.k)
+			continue
+		}
+		cur := target.o.Get(kv.k)
+		if cur == nil {
+			cur = ValueNull
+		}
+		target.o.Set(kv.k, MergePatch(cur, kv.v), Set)
+	}
+	return target
+}
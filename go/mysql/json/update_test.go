@@ -106,3 +106,31 @@ func TestValueDelSet(t *testing.T) {
 		t.Fatalf("unexpected number of items left in the array; got %d; want %d", len(a), 2)
 	}
 }
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		A, B     string
+		Preserve string
+		Patch    string
+	}{
+		{`[1, 2]`, `[true, false]`, `[1, 2, true, false]`, `[true, false]`},
+		{`{"name": "x"}`, `{"id": 47}`, `{"id": 47, "name": "x"}`, `{"id": 47, "name": "x"}`},
+		{`1`, `true`, `[1, true]`, `true`},
+		{`[1, 2]`, `{"id": 47}`, `[1, 2, {"id": 47}]`, `{"id": 47}`},
+		{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": [1, 3], "b": 2, "c": 4}`, `{"a": 3, "b": 2, "c": 4}`},
+		{`{"a": 1, "b": 2}`, `{"b": null}`, `{"a": 1, "b": [2, null]}`, `{"a": 1}`},
+		{`{"a": {"x": 1}}`, `{"a": {"y": 2, "z": null}}`, `{"a": {"x": 1, "y": 2, "z": null}}`, `{"a": {"x": 1, "y": 2}}`},
+		{`[1]`, `{"a": {"b": null}}`, `[1, {"a": {"b": null}}]`, `{"a": {}}`},
+	}
+
+	for _, tc := range cases {
+		preserve := MergePreserve(MustParse(tc.A), MustParse(tc.B)).String()
+		if preserve != tc.Preserve {
+			t.Errorf("MergePreserve(%s, %s) = %s (expected %s)", tc.A, tc.B, preserve, tc.Preserve)
+		}
+		patch := MergePatch(MustParse(tc.A), MustParse(tc.B)).String()
+		if patch != tc.Patch {
+			t.Errorf("MergePatch(%s, %s) = %s (expected %s)", tc.A, tc.B, patch, tc.Patch)
+		}
+	}
+}
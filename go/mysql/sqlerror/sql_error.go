@@ -378,6 +378,8 @@ func demuxResourceExhaustedErrors(msg string) ErrorCode {
 		return ERNetPacketTooLarge
 	case strings.Contains(msg, "Transaction throttled"):
 		return EROutOfResources
+	case strings.Contains(msg, "rate limit exceeded"):
+		return ERUserLimitReached
 	default:
 		return ERTooManyUserConnections
 	}
@@ -45,6 +45,7 @@ func TestDemuxResourceExhaustedErrors(t *testing.T) {
 		// and therefore shouldn't need to be teased out of another error.
 		{"in-memory row count exceeded allowed limit of 13", ERTooManyUserConnections},
 		{"rpc error: code = ResourceExhausted desc = Transaction throttled", EROutOfResources},
+		{"rate limit exceeded for username 'user1'", ERUserLimitReached},
 	}
 
 	for _, c := range cases {
@@ -346,3 +346,19 @@ func (vw *VSchemaWrapper) FindMirrorRule(tab sqlparser.TableName) (*vindexes.Mir
 	}
 	return mirrorRule, err
 }
+
+// FindWeightedRoutingRule finds the weighted routing rule for the requested
+// keyspace, table name, and the tablet type in the VSchema.
+func (vw *VSchemaWrapper) FindWeightedRoutingRule(tab sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	destKeyspace, destTabletType, _, err := topoproto.ParseDestination(tab.Qualifier.String(), vw.TabletType_)
+	if err != nil {
+		return nil, err
+	}
+	return vw.V.FindWeightedRoutingRule(destKeyspace, tab.Name.String(), destTabletType)
+}
+
+// FindWeightedShardRoutingRule finds the weighted shard routing rule for the
+// requested keyspace and the tablet type in the VSchema.
+func (vw *VSchemaWrapper) FindWeightedShardRoutingRule(keyspace string) (*vindexes.WeightedShardRoutingRule, error) {
+	return vw.V.FindWeightedShardRoutingRule(keyspace, vw.TabletType_)
+}
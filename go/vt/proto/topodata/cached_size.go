@@ -41,6 +41,24 @@ func (cached *KeyRange) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *ShardReference) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	// field KeyRange *vitess.io/vitess/go/vt/proto/topodata.KeyRange
+	size += cached.KeyRange.CachedSize(true)
+	// field unknownFields google.golang.org/protobuf/runtime/protoimpl.UnknownFields
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownFields)))
+	}
+	return size
+}
 func (cached *ThrottledAppRule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CutOverWindow is a recurring time window within which a migration is allowed to cut-over.
+// It is given by the --cutover-window DDL strategy flag, in the form of:
+//
+//	[<days>] <HH:MM>-<HH:MM> [<time zone>]
+//
+// where <days> is a comma separated list of week days or ranges of week days, e.g. `Sat`, `Sat,Sun`
+// or `Mon-Fri`, and defaults to every day. The time zone is an IANA time zone name and defaults
+// to UTC. A window whose end is not after its start extends into the next day, in which case the
+// days denote the days the window opens on. Examples:
+//
+//	Sat 02:00-04:00 UTC
+//	Mon-Fri 22:00-02:00 America/New_York
+//	01:00-03:00
+type CutOverWindow struct {
+	days                   [7]bool
+	startHour, startMinute int
+	endHour, endMinute     int
+	location               *time.Location
+	spec                   string
+}
+
+// ParseCutOverWindow parses a --cutover-window value, see CutOverWindow.
+func ParseCutOverWindow(spec string) (*CutOverWindow, error) {
+	w := &CutOverWindow{
+		location: time.UTC,
+		spec:     spec,
+	}
+	tokens := strings.Fields(spec)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid cut-over window '%s': expected '[<days>] <HH:MM>-<HH:MM> [<time zone>]'", spec)
+	}
+	timesIndex := 0
+	if _, _, err := parseCutOverWindowTimes(tokens[0]); err != nil {
+		// First token is not a time range, so it must be the days.
+		if err := w.parseDays(tokens[0]); err != nil {
+			return nil, fmt.Errorf("invalid cut-over window '%s': %w", spec, err)
+		}
+		timesIndex = 1
+	} else {
+		for i := range w.days {
+			w.days[i] = true
+		}
+	}
+	if timesIndex >= len(tokens) {
+		return nil, fmt.Errorf("invalid cut-over window '%s': missing time range", spec)
+	}
+	start, end, err := parseCutOverWindowTimes(tokens[timesIndex])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cut-over window '%s': %w", spec, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid cut-over window '%s': window start and end are equal", spec)
+	}
+	w.startHour, w.startMinute = start/60, start%60
+	w.endHour, w.endMinute = end/60, end%60
+	switch rest := tokens[timesIndex+1:]; len(rest) {
+	case 0:
+	case 1:
+		location, err := time.LoadLocation(rest[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cut-over window '%s': %w", spec, err)
+		}
+		w.location = location
+	default:
+		return nil, fmt.Errorf("invalid cut-over window '%s': unexpected '%s'", spec, strings.Join(rest, " "))
+	}
+	return w, nil
+}
+
+// parseCutOverWindowTimes parses a `HH:MM-HH:MM` range into minutes since midnight.
+func parseCutOverWindowTimes(s string) (start int, end int, err error) {
+	startStr, endStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time range '%s'", s)
+	}
+	if start, err = parseCutOverWindowTime(startStr); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseCutOverWindowTime(endStr); err != nil {
+		return 0, 0, err
+	}
+	if start == 24*60 {
+		return 0, 0, fmt.Errorf("invalid window start time '%s'", startStr)
+	}
+	return start, end, nil
+}
+
+// parseCutOverWindowTime parses a `HH:MM` time into minutes since midnight. `24:00` is accepted
+// as the end of the day.
+func parseCutOverWindowTime(s string) (int, error) {
+	hourStr, minuteStr, ok := strings.Cut(s, ":")
+	if !ok || len(minuteStr) != 2 {
+		return 0, fmt.Errorf("invalid time '%s': expected HH:MM", s)
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s': expected HH:MM", s)
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s': expected HH:MM", s)
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// parseCutOverWindowDay parses a week day name, either abbreviated (`Sat`) or full (`Saturday`).
+func parseCutOverWindowDay(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) || strings.EqualFold(s, d.String()[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day '%s'", s)
+}
+
+// parseDays parses a comma separated list of week days or week day ranges.
+func (w *CutOverWindow) parseDays(s string) error {
+	for _, part := range strings.Split(s, ",") {
+		fromStr, toStr, isRange := strings.Cut(part, "-")
+		from, err := parseCutOverWindowDay(fromStr)
+		if err != nil {
+			return err
+		}
+		to := from
+		if isRange {
+			if to, err = parseCutOverWindowDay(toStr); err != nil {
+				return err
+			}
+		}
+		// Ranges may wrap around the end of the week, e.g. `Fri-Mon`.
+		for d := from; ; d = (d + 1) % 7 {
+			w.days[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// String returns the window as it was given.
+func (w *CutOverWindow) String() string {
+	return w.spec
+}
+
+// windowAt returns the window that opens on the given day of t.
+func (w *CutOverWindow) windowAt(t time.Time, dayOffset int) (start time.Time, end time.Time, ok bool) {
+	year, month, day := t.Date()
+	start = time.Date(year, month, day+dayOffset, w.startHour, w.startMinute, 0, 0, w.location)
+	if !w.days[start.Weekday()] {
+		return start, end, false
+	}
+	end = time.Date(year, month, day+dayOffset, w.endHour, w.endMinute, 0, 0, w.location)
+	if !end.After(start) {
+		// The window extends into the next day.
+		end = time.Date(year, month, day+dayOffset+1, w.endHour, w.endMinute, 0, 0, w.location)
+	}
+	return start, end, true
+}
+
+// Contains returns true when the given time is within the window.
+func (w *CutOverWindow) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	// A window that opened yesterday may still be open.
+	for _, dayOffset := range []int{-1, 0} {
+		if start, end, ok := w.windowAt(t, dayOffset); ok && !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpening returns the time the window next opens at, strictly after the given time.
+func (w *CutOverWindow) NextOpening(t time.Time) time.Time {
+	t = t.In(w.location)
+	for dayOffset := 0; dayOffset <= 7; dayOffset++ {
+		if start, _, ok := w.windowAt(t, dayOffset); ok && start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
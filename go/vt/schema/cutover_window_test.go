@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCutOverWindow(t *testing.T) {
+	tt := []struct {
+		spec        string
+		expectError string
+	}{
+		{spec: "Sat 02:00-04:00 UTC"},
+		{spec: "sat 02:00-04:00"},
+		{spec: "Saturday,Sunday 02:00-04:00"},
+		{spec: "Mon-Fri 22:00-02:00 America/New_York"},
+		{spec: "01:00-03:00"},
+		{spec: "23:00-24:00 UTC"},
+		{spec: "", expectError: "expected '[<days>] <HH:MM>-<HH:MM> [<time zone>]'"},
+		{spec: "Sat", expectError: "missing time range"},
+		{spec: "Sat 02:00", expectError: "invalid time range"},
+		{spec: "Sat 02:00-02:00", expectError: "window start and end are equal"},
+		{spec: "Sat 02:00-25:00", expectError: "invalid time '25:00'"},
+		{spec: "Sat 2-4", expectError: "expected HH:MM"},
+		{spec: "Sat 24:00-04:00", expectError: "invalid window start time"},
+		{spec: "Sad 02:00-04:00", expectError: "invalid day 'Sad'"},
+		{spec: "Sat 02:00-04:00 Mars/Olympus", expectError: "unknown time zone"},
+		{spec: "Sat 02:00-04:00 UTC extra", expectError: "unexpected 'UTC extra'"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.spec, func(t *testing.T) {
+			w, err := ParseCutOverWindow(tc.spec)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.spec, w.String())
+		})
+	}
+}
+
+func TestCutOverWindowContains(t *testing.T) {
+	// 2026-10-17 is a Saturday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+	tt := []struct {
+		spec        string
+		t           time.Time
+		contains    bool
+		nextOpening time.Time
+	}{
+		{
+			spec:        "Sat 02:00-04:00 UTC",
+			t:           at(17, 3, 0),
+			contains:    true,
+			nextOpening: at(24, 2, 0),
+		},
+		{
+			spec:        "Sat 02:00-04:00 UTC",
+			t:           at(17, 4, 0),
+			nextOpening: at(24, 2, 0),
+		},
+		{
+			spec:        "Sat 02:00-04:00 UTC",
+			t:           at(16, 3, 0),
+			nextOpening: at(17, 2, 0),
+		},
+		{
+			spec:        "Sat 02:00-04:00 UTC",
+			t:           at(17, 2, 0),
+			contains:    true,
+			nextOpening: at(24, 2, 0),
+		},
+		{
+			// The window opened on Friday, and extends into Saturday.
+			spec:        "Fri 22:00-02:00",
+			t:           at(17, 1, 0),
+			contains:    true,
+			nextOpening: at(23, 22, 0),
+		},
+		{
+			spec:        "Fri 22:00-02:00",
+			t:           at(18, 1, 0),
+			nextOpening: at(23, 22, 0),
+		},
+		{
+			spec:        "Mon-Fri 09:00-10:00",
+			t:           at(17, 9, 30),
+			nextOpening: at(19, 9, 0),
+		},
+		{
+			spec:        "Fri-Mon 09:00-10:00",
+			t:           at(18, 9, 30),
+			contains:    true,
+			nextOpening: at(19, 9, 0),
+		},
+		{
+			spec:        "23:00-24:00",
+			t:           at(17, 23, 59),
+			contains:    true,
+			nextOpening: at(18, 23, 0),
+		},
+		{
+			// 02:00 UTC is 22:00 EDT on the previous day.
+			spec:        "Fri 21:00-23:00 America/New_York",
+			t:           at(17, 2, 0),
+			contains:    true,
+			nextOpening: at(24, 1, 0),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.spec+" "+tc.t.String(), func(t *testing.T) {
+			w, err := ParseCutOverWindow(tc.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.contains, w.Contains(tc.t))
+			assert.True(t, tc.nextOpening.Equal(w.NextOpening(tc.t)), "next opening: %v", w.NextOpening(tc.t))
+		})
+	}
+}
@@ -31,6 +31,7 @@ var (
 	cutOverThresholdFlagRegexp  = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverThresholdFlag))
 	forceCutOverAfterFlagRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, forceCutOverAfterFlag))
 	retainArtifactsFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, retainArtifactsFlag))
+	cutOverWindowFlagRegexp     = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverWindowFlag))
 )
 
 const (
@@ -49,9 +50,11 @@ const (
 	cutOverThresholdFlag   = "cut-over-threshold"
 	forceCutOverAfterFlag  = "force-cut-over-after"
 	retainArtifactsFlag    = "retain-artifacts"
+	cutOverWindowFlag      = "cutover-window"
 	vreplicationTestSuite  = "vreplication-test-suite"
 	allowForeignKeysFlag   = "unsafe-allow-foreign-keys"
 	analyzeTableFlag       = "analyze-table"
+	lintFlag               = "lint"
 )
 
 // DDLStrategy suggests how an ALTER TABLE should run (e.g. "direct", "online", "mysql")
@@ -119,12 +122,19 @@ func ParseDDLStrategy(strategyVariable string) (*DDLStrategySetting, error) {
 	if err != nil {
 		return nil, err
 	}
+	cutOverWindow, err := setting.CutOverWindow()
+	if err != nil {
+		return nil, err
+	}
 	switch setting.Strategy {
 	case DDLStrategyVitess, DDLStrategyOnline:
 	default:
 		if cutoverAfter != 0 {
 			return nil, fmt.Errorf("--force-cut-over-after is only valid in 'vitess' strategy. Found %v value in '%v' strategy", cutoverAfter, setting.Strategy)
 		}
+		if cutOverWindow != nil {
+			return nil, fmt.Errorf("--cutover-window is only valid in 'vitess' strategy. Found '%v' value in '%v' strategy", cutOverWindow, setting.Strategy)
+		}
 	}
 
 	switch setting.Strategy {
@@ -236,6 +246,15 @@ func isRetainArtifactsFlag(opt string) (string, bool) {
 	return submatch[1], true
 }
 
+// isCutOverWindowFlag returns true when given option denotes a `--cutover-window=[...]` flag
+func isCutOverWindowFlag(opt string) (string, bool) {
+	submatch := cutOverWindowFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
 // CutOverThreshold returns a the duration threshold indicated by --cut-over-threshold
 func (setting *DDLStrategySetting) CutOverThreshold() (d time.Duration, err error) {
 	// We do some ugly manual parsing of --cut-over-threshold value
@@ -290,6 +309,23 @@ func (setting *DDLStrategySetting) RetainArtifactsDuration() (d time.Duration, e
 	return d, err
 }
 
+// CutOverWindow returns the window indicated by --cutover-window, or nil when there is none
+func (setting *DDLStrategySetting) CutOverWindow() (w *CutOverWindow, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isCutOverWindow := isCutOverWindowFlag(opt); isCutOverWindow {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				w, err = ParseCutOverWindow(val)
+			}
+		}
+	}
+	return w, err
+}
+
 // IsVreplicationTestSuite checks if strategy options include --vreplicatoin-test-suite
 func (setting *DDLStrategySetting) IsVreplicationTestSuite() bool {
 	return setting.hasFlag(vreplicationTestSuite)
@@ -305,6 +341,11 @@ func (setting *DDLStrategySetting) IsAnalyzeTableFlag() bool {
 	return setting.hasFlag(analyzeTableFlag)
 }
 
+// IsLintFlag checks if strategy options include --lint
+func (setting *DDLStrategySetting) IsLintFlag() bool {
+	return setting.hasFlag(lintFlag)
+}
+
 // RuntimeOptions returns the options used as runtime flags for given strategy, removing any internal hint options
 func (setting *DDLStrategySetting) RuntimeOptions() []string {
 	opts, _ := shlex.Split(setting.Options)
@@ -319,6 +360,9 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		if _, ok := isRetainArtifactsFlag(opt); ok {
 			continue
 		}
+		if _, ok := isCutOverWindowFlag(opt); ok {
+			continue
+		}
 		switch {
 		case isFlag(opt, declarativeFlag):
 		case isFlag(opt, skipTopoFlag): // deprecated flag, parsed for backwards compatibility
@@ -335,6 +379,7 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		case isFlag(opt, vreplicationTestSuite):
 		case isFlag(opt, allowForeignKeysFlag):
 		case isFlag(opt, analyzeTableFlag):
+		case isFlag(opt, lintFlag):
 		default:
 			validOpts = append(validOpts, opt)
 		}
@@ -195,9 +195,11 @@ func TestParseDDLStrategy(t *testing.T) {
 		fastRangeRotation    bool
 		allowForeignKeys     bool
 		analyzeTable         bool
+		lint                 bool
 		cutOverThreshold     time.Duration
 		forceCutOverAfter    time.Duration
 		expireArtifacts      time.Duration
+		cutOverWindow        string
 		runtimeOptions       string
 		expectError          string
 	}{
@@ -345,6 +347,25 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			expireArtifacts:  4 * time.Minute,
 		},
+		{
+			strategyVariable: `vitess --cutover-window="Sat 02:00-04:00 UTC"`,
+			strategy:         DDLStrategyVitess,
+			options:          `--cutover-window="Sat 02:00-04:00 UTC"`,
+			runtimeOptions:   "",
+			cutOverWindow:    "Sat 02:00-04:00 UTC",
+		},
+		{
+			strategyVariable: `vitess --cutover-window="Sat 02:00" --postpone-completion`,
+			strategy:         DDLStrategyVitess,
+			runtimeOptions:   "",
+			expectError:      "invalid cut-over window",
+		},
+		{
+			strategyVariable: `mysql --cutover-window="Sat 02:00-04:00"`,
+			strategy:         DDLStrategyMySQL,
+			runtimeOptions:   "",
+			expectError:      "--cutover-window is only valid in 'vitess' strategy",
+		},
 		{
 			strategyVariable: "vitess --analyze-table",
 			strategy:         DDLStrategyVitess,
@@ -352,6 +373,13 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			analyzeTable:     true,
 		},
+		{
+			strategyVariable: "vitess --lint",
+			strategy:         DDLStrategyVitess,
+			options:          "--lint",
+			runtimeOptions:   "",
+			lint:             true,
+		},
 
 		{
 			strategyVariable: "vitess --alow-concrrnt", // intentional typo
@@ -386,12 +414,20 @@ func TestParseDDLStrategy(t *testing.T) {
 			assert.Equal(t, ts.fastOverRevertible, setting.IsPreferInstantDDL())
 			assert.Equal(t, ts.allowForeignKeys, setting.IsAllowForeignKeysFlag())
 			assert.Equal(t, ts.analyzeTable, setting.IsAnalyzeTableFlag())
+			assert.Equal(t, ts.lint, setting.IsLintFlag())
 			cutOverThreshold, err := setting.CutOverThreshold()
 			assert.NoError(t, err)
 			assert.Equal(t, ts.cutOverThreshold, cutOverThreshold)
 			forceCutOverAfter, err := setting.ForceCutOverAfter()
 			assert.NoError(t, err)
 			assert.Equal(t, ts.forceCutOverAfter, forceCutOverAfter)
+			cutOverWindow, err := setting.CutOverWindow()
+			assert.NoError(t, err)
+			if ts.cutOverWindow == "" {
+				assert.Nil(t, cutOverWindow)
+			} else {
+				assert.Equal(t, ts.cutOverWindow, cutOverWindow.String())
+			}
 
 			runtimeOptions := strings.Join(setting.RuntimeOptions(), " ")
 			assert.Equal(t, ts.runtimeOptions, runtimeOptions)
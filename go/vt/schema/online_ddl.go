@@ -179,6 +179,12 @@ func NewOnlineDDLs(keyspace string, sql string, ddlStmt sqlparser.DDLStatement,
 		if err := appendOnlineDDL(ddlStmt.GetTable().Name.String(), ddlStmt); err != nil {
 			return nil, err
 		}
+	case *sqlparser.CreateProcedure, *sqlparser.CreateFunction, *sqlparser.CreateTrigger, *sqlparser.CreateEvent,
+		*sqlparser.DropProcedure, *sqlparser.DropFunction, *sqlparser.DropTrigger, *sqlparser.DropEvent:
+		// The migration's "table" is the routine's name
+		if err := appendOnlineDDL(ddlStmt.GetTable().Name.String(), ddlStmt); err != nil {
+			return nil, err
+		}
 	case *sqlparser.DropTable, *sqlparser.DropView:
 		tables := ddlStmt.GetFromTables()
 		for _, table := range tables {
@@ -378,6 +384,20 @@ func (onlineDDL *OnlineDDL) IsView(parser *sqlparser.Parser) bool {
 	return false
 }
 
+// IsRoutine returns 'true' when the migration creates or drops a stored procedure, stored function, trigger or event.
+func (onlineDDL *OnlineDDL) IsRoutine(parser *sqlparser.Parser) bool {
+	stmt, _, err := ParseOnlineDDLStatement(onlineDDL.SQL, parser)
+	if err != nil {
+		return false
+	}
+	switch stmt.(type) {
+	case *sqlparser.CreateProcedure, *sqlparser.CreateFunction, *sqlparser.CreateTrigger, *sqlparser.CreateEvent,
+		*sqlparser.DropProcedure, *sqlparser.DropFunction, *sqlparser.DropTrigger, *sqlparser.DropEvent:
+		return true
+	}
+	return false
+}
+
 // GetActionStr returns a string representation of the DDL action
 func (onlineDDL *OnlineDDL) GetActionStr(parser *sqlparser.Parser) (action sqlparser.DDLAction, actionStr string, err error) {
 	action, err = onlineDDL.GetAction(parser)
@@ -287,6 +287,7 @@ func TestNewOnlineDDLs(t *testing.T) {
 		isError         bool
 		expectErrorText string
 		isView          bool
+		isRoutine       bool
 	}
 	tests := map[string]expect{
 		"alter table t add column i int, drop column d": {sqls: []string{"alter table t add column i int, drop column d"}},
@@ -313,6 +314,11 @@ func TestNewOnlineDDLs(t *testing.T) {
 		"alter table corder add FOREIGN KEY my_fk(customer_id) references customer(customer_id)":                                                                                     {isError: true, expectErrorText: "foreign key constraints are not supported"},
 		"alter table corder rename as something_else":                                                                                                                                {isError: true, expectErrorText: "RENAME is not supported in online DDL"},
 		"CREATE TABLE if not exists t (id bigint unsigned NOT NULL AUTO_INCREMENT, ts datetime(6) DEFAULT NULL, error_column NO_SUCH_TYPE NOT NULL, PRIMARY KEY (id)) ENGINE=InnoDB": {isError: true, expectErrorText: "near"},
+
+		"create procedure p() begin delete from t; end;":                                       {sqls: []string{"create procedure p () begin delete from t; end;"}, isRoutine: true},
+		"create trigger tr before insert on t for each row insert into t_log values (new.id);": {sqls: []string{"create trigger tr before insert on t for each row insert into t_log values (new.id);"}, isRoutine: true},
+		"drop function if exists f":                                                            {sqls: []string{"drop function if exists f"}, isRoutine: true},
+		"drop event ev":                                                                        {sqls: []string{"drop event ev"}, isRoutine: true},
 	}
 	migrationContext := "354b-11eb-82cd-f875a4d24e90"
 	parser := sqlparser.NewTestParser()
@@ -347,6 +353,7 @@ func TestNewOnlineDDLs(t *testing.T) {
 				sql = strings.ReplaceAll(sql, "\t", "")
 				sqls = append(sqls, sql)
 				assert.Equal(t, expect.isView, onlineDDL.IsView(parser))
+				assert.Equal(t, expect.isRoutine, onlineDDL.IsRoutine(parser))
 			}
 			assert.Equal(t, expect.sqls, sqls)
 		})
@@ -178,6 +178,61 @@ func DiffViews(env *Environment, create1 *sqlparser.CreateView, create2 *sqlpars
 	}
 }
 
+// DiffCreateRoutinesQueries compares two `CREATE PROCEDURE|FUNCTION|TRIGGER|EVENT ...` queries (in string form) and
+// returns the diff from routine1 to routine2. Either or both of the queries can be empty. Based on this, the diff could be
+// nil, a CREATE, a DROP, or a DROP followed by a CREATE.
+func DiffCreateRoutinesQueries(env *Environment, query1 string, query2 string, hints *DiffHints) (EntityDiff, error) {
+	var fromCreateRoutine sqlparser.Statement
+	if query1 != "" {
+		stmt, err := env.Parser().ParseStrictDDL(query1)
+		if err != nil {
+			return nil, err
+		}
+		fromCreateRoutine = stmt
+	}
+	var toCreateRoutine sqlparser.Statement
+	if query2 != "" {
+		stmt, err := env.Parser().ParseStrictDDL(query2)
+		if err != nil {
+			return nil, err
+		}
+		toCreateRoutine = stmt
+	}
+	return DiffRoutines(env, fromCreateRoutine, toCreateRoutine, hints)
+}
+
+// DiffRoutines compares two routines and returns the diff from routine1 to routine2.
+// Each statement must be nil, or one of CreateProcedure, CreateFunction, CreateTrigger, CreateEvent.
+// Based on this, the diff could be nil, a CREATE, a DROP, or a DROP followed by a CREATE.
+func DiffRoutines(env *Environment, create1 sqlparser.Statement, create2 sqlparser.Statement, hints *DiffHints) (EntityDiff, error) {
+	switch {
+	case create1 == nil && create2 == nil:
+		return nil, nil
+	case create1 == nil:
+		c2, err := NewRoutineEntity(env, create2)
+		if err != nil {
+			return nil, err
+		}
+		return c2.Create(), nil
+	case create2 == nil:
+		c1, err := NewRoutineEntity(env, create1)
+		if err != nil {
+			return nil, err
+		}
+		return c1.Drop(), nil
+	default:
+		c1, err := NewRoutineEntity(env, create1)
+		if err != nil {
+			return nil, err
+		}
+		c2, err := NewRoutineEntity(env, create2)
+		if err != nil {
+			return nil, err
+		}
+		return c1.Diff(c2, hints)
+	}
+}
+
 // DiffSchemasSQL compares two schemas and returns the rich diff that turns
 // 1st schema into 2nd. Schemas are build from SQL, each of which can contain an arbitrary number of
 // CREATE TABLE and CREATE VIEW statements.
@@ -223,6 +278,10 @@ func EntityDiffByStatement(statement sqlparser.Statement) EntityDiff {
 		return &AlterViewEntityDiff{alterView: stmt}
 	case *sqlparser.DropView:
 		return &DropViewEntityDiff{dropView: stmt}
+	case *sqlparser.CreateProcedure, *sqlparser.CreateFunction, *sqlparser.CreateTrigger, *sqlparser.CreateEvent:
+		return &CreateRoutineEntityDiff{statement: stmt.(sqlparser.DDLStatement)}
+	case *sqlparser.DropProcedure, *sqlparser.DropFunction, *sqlparser.DropTrigger, *sqlparser.DropEvent:
+		return &DropRoutineEntityDiff{statement: stmt.(sqlparser.DDLStatement)}
 	}
 	return nil
 }
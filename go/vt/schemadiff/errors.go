@@ -32,6 +32,7 @@ var (
 	ErrUnexpectedTableSpec            = errors.New("unexpected table spec")
 	ErrExpectedCreateTable            = errors.New("expected a CREATE TABLE statement")
 	ErrExpectedCreateView             = errors.New("expected a CREATE VIEW statement")
+	ErrExpectedCreateRoutine          = errors.New("expected a CREATE PROCEDURE, FUNCTION, TRIGGER or EVENT statement")
 )
 
 type ImpossibleApplyDiffOrderError struct {
@@ -116,6 +117,15 @@ func (e *ApplyViewNotFoundError) Error() string {
 	return fmt.Sprintf("view %s not found", sqlescape.EscapeID(e.View))
 }
 
+type ApplyRoutineNotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *ApplyRoutineNotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, sqlescape.EscapeID(e.Name))
+}
+
 type ApplyKeyNotFoundError struct {
 	Table string
 	Key   string
@@ -405,6 +415,42 @@ func (e *ViewDependencyUnresolvedError) Error() string {
 	return b.String()
 }
 
+type RoutineDependencyUnresolvedError struct {
+	Kind                      string
+	Name                      string
+	MissingReferencedEntities []string
+}
+
+func (e *RoutineDependencyUnresolvedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s references nonexistent tables/views: ", e.Kind, sqlescape.EscapeID(e.Name))
+	for i, entity := range e.MissingReferencedEntities {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		sqlescape.WriteEscapeID(&b, entity)
+	}
+	return b.String()
+}
+
+type TriggerTableNotFoundError struct {
+	Trigger string
+	Table   string
+}
+
+func (e *TriggerTableNotFoundError) Error() string {
+	return fmt.Sprintf("trigger %s is defined on nonexistent table %s", sqlescape.EscapeID(e.Trigger), sqlescape.EscapeID(e.Table))
+}
+
+type TriggerOnViewError struct {
+	Trigger string
+	View    string
+}
+
+func (e *TriggerOnViewError) Error() string {
+	return fmt.Sprintf("trigger %s cannot be defined on view %s", sqlescape.EscapeID(e.Trigger), sqlescape.EscapeID(e.View))
+}
+
 type InvalidColumnReferencedInViewError struct {
 	View      string
 	Column    string
@@ -534,3 +580,26 @@ type DuplicateForeignKeyConstraintNameError struct {
 func (e *DuplicateForeignKeyConstraintNameError) Error() string {
 	return fmt.Sprintf("duplicate foreign key constraint name %s in table %s", sqlescape.EscapeID(e.Constraint), sqlescape.EscapeID(e.Table))
 }
+
+type UnknownLintRuleError struct {
+	Rule string
+}
+
+func (e *UnknownLintRuleError) Error() string {
+	return fmt.Sprintf("unknown lint rule: %s", e.Rule)
+}
+
+// LintViolationsError is returned when a schema change breaks lint rules.
+type LintViolationsError struct {
+	Violations []*LintViolation
+}
+
+func (e *LintViolationsError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("schema lint found %d violation(s):", len(e.Violations)))
+	for _, v := range e.Violations {
+		b.WriteString("\n")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// Names of built-in lint rules.
+const (
+	LintRulePrimaryKey           = "primary-key"
+	LintRuleNoFloatMoney         = "no-float-money"
+	LintRuleAllowedCharsets      = "allowed-charsets"
+	LintRuleRequiredColumns      = "required-columns"
+	LintRuleMaxIndexes           = "max-indexes"
+	LintRuleNoForeignKeysSharded = "no-foreign-keys-sharded"
+)
+
+const (
+	defaultLintMoneyColumnPattern = `(?i)(price|amount|cost|balance|total|fee|salary|money)`
+	defaultLintMaxIndexes         = 16
+)
+
+var (
+	defaultLintAllowedCharsets = []string{"utf8mb4"}
+	defaultLintRequiredColumns = []string{"created_at"}
+)
+
+// LintConfig configures a Linter. It is typically read from a JSON file via LoadLintConfig. Zero values
+// are replaced with defaults, see DefaultLintConfig.
+type LintConfig struct {
+	// Rules, when non-empty, is the explicit list of rules to apply. By default all rules apply.
+	Rules []string `json:"rules,omitempty"`
+	// DisabledRules lists rules that do not apply.
+	DisabledRules []string `json:"disabled_rules,omitempty"`
+	// MoneyColumnPattern is a regular expression matching names of columns that hold monetary values.
+	// Such columns may not be of FLOAT or DOUBLE type.
+	MoneyColumnPattern string `json:"money_column_pattern,omitempty"`
+	// AllowedCharsets lists the character sets tables and textual columns may use.
+	AllowedCharsets []string `json:"allowed_charsets,omitempty"`
+	// RequiredColumns lists columns every table must have.
+	RequiredColumns []string `json:"required_columns,omitempty"`
+	// MaxIndexes is the maximum number of indexes, excluding the PRIMARY KEY, a table may have.
+	MaxIndexes int `json:"max_indexes,omitempty"`
+	// Sharded indicates the schema belongs to a sharded keyspace. Rules such as no-foreign-keys-sharded
+	// only apply in sharded keyspaces. This is normally set by the caller rather than in the file.
+	Sharded bool `json:"sharded,omitempty"`
+}
+
+// DefaultLintConfig returns a config with all rules enabled and their default settings.
+func DefaultLintConfig() *LintConfig {
+	return &LintConfig{
+		MoneyColumnPattern: defaultLintMoneyColumnPattern,
+		AllowedCharsets:    slices.Clone(defaultLintAllowedCharsets),
+		RequiredColumns:    slices.Clone(defaultLintRequiredColumns),
+		MaxIndexes:         defaultLintMaxIndexes,
+	}
+}
+
+// ParseLintConfig parses a JSON lint config. Settings missing from the JSON take their default values.
+func ParseLintConfig(data []byte) (*LintConfig, error) {
+	config := &LintConfig{}
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("invalid lint config: %w", err)
+		}
+	}
+	defaults := DefaultLintConfig()
+	if config.MoneyColumnPattern == "" {
+		config.MoneyColumnPattern = defaults.MoneyColumnPattern
+	}
+	if len(config.AllowedCharsets) == 0 {
+		config.AllowedCharsets = defaults.AllowedCharsets
+	}
+	if config.RequiredColumns == nil {
+		config.RequiredColumns = defaults.RequiredColumns
+	}
+	if config.MaxIndexes == 0 {
+		config.MaxIndexes = defaults.MaxIndexes
+	}
+	return config, nil
+}
+
+// LoadLintConfig reads and parses a JSON lint config file.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLintConfig(data)
+}
+
+// LintViolation is a single breach of a lint rule by a schema entity.
+type LintViolation struct {
+	Rule    string
+	Entity  string
+	Message string
+}
+
+func (v *LintViolation) String() string {
+	return fmt.Sprintf("%s: %s: %s", v.Rule, sqlescape.EscapeID(v.Entity), v.Message)
+}
+
+// LintRule is a single lint check, applied to each entity in turn.
+type LintRule interface {
+	// Name is the rule's unique name, as used in LintConfig.
+	Name() string
+	// Lint returns the violations of this rule by the given entity, if any.
+	Lint(entity Entity, config *LintConfig) []*LintViolation
+}
+
+// tableLintRule is a LintRule that only applies to tables.
+type tableLintRule struct {
+	name string
+	lint func(table *CreateTableEntity, config *LintConfig) []string
+}
+
+func (r *tableLintRule) Name() string {
+	return r.name
+}
+
+func (r *tableLintRule) Lint(entity Entity, config *LintConfig) (violations []*LintViolation) {
+	table, ok := entity.(*CreateTableEntity)
+	if !ok {
+		return nil
+	}
+	for _, message := range r.lint(table, config) {
+		violations = append(violations, &LintViolation{Rule: r.name, Entity: table.Name(), Message: message})
+	}
+	return violations
+}
+
+// BuiltinLintRules returns the rules shipped with schemadiff.
+func BuiltinLintRules() []LintRule {
+	return []LintRule{
+		&tableLintRule{name: LintRulePrimaryKey, lint: lintPrimaryKey},
+		&tableLintRule{name: LintRuleNoFloatMoney, lint: lintNoFloatMoney},
+		&tableLintRule{name: LintRuleAllowedCharsets, lint: lintAllowedCharsets},
+		&tableLintRule{name: LintRuleRequiredColumns, lint: lintRequiredColumns},
+		&tableLintRule{name: LintRuleMaxIndexes, lint: lintMaxIndexes},
+		&tableLintRule{name: LintRuleNoForeignKeysSharded, lint: lintNoForeignKeysSharded},
+	}
+}
+
+func lintPrimaryKey(table *CreateTableEntity, config *LintConfig) []string {
+	for _, key := range table.CreateTable.TableSpec.Indexes {
+		if key.Info.Type == sqlparser.IndexTypePrimary {
+			return nil
+		}
+	}
+	return []string{"table has no PRIMARY KEY"}
+}
+
+func lintNoFloatMoney(table *CreateTableEntity, config *LintConfig) (messages []string) {
+	re, err := regexp.Compile(config.MoneyColumnPattern)
+	if err != nil {
+		// Validated by NewLinter.
+		return nil
+	}
+	for _, col := range table.ColumnDefinitionEntities() {
+		if col.IsFloatingPointType() && re.MatchString(col.Name()) {
+			messages = append(messages, fmt.Sprintf("column %s holds monetary values and may not be %s; use DECIMAL", sqlescape.EscapeID(col.Name()), strings.ToUpper(col.Type())))
+		}
+	}
+	return messages
+}
+
+func lintAllowedCharsets(table *CreateTableEntity, config *LintConfig) (messages []string) {
+	allowed := func(charset string) bool {
+		return slices.ContainsFunc(config.AllowedCharsets, func(s string) bool { return strings.EqualFold(s, charset) })
+	}
+	tableCharset := getTableCharsetCollate(table.Env, &table.CreateTable.TableSpec.Options).charset
+	if !allowed(tableCharset) {
+		messages = append(messages, fmt.Sprintf("table character set %s is not allowed", tableCharset))
+	}
+	for _, col := range table.ColumnDefinitionEntities() {
+		_, charset, _, isTextual, err := col.InferCharsetCollate()
+		if err != nil || !isTextual {
+			continue
+		}
+		if strings.EqualFold(charset, tableCharset) {
+			// Already reported on the table level, if at all.
+			continue
+		}
+		if !allowed(charset) {
+			messages = append(messages, fmt.Sprintf("column %s character set %s is not allowed", sqlescape.EscapeID(col.Name()), charset))
+		}
+	}
+	return messages
+}
+
+func lintRequiredColumns(table *CreateTableEntity, config *LintConfig) (messages []string) {
+	columns := table.ColumnDefinitionEntitiesMap()
+	for _, name := range config.RequiredColumns {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			messages = append(messages, fmt.Sprintf("missing required column %s", sqlescape.EscapeID(name)))
+		}
+	}
+	return messages
+}
+
+func lintMaxIndexes(table *CreateTableEntity, config *LintConfig) []string {
+	count := 0
+	for _, key := range table.CreateTable.TableSpec.Indexes {
+		if key.Info.Type != sqlparser.IndexTypePrimary {
+			count++
+		}
+	}
+	if count > config.MaxIndexes {
+		return []string{fmt.Sprintf("table has %d indexes, more than the maximum of %d", count, config.MaxIndexes)}
+	}
+	return nil
+}
+
+func lintNoForeignKeysSharded(table *CreateTableEntity, config *LintConfig) (messages []string) {
+	if !config.Sharded {
+		return nil
+	}
+	for _, constraint := range table.CreateTable.TableSpec.Constraints {
+		if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); ok {
+			messages = append(messages, fmt.Sprintf("foreign key %s is not allowed in a sharded keyspace", sqlescape.EscapeID(constraint.Name.String())))
+		}
+	}
+	return messages
+}
+
+// Linter checks schema entities against a set of rules.
+type Linter struct {
+	env    *Environment
+	config *LintConfig
+	rules  []LintRule
+}
+
+// NewLinter returns a linter applying the built-in rules selected by the given config. A nil config
+// means DefaultLintConfig.
+func NewLinter(env *Environment, config *LintConfig) (*Linter, error) {
+	if config == nil {
+		config = DefaultLintConfig()
+	}
+	if _, err := regexp.Compile(config.MoneyColumnPattern); err != nil {
+		return nil, fmt.Errorf("invalid money_column_pattern in lint config: %w", err)
+	}
+	builtin := BuiltinLintRules()
+	known := func(name string) bool {
+		return slices.ContainsFunc(builtin, func(rule LintRule) bool { return rule.Name() == name })
+	}
+	for _, name := range append(slices.Clone(config.Rules), config.DisabledRules...) {
+		if !known(name) {
+			return nil, &UnknownLintRuleError{Rule: name}
+		}
+	}
+	l := &Linter{env: env, config: config}
+	for _, rule := range builtin {
+		if len(config.Rules) > 0 && !slices.Contains(config.Rules, rule.Name()) {
+			continue
+		}
+		if slices.Contains(config.DisabledRules, rule.Name()) {
+			continue
+		}
+		l.rules = append(l.rules, rule)
+	}
+	return l, nil
+}
+
+// AddRule adds a custom rule to this linter.
+func (l *Linter) AddRule(rule LintRule) {
+	l.rules = append(l.rules, rule)
+}
+
+// Rules returns the names of the rules this linter applies.
+func (l *Linter) Rules() (names []string) {
+	for _, rule := range l.rules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
+
+// LintEntity returns all violations by the given entity.
+func (l *Linter) LintEntity(entity Entity) (violations []*LintViolation) {
+	if table, ok := entity.(*CreateTableEntity); ok && table.Env == nil {
+		// Tables built directly from a statement, e.g. by EntityDiffByStatement, have no environment.
+		normalized, err := NewCreateTableEntity(l.env, sqlparser.Clone(table.CreateTable))
+		if err != nil {
+			return nil
+		}
+		entity = normalized
+	}
+	for _, rule := range l.rules {
+		violations = append(violations, rule.Lint(entity, l.config)...)
+	}
+	return violations
+}
+
+// LintSchema returns all violations by all entities in the given schema.
+func (l *Linter) LintSchema(schema *Schema) (violations []*LintViolation) {
+	for _, entity := range schema.Entities() {
+		violations = append(violations, l.LintEntity(entity)...)
+	}
+	return violations
+}
+
+// LintDiff returns all violations by the entity resulting from the given diff, and from any
+// subsequent diffs. A diff that drops an entity has no violations. Diffs created from a statement
+// via EntityDiffByStatement may not carry their resulting entity, e.g. an ALTER TABLE diff; use LintApply
+// for those.
+func (l *Linter) LintDiff(diff EntityDiff) (violations []*LintViolation) {
+	for ; diff != nil && !diff.IsEmpty(); diff = diff.SubsequentDiff() {
+		if to := diffToEntity(diff); to != nil {
+			violations = append(violations, l.LintEntity(to)...)
+		}
+	}
+	return violations
+}
+
+// diffToEntity returns the "to" entity of the given diff, or nil if the diff does not carry one.
+func diffToEntity(diff EntityDiff) Entity {
+	_, to := diff.Entities()
+	switch to := to.(type) {
+	case *CreateTableEntity:
+		if to == nil {
+			return nil
+		}
+	case *CreateViewEntity:
+		if to == nil {
+			return nil
+		}
+	}
+	return to
+}
+
+// LintApply applies the given table diffs onto the tables of the given schema, and returns all violations
+// by the resulting tables. Tables untouched by the diffs are not linted. Unlike LintDiff, this supports
+// diffs created from a statement via EntityDiffByStatement, such as an ALTER TABLE on an existing table.
+func (l *Linter) LintApply(schema *Schema, diffs []EntityDiff) ([]*LintViolation, error) {
+	tables := map[string]*CreateTableEntity{}
+	for _, table := range schema.Tables() {
+		tables[table.Name()] = table
+	}
+	var affected []string
+	untouch := func(name string) {
+		affected = slices.DeleteFunc(affected, func(s string) bool { return s == name })
+	}
+	for _, diff := range diffs {
+		switch stmt := diff.Statement().(type) {
+		case *sqlparser.CreateTable:
+			table, err := NewCreateTableEntity(l.env, sqlparser.Clone(stmt))
+			if err != nil {
+				return nil, err
+			}
+			name := table.Name()
+			tables[name] = table
+			untouch(name)
+			affected = append(affected, name)
+		case *sqlparser.AlterTable:
+			name := stmt.Table.Name.String()
+			to, ok := diffToEntity(diff).(*CreateTableEntity)
+			if !ok || to == nil {
+				table, ok := tables[name]
+				if !ok {
+					return nil, &ApplyTableNotFoundError{Table: name}
+				}
+				applied, err := table.Apply(&AlterTableEntityDiff{alterTable: sqlparser.Clone(stmt)})
+				if err != nil {
+					return nil, err
+				}
+				to = applied.(*CreateTableEntity)
+			}
+			tables[name] = to
+			untouch(name)
+			affected = append(affected, name)
+		case *sqlparser.RenameTable:
+			for _, pair := range stmt.TablePairs {
+				fromName, toName := pair.FromTable.Name.String(), pair.ToTable.Name.String()
+				table, ok := tables[fromName]
+				if !ok {
+					return nil, &ApplyTableNotFoundError{Table: fromName}
+				}
+				renamed := table.Clone().(*CreateTableEntity)
+				renamed.CreateTable.Table.Name = pair.ToTable.Name
+				delete(tables, fromName)
+				tables[toName] = renamed
+				untouch(fromName)
+				affected = append(affected, toName)
+			}
+		case *sqlparser.DropTable:
+			for _, tableName := range stmt.FromTables {
+				name := tableName.Name.String()
+				delete(tables, name)
+				untouch(name)
+			}
+		}
+	}
+	var violations []*LintViolation
+	for _, name := range affected {
+		violations = append(violations, l.LintEntity(tables[name])...)
+	}
+	return violations, nil
+}
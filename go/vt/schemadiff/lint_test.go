@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintViolationStrings(violations []*LintViolation) (result []string) {
+	for _, v := range violations {
+		result = append(result, v.String())
+	}
+	return result
+}
+
+func TestLintTable(t *testing.T) {
+	tt := []struct {
+		name       string
+		create     string
+		config     string
+		sharded    bool
+		violations []string
+	}{
+		{
+			name:   "compliant table",
+			create: "create table t1 (id int primary key, price decimal(10,2), name varchar(64), created_at timestamp)",
+		},
+		{
+			name:   "no primary key",
+			create: "create table t1 (id int, created_at timestamp)",
+			violations: []string{
+				"primary-key: `t1`: table has no PRIMARY KEY",
+			},
+		},
+		{
+			name:   "float money",
+			create: "create table t1 (id int primary key, total_price float, ratio double, created_at timestamp)",
+			violations: []string{
+				"no-float-money: `t1`: column `total_price` holds monetary values and may not be FLOAT; use DECIMAL",
+			},
+		},
+		{
+			name:   "custom money pattern",
+			create: "create table t1 (id int primary key, total_price float, ratio double, created_at timestamp)",
+			config: `{"money_column_pattern": "^ratio$"}`,
+			violations: []string{
+				"no-float-money: `t1`: column `ratio` holds monetary values and may not be DOUBLE; use DECIMAL",
+			},
+		},
+		{
+			name:   "table charset",
+			create: "create table t1 (id int primary key, name varchar(64), created_at timestamp) default charset=latin1",
+			violations: []string{
+				"allowed-charsets: `t1`: table character set latin1 is not allowed",
+			},
+		},
+		{
+			name:   "column charset",
+			create: "create table t1 (id int primary key, name varchar(64) charset utf8, created_at timestamp)",
+			violations: []string{
+				"allowed-charsets: `t1`: column `name` character set utf8mb3 is not allowed",
+			},
+		},
+		{
+			name:   "allowed column charset",
+			create: "create table t1 (id int primary key, name varchar(64) charset ascii, created_at timestamp)",
+			config: `{"allowed_charsets": ["utf8mb4", "ascii"]}`,
+		},
+		{
+			name:   "missing required column",
+			create: "create table t1 (id int primary key)",
+			violations: []string{
+				"required-columns: `t1`: missing required column `created_at`",
+			},
+		},
+		{
+			name:   "custom required columns",
+			create: "create table t1 (id int primary key, Created_At timestamp)",
+			config: `{"required_columns": ["created_at", "updated_at"]}`,
+			violations: []string{
+				"required-columns: `t1`: missing required column `updated_at`",
+			},
+		},
+		{
+			name:   "too many indexes",
+			create: "create table t1 (id int primary key, a int, b int, c int, created_at timestamp, key (a), key (b), key (c))",
+			config: `{"max_indexes": 2}`,
+			violations: []string{
+				"max-indexes: `t1`: table has 3 indexes, more than the maximum of 2",
+			},
+		},
+		{
+			name:   "foreign key, unsharded",
+			create: "create table t1 (id int primary key, p int, created_at timestamp, key p_idx (p), constraint p_fk foreign key (p) references parent (id))",
+		},
+		{
+			name:    "foreign key, sharded",
+			create:  "create table t1 (id int primary key, p int, created_at timestamp, key p_idx (p), constraint p_fk foreign key (p) references parent (id))",
+			sharded: true,
+			violations: []string{
+				"no-foreign-keys-sharded: `t1`: foreign key `p_fk` is not allowed in a sharded keyspace",
+			},
+		},
+		{
+			name:   "disabled rules",
+			create: "create table t1 (id int)",
+			config: `{"disabled_rules": ["primary-key", "required-columns"]}`,
+		},
+		{
+			name:   "explicit rules",
+			create: "create table t1 (id int) default charset=latin1",
+			config: `{"rules": ["allowed-charsets"]}`,
+			violations: []string{
+				"allowed-charsets: `t1`: table character set latin1 is not allowed",
+			},
+		},
+	}
+	env := NewTestEnv()
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := ParseLintConfig([]byte(tc.config))
+			require.NoError(t, err)
+			config.Sharded = tc.sharded
+			linter, err := NewLinter(env, config)
+			require.NoError(t, err)
+
+			table, err := NewCreateTableEntityFromSQL(env, tc.create)
+			require.NoError(t, err)
+			assert.Equal(t, tc.violations, lintViolationStrings(linter.LintEntity(table)))
+		})
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	env := NewTestEnv()
+	t.Run("defaults", func(t *testing.T) {
+		config, err := ParseLintConfig(nil)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultLintConfig(), config)
+
+		linter, err := NewLinter(env, nil)
+		require.NoError(t, err)
+		assert.Len(t, linter.Rules(), len(BuiltinLintRules()))
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := ParseLintConfig([]byte(`{"max_indexes": "many"}`))
+		assert.Error(t, err)
+	})
+	t.Run("unknown rule", func(t *testing.T) {
+		config, err := ParseLintConfig([]byte(`{"disabled_rules": ["no-such-rule"]}`))
+		require.NoError(t, err)
+		_, err = NewLinter(env, config)
+		var ruleErr *UnknownLintRuleError
+		require.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, "no-such-rule", ruleErr.Rule)
+	})
+	t.Run("invalid money pattern", func(t *testing.T) {
+		config, err := ParseLintConfig([]byte(`{"money_column_pattern": "("}`))
+		require.NoError(t, err)
+		_, err = NewLinter(env, config)
+		assert.ErrorContains(t, err, "money_column_pattern")
+	})
+}
+
+func TestLintSchema(t *testing.T) {
+	env := NewTestEnv()
+	schema, err := NewSchemaFromSQL(env, `
+		create table t1 (id int primary key, created_at timestamp);
+		create table t2 (id int);
+		create view v1 as select id from t1;
+	`)
+	require.NoError(t, err)
+	linter, err := NewLinter(env, nil)
+	require.NoError(t, err)
+
+	violations := linter.LintSchema(schema)
+	assert.Equal(t, []string{
+		"primary-key: `t2`: table has no PRIMARY KEY",
+		"required-columns: `t2`: missing required column `created_at`",
+	}, lintViolationStrings(violations))
+}
+
+func TestLintDiff(t *testing.T) {
+	env := NewTestEnv()
+	linter, err := NewLinter(env, nil)
+	require.NoError(t, err)
+
+	from, err := NewCreateTableEntityFromSQL(env, "create table t1 (id int primary key, created_at timestamp)")
+	require.NoError(t, err)
+	to, err := NewCreateTableEntityFromSQL(env, "create table t1 (id int primary key, amount double, created_at timestamp)")
+	require.NoError(t, err)
+	diff, err := from.Diff(to, EmptyDiffHints())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"no-float-money: `t1`: column `amount` holds monetary values and may not be DOUBLE; use DECIMAL",
+	}, lintViolationStrings(linter.LintDiff(diff)))
+
+	assert.Empty(t, linter.LintDiff(from.Drop()))
+}
+
+func TestLintApply(t *testing.T) {
+	env := NewTestEnv()
+	linter, err := NewLinter(env, nil)
+	require.NoError(t, err)
+	schema, err := NewSchemaFromSQL(env, `
+		create table t1 (id int primary key, created_at timestamp);
+		create table t2 (id int);
+	`)
+	require.NoError(t, err)
+
+	tt := []struct {
+		name       string
+		statements []string
+		violations []string
+		isError    bool
+	}{
+		{
+			name:       "compliant alter",
+			statements: []string{"alter table t1 add column name varchar(64)"},
+		},
+		{
+			name:       "violating alter",
+			statements: []string{"alter table t1 drop column created_at"},
+			violations: []string{"required-columns: `t1`: missing required column `created_at`"},
+		},
+		{
+			name:       "untouched tables are not linted",
+			statements: []string{"drop table t1"},
+		},
+		{
+			name: "create and alter table",
+			statements: []string{
+				"create table t3 (id int primary key)",
+				"alter table t3 add column created_at timestamp",
+			},
+		},
+		{
+			name:       "create table",
+			statements: []string{"create table t3 (id int primary key, price float, created_at timestamp)"},
+			violations: []string{"no-float-money: `t3`: column `price` holds monetary values and may not be FLOAT; use DECIMAL"},
+		},
+		{
+			name:       "rename table",
+			statements: []string{"rename table t2 to t4"},
+			violations: []string{
+				"primary-key: `t4`: table has no PRIMARY KEY",
+				"required-columns: `t4`: missing required column `created_at`",
+			},
+		},
+		{
+			name:       "invalid alter",
+			statements: []string{"alter table t1 drop column no_such_column"},
+			isError:    true,
+		},
+		{
+			name:       "alter nonexistent table",
+			statements: []string{"alter table t9 add column i int"},
+			isError:    true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var diffs []EntityDiff
+			for _, sql := range tc.statements {
+				stmt, err := env.Parser().ParseStrictDDL(sql)
+				require.NoError(t, err)
+				diffs = append(diffs, EntityDiffByStatement(stmt))
+			}
+			violations, err := linter.LintApply(schema, diffs)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.violations, lintViolationStrings(violations))
+		})
+	}
+}
+
+func TestLintViolationsError(t *testing.T) {
+	err := &LintViolationsError{Violations: []*LintViolation{
+		{Rule: LintRulePrimaryKey, Entity: "t1", Message: "table has no PRIMARY KEY"},
+	}}
+	assert.Equal(t, "schema lint found 1 violation(s):\nprimary-key: `t1`: table has no PRIMARY KEY", err.Error())
+}
@@ -0,0 +1,653 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// Routine kinds. Each kind has its own namespace, distinct from that of tables and views and distinct from each other:
+// a procedure and a function may share a name, and both may share a name with a table.
+const (
+	FunctionRoutineKind  = "function"
+	ProcedureRoutineKind = "procedure"
+	TriggerRoutineKind   = "trigger"
+	EventRoutineKind     = "event"
+)
+
+// routineEntity is implemented by the stored program entities: procedures, functions, triggers and events.
+type routineEntity interface {
+	Entity
+	// Kind returns the routine kind, e.g. "procedure"
+	Kind() string
+	createStatement() sqlparser.DDLStatement
+	dropStatement() sqlparser.DDLStatement
+	// dependentEntityNames returns the names of tables/views this routine requires to exist
+	dependentEntityNames() []string
+}
+
+// routineKey identifies a routine within a schema
+type routineKey struct {
+	kind string
+	name string
+}
+
+func routineKeyOf(r routineEntity) routineKey {
+	return routineKey{kind: r.Kind(), name: r.Name()}
+}
+
+type CreateRoutineEntityDiff struct {
+	to        routineEntity
+	statement sqlparser.DDLStatement
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *CreateRoutineEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *CreateRoutineEntityDiff) EntityName() string {
+	return d.statement.GetTable().Name.String()
+}
+
+// Entities implements EntityDiff
+func (d *CreateRoutineEntityDiff) Entities() (from Entity, to Entity) {
+	if d.to == nil {
+		return nil, nil
+	}
+	return nil, d.to
+}
+
+func (d *CreateRoutineEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *CreateRoutineEntityDiff) Statement() sqlparser.Statement {
+	if d == nil || d.statement == nil {
+		return nil
+	}
+	return d.statement
+}
+
+// StatementString implements EntityDiff
+func (d *CreateRoutineEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *CreateRoutineEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *CreateRoutineEntityDiff) SubsequentDiff() EntityDiff {
+	return nil
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *CreateRoutineEntityDiff) SetSubsequentDiff(EntityDiff) {
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *CreateRoutineEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *CreateRoutineEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &CreateRoutineEntityDiff{
+		statement: sqlparser.Clone(d.statement),
+	}
+	if d.to != nil {
+		clone.to = d.to.Clone().(routineEntity)
+	}
+	return clone
+}
+
+// DropRoutineEntityDiff drops a routine. Routines cannot be altered in place, and so a changed routine is
+// expressed as a DropRoutineEntityDiff followed by a subsequent CreateRoutineEntityDiff.
+type DropRoutineEntityDiff struct {
+	from           routineEntity
+	statement      sqlparser.DDLStatement
+	subsequentDiff *CreateRoutineEntityDiff
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *DropRoutineEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *DropRoutineEntityDiff) EntityName() string {
+	return d.statement.GetTable().Name.String()
+}
+
+// Entities implements EntityDiff
+func (d *DropRoutineEntityDiff) Entities() (from Entity, to Entity) {
+	if d.from != nil {
+		from = d.from
+	}
+	if d.subsequentDiff != nil {
+		_, to = d.subsequentDiff.Entities()
+	}
+	return from, to
+}
+
+func (d *DropRoutineEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *DropRoutineEntityDiff) Statement() sqlparser.Statement {
+	if d == nil || d.statement == nil {
+		return nil
+	}
+	return d.statement
+}
+
+// StatementString implements EntityDiff
+func (d *DropRoutineEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *DropRoutineEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *DropRoutineEntityDiff) SubsequentDiff() EntityDiff {
+	if d == nil || d.subsequentDiff == nil {
+		return nil
+	}
+	return d.subsequentDiff
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *DropRoutineEntityDiff) SetSubsequentDiff(subDiff EntityDiff) {
+	if d == nil {
+		return
+	}
+	if createDiff, ok := subDiff.(*CreateRoutineEntityDiff); ok {
+		d.subsequentDiff = createDiff
+	} else {
+		d.subsequentDiff = nil
+	}
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *DropRoutineEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *DropRoutineEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &DropRoutineEntityDiff{
+		statement: sqlparser.Clone(d.statement),
+	}
+	if d.from != nil {
+		clone.from = d.from.Clone().(routineEntity)
+	}
+	if d.subsequentDiff != nil {
+		clone.subsequentDiff = d.subsequentDiff.Clone().(*CreateRoutineEntityDiff)
+	}
+	return clone
+}
+
+// diffRoutines compares two routines of the same kind. MySQL does not support modifying the body of a routine,
+// and so when the two differ, the diff drops the first routine and then creates the second.
+func diffRoutines(from routineEntity, to routineEntity) EntityDiff {
+	if sqlparser.Equals.Statement(from.createStatement(), to.createStatement()) {
+		return nil
+	}
+	dropDiff := from.Drop().(*DropRoutineEntityDiff)
+	dropDiff.subsequentDiff = to.Create().(*CreateRoutineEntityDiff)
+	return dropDiff
+}
+
+// applyRoutineDiff returns the routine that results from applying the given diff, which is expected
+// to be a drop-and-create diff as generated by diffRoutines().
+func applyRoutineDiff(diff EntityDiff) (Entity, error) {
+	dropDiff, ok := diff.(*DropRoutineEntityDiff)
+	if !ok || dropDiff.subsequentDiff == nil || dropDiff.subsequentDiff.to == nil {
+		return nil, ErrEntityTypeMismatch
+	}
+	return dropDiff.subsequentDiff.to.Clone(), nil
+}
+
+// normalizeRoutineCharacteristics removes characteristics that are implied by default.
+func normalizeRoutineCharacteristics(characteristics *sqlparser.RoutineCharacteristics) *sqlparser.RoutineCharacteristics {
+	if characteristics == nil {
+		return nil
+	}
+	// Drop the default security model
+	if strings.EqualFold(characteristics.Security, "definer") {
+		characteristics.Security = ""
+	}
+	// Drop the default data access
+	if characteristics.DataAccess == sqlparser.ContainsSQLDataAccess {
+		characteristics.DataAccess = sqlparser.UnspecifiedDataAccess
+	}
+	if *characteristics == (sqlparser.RoutineCharacteristics{}) {
+		return nil
+	}
+	return characteristics
+}
+
+// getRoutineDependentTableNames analyzes a routine's body and extracts all tables/views it reads from or writes to.
+// Names qualified by a schema, CTEs, `dual` and tables created within the body itself are excluded, since
+// none of those are expected to exist in the schema.
+func getRoutineDependentTableNames(body sqlparser.SQLNode) (names []string) {
+	if body == nil {
+		return nil
+	}
+	excluded := map[string]bool{"dual": true}
+	var candidates []string
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node := node.(type) {
+		case *sqlparser.CommonTableExpr:
+			excluded[node.ID.String()] = true
+		case *sqlparser.CreateTable:
+			excluded[node.Table.Name.String()] = true
+		case *sqlparser.AliasedTableExpr:
+			if tableName, ok := node.Expr.(sqlparser.TableName); ok && tableName.Qualifier.IsEmpty() {
+				candidates = append(candidates, tableName.Name.String())
+			}
+		}
+		return true, nil
+	}, body)
+	seen := map[string]bool{}
+	for _, name := range candidates {
+		if excluded[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// CreateProcedureEntity stands for a stored procedure. It contains the procedure's CREATE statement.
+type CreateProcedureEntity struct {
+	*sqlparser.CreateProcedure
+	env *Environment
+}
+
+func NewCreateProcedureEntity(env *Environment, c *sqlparser.CreateProcedure) (*CreateProcedureEntity, error) {
+	if !c.IsFullyParsed() {
+		return nil, &NotFullyParsedError{Entity: c.Name.Name.String(), Statement: sqlparser.CanonicalString(c)}
+	}
+	entity := &CreateProcedureEntity{CreateProcedure: c, env: env}
+	entity.normalize()
+	return entity, nil
+}
+
+func (c *CreateProcedureEntity) normalize() {
+	c.CreateProcedure.Characteristics = normalizeRoutineCharacteristics(c.CreateProcedure.Characteristics)
+}
+
+// Name implements Entity interface
+func (c *CreateProcedureEntity) Name() string {
+	return c.CreateProcedure.Name.Name.String()
+}
+
+// Kind implements routineEntity interface
+func (c *CreateProcedureEntity) Kind() string {
+	return ProcedureRoutineKind
+}
+
+// Diff implements Entity interface function
+func (c *CreateProcedureEntity) Diff(other Entity, _ *DiffHints) (EntityDiff, error) {
+	otherCreateProcedure, ok := other.(*CreateProcedureEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return diffRoutines(c, otherCreateProcedure), nil
+}
+
+// Create implements Entity interface
+func (c *CreateProcedureEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateRoutineEntityDiff{to: c, statement: c.CreateProcedure}
+}
+
+// Drop implements Entity interface
+func (c *CreateProcedureEntity) Drop() EntityDiff {
+	return &DropRoutineEntityDiff{from: c, statement: c.dropStatement()}
+}
+
+// Apply attempts to apply given diff onto the procedure defined by this entity.
+// This entity is unmodified. If successful, a new CREATE PROCEDURE entity is returned.
+func (c *CreateProcedureEntity) Apply(diff EntityDiff) (Entity, error) {
+	to, err := applyRoutineDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := to.(*CreateProcedureEntity); !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return to, nil
+}
+
+func (c *CreateProcedureEntity) Clone() Entity {
+	return &CreateProcedureEntity{CreateProcedure: sqlparser.Clone(c.CreateProcedure), env: c.env}
+}
+
+func (c *CreateProcedureEntity) createStatement() sqlparser.DDLStatement {
+	return c.CreateProcedure
+}
+
+func (c *CreateProcedureEntity) dropStatement() sqlparser.DDLStatement {
+	return &sqlparser.DropProcedure{Name: c.CreateProcedure.Name}
+}
+
+func (c *CreateProcedureEntity) dependentEntityNames() []string {
+	return getRoutineDependentTableNames(c.Body)
+}
+
+// CreateFunctionEntity stands for a stored function. It contains the function's CREATE statement.
+type CreateFunctionEntity struct {
+	*sqlparser.CreateFunction
+	env *Environment
+}
+
+func NewCreateFunctionEntity(env *Environment, c *sqlparser.CreateFunction) (*CreateFunctionEntity, error) {
+	if !c.IsFullyParsed() {
+		return nil, &NotFullyParsedError{Entity: c.Name.Name.String(), Statement: sqlparser.CanonicalString(c)}
+	}
+	entity := &CreateFunctionEntity{CreateFunction: c, env: env}
+	entity.normalize()
+	return entity, nil
+}
+
+func (c *CreateFunctionEntity) normalize() {
+	c.CreateFunction.Characteristics = normalizeRoutineCharacteristics(c.CreateFunction.Characteristics)
+}
+
+// Name implements Entity interface
+func (c *CreateFunctionEntity) Name() string {
+	return c.CreateFunction.Name.Name.String()
+}
+
+// Kind implements routineEntity interface
+func (c *CreateFunctionEntity) Kind() string {
+	return FunctionRoutineKind
+}
+
+// Diff implements Entity interface function
+func (c *CreateFunctionEntity) Diff(other Entity, _ *DiffHints) (EntityDiff, error) {
+	otherCreateFunction, ok := other.(*CreateFunctionEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return diffRoutines(c, otherCreateFunction), nil
+}
+
+// Create implements Entity interface
+func (c *CreateFunctionEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateRoutineEntityDiff{to: c, statement: c.CreateFunction}
+}
+
+// Drop implements Entity interface
+func (c *CreateFunctionEntity) Drop() EntityDiff {
+	return &DropRoutineEntityDiff{from: c, statement: c.dropStatement()}
+}
+
+// Apply attempts to apply given diff onto the function defined by this entity.
+// This entity is unmodified. If successful, a new CREATE FUNCTION entity is returned.
+func (c *CreateFunctionEntity) Apply(diff EntityDiff) (Entity, error) {
+	to, err := applyRoutineDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := to.(*CreateFunctionEntity); !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return to, nil
+}
+
+func (c *CreateFunctionEntity) Clone() Entity {
+	return &CreateFunctionEntity{CreateFunction: sqlparser.Clone(c.CreateFunction), env: c.env}
+}
+
+func (c *CreateFunctionEntity) createStatement() sqlparser.DDLStatement {
+	return c.CreateFunction
+}
+
+func (c *CreateFunctionEntity) dropStatement() sqlparser.DDLStatement {
+	return &sqlparser.DropFunction{Name: c.CreateFunction.Name}
+}
+
+func (c *CreateFunctionEntity) dependentEntityNames() []string {
+	return getRoutineDependentTableNames(c.Body)
+}
+
+// CreateTriggerEntity stands for a trigger. It contains the trigger's CREATE statement.
+type CreateTriggerEntity struct {
+	*sqlparser.CreateTrigger
+	env *Environment
+}
+
+func NewCreateTriggerEntity(env *Environment, c *sqlparser.CreateTrigger) (*CreateTriggerEntity, error) {
+	if !c.IsFullyParsed() {
+		return nil, &NotFullyParsedError{Entity: c.Name.Name.String(), Statement: sqlparser.CanonicalString(c)}
+	}
+	return &CreateTriggerEntity{CreateTrigger: c, env: env}, nil
+}
+
+// Name implements Entity interface
+func (c *CreateTriggerEntity) Name() string {
+	return c.CreateTrigger.Name.Name.String()
+}
+
+// Kind implements routineEntity interface
+func (c *CreateTriggerEntity) Kind() string {
+	return TriggerRoutineKind
+}
+
+// TableName returns the name of the table this trigger is defined on
+func (c *CreateTriggerEntity) TableName() string {
+	return c.CreateTrigger.Table.Name.String()
+}
+
+// Diff implements Entity interface function
+func (c *CreateTriggerEntity) Diff(other Entity, _ *DiffHints) (EntityDiff, error) {
+	otherCreateTrigger, ok := other.(*CreateTriggerEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return diffRoutines(c, otherCreateTrigger), nil
+}
+
+// Create implements Entity interface
+func (c *CreateTriggerEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateRoutineEntityDiff{to: c, statement: c.CreateTrigger}
+}
+
+// Drop implements Entity interface
+func (c *CreateTriggerEntity) Drop() EntityDiff {
+	return &DropRoutineEntityDiff{from: c, statement: c.dropStatement()}
+}
+
+// Apply attempts to apply given diff onto the trigger defined by this entity.
+// This entity is unmodified. If successful, a new CREATE TRIGGER entity is returned.
+func (c *CreateTriggerEntity) Apply(diff EntityDiff) (Entity, error) {
+	to, err := applyRoutineDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := to.(*CreateTriggerEntity); !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return to, nil
+}
+
+func (c *CreateTriggerEntity) Clone() Entity {
+	return &CreateTriggerEntity{CreateTrigger: sqlparser.Clone(c.CreateTrigger), env: c.env}
+}
+
+func (c *CreateTriggerEntity) createStatement() sqlparser.DDLStatement {
+	return c.CreateTrigger
+}
+
+func (c *CreateTriggerEntity) dropStatement() sqlparser.DDLStatement {
+	return &sqlparser.DropTrigger{Name: c.CreateTrigger.Name}
+}
+
+func (c *CreateTriggerEntity) dependentEntityNames() []string {
+	names := []string{c.TableName()}
+	for _, name := range getRoutineDependentTableNames(c.Body) {
+		if name != c.TableName() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CreateEventEntity stands for a scheduled event. It contains the event's CREATE statement.
+type CreateEventEntity struct {
+	*sqlparser.CreateEvent
+	env *Environment
+}
+
+func NewCreateEventEntity(env *Environment, c *sqlparser.CreateEvent) (*CreateEventEntity, error) {
+	if !c.IsFullyParsed() {
+		return nil, &NotFullyParsedError{Entity: c.Name.Name.String(), Statement: sqlparser.CanonicalString(c)}
+	}
+	return &CreateEventEntity{CreateEvent: c, env: env}, nil
+}
+
+// Name implements Entity interface
+func (c *CreateEventEntity) Name() string {
+	return c.CreateEvent.Name.Name.String()
+}
+
+// Kind implements routineEntity interface
+func (c *CreateEventEntity) Kind() string {
+	return EventRoutineKind
+}
+
+// Diff implements Entity interface function
+func (c *CreateEventEntity) Diff(other Entity, _ *DiffHints) (EntityDiff, error) {
+	otherCreateEvent, ok := other.(*CreateEventEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return diffRoutines(c, otherCreateEvent), nil
+}
+
+// Create implements Entity interface
+func (c *CreateEventEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateRoutineEntityDiff{to: c, statement: c.CreateEvent}
+}
+
+// Drop implements Entity interface
+func (c *CreateEventEntity) Drop() EntityDiff {
+	return &DropRoutineEntityDiff{from: c, statement: c.dropStatement()}
+}
+
+// Apply attempts to apply given diff onto the event defined by this entity.
+// This entity is unmodified. If successful, a new CREATE EVENT entity is returned.
+func (c *CreateEventEntity) Apply(diff EntityDiff) (Entity, error) {
+	to, err := applyRoutineDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := to.(*CreateEventEntity); !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return to, nil
+}
+
+func (c *CreateEventEntity) Clone() Entity {
+	return &CreateEventEntity{CreateEvent: sqlparser.Clone(c.CreateEvent), env: c.env}
+}
+
+func (c *CreateEventEntity) createStatement() sqlparser.DDLStatement {
+	return c.CreateEvent
+}
+
+func (c *CreateEventEntity) dropStatement() sqlparser.DDLStatement {
+	return &sqlparser.DropEvent{Name: c.CreateEvent.Name}
+}
+
+func (c *CreateEventEntity) dependentEntityNames() []string {
+	return getRoutineDependentTableNames(c.Body)
+}
+
+// NewRoutineEntity creates a routine entity out of a CREATE PROCEDURE, CREATE FUNCTION, CREATE TRIGGER
+// or CREATE EVENT statement.
+func NewRoutineEntity(env *Environment, stmt sqlparser.Statement) (Entity, error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.CreateProcedure:
+		return NewCreateProcedureEntity(env, stmt)
+	case *sqlparser.CreateFunction:
+		return NewCreateFunctionEntity(env, stmt)
+	case *sqlparser.CreateTrigger:
+		return NewCreateTriggerEntity(env, stmt)
+	case *sqlparser.CreateEvent:
+		return NewCreateEventEntity(env, stmt)
+	}
+	return nil, ErrExpectedCreateRoutine
+}
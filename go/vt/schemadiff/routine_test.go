@@ -0,0 +1,290 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRoutineDiff(t *testing.T) {
+	tt := []struct {
+		name    string
+		from    string
+		to      string
+		diffs   []string
+		isError bool
+	}{
+		{
+			name: "identical procedures",
+			from: "create procedure p1() begin select 1; end;",
+			to:   "create procedure p1() begin select 1; end;",
+		},
+		{
+			name: "identical procedures other than default characteristics",
+			from: "create procedure p1() sql security definer contains sql begin select 1; end;",
+			to:   "create procedure p1() begin select 1; end;",
+		},
+		{
+			name: "changed procedure body",
+			from: "create procedure p1() begin select 1; end;",
+			to:   "create procedure p1() begin select 2; end;",
+			diffs: []string{
+				"DROP PROCEDURE `p1`",
+				"CREATE PROCEDURE `p1` () BEGIN SELECT 2 FROM dual; END;",
+			},
+		},
+		{
+			name: "changed function characteristics",
+			from: "create function f1(a int) returns int deterministic return a + 1;",
+			to:   "create function f1(a int) returns int return a + 1;",
+			diffs: []string{
+				"DROP FUNCTION `f1`",
+				"CREATE FUNCTION `f1` (`a` int) RETURNS int RETURN `a` + 1;",
+			},
+		},
+		{
+			name: "changed trigger timing",
+			from: "create trigger tr1 before insert on t1 for each row insert into t1_log values (new.i);",
+			to:   "create trigger tr1 after insert on t1 for each row insert into t1_log values (new.i);",
+			diffs: []string{
+				"DROP TRIGGER `tr1`",
+				"CREATE TRIGGER `tr1` AFTER INSERT ON `t1` FOR EACH ROW INSERT INTO `t1_log` VALUES (`new`.`i`);",
+			},
+		},
+		{
+			name: "created event",
+			to:   "create event ev1 on schedule every 1 day do delete from t1;",
+			diffs: []string{
+				"CREATE EVENT `ev1` ON SCHEDULE EVERY 1 day DO DELETE FROM `t1`;",
+			},
+		},
+		{
+			name: "dropped event",
+			from: "create event ev1 on schedule every 1 day do delete from t1;",
+			diffs: []string{
+				"DROP EVENT `ev1`",
+			},
+		},
+		{
+			name:    "mismatched kinds",
+			from:    "create procedure r1() begin select 1; end;",
+			to:      "create function r1() returns int return 1;",
+			isError: true,
+		},
+		{
+			name:    "not a routine",
+			from:    "create table t1 (id int primary key)",
+			isError: true,
+		},
+	}
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			diff, err := DiffCreateRoutinesQueries(env, ts.from, ts.to, EmptyDiffHints())
+			if ts.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var diffs []string
+			for _, d := range AllSubsequent(diff) {
+				diffs = append(diffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.diffs, diffs)
+		})
+	}
+}
+
+func TestRoutineSchema(t *testing.T) {
+	queries := []string{
+		"create event ev1 on schedule every 1 hour do call p1();",
+		"create trigger tr1 before insert on t1 for each row insert into t1_log values (f1(new.i));",
+		"create procedure p1() begin delete from t1 where i < 0; end;",
+		"create function f1(a int) returns int return a + 1;",
+		"create view v1 as select i from t1",
+		"create table t1 (id int primary key, i int)",
+		"create table t1_log (i int)",
+		// a procedure may share a name with a table
+		"create procedure t1() begin select * from v1; end;",
+	}
+	schema, err := NewSchemaFromQueries(NewTestEnv(), queries)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t1_log", "v1", "f1", "p1", "t1", "tr1", "ev1"}, schema.EntityNames())
+	assert.Equal(t, []string{"t1", "t1_log"}, schema.TableNames())
+	assert.Len(t, schema.Functions(), 1)
+	assert.Len(t, schema.Procedures(), 2)
+	assert.Len(t, schema.Triggers(), 1)
+	assert.Len(t, schema.Events(), 1)
+	assert.NotNil(t, schema.Procedure("t1"))
+	assert.Nil(t, schema.Function("t1"))
+	assert.Equal(t, "t1", schema.Trigger("tr1").TableName())
+	assert.NotNil(t, schema.Event("ev1"))
+	assert.IsType(t, &CreateTableEntity{}, schema.Entity("t1"))
+
+	// Schema survives a roundtrip through SQL
+	dup, err := NewSchemaFromSQL(NewTestEnv(), schema.ToSQL())
+	require.NoError(t, err)
+	assert.Equal(t, schema.ToQueries(), dup.ToQueries())
+
+	// A copy is independent of the original
+	copied := schema.copy()
+	require.True(t, copied.removeRoutine(routineKey{kind: TriggerRoutineKind, name: "tr1"}))
+	assert.Nil(t, copied.Trigger("tr1"))
+	assert.NotNil(t, schema.Trigger("tr1"))
+}
+
+func TestInvalidRoutineSchema(t *testing.T) {
+	tt := []struct {
+		name      string
+		schema    string
+		expectErr error
+	}{
+		{
+			name:   "valid trigger",
+			schema: "create table t1 (id int primary key, i int); create trigger tr1 before insert on t1 for each row insert into t1 values (new.id, new.i);",
+		},
+		{
+			name:      "trigger on nonexistent table",
+			schema:    "create table t1 (id int primary key, i int); create trigger tr1 before insert on t2 for each row insert into t1 values (new.id, new.i);",
+			expectErr: &TriggerTableNotFoundError{Trigger: "tr1", Table: "t2"},
+		},
+		{
+			name:      "trigger on view",
+			schema:    "create table t1 (id int primary key, i int); create view v1 as select * from t1; create trigger tr1 before insert on v1 for each row insert into t1 values (new.id, new.i);",
+			expectErr: &TriggerOnViewError{Trigger: "tr1", View: "v1"},
+		},
+		{
+			name:      "trigger body references nonexistent table",
+			schema:    "create table t1 (id int primary key, i int); create trigger tr1 after insert on t1 for each row insert into t1_log (id) values (new.id);",
+			expectErr: &RoutineDependencyUnresolvedError{Kind: TriggerRoutineKind, Name: "tr1", MissingReferencedEntities: []string{"t1_log"}},
+		},
+		{
+			name:      "procedure references nonexistent tables",
+			schema:    "create table t1 (id int primary key); create procedure p1() begin select * from t1 join t2 on t1.id = t2.id; delete from t3; end;",
+			expectErr: &RoutineDependencyUnresolvedError{Kind: ProcedureRoutineKind, Name: "p1", MissingReferencedEntities: []string{"t2", "t3"}},
+		},
+		{
+			name:      "function references nonexistent view",
+			schema:    "create function f1() returns int return (select count(*) from v1);",
+			expectErr: &RoutineDependencyUnresolvedError{Kind: FunctionRoutineKind, Name: "f1", MissingReferencedEntities: []string{"v1"}},
+		},
+		{
+			name:   "procedure references dual, qualified table, CTE and a table it creates",
+			schema: "create procedure p1() begin select 1 from dual; select * from other.t1; with cte as (select 1) select * from cte; create temporary table tmp (id int); insert into tmp values (1); end;",
+		},
+		{
+			name:      "duplicate procedure",
+			schema:    "create procedure p1() begin select 1; end;; create procedure p1() begin select 2; end;",
+			expectErr: &ApplyDuplicateEntityError{Entity: "p1"},
+		},
+		{
+			name:   "procedure and function share a name",
+			schema: "create procedure r1() begin select 1; end;; create function r1() returns int return 1;",
+		},
+	}
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			_, err := NewSchemaFromSQL(NewTestEnv(), ts.schema)
+			if ts.expectErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, ts.expectErr.Error())
+		})
+	}
+}
+
+func TestRoutineSchemaDiff(t *testing.T) {
+	tt := []struct {
+		name  string
+		from  string
+		to    string
+		diffs []string
+	}{
+		{
+			name: "no change",
+			from: "create table t1 (id int primary key); create table t1_log (id int); create trigger tr1 before insert on t1 for each row insert into t1_log values (new.id);",
+			to:   "create table t1 (id int primary key); create table t1_log (id int); create trigger tr1 before insert on t1 for each row insert into t1_log values (new.id);",
+		},
+		{
+			name: "create table and trigger",
+			to:   "create trigger tr1 before insert on t1 for each row insert into t1_log values (new.id);;create table t1 (id int primary key); create table t1_log (id int);",
+			diffs: []string{
+				"CREATE TABLE `t1` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)",
+				"CREATE TABLE `t1_log` (\n\t`id` int\n)",
+				"CREATE TRIGGER `tr1` BEFORE INSERT ON `t1` FOR EACH ROW INSERT INTO `t1_log` VALUES (`new`.`id`);",
+			},
+		},
+		{
+			name: "drop table and trigger",
+			from: "create table t1 (id int primary key); create table t1_log (id int); create trigger tr1 before insert on t1 for each row insert into t1_log values (new.id);",
+			diffs: []string{
+				"DROP TRIGGER `tr1`",
+				"DROP TABLE `t1_log`",
+				"DROP TABLE `t1`",
+			},
+		},
+		{
+			name: "modify procedure",
+			from: "create table t1 (id int primary key); create procedure p1() begin delete from t1; end;",
+			to:   "create table t1 (id int primary key); create procedure p1() begin delete from t1 where id > 0; end;",
+			diffs: []string{
+				"DROP PROCEDURE `p1`",
+				"CREATE PROCEDURE `p1` () BEGIN DELETE FROM `t1` WHERE `id` > 0; END;",
+			},
+		},
+		{
+			name: "replace table with another, procedure follows",
+			from: "create table t1 (id int primary key); create procedure p1() begin delete from t1; end;",
+			to:   "create table t2 (id int primary key, i int); create procedure p1() begin delete from t2; end;",
+			diffs: []string{
+				"DROP PROCEDURE `p1`",
+				"DROP TABLE `t1`",
+				"CREATE TABLE `t2` (\n\t`id` int,\n\t`i` int,\n\tPRIMARY KEY (`id`)\n)",
+				"CREATE PROCEDURE `p1` () BEGIN DELETE FROM `t2`; END;",
+			},
+		},
+	}
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			fromSchema, err := NewSchemaFromSQL(env, ts.from)
+			require.NoError(t, err)
+			toSchema, err := NewSchemaFromSQL(env, ts.to)
+			require.NoError(t, err)
+
+			schemaDiff, err := fromSchema.SchemaDiff(toSchema, EmptyDiffHints())
+			require.NoError(t, err)
+			orderedDiffs, err := schemaDiff.OrderedDiffs(context.Background())
+			require.NoError(t, err)
+			var diffs []string
+			for _, d := range orderedDiffs {
+				diffs = append(diffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.diffs, diffs)
+
+			// Applying the diffs yields the target schema
+			applied, err := fromSchema.Apply(orderedDiffs)
+			require.NoError(t, err)
+			assert.Equal(t, toSchema.ToSQL(), applied.ToSQL())
+		})
+	}
+}
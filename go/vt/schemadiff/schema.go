@@ -18,6 +18,7 @@ package schemadiff
 
 import (
 	"errors"
+	"slices"
 	"sort"
 	"strings"
 
@@ -26,14 +27,20 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 )
 
-// Schema represents a database schema, which may contain entities such as tables and views.
+// Schema represents a database schema, which may contain entities such as tables, views and routines
+// (stored procedures, stored functions, triggers and events).
 // Schema is not in itself an Entity, since it is more of a collection of entities.
 type Schema struct {
-	tables []*CreateTableEntity
-	views  []*CreateViewEntity
+	tables     []*CreateTableEntity
+	views      []*CreateViewEntity
+	functions  []*CreateFunctionEntity
+	procedures []*CreateProcedureEntity
+	triggers   []*CreateTriggerEntity
+	events     []*CreateEventEntity
 
-	named  map[string]Entity
-	sorted []Entity
+	named    map[string]Entity // tables and views
+	routines map[routineKey]Entity
+	sorted   []Entity
 
 	fkChildToParents   map[string][]*CreateTableEntity
 	fkParentToChildren map[string][]*CreateTableEntity
@@ -44,10 +51,15 @@ type Schema struct {
 // newEmptySchema is used internally to initialize a Schema object
 func newEmptySchema(env *Environment) *Schema {
 	schema := &Schema{
-		tables: []*CreateTableEntity{},
-		views:  []*CreateViewEntity{},
-		named:  map[string]Entity{},
-		sorted: []Entity{},
+		tables:     []*CreateTableEntity{},
+		views:      []*CreateViewEntity{},
+		functions:  []*CreateFunctionEntity{},
+		procedures: []*CreateProcedureEntity{},
+		triggers:   []*CreateTriggerEntity{},
+		events:     []*CreateEventEntity{},
+		named:      map[string]Entity{},
+		routines:   map[routineKey]Entity{},
+		sorted:     []Entity{},
 
 		fkChildToParents:   map[string][]*CreateTableEntity{},
 		fkParentToChildren: map[string][]*CreateTableEntity{},
@@ -66,6 +78,8 @@ func NewSchemaFromEntities(env *Environment, entities []Entity) (*Schema, error)
 			schema.tables = append(schema.tables, c)
 		case *CreateViewEntity:
 			schema.views = append(schema.views, c)
+		case routineEntity:
+			schema.addRoutine(c)
 		default:
 			return nil, &UnsupportedEntityError{Entity: c.Name(), Statement: c.Create().CanonicalStatementString()}
 		}
@@ -91,6 +105,12 @@ func NewSchemaFromStatements(env *Environment, statements []sqlparser.Statement)
 				return nil, err
 			}
 			entities = append(entities, v)
+		case *sqlparser.CreateProcedure, *sqlparser.CreateFunction, *sqlparser.CreateTrigger, *sqlparser.CreateEvent:
+			r, err := NewRoutineEntity(env, stmt)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, r)
 		default:
 			return nil, &UnsupportedStatementError{Statement: sqlparser.CanonicalString(s)}
 		}
@@ -112,7 +132,7 @@ func NewSchemaFromQueries(env *Environment, queries []string) (*Schema, error) {
 }
 
 // NewSchemaFromSQL creates a valid and normalized schema based on a SQL blob that contains
-// CREATE statements for various objects (tables, views)
+// CREATE statements for various objects (tables, views, routines)
 func NewSchemaFromSQL(env *Environment, sql string) (*Schema, error) {
 	statements, err := env.Parser().ParseMultipleIgnoreEmpty(sql)
 	if err != nil {
@@ -167,7 +187,8 @@ func (s *Schema) normalize(hints *DiffHints) error {
 	var errs error
 
 	s.named = make(map[string]Entity, len(s.tables)+len(s.views))
-	s.sorted = make([]Entity, 0, len(s.tables)+len(s.views))
+	s.routines = make(map[routineKey]Entity, len(s.functions)+len(s.procedures)+len(s.triggers)+len(s.events))
+	s.sorted = make([]Entity, 0, len(s.tables)+len(s.views)+len(s.routines))
 	// Verify no two entities share same name
 	for _, t := range s.tables {
 		name := t.Name()
@@ -183,14 +204,34 @@ func (s *Schema) normalize(hints *DiffHints) error {
 		}
 		s.named[name] = v
 	}
+	// Routines have their own namespaces, one per routine kind
+	for _, r := range s.allRoutines() {
+		key := routineKeyOf(r)
+		if _, ok := s.routines[key]; ok {
+			return &ApplyDuplicateEntityError{Entity: key.name}
+		}
+		s.routines[key] = r
+	}
 
-	// Generally speaking, we want tables and views to be sorted alphabetically
+	// Generally speaking, we want tables, views and routines to be sorted alphabetically
 	sort.SliceStable(s.tables, func(i, j int) bool {
 		return s.tables[i].Name() < s.tables[j].Name()
 	})
 	sort.SliceStable(s.views, func(i, j int) bool {
 		return s.views[i].Name() < s.views[j].Name()
 	})
+	sort.SliceStable(s.functions, func(i, j int) bool {
+		return s.functions[i].Name() < s.functions[j].Name()
+	})
+	sort.SliceStable(s.procedures, func(i, j int) bool {
+		return s.procedures[i].Name() < s.procedures[j].Name()
+	})
+	sort.SliceStable(s.triggers, func(i, j int) bool {
+		return s.triggers[i].Name() < s.triggers[j].Name()
+	})
+	sort.SliceStable(s.events, func(i, j int) bool {
+		return s.events[i].Name() < s.events[j].Name()
+	})
 
 	// More importantly, we want tables and views to be sorted in applicable order.
 	// For example, if a view v reads from table t, then t must be defined before v.
@@ -365,6 +406,41 @@ func (s *Schema) normalize(hints *DiffHints) error {
 		}
 	}
 
+	// Routines come last, after all tables and views they may depend on. MySQL does not resolve the tables
+	// referenced by a routine's body until the routine is executed, and so routines do not depend on each other.
+	for _, r := range s.allRoutines() {
+		s.sorted = append(s.sorted, r)
+	}
+	// Validate routine dependencies: a trigger must be defined on an existing table, and routines may
+	// only reference existing tables/views.
+	for _, r := range s.allRoutines() {
+		triggerTableName := ""
+		if trigger, ok := r.(*CreateTriggerEntity); ok {
+			triggerTableName = trigger.TableName()
+			switch s.named[triggerTableName].(type) {
+			case *CreateTableEntity:
+				// good
+			case *CreateViewEntity:
+				errs = errors.Join(errs, &TriggerOnViewError{Trigger: trigger.Name(), View: triggerTableName})
+			default:
+				errs = errors.Join(errs, &TriggerTableNotFoundError{Trigger: trigger.Name(), Table: triggerTableName})
+			}
+		}
+		missingReferencedEntities := []string{}
+		for _, name := range r.dependentEntityNames() {
+			if name == triggerTableName {
+				// Already validated above
+				continue
+			}
+			if _, ok := s.named[name]; !ok {
+				missingReferencedEntities = append(missingReferencedEntities, name)
+			}
+		}
+		if len(missingReferencedEntities) > 0 {
+			errs = errors.Join(errs, &RoutineDependencyUnresolvedError{Kind: r.Kind(), Name: r.Name(), MissingReferencedEntities: missingReferencedEntities})
+		}
+	}
+
 	// Validate views' referenced columns: do these columns actually exist in referenced tables/views?
 	if err := s.ValidateViewReferences(); err != nil {
 		errs = errors.Join(errs, err)
@@ -566,13 +642,118 @@ func (s *Schema) ViewNames() []string {
 	return names
 }
 
+// Functions returns this schema's stored functions, sorted by name
+func (s *Schema) Functions() []*CreateFunctionEntity {
+	var functions []*CreateFunctionEntity
+	for _, entity := range s.sorted {
+		if function, ok := entity.(*CreateFunctionEntity); ok {
+			functions = append(functions, function)
+		}
+	}
+	return functions
+}
+
+// Procedures returns this schema's stored procedures, sorted by name
+func (s *Schema) Procedures() []*CreateProcedureEntity {
+	var procedures []*CreateProcedureEntity
+	for _, entity := range s.sorted {
+		if procedure, ok := entity.(*CreateProcedureEntity); ok {
+			procedures = append(procedures, procedure)
+		}
+	}
+	return procedures
+}
+
+// Triggers returns this schema's triggers, sorted by name
+func (s *Schema) Triggers() []*CreateTriggerEntity {
+	var triggers []*CreateTriggerEntity
+	for _, entity := range s.sorted {
+		if trigger, ok := entity.(*CreateTriggerEntity); ok {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// Events returns this schema's events, sorted by name
+func (s *Schema) Events() []*CreateEventEntity {
+	var events []*CreateEventEntity
+	for _, entity := range s.sorted {
+		if event, ok := entity.(*CreateEventEntity); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// allRoutines returns all routines, grouped by kind: functions, procedures, triggers, then events.
+func (s *Schema) allRoutines() []routineEntity {
+	routines := make([]routineEntity, 0, len(s.functions)+len(s.procedures)+len(s.triggers)+len(s.events))
+	for _, r := range s.functions {
+		routines = append(routines, r)
+	}
+	for _, r := range s.procedures {
+		routines = append(routines, r)
+	}
+	for _, r := range s.triggers {
+		routines = append(routines, r)
+	}
+	for _, r := range s.events {
+		routines = append(routines, r)
+	}
+	return routines
+}
+
+// addRoutine appends the given routine to the list of routines of its kind.
+func (s *Schema) addRoutine(r routineEntity) {
+	switch r := r.(type) {
+	case *CreateFunctionEntity:
+		s.functions = append(s.functions, r)
+	case *CreateProcedureEntity:
+		s.procedures = append(s.procedures, r)
+	case *CreateTriggerEntity:
+		s.triggers = append(s.triggers, r)
+	case *CreateEventEntity:
+		s.events = append(s.events, r)
+	}
+}
+
+// removeRoutine removes the routine of the given kind and name. It returns false if no such routine exists.
+func (s *Schema) removeRoutine(key routineKey) bool {
+	if _, ok := s.routines[key]; !ok {
+		return false
+	}
+	delete(s.routines, key)
+	switch key.kind {
+	case FunctionRoutineKind:
+		s.functions = slices.DeleteFunc(s.functions, func(r *CreateFunctionEntity) bool { return r.Name() == key.name })
+	case ProcedureRoutineKind:
+		s.procedures = slices.DeleteFunc(s.procedures, func(r *CreateProcedureEntity) bool { return r.Name() == key.name })
+	case TriggerRoutineKind:
+		s.triggers = slices.DeleteFunc(s.triggers, func(r *CreateTriggerEntity) bool { return r.Name() == key.name })
+	case EventRoutineKind:
+		s.events = slices.DeleteFunc(s.events, func(r *CreateEventEntity) bool { return r.Name() == key.name })
+	}
+	return true
+}
+
+// lookup finds an entity in this schema that shares the name and namespace of the given entity.
+func (s *Schema) lookup(e Entity) (Entity, bool) {
+	if r, ok := e.(routineEntity); ok {
+		found, ok := s.routines[routineKeyOf(r)]
+		return found, ok
+	}
+	found, ok := s.named[e.Name()]
+	return found, ok
+}
+
 // Diff compares this schema with another schema, and sees what it takes to make this schema look
 // like the other. It returns a list of diffs.
 func (s *Schema) diff(other *Schema, hints *DiffHints) (diffs []EntityDiff, err error) {
 	// dropped entities
 	var dropDiffs []EntityDiff
 	for _, e := range s.Entities() {
-		if _, ok := other.named[e.Name()]; !ok {
+		if _, ok := other.lookup(e); !ok {
 			// other schema does not have the entity
 			// Entities are sorted in foreign key CREATE TABLE valid order (create parents first, then children).
 			// When issuing DROPs, we want to reverse that order. We want to first do it for children, then parents.
@@ -586,7 +767,7 @@ func (s *Schema) diff(other *Schema, hints *DiffHints) (diffs []EntityDiff, err
 	var alterDiffs []EntityDiff
 	var createDiffs []EntityDiff
 	for _, e := range other.Entities() {
-		if fromEntity, ok := s.named[e.Name()]; ok {
+		if fromEntity, ok := s.lookup(e); ok {
 			// entities exist by same name in both schemas. Let's diff them.
 			diff, err := fromEntity.Diff(e, hints)
 
@@ -695,7 +876,7 @@ func (s *Schema) heuristicallyDetectTableRenames(
 	return dropDiffs, createDiffs, renameDiffs
 }
 
-// Entity returns an entity by name, or nil if nonexistent
+// Entity returns a table or a view by name, or nil if nonexistent
 func (s *Schema) Entity(name string) Entity {
 	return s.named[name]
 }
@@ -716,6 +897,38 @@ func (s *Schema) View(name string) *CreateViewEntity {
 	return nil
 }
 
+// Function returns a stored function by name, or nil if nonexistent
+func (s *Schema) Function(name string) *CreateFunctionEntity {
+	if function, ok := s.routines[routineKey{kind: FunctionRoutineKind, name: name}].(*CreateFunctionEntity); ok {
+		return function
+	}
+	return nil
+}
+
+// Procedure returns a stored procedure by name, or nil if nonexistent
+func (s *Schema) Procedure(name string) *CreateProcedureEntity {
+	if procedure, ok := s.routines[routineKey{kind: ProcedureRoutineKind, name: name}].(*CreateProcedureEntity); ok {
+		return procedure
+	}
+	return nil
+}
+
+// Trigger returns a trigger by name, or nil if nonexistent
+func (s *Schema) Trigger(name string) *CreateTriggerEntity {
+	if trigger, ok := s.routines[routineKey{kind: TriggerRoutineKind, name: name}].(*CreateTriggerEntity); ok {
+		return trigger
+	}
+	return nil
+}
+
+// Event returns an event by name, or nil if nonexistent
+func (s *Schema) Event(name string) *CreateEventEntity {
+	if event, ok := s.routines[routineKey{kind: EventRoutineKind, name: name}].(*CreateEventEntity); ok {
+		return event
+	}
+	return nil
+}
+
 // ToStatements returns an ordered list of statements which can be applied to create the schema
 func (s *Schema) ToStatements() []sqlparser.Statement {
 	stmts := make([]sqlparser.Statement, 0, len(s.Entities()))
@@ -752,17 +965,25 @@ func (s *Schema) copy() *Schema {
 	copy(dup.tables, s.tables)
 	dup.views = make([]*CreateViewEntity, len(s.views))
 	copy(dup.views, s.views)
+	dup.functions = slices.Clone(s.functions)
+	dup.procedures = slices.Clone(s.procedures)
+	dup.triggers = slices.Clone(s.triggers)
+	dup.events = slices.Clone(s.events)
 	dup.named = make(map[string]Entity, len(s.named))
 	for k, v := range s.named {
 		dup.named[k] = v
 	}
+	dup.routines = make(map[routineKey]Entity, len(s.routines))
+	for k, v := range s.routines {
+		dup.routines[k] = v
+	}
 	dup.sorted = make([]Entity, len(s.sorted))
 	copy(dup.sorted, s.sorted)
 	return dup
 }
 
 // apply attempts to apply given list of diffs to this object.
-// These diffs are CREATE/DROP/ALTER TABLE/VIEW.
+// These diffs are CREATE/DROP/ALTER TABLE/VIEW, and CREATE/DROP of routines.
 func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 	for _, diff := range diffs {
 		switch diff := diff.(type) {
@@ -869,6 +1090,24 @@ func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 			if !found {
 				return &ApplyTableNotFoundError{Table: diff.from.Table.Name.String()}
 			}
+		case *CreateRoutineEntityDiff:
+			if err := s.applyCreateRoutine(diff); err != nil {
+				return err
+			}
+		case *DropRoutineEntityDiff:
+			// We expect the routine to exist
+			if diff.from == nil {
+				return &UnsupportedApplyOperationError{Statement: diff.CanonicalStatementString()}
+			}
+			if !s.removeRoutine(routineKeyOf(diff.from)) {
+				return &ApplyRoutineNotFoundError{Kind: diff.from.Kind(), Name: diff.from.Name()}
+			}
+			if diff.subsequentDiff != nil {
+				// The routine is being replaced
+				if err := s.applyCreateRoutine(diff.subsequentDiff); err != nil {
+					return err
+				}
+			}
 		default:
 			return &UnsupportedApplyOperationError{Statement: diff.CanonicalStatementString()}
 		}
@@ -879,6 +1118,20 @@ func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 	return nil
 }
 
+// applyCreateRoutine adds the routine created by the given diff. We expect the routine to not exist.
+func (s *Schema) applyCreateRoutine(diff *CreateRoutineEntityDiff) error {
+	if diff.to == nil {
+		return &UnsupportedApplyOperationError{Statement: diff.CanonicalStatementString()}
+	}
+	key := routineKeyOf(diff.to)
+	if _, ok := s.routines[key]; ok {
+		return &ApplyDuplicateEntityError{Entity: key.name}
+	}
+	s.addRoutine(diff.to)
+	s.routines[key] = diff.to
+	return nil
+}
+
 // Apply attempts to apply given list of diffs to the schema described by this object.
 // These diffs are CREATE/DROP/ALTER TABLE/VIEW.
 // The operation does not modify this object. Instead, if successful, a new (modified) Schema is returned.
@@ -1041,6 +1294,14 @@ func (s *Schema) SchemaDiff(other *Schema, hints *DiffHints) (*SchemaDiff, error
 			}, diff.Statement())
 		case *DropTableEntityDiff:
 			// No need to handle. Any dependencies will be resolved by any of the other cases
+		case *CreateRoutineEntityDiff:
+			if diff.to != nil {
+				checkDependencies(diff, diff.to.dependentEntityNames())
+			}
+		case *DropRoutineEntityDiff:
+			if diff.from != nil {
+				checkDependencies(diff, diff.from.dependentEntityNames())
+			}
 		}
 	}
 
@@ -1073,6 +1334,10 @@ func (s *Schema) ValidateViewReferences() error {
 	// that only depend on those tables (or on dual), then 2nd tier views, etc.
 	// Thus, the order of iteration below is valid and sufficient, to build
 	for _, e := range s.Entities() {
+		if _, ok := e.(routineEntity); ok {
+			// Routines do not have columns, and views cannot read from them
+			continue
+		}
 		entityColumns, err := s.getEntityColumnNames(e.Name(), schemaInformation)
 		if err != nil {
 			errs = errors.Join(errs, err)
@@ -84,6 +84,11 @@ func (si *declarativeSchemaInformation) FindMirrorRule(tablename sqlparser.Table
 	return nil, nil
 }
 
+// FindWeightedRoutingRule implements semantics.SchemaInformation.
+func (si *declarativeSchemaInformation) FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	return nil, nil
+}
+
 // addTable adds a fake table with an empty column list
 func (si *declarativeSchemaInformation) addTable(tableName string) {
 	tbl := &vindexes.BaseTable{
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,8 +30,10 @@ import (
 
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtctl/schematools"
@@ -53,6 +56,8 @@ type TabletExecutor struct {
 	tmc                 tmclient.TabletManagerClient
 	logger              logutil.Logger
 	tablets             []*topodatapb.Tablet
+	shards              []string
+	isSharded           bool
 	isClosed            bool
 	keyspace            string
 	waitReplicasTimeout time.Duration
@@ -60,6 +65,8 @@ type TabletExecutor struct {
 	uuids               []string
 	batchSize           int64
 	parser              *sqlparser.Parser
+	lintEnv             *schemadiff.Environment
+	lintConfig          *schemadiff.LintConfig
 }
 
 // NewTabletExecutor creates a new TabletExecutor instance
@@ -102,6 +109,26 @@ func (exec *TabletExecutor) SetUUIDList(uuids []string) error {
 	return nil
 }
 
+// SetShards restricts the executor to the given shards of the keyspace. By default, schema changes
+// apply to all shards.
+func (exec *TabletExecutor) SetShards(shards []string) {
+	exec.shards = shards
+}
+
+// SetLintConfig enables schema linting: Validate rejects schema changes that violate the lint rules
+// of the given config. A nil config means the default rules.
+func (exec *TabletExecutor) SetLintConfig(env *schemadiff.Environment, config *schemadiff.LintConfig) error {
+	if config == nil {
+		config = schemadiff.DefaultLintConfig()
+	}
+	if _, err := schemadiff.NewLinter(env, config); err != nil {
+		return err
+	}
+	exec.lintEnv = env
+	exec.lintConfig = config
+	return nil
+}
+
 // hasProvidedUUIDs returns true when UUIDs were provided
 func (exec *TabletExecutor) hasProvidedUUIDs() bool {
 	return len(exec.uuids) != 0
@@ -119,6 +146,12 @@ func (exec *TabletExecutor) Open(ctx context.Context, keyspace string) error {
 	}
 	exec.tablets = make([]*topodatapb.Tablet, 0, len(shards))
 	for shardName, shardInfo := range shards {
+		if key.KeyRangeIsPartial(shardInfo.KeyRange) {
+			exec.isSharded = true
+		}
+		if len(exec.shards) > 0 && !slices.Contains(exec.shards, shardName) {
+			continue
+		}
 		if !shardInfo.HasPrimary() {
 			return fmt.Errorf("shard: %s does not have a primary", shardName)
 		}
@@ -144,10 +177,62 @@ func (exec *TabletExecutor) Validate(ctx context.Context, sqls []string) error {
 	if err := exec.parseDDLs(sqls); err != nil {
 		return err
 	}
+	if exec.lintConfig != nil {
+		if err := exec.lint(ctx, sqls); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// lint checks the DDL statements against the lint rules, as applied onto the current schema of the
+// first shard's primary.
+func (exec *TabletExecutor) lint(ctx context.Context, sqls []string) error {
+	var diffs []schemadiff.EntityDiff
+	for _, sql := range sqls {
+		stmt, err := exec.parser.Parse(sql)
+		if err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "failed to parse sql: %s, got error: %v", sql, err)
+		}
+		if diff := schemadiff.EntityDiffByStatement(stmt); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	schemaDefinition, err := exec.tmc.GetSchema(ctx, exec.tablets[0], &tabletmanagerdatapb.GetSchemaRequest{TableSchemaOnly: true})
+	if err != nil {
+		return vterrors.Wrapf(err, "unable to read schema for lint")
+	}
+	var queries []string
+	for _, td := range schemaDefinition.TableDefinitions {
+		queries = append(queries, td.Schema)
+	}
+	currentSchema, err := schemadiff.NewSchemaFromQueries(exec.lintEnv, queries)
+	if err != nil {
+		return vterrors.Wrapf(err, "unable to load schema for lint")
+	}
+	config := *exec.lintConfig
+	config.Sharded = exec.isSharded
+	linter, err := schemadiff.NewLinter(exec.lintEnv, &config)
+	if err != nil {
+		return err
+	}
+	violations, err := linter.LintApply(currentSchema, diffs)
+	if err != nil {
+		return vterrors.Wrapf(err, "unable to lint schema changes")
+	}
+	if len(violations) > 0 {
+		for _, v := range violations {
+			exec.logger.Errorf("schema lint: %s", v.String())
+		}
+		return vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, (&schemadiff.LintViolationsError{Violations: violations}).Error())
+	}
+	return nil
+}
+
 func (exec *TabletExecutor) parseDDLs(sqls []string) error {
 	for _, sql := range sqls {
 		stmt, err := exec.parser.Parse(sql)
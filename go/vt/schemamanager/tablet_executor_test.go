@@ -26,12 +26,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/sqlparser"
 )
 
@@ -76,6 +78,38 @@ func TestTabletExecutorOpenWithEmptyPrimaryAlias(t *testing.T) {
 	executor.Close()
 }
 
+func TestTabletExecutorOpenWithShards(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "test_cell")
+	for i, tablet := range []*topodatapb.Tablet{
+		{Keyspace: "test_keyspace", Shard: "-80", Type: topodatapb.TabletType_PRIMARY},
+		{Keyspace: "test_keyspace", Shard: "80-", Type: topodatapb.TabletType_REPLICA},
+	} {
+		tablet.Alias = &topodatapb.TabletAlias{Cell: "test_cell", Uid: uint32(i + 1)}
+		err := ts.InitTablet(ctx, tablet, false /*allowPrimaryOverride*/, true /*createShardAndKeyspace*/, false /*allowUpdate*/)
+		require.NoError(t, err)
+	}
+	_, err := ts.UpdateShardFields(ctx, "test_keyspace", "-80", func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = &topodatapb.TabletAlias{Cell: "test_cell", Uid: 1}
+		return nil
+	})
+	require.NoError(t, err)
+
+	executor := NewTabletExecutor("TestTabletExecutorOpenWithShards", ts, newFakeTabletManagerClient(), logutil.NewConsoleLogger(), testWaitReplicasTimeout, 0, sqlparser.NewTestParser())
+	err = executor.Open(ctx, "test_keyspace")
+	require.ErrorContains(t, err, "does not have a primary")
+	executor.Close()
+
+	executor.SetShards([]string{"-80"})
+	err = executor.Open(ctx, "test_keyspace")
+	require.NoError(t, err)
+	defer executor.Close()
+	require.Len(t, executor.tablets, 1)
+	assert.Equal(t, "-80", executor.tablets[0].Shard)
+	assert.True(t, executor.isSharded)
+}
+
 func TestTabletExecutorValidate(t *testing.T) {
 	fakeTmc := newFakeTabletManagerClient()
 
@@ -184,6 +218,50 @@ func TestTabletExecutorDML(t *testing.T) {
 	require.NoError(t, err, "executor.Validate should succeed, for DML to unsharded keyspace")
 }
 
+func TestTabletExecutorLint(t *testing.T) {
+	fakeTmc := newFakeTabletManagerClient()
+
+	fakeTmc.AddSchemaDefinition("vt_test_keyspace", &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:   "test_table",
+				Schema: "CREATE TABLE `test_table` (`id` int NOT NULL, `created_at` timestamp NULL, PRIMARY KEY (`id`))",
+				Type:   tmutils.TableBaseTable,
+			},
+		},
+	})
+
+	executor := NewTabletExecutor("TestTabletExecutorLint", newFakeTopo(t), fakeTmc, logutil.NewConsoleLogger(), testWaitReplicasTimeout, 0, sqlparser.NewTestParser())
+	ctx := context.Background()
+
+	executor.Open(ctx, "unsharded_keyspace")
+	defer executor.Close()
+
+	err := executor.Validate(ctx, []string{"ALTER TABLE test_table DROP COLUMN created_at"})
+	require.NoError(t, err, "linting is disabled by default")
+
+	env := schemadiff.NewTestEnv()
+	err = executor.SetLintConfig(env, &schemadiff.LintConfig{DisabledRules: []string{"no-such-rule"}})
+	require.Error(t, err)
+
+	err = executor.SetLintConfig(env, nil)
+	require.NoError(t, err)
+
+	err = executor.Validate(ctx, []string{
+		"ALTER TABLE test_table ADD COLUMN name varchar(64)",
+		"CREATE TABLE test_table_02 (id int PRIMARY KEY, price decimal(10,2), created_at timestamp)",
+	})
+	require.NoError(t, err)
+
+	err = executor.Validate(ctx, []string{"ALTER TABLE test_table DROP COLUMN created_at"})
+	require.ErrorContains(t, err, "required-columns: `test_table`: missing required column `created_at`")
+
+	err = executor.Validate(ctx, []string{"CREATE TABLE test_table_02 (id int, price float, created_at timestamp)"})
+	require.ErrorContains(t, err, "schema lint found 2 violation(s)")
+	require.ErrorContains(t, err, "primary-key: `test_table_02`")
+	require.ErrorContains(t, err, "no-float-money: `test_table_02`")
+}
+
 func TestTabletExecutorExecute(t *testing.T) {
 	executor := newFakeExecutor(t)
 	ctx := context.Background()
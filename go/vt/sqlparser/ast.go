@@ -531,14 +531,53 @@ type (
 
 	// CreateProcedure represents a CREATE PROCEDURE statement.
 	CreateProcedure struct {
+		Name            TableName
+		Comments        *ParsedComments
+		IfNotExists     bool
+		Definer         *Definer
+		Params          []*ProcParameter
+		Characteristics *RoutineCharacteristics
+		Body            CompoundStatement
+	}
+
+	// CreateFunction represents a CREATE FUNCTION statement.
+	CreateFunction struct {
+		Name            TableName
+		Comments        *ParsedComments
+		IfNotExists     bool
+		Definer         *Definer
+		Params          []*ProcParameter
+		Returns         *ColumnType
+		Characteristics *RoutineCharacteristics
+		Body            CompoundStatement
+	}
+
+	// CreateTrigger represents a CREATE TRIGGER statement.
+	CreateTrigger struct {
 		Name        TableName
 		Comments    *ParsedComments
 		IfNotExists bool
 		Definer     *Definer
-		Params      []*ProcParameter
+		Timing      TriggerTiming
+		Event       TriggerEvent
+		Table       TableName
+		Order       *TriggerOrder
 		Body        CompoundStatement
 	}
 
+	// CreateEvent represents a CREATE EVENT statement.
+	CreateEvent struct {
+		Name                 TableName
+		Comments             *ParsedComments
+		IfNotExists          bool
+		Definer              *Definer
+		Schedule             *EventSchedule
+		OnCompletionPreserve bool
+		Status               EventStatus
+		Comment              *Literal
+		Body                 CompoundStatement
+	}
+
 	// AlterTable represents a ALTER TABLE statement.
 	AlterTable struct {
 		Table           TableName
@@ -572,6 +611,27 @@ type (
 		IfExists bool
 	}
 
+	// DropFunction represents a DROP FUNCTION statement.
+	DropFunction struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
+	// DropTrigger represents a DROP TRIGGER statement.
+	DropTrigger struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
+	// DropEvent represents a DROP EVENT statement.
+	DropEvent struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
 	// IgnoreOrReplaceType represents conflict handling mode for CREATE TABLE ... SELECT
 	IgnoreOrReplaceType int8
 
@@ -841,6 +901,11 @@ type (
 		Condition HandlerCondition
 		SetValues []*SignalSet
 	}
+
+	// ReturnStatement represents a RETURN statement of a stored function
+	ReturnStatement struct {
+		Expr Expr
+	}
 )
 
 func (*SingleStatement) iCompoundStatement()   {}
@@ -850,6 +915,7 @@ func (*DeclareVar) iCompoundStatement()        {}
 func (*DeclareHandler) iCompoundStatement()    {}
 func (*DeclareCondition) iCompoundStatement()  {}
 func (*Signal) iCompoundStatement()            {}
+func (*ReturnStatement) iCompoundStatement()   {}
 
 // SignalConditionName is an enum for the name of the condition variable being set in SIGNAL statement
 type SignalConditionName int8
@@ -943,6 +1009,9 @@ func (*AlterTable) iStatement()            {}
 func (*AlterVschema) iStatement()          {}
 func (*AlterMigration) iStatement()        {}
 func (*CreateProcedure) iStatement()       {}
+func (*CreateFunction) iStatement()        {}
+func (*CreateTrigger) iStatement()         {}
+func (*CreateEvent) iStatement()           {}
 func (*RevertMigration) iStatement()       {}
 func (*ShowMigrationLogs) iStatement()     {}
 func (*ShowThrottledApps) iStatement()     {}
@@ -961,6 +1030,9 @@ func (*DeallocateStmt) iStatement()        {}
 func (*PurgeBinaryLogs) iStatement()       {}
 func (*Kill) iStatement()                  {}
 func (*DropProcedure) iStatement()         {}
+func (*DropFunction) iStatement()          {}
+func (*DropTrigger) iStatement()           {}
+func (*DropEvent) iStatement()             {}
 
 func (*CreateView) iDDLStatement()      {}
 func (*AlterView) iDDLStatement()       {}
@@ -971,7 +1043,13 @@ func (*AlterTable) iDDLStatement()      {}
 func (*TruncateTable) iDDLStatement()   {}
 func (*RenameTable) iDDLStatement()     {}
 func (*CreateProcedure) iDDLStatement() {}
+func (*CreateFunction) iDDLStatement()  {}
+func (*CreateTrigger) iDDLStatement()   {}
+func (*CreateEvent) iDDLStatement()     {}
 func (*DropProcedure) iDDLStatement()   {}
+func (*DropFunction) iDDLStatement()    {}
+func (*DropTrigger) iDDLStatement()     {}
+func (*DropEvent) iDDLStatement()       {}
 
 func (*AddConstraintDefinition) iAlterOption() {}
 func (*AddIndexDefinition) iAlterOption()      {}
@@ -1023,15 +1101,51 @@ func (*RenameTable) IsFullyParsed() bool {
 // IsFullyParsed implements the DDLStatement interface
 func (node *CreateProcedure) IsFullyParsed() bool { return true }
 
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateFunction) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateTrigger) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateEvent) IsFullyParsed() bool { return true }
+
 // IsFullyParsed implements the DDLStatement interface
 func (node *DropProcedure) IsFullyParsed() bool { return true }
 
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropFunction) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropTrigger) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropEvent) IsFullyParsed() bool { return true }
+
 // SetFullyParsed implements the DDL interface
 func (node *DropProcedure) SetFullyParsed(fullyParsed bool) {}
 
+// SetFullyParsed implements the DDL interface
+func (node *DropFunction) SetFullyParsed(fullyParsed bool) {}
+
+// SetFullyParsed implements the DDL interface
+func (node *DropTrigger) SetFullyParsed(fullyParsed bool) {}
+
+// SetFullyParsed implements the DDL interface
+func (node *DropEvent) SetFullyParsed(fullyParsed bool) {}
+
 // SetFullyParsed implements the DDLStatement interface
 func (node *CreateProcedure) SetFullyParsed(bool) {}
 
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateFunction) SetFullyParsed(bool) {}
+
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateTrigger) SetFullyParsed(bool) {}
+
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateEvent) SetFullyParsed(bool) {}
+
 // SetFullyParsed implements the DDLStatement interface
 func (node *RenameTable) SetFullyParsed(fullyParsed bool) {}
 
@@ -1095,11 +1209,35 @@ func (*TruncateTable) IsTemporary() bool {
 // IsTemporary implements the DDLStatement interface
 func (node *CreateProcedure) IsTemporary() bool { return false }
 
+// IsTemporary implements the DDLStatement interface
+func (node *CreateFunction) IsTemporary() bool { return false }
+
+// IsTemporary implements the DDLStatement interface
+func (node *CreateTrigger) IsTemporary() bool { return false }
+
+// IsTemporary implements the DDLStatement interface
+func (node *CreateEvent) IsTemporary() bool { return false }
+
 // IsTemporary implements the DDL interface
 func (node *DropProcedure) IsTemporary() bool {
 	return false
 }
 
+// IsTemporary implements the DDL interface
+func (node *DropFunction) IsTemporary() bool {
+	return false
+}
+
+// IsTemporary implements the DDL interface
+func (node *DropTrigger) IsTemporary() bool {
+	return false
+}
+
+// IsTemporary implements the DDL interface
+func (node *DropEvent) IsTemporary() bool {
+	return false
+}
+
 // IsTemporary implements the DDLStatement interface
 func (*RenameTable) IsTemporary() bool {
 	return false
@@ -1178,11 +1316,35 @@ func (node *RenameTable) GetTable() TableName {
 // GetTable implements the DDLStatement interface
 func (node *CreateProcedure) GetTable() TableName { return node.Name }
 
+// GetTable implements the DDLStatement interface
+func (node *CreateFunction) GetTable() TableName { return node.Name }
+
+// GetTable implements the DDLStatement interface
+func (node *CreateTrigger) GetTable() TableName { return node.Name }
+
+// GetTable implements the DDLStatement interface
+func (node *CreateEvent) GetTable() TableName { return node.Name }
+
 // GetTable implements the DDL interface
 func (node *DropProcedure) GetTable() TableName {
 	return node.Name
 }
 
+// GetTable implements the DDL interface
+func (node *DropFunction) GetTable() TableName {
+	return node.Name
+}
+
+// GetTable implements the DDL interface
+func (node *DropTrigger) GetTable() TableName {
+	return node.Name
+}
+
+// GetTable implements the DDL interface
+func (node *DropEvent) GetTable() TableName {
+	return node.Name
+}
+
 // GetAction implements the DDLStatement interface
 func (node *TruncateTable) GetAction() DDLAction {
 	return TruncateDDLAction
@@ -1228,11 +1390,41 @@ func (node *DropProcedure) GetAction() DDLAction {
 	return DropDDLAction
 }
 
+// GetAction implements the DDL interface
+func (node *DropFunction) GetAction() DDLAction {
+	return DropDDLAction
+}
+
+// GetAction implements the DDL interface
+func (node *DropTrigger) GetAction() DDLAction {
+	return DropDDLAction
+}
+
+// GetAction implements the DDL interface
+func (node *DropEvent) GetAction() DDLAction {
+	return DropDDLAction
+}
+
 // GetAction implements the DDLStatement interface
 func (node *CreateProcedure) GetAction() DDLAction {
 	return CreateProcedureAction
 }
 
+// GetAction implements the DDLStatement interface
+func (node *CreateFunction) GetAction() DDLAction {
+	return CreateDDLAction
+}
+
+// GetAction implements the DDLStatement interface
+func (node *CreateTrigger) GetAction() DDLAction {
+	return CreateDDLAction
+}
+
+// GetAction implements the DDLStatement interface
+func (node *CreateEvent) GetAction() DDLAction {
+	return CreateDDLAction
+}
+
 // GetOptLike implements the DDLStatement interface
 func (node *CreateTable) GetOptLike() *OptLike {
 	return node.OptLike
@@ -1278,11 +1470,41 @@ func (node *CreateProcedure) GetOptLike() *OptLike {
 	return nil
 }
 
+// GetOptLike implements the DDLStatement interface
+func (node *CreateFunction) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDLStatement interface
+func (node *CreateTrigger) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDLStatement interface
+func (node *CreateEvent) GetOptLike() *OptLike {
+	return nil
+}
+
 // GetOptLike implements the DDL interface
 func (node *DropProcedure) GetOptLike() *OptLike {
 	return nil
 }
 
+// GetOptLike implements the DDL interface
+func (node *DropFunction) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDL interface
+func (node *DropTrigger) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDL interface
+func (node *DropEvent) GetOptLike() *OptLike {
+	return nil
+}
+
 // GetIfExists implements the DDLStatement interface
 func (node *RenameTable) GetIfExists() bool {
 	return false
@@ -1328,11 +1550,41 @@ func (node *DropProcedure) GetIfExists() bool {
 	return node.IfExists
 }
 
+// GetIfExists implements the DDL interface
+func (node *DropFunction) GetIfExists() bool {
+	return node.IfExists
+}
+
+// GetIfExists implements the DDL interface
+func (node *DropTrigger) GetIfExists() bool {
+	return node.IfExists
+}
+
+// GetIfExists implements the DDL interface
+func (node *DropEvent) GetIfExists() bool {
+	return node.IfExists
+}
+
 // GetIfExists implements the DDLStatement interface
 func (node *CreateProcedure) GetIfExists() bool {
 	return false
 }
 
+// GetIfExists implements the DDLStatement interface
+func (node *CreateFunction) GetIfExists() bool {
+	return false
+}
+
+// GetIfExists implements the DDLStatement interface
+func (node *CreateTrigger) GetIfExists() bool {
+	return false
+}
+
+// GetIfExists implements the DDLStatement interface
+func (node *CreateEvent) GetIfExists() bool {
+	return false
+}
+
 // GetIfNotExists implements the DDLStatement interface
 func (node *RenameTable) GetIfNotExists() bool {
 	return false
@@ -1378,11 +1630,41 @@ func (node *CreateProcedure) GetIfNotExists() bool {
 	return node.IfNotExists
 }
 
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateFunction) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateTrigger) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateEvent) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
 // GetIfNotExists implements the DDL interface
 func (node *DropProcedure) GetIfNotExists() bool {
 	return false
 }
 
+// GetIfNotExists implements the DDL interface
+func (node *DropFunction) GetIfNotExists() bool {
+	return false
+}
+
+// GetIfNotExists implements the DDL interface
+func (node *DropTrigger) GetIfNotExists() bool {
+	return false
+}
+
+// GetIfNotExists implements the DDL interface
+func (node *DropEvent) GetIfNotExists() bool {
+	return false
+}
+
 // GetIsReplace implements the DDLStatement interface
 func (node *RenameTable) GetIsReplace() bool {
 	return false
@@ -1428,11 +1710,41 @@ func (node *CreateProcedure) GetIsReplace() bool {
 	return false
 }
 
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateFunction) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateTrigger) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateEvent) GetIsReplace() bool {
+	return false
+}
+
 // GetIsReplace implements the DDL interface
 func (node *DropProcedure) GetIsReplace() bool {
 	return false
 }
 
+// GetIsReplace implements the DDL interface
+func (node *DropFunction) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDL interface
+func (node *DropTrigger) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDL interface
+func (node *DropEvent) GetIsReplace() bool {
+	return false
+}
+
 // GetTableSpec implements the DDLStatement interface
 func (node *CreateTable) GetTableSpec() *TableSpec {
 	return node.TableSpec
@@ -1478,11 +1790,41 @@ func (node *CreateProcedure) GetTableSpec() *TableSpec {
 	return nil
 }
 
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateFunction) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateTrigger) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateEvent) GetTableSpec() *TableSpec {
+	return nil
+}
+
 // GetTableSpec implements the DDL interface
 func (node *DropProcedure) GetTableSpec() *TableSpec {
 	return nil
 }
 
+// GetTableSpec implements the DDL interface
+func (node *DropFunction) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDL interface
+func (node *DropTrigger) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDL interface
+func (node *DropEvent) GetTableSpec() *TableSpec {
+	return nil
+}
+
 // GetFromTables implements the DDLStatement interface
 func (node *RenameTable) GetFromTables() TableNames {
 	var fromTables TableNames
@@ -1532,11 +1874,41 @@ func (node *CreateProcedure) GetFromTables() TableNames {
 	return nil
 }
 
+// GetFromTables implements the DDLStatement interface
+func (node *CreateFunction) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDLStatement interface
+func (node *CreateTrigger) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDLStatement interface
+func (node *CreateEvent) GetFromTables() TableNames {
+	return nil
+}
+
 // GetFromTables implements the DDL interface
 func (node *DropProcedure) GetFromTables() TableNames {
 	return nil
 }
 
+// GetFromTables implements the DDL interface
+func (node *DropFunction) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDL interface
+func (node *DropTrigger) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDL interface
+func (node *DropEvent) GetFromTables() TableNames {
+	return nil
+}
+
 // SetFromTables implements DDLStatement.
 func (node *RenameTable) SetFromTables(tables TableNames) {
 	if len(node.TablePairs) != len(tables) {
@@ -1587,9 +1959,33 @@ func (node *CreateProcedure) SetFromTables(tables TableNames) {
 	// irrelevant
 }
 
+// SetFromTables implements the DDLStatement interface
+func (node *CreateFunction) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
+// SetFromTables implements the DDLStatement interface
+func (node *CreateTrigger) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
+// SetFromTables implements the DDLStatement interface
+func (node *CreateEvent) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
 // SetFromTables implements the DDL interface
 func (node *DropProcedure) SetFromTables(tables TableNames) {}
 
+// SetFromTables implements the DDL interface
+func (node *DropFunction) SetFromTables(tables TableNames) {}
+
+// SetFromTables implements the DDL interface
+func (node *DropTrigger) SetFromTables(tables TableNames) {}
+
+// SetFromTables implements the DDL interface
+func (node *DropEvent) SetFromTables(tables TableNames) {}
+
 // SetComments implements Commented interface.
 func (node *RenameTable) SetComments(comments Comments) {
 	// irrelevant
@@ -1675,11 +2071,41 @@ func (node *CreateProcedure) SetComments(comments Comments) {
 	node.Comments = comments.Parsed()
 }
 
+// SetComments for CreateFunction
+func (node *CreateFunction) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments for CreateTrigger
+func (node *CreateTrigger) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments for CreateEvent
+func (node *CreateEvent) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
 // SetComments implements the DDL interface
 func (node *DropProcedure) SetComments(comments Comments) {
 	node.Comments = comments.Parsed()
 }
 
+// SetComments implements the DDL interface
+func (node *DropFunction) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments implements the DDL interface
+func (node *DropTrigger) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments implements the DDL interface
+func (node *DropEvent) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
 // GetParsedComments implements Commented interface.
 func (node *RenameTable) GetParsedComments() *ParsedComments {
 	// irrelevant
@@ -1768,11 +2194,35 @@ func (node *VStream) GetParsedComments() *ParsedComments {
 // GetParsedComments implements Commented interface.
 func (node *CreateProcedure) GetParsedComments() *ParsedComments { return node.Comments }
 
+// GetParsedComments implements Commented interface.
+func (node *CreateFunction) GetParsedComments() *ParsedComments { return node.Comments }
+
+// GetParsedComments implements Commented interface.
+func (node *CreateTrigger) GetParsedComments() *ParsedComments { return node.Comments }
+
+// GetParsedComments implements Commented interface.
+func (node *CreateEvent) GetParsedComments() *ParsedComments { return node.Comments }
+
 // GetParsedComments implements the DDL interface
 func (node *DropProcedure) GetParsedComments() *ParsedComments {
 	return node.Comments
 }
 
+// GetParsedComments implements the DDL interface
+func (node *DropFunction) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
+// GetParsedComments implements the DDL interface
+func (node *DropTrigger) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
+// GetParsedComments implements the DDL interface
+func (node *DropEvent) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
 // GetToTables implements the DDLStatement interface
 func (node *RenameTable) GetToTables() TableNames {
 	var toTables TableNames
@@ -1828,11 +2278,41 @@ func (node *CreateProcedure) GetToTables() TableNames {
 	return nil
 }
 
+// GetToTables implements the DDLStatement interface
+func (node *CreateFunction) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDLStatement interface
+func (node *CreateTrigger) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDLStatement interface
+func (node *CreateEvent) GetToTables() TableNames {
+	return nil
+}
+
 // GetToTables implements the DDL interface
 func (node *DropProcedure) GetToTables() TableNames {
 	return nil
 }
 
+// GetToTables implements the DDL interface
+func (node *DropFunction) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDL interface
+func (node *DropTrigger) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDL interface
+func (node *DropEvent) GetToTables() TableNames {
+	return nil
+}
+
 // AffectedTables returns the list table names affected by the DDLStatement.
 func (node *RenameTable) AffectedTables() TableNames {
 	list := make(TableNames, 0, 2*len(node.TablePairs))
@@ -1890,11 +2370,41 @@ func (node *CreateProcedure) AffectedTables() TableNames {
 	return TableNames{node.GetTable()}
 }
 
+// AffectedTables implements the DDLStatement interface
+func (node *CreateFunction) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDLStatement interface
+func (node *CreateTrigger) AffectedTables() TableNames {
+	return TableNames{node.Table}
+}
+
+// AffectedTables implements the DDLStatement interface
+func (node *CreateEvent) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
 // AffectedTables implements the DDL interface
 func (node *DropProcedure) AffectedTables() TableNames {
 	return TableNames{node.GetTable()}
 }
 
+// AffectedTables implements the DDL interface
+func (node *DropFunction) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDL interface
+func (node *DropTrigger) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDL interface
+func (node *DropEvent) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
 // SetTable implements DDLStatement.
 func (node *TruncateTable) SetTable(qualifier string, name string) {
 	node.Table.Qualifier = NewIdentifierCS(qualifier)
@@ -1940,12 +2450,48 @@ func (node *CreateProcedure) SetTable(qualifier string, name string) {
 	node.Name.Name = NewIdentifierCS(name)
 }
 
+// SetTable implements the DDLStatement interface
+func (node *CreateFunction) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDLStatement interface
+func (node *CreateTrigger) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDLStatement interface
+func (node *CreateEvent) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
 // SetTable implements the DDL interface
 func (node *DropProcedure) SetTable(qualifier string, name string) {
 	node.Name.Qualifier = NewIdentifierCS(qualifier)
 	node.Name.Name = NewIdentifierCS(name)
 }
 
+// SetTable implements the DDL interface
+func (node *DropFunction) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDL interface
+func (node *DropTrigger) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDL interface
+func (node *DropEvent) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
 func (*DropDatabase) iDBDDLStatement()   {}
 func (*CreateDatabase) iDBDDLStatement() {}
 func (*AlterDatabase) iDBDDLStatement()  {}
@@ -2062,6 +2608,42 @@ type ProcParameter struct {
 // ProcParameterMode is an enum for ProcParameter.Mode
 type ProcParameterMode int8
 
+// RoutineCharacteristics represents the characteristics of a stored procedure or function
+type RoutineCharacteristics struct {
+	Comment       *Literal
+	Deterministic bool
+	DataAccess    RoutineDataAccess
+	Security      string
+}
+
+// RoutineDataAccess is an enum for RoutineCharacteristics.DataAccess
+type RoutineDataAccess int8
+
+// TriggerTiming is an enum for CreateTrigger.Timing
+type TriggerTiming int8
+
+// TriggerEvent is an enum for CreateTrigger.Event
+type TriggerEvent int8
+
+// TriggerOrder represents the FOLLOWS or PRECEDES clause of a CREATE TRIGGER statement
+type TriggerOrder struct {
+	Precedes     bool
+	OtherTrigger IdentifierCI
+}
+
+// EventSchedule represents the ON SCHEDULE clause of a CREATE EVENT statement.
+// Either At is set, for a one time event, or Every and Unit are, for a recurring event.
+type EventSchedule struct {
+	At     Expr
+	Every  Expr
+	Unit   IntervalType
+	Starts Expr
+	Ends   Expr
+}
+
+// EventStatus is an enum for CreateEvent.Status
+type EventStatus int8
+
 // PartitionSpec describe partition actions (for alter statements)
 type PartitionSpec struct {
 	Action            PartitionSpecAction
@@ -380,7 +380,109 @@ func (node *CreateProcedure) Format(buf *TrackedBuffer) {
 		prefix = ", "
 	}
 	buf.literal(") ")
-	buf.astPrintf(node, "%v", node.Body)
+	buf.astPrintf(node, "%v%v", node.Characteristics, node.Body)
+}
+
+// Format formats the node.
+func (node *CreateFunction) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("function ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v (", node.Name)
+	prefix := ""
+	for _, param := range node.Params {
+		buf.astPrintf(node, "%s%v %v", prefix, param.Name, param.Type)
+		prefix = ", "
+	}
+	buf.astPrintf(node, ") returns %v %v%v", node.Returns, node.Characteristics, node.Body)
+}
+
+// Format formats the node.
+func (node *CreateTrigger) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("trigger ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v %s %s on %v for each row %v%v", node.Name, node.Timing.ToString(), node.Event.ToString(), node.Table, node.Order, node.Body)
+}
+
+// Format formats the node.
+func (node *CreateEvent) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("event ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v on schedule %v", node.Name, node.Schedule)
+	if node.OnCompletionPreserve {
+		buf.literal(" on completion preserve")
+	}
+	if node.Status != EnableEventStatus {
+		buf.astPrintf(node, " %s", node.Status.ToString())
+	}
+	if node.Comment != nil {
+		buf.astPrintf(node, " comment %v", node.Comment)
+	}
+	buf.astPrintf(node, " do %v", node.Body)
+}
+
+// Format formats the node.
+func (node *RoutineCharacteristics) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Comment != nil {
+		buf.astPrintf(node, "comment %v ", node.Comment)
+	}
+	if node.Deterministic {
+		buf.literal("deterministic ")
+	}
+	if node.DataAccess != UnspecifiedDataAccess {
+		buf.astPrintf(node, "%s ", node.DataAccess.ToString())
+	}
+	if node.Security != "" {
+		buf.astPrintf(node, "sql security %s ", node.Security)
+	}
+}
+
+// Format formats the node.
+func (node *TriggerOrder) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Precedes {
+		buf.literal("precedes ")
+	} else {
+		buf.literal("follows ")
+	}
+	buf.astPrintf(node, "%v ", node.OtherTrigger)
+}
+
+// Format formats the node.
+func (node *EventSchedule) Format(buf *TrackedBuffer) {
+	if node.At != nil {
+		buf.astPrintf(node, "at %v", node.At)
+		return
+	}
+	buf.astPrintf(node, "every %v %#s", node.Every, node.Unit.ToString())
+	if node.Starts != nil {
+		buf.astPrintf(node, " starts %v", node.Starts)
+	}
+	if node.Ends != nil {
+		buf.astPrintf(node, " ends %v", node.Ends)
+	}
 }
 
 // Format formats the node.
@@ -392,6 +494,33 @@ func (node *DropProcedure) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "%s %vprocedure %s%v", DropStr, node.Comments, exists, node.Name)
 }
 
+// Format formats the node.
+func (node *DropFunction) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vfunction %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
+// Format formats the node.
+func (node *DropTrigger) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vtrigger %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
+// Format formats the node.
+func (node *DropEvent) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vevent %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
 // Format formats the node.
 func (pp *ProcParameter) Format(buf *TrackedBuffer) {
 	buf.astPrintf(pp, "%s %v %v", pp.Mode.ToString(), pp.Name, pp.Type)
@@ -473,6 +602,11 @@ func (s *Signal) Format(buf *TrackedBuffer) {
 	buf.literal(";")
 }
 
+// Format formats the node.
+func (rs *ReturnStatement) Format(buf *TrackedBuffer) {
+	buf.astPrintf(rs, "return %v;", rs.Expr)
+}
+
 // Format formats the node.
 func (s *SignalSet) Format(buf *TrackedBuffer) {
 	buf.astPrintf(s, "%s = %v", s.ConditionName.ToString(), s.Value)
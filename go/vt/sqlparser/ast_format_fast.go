@@ -523,9 +523,155 @@ func (node *CreateProcedure) FormatFast(buf *TrackedBuffer) {
 		prefix = ", "
 	}
 	buf.WriteString(") ")
+	node.Characteristics.FormatFast(buf)
 	node.Body.FormatFast(buf)
 }
 
+// FormatFast formats the node.
+func (node *CreateFunction) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("function ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteString(" (")
+	prefix := ""
+	for _, param := range node.Params {
+		buf.WriteString(prefix)
+		param.Name.FormatFast(buf)
+		buf.WriteByte(' ')
+		param.Type.FormatFast(buf)
+		prefix = ", "
+	}
+	buf.WriteString(") returns ")
+	node.Returns.FormatFast(buf)
+	buf.WriteByte(' ')
+	node.Characteristics.FormatFast(buf)
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *CreateTrigger) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("trigger ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteByte(' ')
+	buf.WriteString(node.Timing.ToString())
+	buf.WriteByte(' ')
+	buf.WriteString(node.Event.ToString())
+	buf.WriteString(" on ")
+	node.Table.FormatFast(buf)
+	buf.WriteString(" for each row ")
+	node.Order.FormatFast(buf)
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *CreateEvent) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("event ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteString(" on schedule ")
+	node.Schedule.FormatFast(buf)
+	if node.OnCompletionPreserve {
+		buf.WriteString(" on completion preserve")
+	}
+	if node.Status != EnableEventStatus {
+		buf.WriteByte(' ')
+		buf.WriteString(node.Status.ToString())
+	}
+	if node.Comment != nil {
+		buf.WriteString(" comment ")
+		node.Comment.FormatFast(buf)
+	}
+	buf.WriteString(" do ")
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *RoutineCharacteristics) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Comment != nil {
+		buf.WriteString("comment ")
+		node.Comment.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	if node.Deterministic {
+		buf.WriteString("deterministic ")
+	}
+	if node.DataAccess != UnspecifiedDataAccess {
+		buf.WriteString(node.DataAccess.ToString())
+		buf.WriteByte(' ')
+	}
+	if node.Security != "" {
+		buf.WriteString("sql security ")
+		buf.WriteString(node.Security)
+		buf.WriteByte(' ')
+	}
+}
+
+// FormatFast formats the node.
+func (node *TriggerOrder) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Precedes {
+		buf.WriteString("precedes ")
+	} else {
+		buf.WriteString("follows ")
+	}
+	node.OtherTrigger.FormatFast(buf)
+	buf.WriteByte(' ')
+}
+
+// FormatFast formats the node.
+func (node *EventSchedule) FormatFast(buf *TrackedBuffer) {
+	if node.At != nil {
+		buf.WriteString("at ")
+		node.At.FormatFast(buf)
+		return
+	}
+	buf.WriteString("every ")
+	node.Every.FormatFast(buf)
+	buf.WriteByte(' ')
+	buf.WriteString(node.Unit.ToString())
+	if node.Starts != nil {
+		buf.WriteString(" starts ")
+		node.Starts.FormatFast(buf)
+	}
+	if node.Ends != nil {
+		buf.WriteString(" ends ")
+		node.Ends.FormatFast(buf)
+	}
+}
+
 // FormatFast formats the node.
 func (node *DropProcedure) FormatFast(buf *TrackedBuffer) {
 	exists := ""
@@ -540,6 +686,48 @@ func (node *DropProcedure) FormatFast(buf *TrackedBuffer) {
 	node.Name.FormatFast(buf)
 }
 
+// FormatFast formats the node.
+func (node *DropFunction) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("function ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *DropTrigger) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("trigger ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *DropEvent) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("event ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
 // FormatFast formats the node.
 func (pp *ProcParameter) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(pp.Mode.ToString())
@@ -650,6 +838,13 @@ func (s *Signal) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(";")
 }
 
+// FormatFast formats the node.
+func (rs *ReturnStatement) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("return ")
+	rs.Expr.FormatFast(buf)
+	buf.WriteByte(';')
+}
+
 // FormatFast formats the node.
 func (s *SignalSet) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(s.ConditionName.ToString())
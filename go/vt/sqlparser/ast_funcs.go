@@ -1499,6 +1499,77 @@ func (action ProcParameterMode) ToString() string {
 	}
 }
 
+// merge combines the characteristics of other into node, with the ones in other taking precedence.
+func (node *RoutineCharacteristics) merge(other *RoutineCharacteristics) *RoutineCharacteristics {
+	if other.Comment != nil {
+		node.Comment = other.Comment
+	}
+	node.Deterministic = node.Deterministic || other.Deterministic
+	if other.DataAccess != UnspecifiedDataAccess {
+		node.DataAccess = other.DataAccess
+	}
+	if other.Security != "" {
+		node.Security = other.Security
+	}
+	return node
+}
+
+// ToString returns the data access characteristic as a string
+func (da RoutineDataAccess) ToString() string {
+	switch da {
+	case ContainsSQLDataAccess:
+		return ContainsSQLStr
+	case NoSQLDataAccess:
+		return NoSQLStr
+	case ReadsSQLDataAccess:
+		return ReadsSQLDataStr
+	case ModifiesSQLDataAccess:
+		return ModifiesSQLDataStr
+	default:
+		return ""
+	}
+}
+
+// ToString returns the trigger timing as a string
+func (timing TriggerTiming) ToString() string {
+	switch timing {
+	case BeforeTiming:
+		return BeforeStr
+	case AfterTiming:
+		return AfterStr
+	default:
+		return "Unknown Trigger Timing"
+	}
+}
+
+// ToString returns the trigger event as a string
+func (event TriggerEvent) ToString() string {
+	switch event {
+	case InsertTriggerEvent:
+		return InsertStr
+	case UpdateTriggerEvent:
+		return UpdateStr
+	case DeleteTriggerEvent:
+		return DeleteStr
+	default:
+		return "Unknown Trigger Event"
+	}
+}
+
+// ToString returns the event status as a string
+func (status EventStatus) ToString() string {
+	switch status {
+	case EnableEventStatus:
+		return EnableStr
+	case DisableEventStatus:
+		return DisableStr
+	case DisableOnReplicaEventStatus:
+		return DisableOnReplicaStr
+	default:
+		return "Unknown Event Status"
+	}
+}
+
 // ToString returns the type as a string
 func (scn SignalConditionName) ToString() string {
 	switch scn {
@@ -2279,6 +2350,8 @@ func (ty ShowCommandType) ToString() string {
 		return VGtidExecGlobalStr
 	case VitessMigrations:
 		return VitessMigrationsStr
+	case VitessQueryDigests:
+		return VitessQueryDigestsStr
 	case VitessReplicationStatus:
 		return VitessReplicationStatusStr
 	case VitessShards:
@@ -110,16 +110,36 @@ const (
 	RefOfCountStarOverClause
 	RefOfCreateDatabaseComments
 	RefOfCreateDatabaseDBName
+	RefOfCreateEventName
+	RefOfCreateEventComments
+	RefOfCreateEventDefiner
+	RefOfCreateEventSchedule
+	RefOfCreateEventComment
+	RefOfCreateEventBody
+	RefOfCreateFunctionName
+	RefOfCreateFunctionComments
+	RefOfCreateFunctionDefiner
+	RefOfCreateFunctionParamsOffset
+	RefOfCreateFunctionReturns
+	RefOfCreateFunctionCharacteristics
+	RefOfCreateFunctionBody
 	RefOfCreateProcedureName
 	RefOfCreateProcedureComments
 	RefOfCreateProcedureDefiner
 	RefOfCreateProcedureParamsOffset
+	RefOfCreateProcedureCharacteristics
 	RefOfCreateProcedureBody
 	RefOfCreateTableTable
 	RefOfCreateTableTableSpec
 	RefOfCreateTableOptLike
 	RefOfCreateTableComments
 	RefOfCreateTableSelect
+	RefOfCreateTriggerName
+	RefOfCreateTriggerComments
+	RefOfCreateTriggerDefiner
+	RefOfCreateTriggerTable
+	RefOfCreateTriggerOrder
+	RefOfCreateTriggerBody
 	RefOfCreateViewViewName
 	RefOfCreateViewDefiner
 	RefOfCreateViewColumns
@@ -146,15 +166,25 @@ const (
 	RefOfDropColumnName
 	RefOfDropDatabaseComments
 	RefOfDropDatabaseDBName
+	RefOfDropEventComments
+	RefOfDropEventName
+	RefOfDropFunctionComments
+	RefOfDropFunctionName
 	RefOfDropKeyName
 	RefOfDropProcedureComments
 	RefOfDropProcedureName
 	RefOfDropTableFromTables
 	RefOfDropTableComments
+	RefOfDropTriggerComments
+	RefOfDropTriggerName
 	RefOfDropViewFromTables
 	RefOfDropViewComments
 	RefOfElseIfBlockSearchCondition
 	RefOfElseIfBlockThenStatements
+	RefOfEventScheduleAt
+	RefOfEventScheduleEvery
+	RefOfEventScheduleStarts
+	RefOfEventScheduleEnds
 	RefOfExecuteStmtName
 	RefOfExecuteStmtComments
 	RefOfExecuteStmtArgumentsOffset
@@ -409,8 +439,10 @@ const (
 	RefOfRenameIndexOldName
 	RefOfRenameIndexNewName
 	RefOfRenameTableNameTable
+	RefOfReturnStatementExpr
 	RefOfRevertMigrationComments
 	RootNodeSQLNode
+	RefOfRoutineCharacteristicsComment
 	RefOfRowAliasTableName
 	RefOfRowAliasColumns
 	RefOfSRollbackName
@@ -482,6 +514,7 @@ const (
 	RefOfTableSpecPartitionOption
 	RefOfTimestampDiffExprExpr1
 	RefOfTimestampDiffExprExpr2
+	RefOfTriggerOrderOtherTrigger
 	RefOfTrimFuncExprTrimArg
 	RefOfTrimFuncExprStringArg
 	RefOfTruncateTableTable
@@ -782,6 +815,32 @@ func (s ASTStep) DebugString() string {
 		return "(*CreateDatabase).Comments"
 	case RefOfCreateDatabaseDBName:
 		return "(*CreateDatabase).DBName"
+	case RefOfCreateEventName:
+		return "(*CreateEvent).Name"
+	case RefOfCreateEventComments:
+		return "(*CreateEvent).Comments"
+	case RefOfCreateEventDefiner:
+		return "(*CreateEvent).Definer"
+	case RefOfCreateEventSchedule:
+		return "(*CreateEvent).Schedule"
+	case RefOfCreateEventComment:
+		return "(*CreateEvent).Comment"
+	case RefOfCreateEventBody:
+		return "(*CreateEvent).Body"
+	case RefOfCreateFunctionName:
+		return "(*CreateFunction).Name"
+	case RefOfCreateFunctionComments:
+		return "(*CreateFunction).Comments"
+	case RefOfCreateFunctionDefiner:
+		return "(*CreateFunction).Definer"
+	case RefOfCreateFunctionParamsOffset:
+		return "(*CreateFunction).ParamsOffset"
+	case RefOfCreateFunctionReturns:
+		return "(*CreateFunction).Returns"
+	case RefOfCreateFunctionCharacteristics:
+		return "(*CreateFunction).Characteristics"
+	case RefOfCreateFunctionBody:
+		return "(*CreateFunction).Body"
 	case RefOfCreateProcedureName:
 		return "(*CreateProcedure).Name"
 	case RefOfCreateProcedureComments:
@@ -790,6 +849,8 @@ func (s ASTStep) DebugString() string {
 		return "(*CreateProcedure).Definer"
 	case RefOfCreateProcedureParamsOffset:
 		return "(*CreateProcedure).ParamsOffset"
+	case RefOfCreateProcedureCharacteristics:
+		return "(*CreateProcedure).Characteristics"
 	case RefOfCreateProcedureBody:
 		return "(*CreateProcedure).Body"
 	case RefOfCreateTableTable:
@@ -802,6 +863,18 @@ func (s ASTStep) DebugString() string {
 		return "(*CreateTable).Comments"
 	case RefOfCreateTableSelect:
 		return "(*CreateTable).Select"
+	case RefOfCreateTriggerName:
+		return "(*CreateTrigger).Name"
+	case RefOfCreateTriggerComments:
+		return "(*CreateTrigger).Comments"
+	case RefOfCreateTriggerDefiner:
+		return "(*CreateTrigger).Definer"
+	case RefOfCreateTriggerTable:
+		return "(*CreateTrigger).Table"
+	case RefOfCreateTriggerOrder:
+		return "(*CreateTrigger).Order"
+	case RefOfCreateTriggerBody:
+		return "(*CreateTrigger).Body"
 	case RefOfCreateViewViewName:
 		return "(*CreateView).ViewName"
 	case RefOfCreateViewDefiner:
@@ -854,6 +927,14 @@ func (s ASTStep) DebugString() string {
 		return "(*DropDatabase).Comments"
 	case RefOfDropDatabaseDBName:
 		return "(*DropDatabase).DBName"
+	case RefOfDropEventComments:
+		return "(*DropEvent).Comments"
+	case RefOfDropEventName:
+		return "(*DropEvent).Name"
+	case RefOfDropFunctionComments:
+		return "(*DropFunction).Comments"
+	case RefOfDropFunctionName:
+		return "(*DropFunction).Name"
 	case RefOfDropKeyName:
 		return "(*DropKey).Name"
 	case RefOfDropProcedureComments:
@@ -864,6 +945,10 @@ func (s ASTStep) DebugString() string {
 		return "(*DropTable).FromTables"
 	case RefOfDropTableComments:
 		return "(*DropTable).Comments"
+	case RefOfDropTriggerComments:
+		return "(*DropTrigger).Comments"
+	case RefOfDropTriggerName:
+		return "(*DropTrigger).Name"
 	case RefOfDropViewFromTables:
 		return "(*DropView).FromTables"
 	case RefOfDropViewComments:
@@ -872,6 +957,14 @@ func (s ASTStep) DebugString() string {
 		return "(*ElseIfBlock).SearchCondition"
 	case RefOfElseIfBlockThenStatements:
 		return "(*ElseIfBlock).ThenStatements"
+	case RefOfEventScheduleAt:
+		return "(*EventSchedule).At"
+	case RefOfEventScheduleEvery:
+		return "(*EventSchedule).Every"
+	case RefOfEventScheduleStarts:
+		return "(*EventSchedule).Starts"
+	case RefOfEventScheduleEnds:
+		return "(*EventSchedule).Ends"
 	case RefOfExecuteStmtName:
 		return "(*ExecuteStmt).Name"
 	case RefOfExecuteStmtComments:
@@ -1380,10 +1473,14 @@ func (s ASTStep) DebugString() string {
 		return "(*RenameIndex).NewName"
 	case RefOfRenameTableNameTable:
 		return "(*RenameTableName).Table"
+	case RefOfReturnStatementExpr:
+		return "(*ReturnStatement).Expr"
 	case RefOfRevertMigrationComments:
 		return "(*RevertMigration).Comments"
 	case RootNodeSQLNode:
 		return "(RootNode).SQLNode"
+	case RefOfRoutineCharacteristicsComment:
+		return "(*RoutineCharacteristics).Comment"
 	case RefOfRowAliasTableName:
 		return "(*RowAlias).TableName"
 	case RefOfRowAliasColumns:
@@ -1526,6 +1623,8 @@ func (s ASTStep) DebugString() string {
 		return "(*TimestampDiffExpr).Expr1"
 	case RefOfTimestampDiffExprExpr2:
 		return "(*TimestampDiffExpr).Expr2"
+	case RefOfTriggerOrderOtherTrigger:
+		return "(*TriggerOrder).OtherTrigger"
 	case RefOfTrimFuncExprTrimArg:
 		return "(*TrimFuncExpr).TrimArg"
 	case RefOfTrimFuncExprStringArg:
@@ -1964,6 +2063,34 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*CreateDatabase).Comments
 		case RefOfCreateDatabaseDBName:
 			node = node.(*CreateDatabase).DBName
+		case RefOfCreateEventName:
+			node = node.(*CreateEvent).Name
+		case RefOfCreateEventComments:
+			node = node.(*CreateEvent).Comments
+		case RefOfCreateEventDefiner:
+			node = node.(*CreateEvent).Definer
+		case RefOfCreateEventSchedule:
+			node = node.(*CreateEvent).Schedule
+		case RefOfCreateEventComment:
+			node = node.(*CreateEvent).Comment
+		case RefOfCreateEventBody:
+			node = node.(*CreateEvent).Body
+		case RefOfCreateFunctionName:
+			node = node.(*CreateFunction).Name
+		case RefOfCreateFunctionComments:
+			node = node.(*CreateFunction).Comments
+		case RefOfCreateFunctionDefiner:
+			node = node.(*CreateFunction).Definer
+		case RefOfCreateFunctionParamsOffset:
+			idx, bytesRead := path.nextPathOffset()
+			path = path[bytesRead:]
+			node = node.(*CreateFunction).Params[idx]
+		case RefOfCreateFunctionReturns:
+			node = node.(*CreateFunction).Returns
+		case RefOfCreateFunctionCharacteristics:
+			node = node.(*CreateFunction).Characteristics
+		case RefOfCreateFunctionBody:
+			node = node.(*CreateFunction).Body
 		case RefOfCreateProcedureName:
 			node = node.(*CreateProcedure).Name
 		case RefOfCreateProcedureComments:
@@ -1974,6 +2101,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			idx, bytesRead := path.nextPathOffset()
 			path = path[bytesRead:]
 			node = node.(*CreateProcedure).Params[idx]
+		case RefOfCreateProcedureCharacteristics:
+			node = node.(*CreateProcedure).Characteristics
 		case RefOfCreateProcedureBody:
 			node = node.(*CreateProcedure).Body
 		case RefOfCreateTableTable:
@@ -1986,6 +2115,18 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*CreateTable).Comments
 		case RefOfCreateTableSelect:
 			node = node.(*CreateTable).Select
+		case RefOfCreateTriggerName:
+			node = node.(*CreateTrigger).Name
+		case RefOfCreateTriggerComments:
+			node = node.(*CreateTrigger).Comments
+		case RefOfCreateTriggerDefiner:
+			node = node.(*CreateTrigger).Definer
+		case RefOfCreateTriggerTable:
+			node = node.(*CreateTrigger).Table
+		case RefOfCreateTriggerOrder:
+			node = node.(*CreateTrigger).Order
+		case RefOfCreateTriggerBody:
+			node = node.(*CreateTrigger).Body
 		case RefOfCreateViewViewName:
 			node = node.(*CreateView).ViewName
 		case RefOfCreateViewDefiner:
@@ -2044,6 +2185,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*DropDatabase).Comments
 		case RefOfDropDatabaseDBName:
 			node = node.(*DropDatabase).DBName
+		case RefOfDropEventComments:
+			node = node.(*DropEvent).Comments
+		case RefOfDropEventName:
+			node = node.(*DropEvent).Name
+		case RefOfDropFunctionComments:
+			node = node.(*DropFunction).Comments
+		case RefOfDropFunctionName:
+			node = node.(*DropFunction).Name
 		case RefOfDropKeyName:
 			node = node.(*DropKey).Name
 		case RefOfDropProcedureComments:
@@ -2054,6 +2203,10 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*DropTable).FromTables
 		case RefOfDropTableComments:
 			node = node.(*DropTable).Comments
+		case RefOfDropTriggerComments:
+			node = node.(*DropTrigger).Comments
+		case RefOfDropTriggerName:
+			node = node.(*DropTrigger).Name
 		case RefOfDropViewFromTables:
 			node = node.(*DropView).FromTables
 		case RefOfDropViewComments:
@@ -2062,6 +2215,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*ElseIfBlock).SearchCondition
 		case RefOfElseIfBlockThenStatements:
 			node = node.(*ElseIfBlock).ThenStatements
+		case RefOfEventScheduleAt:
+			node = node.(*EventSchedule).At
+		case RefOfEventScheduleEvery:
+			node = node.(*EventSchedule).Every
+		case RefOfEventScheduleStarts:
+			node = node.(*EventSchedule).Starts
+		case RefOfEventScheduleEnds:
+			node = node.(*EventSchedule).Ends
 		case RefOfExecuteStmtName:
 			node = node.(*ExecuteStmt).Name
 		case RefOfExecuteStmtComments:
@@ -2632,10 +2793,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*RenameIndex).NewName
 		case RefOfRenameTableNameTable:
 			node = node.(*RenameTableName).Table
+		case RefOfReturnStatementExpr:
+			node = node.(*ReturnStatement).Expr
 		case RefOfRevertMigrationComments:
 			node = node.(*RevertMigration).Comments
 		case RootNodeSQLNode:
 			node = node.(RootNode).SQLNode
+		case RefOfRoutineCharacteristicsComment:
+			node = node.(*RoutineCharacteristics).Comment
 		case RefOfRowAliasTableName:
 			node = node.(*RowAlias).TableName
 		case RefOfRowAliasColumns:
@@ -2800,6 +2965,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*TimestampDiffExpr).Expr1
 		case RefOfTimestampDiffExprExpr2:
 			node = node.(*TimestampDiffExpr).Expr2
+		case RefOfTriggerOrderOtherTrigger:
+			node = node.(*TriggerOrder).OtherTrigger
 		case RefOfTrimFuncExprTrimArg:
 			node = node.(*TrimFuncExpr).TrimArg
 		case RefOfTrimFuncExprStringArg:
@@ -1043,7 +1043,7 @@ func (cached *CreateDatabase) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
-func (cached *CreateProcedure) CachedSize(alloc bool) int64 {
+func (cached *CreateEvent) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
@@ -1057,6 +1057,30 @@ func (cached *CreateProcedure) CachedSize(alloc bool) int64 {
 	size += cached.Comments.CachedSize(true)
 	// field Definer *vitess.io/vitess/go/vt/sqlparser.Definer
 	size += cached.Definer.CachedSize(true)
+	// field Schedule *vitess.io/vitess/go/vt/sqlparser.EventSchedule
+	size += cached.Schedule.CachedSize(true)
+	// field Comment *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.Comment.CachedSize(true)
+	// field Body vitess.io/vitess/go/vt/sqlparser.CompoundStatement
+	if cc, ok := cached.Body.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+func (cached *CreateFunction) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Definer *vitess.io/vitess/go/vt/sqlparser.Definer
+	size += cached.Definer.CachedSize(true)
 	// field Params []*vitess.io/vitess/go/vt/sqlparser.ProcParameter
 	{
 		size += hack.RuntimeAllocSize(int64(cap(cached.Params)) * int64(8))
@@ -1064,6 +1088,39 @@ func (cached *CreateProcedure) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field Returns *vitess.io/vitess/go/vt/sqlparser.ColumnType
+	size += cached.Returns.CachedSize(true)
+	// field Characteristics *vitess.io/vitess/go/vt/sqlparser.RoutineCharacteristics
+	size += cached.Characteristics.CachedSize(true)
+	// field Body vitess.io/vitess/go/vt/sqlparser.CompoundStatement
+	if cc, ok := cached.Body.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+func (cached *CreateProcedure) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Definer *vitess.io/vitess/go/vt/sqlparser.Definer
+	size += cached.Definer.CachedSize(true)
+	// field Params []*vitess.io/vitess/go/vt/sqlparser.ProcParameter
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Params)) * int64(8))
+		for _, elem := range cached.Params {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Characteristics *vitess.io/vitess/go/vt/sqlparser.RoutineCharacteristics
+	size += cached.Characteristics.CachedSize(true)
 	// field Body vitess.io/vitess/go/vt/sqlparser.CompoundStatement
 	if cc, ok := cached.Body.(cachedObject); ok {
 		size += cc.CachedSize(true)
@@ -1092,6 +1149,30 @@ func (cached *CreateTable) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *CreateTrigger) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(128)
+	}
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Definer *vitess.io/vitess/go/vt/sqlparser.Definer
+	size += cached.Definer.CachedSize(true)
+	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Table.CachedSize(false)
+	// field Order *vitess.io/vitess/go/vt/sqlparser.TriggerOrder
+	size += cached.Order.CachedSize(true)
+	// field Body vitess.io/vitess/go/vt/sqlparser.CompoundStatement
+	if cc, ok := cached.Body.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *CreateView) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1335,6 +1416,34 @@ func (cached *DropDatabase) CachedSize(alloc bool) int64 {
 	size += cached.DBName.CachedSize(false)
 	return size
 }
+func (cached *DropEvent) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	return size
+}
+func (cached *DropFunction) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	return size
+}
 func (cached *DropKey) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1380,6 +1489,20 @@ func (cached *DropTable) CachedSize(alloc bool) int64 {
 	size += cached.Comments.CachedSize(true)
 	return size
 }
+func (cached *DropTrigger) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
+	// field Name vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Name.CachedSize(false)
+	return size
+}
 func (cached *DropView) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1415,6 +1538,32 @@ func (cached *ElseIfBlock) CachedSize(alloc bool) int64 {
 	size += cached.ThenStatements.CachedSize(true)
 	return size
 }
+func (cached *EventSchedule) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field At vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.At.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Every vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Every.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Starts vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Starts.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Ends vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Ends.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *ExecuteStmt) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -3975,6 +4124,20 @@ func (cached *RenameTablePair) CachedSize(alloc bool) int64 {
 	size += cached.ToTable.CachedSize(false)
 	return size
 }
+func (cached *ReturnStatement) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field Expr vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Expr.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *RevertMigration) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -3989,6 +4152,20 @@ func (cached *RevertMigration) CachedSize(alloc bool) int64 {
 	size += cached.Comments.CachedSize(true)
 	return size
 }
+func (cached *RoutineCharacteristics) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field Comment *vitess.io/vitess/go/vt/sqlparser.Literal
+	size += cached.Comment.CachedSize(true)
+	// field Security string
+	size += hack.RuntimeAllocSize(int64(len(cached.Security)))
+	return size
+}
 func (cached *RowAlias) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -4757,6 +4934,18 @@ func (cached *TimestampDiffExpr) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *TriggerOrder) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field OtherTrigger vitess.io/vitess/go/vt/sqlparser.IdentifierCI
+	size += cached.OtherTrigger.CachedSize(false)
+	return size
+}
 func (cached *TrimFuncExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -53,6 +54,10 @@ const (
 	DirectiveVExplainRunDMLQueries = "EXECUTE_DML_QUERIES"
 	// DirectiveConsolidator enables the query consolidator.
 	DirectiveConsolidator = "CONSOLIDATOR"
+	// DirectiveSkipVTGateConsolidator opts a query out of the vtgate query consolidator.
+	DirectiveSkipVTGateConsolidator = "SKIP_VTGATE_CONSOLIDATOR"
+	// DirectiveCacheTTL caches the result of a read-only query in vtgate for the given duration, e.g. CACHE_TTL=5s.
+	DirectiveCacheTTL = "CACHE_TTL"
 	// DirectiveWorkloadName specifies the name of the client application workload issuing the query.
 	DirectiveWorkloadName = "WORKLOAD_NAME"
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
@@ -563,12 +568,14 @@ func checkDirective(stmt Statement, key string) bool {
 }
 
 type QueryHints struct {
-	IgnoreMaxMemoryRows bool
-	Consolidator        querypb.ExecuteOptions_Consolidator
-	Workload            string
-	ForeignKeyChecks    *bool
-	Priority            string
-	Timeout             *int
+	IgnoreMaxMemoryRows    bool
+	SkipVTGateConsolidator bool
+	Consolidator           querypb.ExecuteOptions_Consolidator
+	Workload               string
+	ForeignKeyChecks       *bool
+	Priority               string
+	Timeout                *int
+	CacheTTL               time.Duration
 }
 
 func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
@@ -586,10 +593,12 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 		return qh, err
 	}
 	qh.IgnoreMaxMemoryRows = directives.IsSet(DirectiveIgnoreMaxMemoryRows)
+	qh.SkipVTGateConsolidator = directives.IsSet(DirectiveSkipVTGateConsolidator)
 	qh.Consolidator = getConsolidator(stmt, directives)
 	qh.Workload = getWorkload(directives)
 	qh.ForeignKeyChecks = getForeignKeyChecksState(comment)
 	qh.Timeout = getQueryTimeout(directives)
+	qh.CacheTTL = getCacheTTL(directives)
 
 	return qh, nil
 }
@@ -662,3 +671,17 @@ func getQueryTimeout(directives *CommentDirectives) *int {
 	}
 	return &timeout
 }
+
+// getCacheTTL gets the result cache TTL from the provided Statement, using DirectiveCacheTTL
+func getCacheTTL(directives *CommentDirectives) time.Duration {
+	ttlString, ok := directives.GetString(DirectiveCacheTTL, "")
+	if !ok || ttlString == "" {
+		return 0
+	}
+
+	ttl, err := time.ParseDuration(ttlString)
+	if err != nil || ttl < 0 {
+		return 0
+	}
+	return ttl
+}
@@ -19,6 +19,7 @@ package sqlparser
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -698,3 +699,33 @@ func TestQueryTimeout(t *testing.T) {
 		})
 	}
 }
+
+// TestCacheTTL tests the extraction of CACHE_TTL from the comments.
+func TestCacheTTL(t *testing.T) {
+	testCases := []struct {
+		query  string
+		expTTL time.Duration
+	}{{
+		query: "select * from a_table",
+	}, {
+		query:  "select /*vt+ CACHE_TTL=5s */ * from another_table",
+		expTTL: 5 * time.Second,
+	}, {
+		query:  "select /*vt+ CACHE_TTL=1m30s */ * from another_table",
+		expTTL: 90 * time.Second,
+	}, {
+		query: "select /*vt+ CACHE_TTL=5 */ * from another_table",
+	}, {
+		query: "select /*vt+ CACHE_TTL=-5s */ * from another_table",
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			assert.NoError(t, err)
+			qh, _ := BuildQueryHints(stmt)
+			assert.Equal(t, tc.expTTL, qh.CacheTTL)
+		})
+	}
+}
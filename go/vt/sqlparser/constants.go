@@ -164,6 +164,25 @@ const (
 	OutStr   = "out"
 	InoutStr = "inout"
 
+	// RoutineDataAccess
+	ContainsSQLStr     = "contains sql"
+	NoSQLStr           = "no sql"
+	ReadsSQLDataStr    = "reads sql data"
+	ModifiesSQLDataStr = "modifies sql data"
+
+	// TriggerTiming
+	BeforeStr = "before"
+	AfterStr  = "after"
+
+	// TriggerEvent
+	UpdateStr = "update"
+	DeleteStr = "delete"
+
+	// EventStatus
+	EnableStr           = "enable"
+	DisableStr          = "disable"
+	DisableOnReplicaStr = "disable on replica"
+
 	// SignalConditionName
 	ClassOriginTypeStr       = "class_origin"
 	SubclassOriginTypeStr    = "subclass_origin"
@@ -338,6 +357,7 @@ const (
 	VGtidExecGlobalStr         = " global vgtid_executed"
 	KeyspaceStr                = " keyspaces"
 	VitessMigrationsStr        = " vitess_migrations"
+	VitessQueryDigestsStr      = " vitess_query_digests"
 	VitessReplicationStatusStr = " vitess_replication_status"
 	VitessShardsStr            = " vitess_shards"
 	VitessTabletsStr           = " vitess_tablets"
@@ -813,6 +833,35 @@ const (
 	InoutMode
 )
 
+// Constant for Enum Type - RoutineDataAccess
+const (
+	UnspecifiedDataAccess RoutineDataAccess = iota
+	ContainsSQLDataAccess
+	NoSQLDataAccess
+	ReadsSQLDataAccess
+	ModifiesSQLDataAccess
+)
+
+// Constant for Enum Type - TriggerTiming
+const (
+	BeforeTiming TriggerTiming = iota
+	AfterTiming
+)
+
+// Constant for Enum Type - TriggerEvent
+const (
+	InsertTriggerEvent TriggerEvent = iota
+	UpdateTriggerEvent
+	DeleteTriggerEvent
+)
+
+// Constant for Enum Type - EventStatus
+const (
+	EnableEventStatus EventStatus = iota
+	DisableEventStatus
+	DisableOnReplicaEventStatus
+)
+
 // Constant for Enum Type - SignalConditionName
 const (
 	ClassOriginType SignalConditionName = iota
@@ -925,6 +974,7 @@ const (
 	VariableSession
 	VGtidExecGlobal
 	VitessMigrations
+	VitessQueryDigests
 	VitessReplicationStatus
 	VitessShards
 	VitessTablets
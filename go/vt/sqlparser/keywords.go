@@ -133,6 +133,7 @@ var keywords = []keyword{
 	{"asc", ASC},
 	{"ascii", ASCII},
 	{"asensitive", UNUSED},
+	{"at", AT},
 	{"auto_increment", AUTO_INCREMENT},
 	{"autoextend_size", AUTOEXTEND_SIZE},
 	{"avg", AVG},
@@ -181,6 +182,7 @@ var keywords = []keyword{
 	{"commit", COMMIT},
 	{"compact", COMPACT},
 	{"complete", COMPLETE},
+	{"completion", COMPLETION},
 	{"compressed", COMPRESSED},
 	{"compression", COMPRESSION},
 	{"condition", CONDITION},
@@ -190,11 +192,20 @@ var keywords = []keyword{
 	{"constraint_catalog", CONSTRAINT_CATALOG},
 	{"constraint_name", CONSTRAINT_NAME},
 	{"constraint_schema", CONSTRAINT_SCHEMA},
+	{"contains", CONTAINS},
 	{"continue", CONTINUE},
 	{"convert", CONVERT},
 	{"copy", COPY},
 	{"count", COUNT},
 	{"cume_dist", CUME_DIST},
+	{"ends", ENDS},
+	{"every", EVERY},
+	{"follows", FOLLOWS},
+	{"precedes", PRECEDES},
+	{"preserve", PRESERVE},
+	{"returns", RETURNS},
+	{"schedule", SCHEDULE},
+	{"starts", STARTS},
 	{"substr", SUBSTRING},
 	{"subpartition", SUBPARTITION},
 	{"subpartitions", SUBPARTITIONS},
@@ -237,7 +248,7 @@ var keywords = []keyword{
 	{"dense_rank", DENSE_RANK},
 	{"desc", DESC},
 	{"describe", DESCRIBE},
-	{"deterministic", UNUSED},
+	{"deterministic", DETERMINISTIC},
 	{"directory", DIRECTORY},
 	{"disable", DISABLE},
 	{"discard", DISCARD},
@@ -251,7 +262,7 @@ var keywords = []keyword{
 	{"dumpfile", DUMPFILE},
 	{"duplicate", DUPLICATE},
 	{"dynamic", DYNAMIC},
-	{"each", UNUSED},
+	{"each", EACH},
 	{"else", ELSE},
 	{"elseif", ELSEIF},
 	{"empty", EMPTY},
@@ -460,7 +471,7 @@ var keywords = []keyword{
 	{"mod", MOD},
 	{"mode", MODE},
 	{"modify", MODIFY},
-	{"modifies", UNUSED},
+	{"modifies", MODIFIES},
 	{"multilinestring", MULTILINESTRING},
 	{"multipoint", MULTIPOINT},
 	{"multipolygon", MULTIPOLYGON},
@@ -537,7 +548,7 @@ var keywords = []keyword{
 	{"rank", RANK},
 	{"ratio", RATIO},
 	{"read", READ},
-	{"reads", UNUSED},
+	{"reads", READS},
 	{"read_write", UNUSED},
 	{"real", REAL},
 	{"rebuild", REBUILD},
@@ -564,7 +575,7 @@ var keywords = []keyword{
 	{"resignal", UNUSED},
 	{"respect", RESPECT},
 	{"restrict", RESTRICT},
-	{"return", UNUSED},
+	{"return", RETURN},
 	{"returning", RETURNING},
 	{"retry", RETRY},
 	{"revert", REVERT},
@@ -788,6 +799,7 @@ var keywords = []keyword{
 	{"vitess_metadata", VITESS_METADATA},
 	{"vitess_migration", VITESS_MIGRATION},
 	{"vitess_migrations", VITESS_MIGRATIONS},
+	{"vitess_query_digests", VITESS_QUERY_DIGESTS},
 	{"vitess_replication_status", VITESS_REPLICATION_STATUS},
 	{"vitess_shards", VITESS_SHARDS},
 	{"vitess_tablets", VITESS_TABLETS},
@@ -452,6 +452,11 @@ func TestNormalize(t *testing.T) {
 		in:      "CREATE PROCEDURE p2 (in x BIGINT) BEGIN declare y DECIMAL(14,2); START TRANSACTION; set y = 4.2; SELECT 128 from dual; COMMIT; END",
 		outstmt: "create procedure p2 (in x BIGINT) begin declare y DECIMAL(14,2); start transaction; set y = 4.2; select 128 from dual; commit; end;",
 		outbv:   map[string]*querypb.BindVariable{},
+	}, {
+		// Verify we don't change anything in the normalization of create triggers.
+		in:      "CREATE TRIGGER trg1 BEFORE INSERT ON t1 FOR EACH ROW INSERT INTO audit VALUES (new.id, 'inserted')",
+		outstmt: "create trigger trg1 before insert on t1 for each row insert into audit values (new.id, 'inserted');",
+		outbv:   map[string]*querypb.BindVariable{},
 	}}
 	parser := NewTestParser()
 	for _, tc := range testcases {
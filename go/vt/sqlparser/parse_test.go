@@ -2231,6 +2231,44 @@ var (
 		output: "create procedure ConditionWithSignalAndHandler () begin declare custom_error condition for sqlstate '45000'; declare exit handler for custom_error begin select 'Handled with custom condition and signal' from dual; end; signal sqlstate '45000' set message_text = 'Custom signal triggered'; end;",
 	}, {
 		input: "create procedure t1 (in x BIGINT) begin start transaction; insert into unsharded_a values (1, 'a', 'a'); commit; end;",
+	}, {
+		input:  "create procedure p1(in x int) comment 'counts' reads sql data sql security invoker select count(*) from t where id = x;",
+		output: "create procedure p1 (in x int) comment 'counts' reads sql data sql security invoker select count(*) from t where id = x;",
+	}, {
+		input:  "create definer = `root`@`localhost` function f1(a int, b varchar(10)) returns int deterministic no sql return a + 1;",
+		output: "create definer = root@localhost function f1 (a int, b varchar(10)) returns int deterministic no sql return a + 1;",
+	}, {
+		input:  "create function if not exists f2() returns varchar(20) language sql not deterministic begin declare x varchar(20); set x = 'hello'; return x; end;",
+		output: "create function if not exists f2 () returns varchar(20) begin declare x varchar(20); set x = 'hello'; return x; end;",
+	}, {
+		input:  "create trigger trg1 before insert on t1 for each row insert into audit values (new.id);",
+		output: "create trigger trg1 before insert on t1 for each row insert into audit values (new.id);",
+	}, {
+		input:  "create definer = current_user trigger if not exists trg2 after update on db.t1 for each row follows trg1 begin insert into audit values (old.id); insert into audit values (new.id); end;",
+		output: "create definer = current_user trigger if not exists trg2 after update on db.t1 for each row follows trg1 begin insert into audit values (old.id); insert into audit values (new.id); end;",
+	}, {
+		input:  "create trigger trg3 after delete on t1 for each row precedes trg2 delete from t2 where id = old.id;",
+		output: "create trigger trg3 after delete on t1 for each row precedes trg2 delete from t2 where id = old.id;",
+	}, {
+		input:  "create event ev1 on schedule every 1 hour do delete from t1 where ts < now() - interval 1 day;",
+		output: "create event ev1 on schedule every 1 hour do delete from t1 where ts < now() - interval 1 day;",
+	}, {
+		input:  "create event if not exists ev2 on schedule every 10 minute starts '2026-01-01 00:00:00' ends '2027-01-01 00:00:00' on completion preserve disable on replica comment 'cleanup' do begin delete from t1; delete from t2; end;",
+		output: "create event if not exists ev2 on schedule every 10 minute starts '2026-01-01 00:00:00' ends '2027-01-01 00:00:00' on completion preserve disable on replica comment 'cleanup' do begin delete from t1; delete from t2; end;",
+	}, {
+		input:  "create event ev3 on schedule at current_timestamp + interval 1 hour on completion not preserve enable do update t1 set a = 1;",
+		output: "create event ev3 on schedule at current_timestamp() + interval 1 hour do update t1 set a = 1;",
+	}, {
+		input:  "create event ev4 on schedule at '2026-06-01 12:00:00' disable on slave do call purge_rows();",
+		output: "create event ev4 on schedule at '2026-06-01 12:00:00' disable on replica do call purge_rows();",
+	}, {
+		input: "drop function f1",
+	}, {
+		input: "drop function if exists db.f1",
+	}, {
+		input: "drop trigger if exists trg1",
+	}, {
+		input: "drop /* comment */ event ev1",
 	}, {
 		input: "create /*vt+ strategy=online */ or replace view v as select a, b, c from t",
 	}, {
@@ -2512,6 +2550,10 @@ var (
 		output: "show keyspaces like '%'",
 	}, {
 		input: "show vitess_metadata variables",
+	}, {
+		input: "show vitess_query_digests",
+	}, {
+		input: "show vitess_query_digests like '%select%'",
 	}, {
 		input: "show vitess_replication_status",
 	}, {
@@ -6402,6 +6444,9 @@ var (
 	}{{
 		input:  "select : from t",
 		output: "syntax error at position 9 near ':'",
+	}, {
+		input:  "create event ev1 on schedule every 1 day disable on master do select 1",
+		output: "expecting replica or slave after disable on at position 59 near 'master'",
 	}, {
 		input:  "execute stmt using 1;",
 		output: "syntax error at position 21 near '1'",
@@ -251,10 +251,35 @@ var validCreatePrefixes = [][]int{
 	{CREATE, DEFINER, '=', STRING, AT_ID, PROCEDURE},
 	{CREATE, DEFINER, '=', ID, PROCEDURE},
 	{CREATE, DEFINER, '=', ID, AT_ID, PROCEDURE},
+	// These are the tokens (in order) for valid "create function" forms.
+	{CREATE, FUNCTION},
+	{CREATE, DEFINER, '=', CURRENT_USER, FUNCTION},
+	{CREATE, DEFINER, '=', CURRENT_USER, '(', ')', FUNCTION},
+	{CREATE, DEFINER, '=', STRING, FUNCTION},
+	{CREATE, DEFINER, '=', STRING, AT_ID, FUNCTION},
+	{CREATE, DEFINER, '=', ID, FUNCTION},
+	{CREATE, DEFINER, '=', ID, AT_ID, FUNCTION},
+	// These are the tokens (in order) for valid "create trigger" forms.
+	{CREATE, TRIGGER},
+	{CREATE, DEFINER, '=', CURRENT_USER, TRIGGER},
+	{CREATE, DEFINER, '=', CURRENT_USER, '(', ')', TRIGGER},
+	{CREATE, DEFINER, '=', STRING, TRIGGER},
+	{CREATE, DEFINER, '=', STRING, AT_ID, TRIGGER},
+	{CREATE, DEFINER, '=', ID, TRIGGER},
+	{CREATE, DEFINER, '=', ID, AT_ID, TRIGGER},
+	// These are the tokens (in order) for valid "create event" forms.
+	{CREATE, EVENT},
+	{CREATE, DEFINER, '=', CURRENT_USER, EVENT},
+	{CREATE, DEFINER, '=', CURRENT_USER, '(', ')', EVENT},
+	{CREATE, DEFINER, '=', STRING, EVENT},
+	{CREATE, DEFINER, '=', STRING, AT_ID, EVENT},
+	{CREATE, DEFINER, '=', ID, EVENT},
+	{CREATE, DEFINER, '=', ID, AT_ID, EVENT},
 }
 
 // matchesCreateProcedurePrefix checks if the given token sequence
-// is a create procedure statement or not.
+// is a create procedure, function, trigger or event statement or not.
+// The bodies of all of these can contain semicolons.
 func matchesCreateProcedurePrefix(tokens []int) bool {
 	// Check each candidate sequence.
 	for _, pattern := range validCreatePrefixes {
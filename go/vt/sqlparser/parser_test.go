@@ -141,6 +141,10 @@ func TestSplitStatementToPieces(t *testing.T) {
 		// Create procedure with comments.
 		input:     "select * from t1; /* comment1 */ create /* comment2 */ procedure /* comment3 */ p1 (in country CHAR(3), out cities INT) begin select count(*) from x where d = e; end;select * from t2",
 		lenWanted: 3,
+	}, {
+		// Test that we don't split on semicolons inside create function, trigger and event bodies.
+		input:     "create function f1 () returns int begin declare x int; set x = 1; return x; end;create trigger trg1 before insert on t1 for each row begin insert into audit values (new.id); end;create definer=CURRENT_USER event ev1 on schedule every 1 day do begin delete from t1; delete from t2; end;select * from t2",
+		lenWanted: 4,
 	}, {
 		// Create procedure with definer current_user.
 		input:     "create DEFINER=CURRENT_USER procedure p1 (in country CHAR(3))  begin declare abc DECIMAL(14,2); DECLARE def DECIMAL(14,2); end",
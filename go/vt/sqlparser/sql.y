@@ -117,6 +117,12 @@ func markBindVariable(yylex yyLexer, bvar string) {
   createTable      *CreateTable
   createView      *CreateView
   createProcedure  *CreateProcedure
+  routineCharacteristics *RoutineCharacteristics
+  triggerTiming TriggerTiming
+  triggerEvent TriggerEvent
+  triggerOrder *TriggerOrder
+  eventSchedule *EventSchedule
+  eventStatus EventStatus
   tableAndLockType *TableAndLockType
   alterTable       *AlterTable
   tableOption      *TableOption
@@ -280,6 +286,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // When we see '(', we can either reduce ignore_or_replace_opt to empty or shift '(' to parse table_spec.
 // We want shifting to take precedence, so we add lower precedence to the reduce rule.
 %nonassoc EMPTY_IGNORE_OR_REPLACE
+// EMPTY_LENGTH is used to resolve shift-reduce conflicts occurring due to '(' in CREATE FUNCTION ... RETURNS statements.
+// When we see '(' after the return type, we can either reduce the length of the type to empty or shift '(' to parse the length.
+// We want shifting to take precedence, so we add lower precedence to the reduce rule.
+%nonassoc EMPTY_LENGTH
 
 %token LEX_ERROR
 %left <str> UNION
@@ -392,7 +402,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // SHOW tokens
 %token <str> CODE COLLATION COLUMNS DATABASES ENGINES EVENT EXTENDED FIELDS FULL FUNCTION GTID_EXECUTED
 %token <str> KEYSPACES OPEN PLUGINS PRIVILEGES PROCESSLIST SCHEMAS TABLES TRIGGERS USER
-%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
+%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_QUERY_DIGESTS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
 
 // SET tokens
 %token <str> NAMES GLOBAL SESSION ISOLATION LEVEL READ WRITE ONLY REPEATABLE COMMITTED UNCOMMITTED SERIALIZABLE
@@ -439,6 +449,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> MANUAL PARALLEL QUALIFY TABLESAMPLE
 %token <str> OUT INOUT
 
+// Stored routines, triggers and events tokens
+%token <str> EACH RETURN RETURNS DETERMINISTIC READS MODIFIES CONTAINS FOLLOWS PRECEDES
+%token <str> SCHEDULE AT EVERY STARTS ENDS COMPLETION PRESERVE
+
 // Performance Schema Functions
 %token <str> FORMAT_BYTES FORMAT_PICO_TIME PS_CURRENT_THREAD_ID PS_THREAD_ID
 
@@ -479,6 +493,17 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <renameTablePairs> rename_list
 %type <createTable> create_table_prefix
 %type <createProcedure> create_procedure
+%type <statement> create_function create_trigger create_event
+%type <routineCharacteristics> routine_characteristic routine_characteristic_list routine_characteristic_list_opt
+%type <triggerTiming> trigger_timing
+%type <triggerEvent> trigger_event
+%type <triggerOrder> trigger_order_opt
+%type <eventSchedule> event_schedule
+%type <eventStatus> event_status_opt
+%type <boolean> event_on_completion_opt
+%type <literal> event_comment_opt
+%type <procParams> func_params_list func_params_list_opt
+%type <procParam> func_param
 %type <createView> create_view_prefix
 %type <alterTable> alter_table_prefix
 %type <alterOption> alter_option alter_commands_modifier lock_index algorithm_index
@@ -792,6 +817,10 @@ compound_statement_without_semicolon:
   {
     $$ = &Signal{Condition: $2, SetValues: $3}
   }
+| RETURN expression
+  {
+    $$ = &ReturnStatement{Expr: $2}
+  }
 
 signal_set_list_opt:
   {
@@ -1520,6 +1549,18 @@ create_statement:
   {
     $$ = $1
   }
+| create_function
+  {
+    $$ = $1
+  }
+| create_trigger
+  {
+    $$ = $1
+  }
+| create_event
+  {
+    $$ = $1
+  }
 | create_index_prefix '(' index_column_list ')' index_option_list_opt algorithm_lock_opt
   {
     indexDef := $1.AlterOptions[0].(*AddIndexDefinition).IndexDefinition
@@ -1617,9 +1658,27 @@ json_object_param:
   }
 
 create_procedure:
-  CREATE comment_opt definer_opt PROCEDURE not_exists_opt table_name openb proc_params_list_opt closeb compound_statement
+  CREATE comment_opt definer_opt PROCEDURE not_exists_opt table_name openb proc_params_list_opt closeb routine_characteristic_list_opt compound_statement
+  {
+    $$ = &CreateProcedure{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Params: $8, Characteristics: $10, Body: $11}
+  }
+
+create_function:
+  CREATE comment_opt definer_opt FUNCTION not_exists_opt table_name openb func_params_list_opt closeb RETURNS column_type routine_characteristic_list_opt compound_statement
+  {
+    $$ = &CreateFunction{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Params: $8, Returns: $11, Characteristics: $12, Body: $13}
+  }
+
+create_trigger:
+  CREATE comment_opt definer_opt TRIGGER not_exists_opt table_name trigger_timing trigger_event ON table_name FOR EACH ROW trigger_order_opt compound_statement
   {
-    $$ = &CreateProcedure{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Params: $8, Body: $10}
+    $$ = &CreateTrigger{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Timing: $7, Event: $8, Table: $10, Order: $14, Body: $15}
+  }
+
+create_event:
+  CREATE comment_opt definer_opt EVENT not_exists_opt table_name ON SCHEDULE event_schedule event_on_completion_opt event_status_opt event_comment_opt DO compound_statement
+  {
+    $$ = &CreateEvent{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Schedule: $9, OnCompletionPreserve: $10, Status: $11, Comment: $12, Body: $14}
   }
 
 create_table_prefix:
@@ -2634,6 +2693,7 @@ enum_values:
   }
 
 length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = nil
   }
@@ -2643,6 +2703,7 @@ length_opt:
   }
 
 double_length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = LengthScaleOption{}
   }
@@ -2667,6 +2728,7 @@ double_length_opt
   }
 
 decimal_length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = LengthScaleOption{}
   }
@@ -4425,6 +4487,18 @@ drop_statement:
   {
     $$ = &DropProcedure{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
   }
+| DROP comment_opt FUNCTION exists_opt table_name
+  {
+    $$ = &DropFunction{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
+| DROP comment_opt TRIGGER exists_opt table_name
+  {
+    $$ = &DropTrigger{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
+| DROP comment_opt EVENT exists_opt table_name
+  {
+    $$ = &DropEvent{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
 
 truncate_statement:
   TRUNCATE TABLE table_name
@@ -4617,6 +4691,10 @@ show_statement:
   {
     $$ = &Show{&ShowBasic{Command: Warnings}}
   }
+| SHOW VITESS_QUERY_DIGESTS like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessQueryDigests, Filter: $3}}
+  }
 | SHOW VITESS_SHARDS like_or_where_opt
   {
     $$ = &Show{&ShowBasic{Command: VitessShards, Filter: $3}}
@@ -8049,6 +8127,190 @@ proc_param:
     $$ = &ProcParameter{Mode: $1, Name: $2, Type: $3}
   }
 
+func_params_list_opt:
+  {
+    $$ = nil
+  }
+| func_params_list
+  {
+    $$ = $1
+  }
+
+func_params_list:
+  func_param
+  {
+    $$ = []*ProcParameter{$1}
+  }
+| func_params_list ',' func_param
+  {
+    $$ = append($$, $3)
+  }
+
+func_param:
+  sql_id column_type
+  {
+    $$ = &ProcParameter{Mode: InMode, Name: $1, Type: $2}
+  }
+
+routine_characteristic_list_opt:
+  {
+    $$ = nil
+  }
+| routine_characteristic_list
+  {
+    $$ = $1
+  }
+
+routine_characteristic_list:
+  routine_characteristic
+  {
+    $$ = $1
+  }
+| routine_characteristic_list routine_characteristic
+  {
+    $$ = $1.merge($2)
+  }
+
+routine_characteristic:
+  COMMENT_KEYWORD STRING
+  {
+    $$ = &RoutineCharacteristics{Comment: NewStrLiteral($2)}
+  }
+| LANGUAGE SQL
+  {
+    $$ = &RoutineCharacteristics{}
+  }
+| DETERMINISTIC
+  {
+    $$ = &RoutineCharacteristics{Deterministic: true}
+  }
+| NOT DETERMINISTIC
+  {
+    $$ = &RoutineCharacteristics{}
+  }
+| CONTAINS SQL
+  {
+    $$ = &RoutineCharacteristics{DataAccess: ContainsSQLDataAccess}
+  }
+| NO SQL
+  {
+    $$ = &RoutineCharacteristics{DataAccess: NoSQLDataAccess}
+  }
+| READS SQL DATA
+  {
+    $$ = &RoutineCharacteristics{DataAccess: ReadsSQLDataAccess}
+  }
+| MODIFIES SQL DATA
+  {
+    $$ = &RoutineCharacteristics{DataAccess: ModifiesSQLDataAccess}
+  }
+| SQL SECURITY security_view
+  {
+    $$ = &RoutineCharacteristics{Security: $3}
+  }
+
+trigger_timing:
+  BEFORE
+  {
+    $$ = BeforeTiming
+  }
+| AFTER
+  {
+    $$ = AfterTiming
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$ = InsertTriggerEvent
+  }
+| UPDATE
+  {
+    $$ = UpdateTriggerEvent
+  }
+| DELETE
+  {
+    $$ = DeleteTriggerEvent
+  }
+
+trigger_order_opt:
+  {
+    $$ = nil
+  }
+| FOLLOWS sql_id
+  {
+    $$ = &TriggerOrder{OtherTrigger: $2}
+  }
+| PRECEDES sql_id
+  {
+    $$ = &TriggerOrder{Precedes: true, OtherTrigger: $2}
+  }
+
+event_schedule:
+  AT expression
+  {
+    $$ = &EventSchedule{At: $2}
+  }
+| EVERY expression interval
+  {
+    $$ = &EventSchedule{Every: $2, Unit: $3}
+  }
+| EVERY expression interval STARTS expression
+  {
+    $$ = &EventSchedule{Every: $2, Unit: $3, Starts: $5}
+  }
+| EVERY expression interval ENDS expression
+  {
+    $$ = &EventSchedule{Every: $2, Unit: $3, Ends: $5}
+  }
+| EVERY expression interval STARTS expression ENDS expression
+  {
+    $$ = &EventSchedule{Every: $2, Unit: $3, Starts: $5, Ends: $7}
+  }
+
+event_on_completion_opt:
+  {
+    $$ = false
+  }
+| ON COMPLETION PRESERVE
+  {
+    $$ = true
+  }
+| ON COMPLETION NOT PRESERVE
+  {
+    $$ = false
+  }
+
+event_status_opt:
+  {
+    $$ = EnableEventStatus
+  }
+| ENABLE
+  {
+    $$ = EnableEventStatus
+  }
+| DISABLE
+  {
+    $$ = DisableEventStatus
+  }
+| DISABLE ON sql_id
+  {
+    if $3.Lowered() != "replica" && $3.Lowered() != "slave" {
+      yylex.Error("expecting replica or slave after disable on")
+      return 1
+    }
+    $$ = DisableOnReplicaEventStatus
+  }
+
+event_comment_opt:
+  {
+    $$ = nil
+  }
+| COMMENT_KEYWORD STRING
+  {
+    $$ = NewStrLiteral($2)
+  }
+
 proc_param_mode:
   {
     $$ = InMode
@@ -8672,10 +8934,12 @@ reserved_keyword:
 | DENSE_RANK
 | DESC
 | DESCRIBE
+| DETERMINISTIC
 | DISTINCT
 | DISTINCTROW
 | DIV
 | DROP
+| EACH
 | ELSE
 | ELSEIF
 | EMPTY
@@ -8726,6 +8990,7 @@ reserved_keyword:
 | LOW_PRIORITY
 | MATCH
 | MAXVALUE
+| MODIFIES
 | MOD
 | NATURAL
 | NEXT // next should be doable as non-reserved, but is not due to the special `select next num_val` query that vitess supports
@@ -8751,10 +9016,12 @@ reserved_keyword:
 | RANGE
 | RANK
 | READ
+| READS
 | RECURSIVE
 | REGEXP
 | RENAME
 | REPLACE
+| RETURN
 | RIGHT
 | RLIKE
 | ROW
@@ -8821,6 +9088,7 @@ non_reserved_keyword:
 | ANY_VALUE %prec FUNCTION_CALL_NON_KEYWORD
 | ARRAY
 | ASCII
+| AT
 | AUTO_INCREMENT
 | AUTOEXTEND_SIZE
 | AVG %prec FUNCTION_CALL_NON_KEYWORD
@@ -8859,6 +9127,7 @@ non_reserved_keyword:
 | COMMITTED
 | COMPACT
 | COMPLETE
+| COMPLETION
 | COMPONENT
 | COMPRESSED
 | COMPRESSION
@@ -8867,6 +9136,7 @@ non_reserved_keyword:
 | CONSTRAINT_CATALOG
 | CONSTRAINT_NAME
 | CONSTRAINT_SCHEMA
+| CONTAINS
 | COPY
 | COUNT %prec FUNCTION_CALL_NON_KEYWORD
 | CSV
@@ -8897,6 +9167,7 @@ non_reserved_keyword:
 | ENCLOSED
 | ENCRYPTION
 | END
+| ENDS
 | ENFORCED
 | ENGINE
 | ENGINE_ATTRIBUTE
@@ -8905,6 +9176,7 @@ non_reserved_keyword:
 | ERROR
 | ESCAPED
 | EVENT
+| EVERY
 | EXCHANGE
 | EXCLUDE
 | EXCLUSIVE
@@ -8920,6 +9192,7 @@ non_reserved_keyword:
 | FIXED
 | FLUSH
 | FOLLOWING
+| FOLLOWS
 | FORCE_CUTOVER
 | FORMAT
 | FORMAT_BYTES %prec FUNCTION_CALL_NON_KEYWORD
@@ -9067,8 +9340,10 @@ non_reserved_keyword:
 | PERSIST
 | PERSIST_ONLY
 | PLAN
+| PRECEDES
 | PRECEDING
 | PREPARE
+| PRESERVE
 | PRIVILEGE_CHECKS_USER
 | PRIVILEGES
 | PROCESS
@@ -9110,6 +9385,7 @@ non_reserved_keyword:
 | RETAIN
 | RETRY
 | RETURNING
+| RETURNS
 | REUSE
 | ROLE
 | ROLLBACK
@@ -9117,6 +9393,7 @@ non_reserved_keyword:
 | ROW_FORMAT
 | RTRIM %prec FUNCTION_CALL_NON_KEYWORD
 | S3
+| SCHEDULE
 | SCHEMA_NAME
 | SECONDARY
 | SECONDARY_ENGINE
@@ -9153,6 +9430,7 @@ non_reserved_keyword:
 | SRID
 | START
 | STARTING
+| STARTS
 | STATS_AUTO_RECALC
 | STATS_PERSISTENT
 | STATS_SAMPLE_PAGES
@@ -9276,6 +9554,7 @@ non_reserved_keyword:
 | VITESS_METADATA
 | VITESS_MIGRATION
 | VITESS_MIGRATIONS
+| VITESS_QUERY_DIGESTS
 | VITESS_REPLICATION_STATUS
 | VITESS_SHARDS
 | VITESS_TABLETS
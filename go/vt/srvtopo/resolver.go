@@ -96,6 +96,18 @@ func (rs *ResolvedShard) WithKeyspace(newKeyspace string) *ResolvedShard {
 	}
 }
 
+// shardReferencesKey is the context key of the shards set by
+// WithShardReferences.
+type shardReferencesKey struct{}
+
+// WithShardReferences returns a context in which the shards of the keyspaces
+// of shards are resolved against the given shards, instead of the shards of
+// the SrvKeyspace partition of the tablet type. It is used to route reads to
+// shards that don't serve them yet, like the target shards of a Reshard.
+func WithShardReferences(ctx context.Context, shards map[string][]*topodatapb.ShardReference) context.Context {
+	return context.WithValue(ctx, shardReferencesKey{}, shards)
+}
+
 // GetKeyspaceShards return all the shards in a keyspace. It is only valid for the local cell.
 // Do not use it to further resolve shards, instead use the Resolve* methods.
 func (r *Resolver) GetKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, error) {
@@ -104,6 +116,12 @@ func (r *Resolver) GetKeyspaceShards(ctx context.Context, keyspace string, table
 		return "", nil, nil, vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "keyspace %v fetch error: %v", keyspace, err)
 	}
 
+	if overrides, ok := ctx.Value(shardReferencesKey{}).(map[string][]*topodatapb.ShardReference); ok {
+		if shards, ok := overrides[keyspace]; ok {
+			return keyspace, srvKeyspace, shards, nil
+		}
+	}
+
 	partition := topoproto.SrvKeyspaceGetPartition(srvKeyspace, tabletType)
 	if partition == nil {
 		return "", nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "No partition found for tabletType %v in keyspace %v", topoproto.TabletTypeLString(tabletType), keyspace)
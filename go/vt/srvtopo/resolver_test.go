@@ -260,3 +260,35 @@ func TestResolveDestinations(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveDestinationsWithShardReferences(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resolver := initResolver(t, ctx)
+
+	// The reads of sks are resolved against two shards that don't serve them.
+	ctx = WithShardReferences(ctx, map[string][]*topodatapb.ShardReference{
+		"sks": {
+			{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+			{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+		},
+	})
+	rss, _, err := resolver.ResolveDestinations(ctx, "sks", topodatapb.TabletType_REPLICA, nil, []key.ShardDestination{
+		key.DestinationKeyspaceID{0x28},
+	})
+	require.NoError(t, err)
+	require.Len(t, rss, 1)
+	require.Equal(t, "-80", rss[0].Target.Shard)
+
+	rss, _, err = resolver.GetAllShards(ctx, "sks", topodatapb.TabletType_REPLICA)
+	require.NoError(t, err)
+	require.Len(t, rss, 2)
+
+	// The other keyspaces are resolved against their serving shards.
+	rss, _, err = resolver.ResolveDestinations(ctx, "uks", topodatapb.TabletType_REPLICA, nil, []key.ShardDestination{
+		key.DestinationAllShards{},
+	})
+	require.NoError(t, err)
+	require.Len(t, rss, 1)
+	require.Equal(t, "0", rss[0].Target.Shard)
+}
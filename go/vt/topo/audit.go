@@ -0,0 +1,922 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/utils"
+)
+
+const (
+	// AuditSinkFile writes the audit log to a local file, rotated by
+	// size.
+	AuditSinkFile = "file"
+
+	// AuditSinkTopo writes the audit log to the global topo, one file
+	// per entry, in a directory per day. It only records the writes to
+	// the global topo.
+	AuditSinkTopo = "topo"
+
+	// DefaultAuditTopoPath is the default directory of the topo audit
+	// sink, in the global topo.
+	DefaultAuditTopoPath = "internal/topo_audit"
+
+	// DefaultAuditLogLimit is the number of entries returned by a read
+	// of the audit log that doesn't set a limit.
+	DefaultAuditLogLimit = 100
+
+	// auditDayFormat is the format of the directories of the topo sink.
+	auditDayFormat = "2006-01-02"
+
+	// auditPruneInterval is how often the topo sink removes the entries
+	// older than the retention.
+	auditPruneInterval = time.Hour
+
+	// auditBufferSize is the number of entries waiting to be written
+	// to a sink. Entries are dropped when the buffer is full.
+	auditBufferSize = 1000
+
+	// auditTopoMaxEntrySize is the size of the largest entry the topo
+	// sink stores with its values. The values of larger entries are
+	// left out, to stay well below the request size limit of the topo
+	// servers, 1.5MiB by default for etcd.
+	auditTopoMaxEntrySize = 256 * 1024
+
+	// auditKnownContentsSize is the number of files whose contents an
+	// audited connection remembers, to record the contents replaced by
+	// its writes.
+	auditKnownContentsSize = 1000
+)
+
+var (
+	// topoAuditSinkType is the sink to write the audit log of topo writes
+	// to. The audit log is disabled when empty.
+	topoAuditSinkType string
+
+	// topoAuditFile is the file of the file sink.
+	topoAuditFile string
+
+	// topoAuditFileMaxSize is the size at which the file of the file
+	// sink is rotated.
+	topoAuditFileMaxSize int64 = 100 * 1024 * 1024
+
+	// topoAuditFileMaxBackups is the number of rotated files the file
+	// sink keeps.
+	topoAuditFileMaxBackups = 5
+
+	// topoAuditPath is the directory of the topo sink.
+	topoAuditPath = DefaultAuditTopoPath
+
+	// topoAuditRetention is how long the topo sink keeps entries.
+	topoAuditRetention = 30 * 24 * time.Hour
+
+	topoAuditEntries = stats.NewCountersWithMultiLabels(
+		"TopologyAuditEntries",
+		"TopologyAuditEntries written per operation",
+		[]string{"Operation", "Cell"})
+
+	topoAuditErrors = stats.NewCountersWithMultiLabels(
+		"TopologyAuditErrors",
+		"TopologyAuditErrors when writing the audit log per operation",
+		[]string{"Operation", "Cell"})
+
+	topoAuditDropped = stats.NewCountersWithMultiLabels(
+		"TopologyAuditDropped",
+		"TopologyAuditDropped entries because the audit log buffer is full per operation",
+		[]string{"Operation", "Cell"})
+
+	// fileAuditSinks has the file sinks by file name, so all the
+	// servers of a process share the same file.
+	fileAuditSinksMu sync.Mutex
+	fileAuditSinks   = make(map[string]*asyncAuditSink)
+)
+
+func registerTopoAuditFlags(fs *pflag.FlagSet) {
+	utils.SetFlagStringVar(fs, &topoAuditSinkType, "topo-audit-sink", topoAuditSinkType, "If set, record every write to the topology in an audit log. Supported sinks are 'file' and 'topo'. The 'topo' sink only records the writes to the global topology.")
+	utils.SetFlagStringVar(fs, &topoAuditFile, "topo-audit-file", topoAuditFile, "The file of the 'file' topo audit sink.")
+	utils.SetFlagInt64Var(fs, &topoAuditFileMaxSize, "topo-audit-file-max-size", topoAuditFileMaxSize, "The size in bytes at which the file of the 'file' topo audit sink is rotated.")
+	utils.SetFlagIntVar(fs, &topoAuditFileMaxBackups, "topo-audit-file-max-backups", topoAuditFileMaxBackups, "The number of rotated files kept by the 'file' topo audit sink.")
+	utils.SetFlagStringVar(fs, &topoAuditPath, "topo-audit-path", topoAuditPath, "The directory of the 'topo' topo audit sink, in the global topology server.")
+	utils.SetFlagDurationVar(fs, &topoAuditRetention, "topo-audit-retention", topoAuditRetention, "How long the 'topo' topo audit sink keeps entries.")
+}
+
+// AuditOperation is the type of a write recorded in the audit log.
+type AuditOperation string
+
+const (
+	// AuditCreate is a Create of a file.
+	AuditCreate = AuditOperation("create")
+	// AuditUpdate is an Update of a file. The file may not have
+	// existed before.
+	AuditUpdate = AuditOperation("update")
+	// AuditDelete is a Delete of a file.
+	AuditDelete = AuditOperation("delete")
+	// AuditDropped marks the entries that were dropped because the
+	// audit log could not keep up with the writes.
+	AuditDropped = AuditOperation("dropped")
+)
+
+// AuditEntry is a write to the topology recorded in the audit log.
+type AuditEntry struct {
+	Time      time.Time      `json:"time"`
+	Cell      string         `json:"cell"`
+	Path      string         `json:"path"`
+	Operation AuditOperation `json:"operation"`
+
+	// Binary, Hostname, PID and User describe the process that made
+	// the write.
+	Binary   string `json:"binary"`
+	Hostname string `json:"hostname"`
+	PID      int    `json:"pid"`
+	User     string `json:"user,omitempty"`
+
+	// Caller is the caller id of the request that made the write, if
+	// there is one in the context.
+	Caller string `json:"caller,omitempty"`
+
+	// OldValue and NewValue are the contents of the file before and
+	// after the write, decoded as JSON for the known topo records.
+	// OldValue is only known if the process read or wrote the file
+	// before, and is empty otherwise.
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+
+	// Diff is a unified diff from OldValue to NewValue.
+	Diff string `json:"diff,omitempty"`
+
+	// ValuesOmitted is set when OldValue, NewValue and Diff were too
+	// large to be stored, and were left out.
+	ValuesOmitted bool `json:"values_omitted,omitempty"`
+
+	// Dropped is the number of entries dropped before this one, for
+	// the AuditDropped entries.
+	Dropped int64 `json:"dropped,omitempty"`
+}
+
+// AuditFilter selects entries of the audit log. The zero value
+// selects the most recent DefaultAuditLogLimit entries.
+type AuditFilter struct {
+	// Cell only selects the writes to this cell, if set.
+	Cell string
+	// PathPrefix only selects the writes to the files under this
+	// path, if set.
+	PathPrefix string
+	// Since and Until only select the writes in this time range, if
+	// set.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+func (f *AuditFilter) limit() int {
+	if f.Limit <= 0 {
+		return DefaultAuditLogLimit
+	}
+	return f.Limit
+}
+
+func (f *AuditFilter) matches(entry *AuditEntry) bool {
+	// The dropped entries may have matched any cell and path.
+	if entry.Operation == AuditDropped {
+		return f.matchesTime(entry)
+	}
+	if f.Cell != "" && entry.Cell != f.Cell {
+		return false
+	}
+	if f.PathPrefix != "" && !strings.HasPrefix(entry.Path, f.PathPrefix) {
+		return false
+	}
+	return f.matchesTime(entry)
+}
+
+func (f *AuditFilter) matchesTime(entry *AuditEntry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// auditSink stores the audit log.
+type auditSink interface {
+	write(ctx context.Context, entry *AuditEntry) error
+	read(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error)
+}
+
+// newAuditSink returns the sink configured by the flags, or nil if
+// the audit log is disabled. The topo sink writes through globalConn,
+// which must not be audited itself.
+func newAuditSink(globalConn Conn) (*asyncAuditSink, error) {
+	switch topoAuditSinkType {
+	case "":
+		return nil, nil
+	case AuditSinkFile:
+		if topoAuditFile == "" {
+			return nil, fmt.Errorf("--topo-audit-file must be set to use the %v topo audit sink", AuditSinkFile)
+		}
+		fileAuditSinksMu.Lock()
+		defer fileAuditSinksMu.Unlock()
+		// The file sinks are shared by all the servers of the process,
+		// and never closed.
+		sink, ok := fileAuditSinks[topoAuditFile]
+		if !ok {
+			sink = newAsyncAuditSink(&fileAuditSink{
+				path:       topoAuditFile,
+				maxSize:    topoAuditFileMaxSize,
+				maxBackups: topoAuditFileMaxBackups,
+			})
+			fileAuditSinks[topoAuditFile] = sink
+		}
+		return sink, nil
+	case AuditSinkTopo:
+		sink := newAsyncAuditSink(&topoAuditSink{
+			conn:      globalConn,
+			root:      topoAuditPath,
+			retention: topoAuditRetention,
+		})
+		// Recording the writes to the cells in the global topo would
+		// make every cell write a global one.
+		sink.globalOnly = true
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("unknown topo audit sink %q", topoAuditSinkType)
+	}
+}
+
+// GetAuditLog returns the entries of the audit log selected by the
+// filter, most recent first. It reads the local file of this process
+// when the file sink is configured, and the topo sink otherwise.
+func (ts *Server) GetAuditLog(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error) {
+	if filter == nil {
+		filter = &AuditFilter{}
+	}
+	if ts.auditSink != nil {
+		return ts.auditSink.read(ctx, filter)
+	}
+	return ReadTopoAuditLog(ctx, &connAuditLogReader{ts.globalReadOnlyCell}, topoAuditPath, filter)
+}
+
+// auditConn is a wrapper for a Conn that records every write in the
+// audit log.
+type auditConn struct {
+	Conn
+	cell string
+	sink *asyncAuditSink
+
+	// mu protects known.
+	mu sync.Mutex
+	// known has the last contents read or written through this
+	// connection, by file path, so the writes can record the contents
+	// they replace without reading them again.
+	known map[string]knownContents
+}
+
+// knownContents are the contents of a file at a version.
+type knownContents struct {
+	version  string
+	contents []byte
+}
+
+func newAuditConn(cell string, conn Conn, sink *asyncAuditSink) *auditConn {
+	return &auditConn{
+		Conn:  conn,
+		cell:  cell,
+		sink:  sink,
+		known: make(map[string]knownContents),
+	}
+}
+
+// Get is part of the Conn interface.
+func (ac *auditConn) Get(ctx context.Context, filePath string) ([]byte, Version, error) {
+	contents, version, err := ac.Conn.Get(ctx, filePath)
+	if err == nil {
+		ac.remember(filePath, version, contents)
+	}
+	return contents, version, err
+}
+
+// Create is part of the Conn interface.
+func (ac *auditConn) Create(ctx context.Context, filePath string, contents []byte) (Version, error) {
+	version, err := ac.Conn.Create(ctx, filePath, contents)
+	if err == nil {
+		ac.remember(filePath, version, contents)
+		ac.record(ctx, AuditCreate, filePath, nil, contents)
+	}
+	return version, err
+}
+
+// Update is part of the Conn interface.
+func (ac *auditConn) Update(ctx context.Context, filePath string, contents []byte, version Version) (Version, error) {
+	old := ac.oldContents(filePath, version)
+	newVersion, err := ac.Conn.Update(ctx, filePath, contents, version)
+	if err == nil {
+		ac.remember(filePath, newVersion, contents)
+		ac.record(ctx, AuditUpdate, filePath, old, contents)
+	}
+	return newVersion, err
+}
+
+// Delete is part of the Conn interface.
+func (ac *auditConn) Delete(ctx context.Context, filePath string, version Version) error {
+	old := ac.oldContents(filePath, version)
+	err := ac.Conn.Delete(ctx, filePath, version)
+	if err == nil {
+		ac.mu.Lock()
+		delete(ac.known, filePath)
+		ac.mu.Unlock()
+		ac.record(ctx, AuditDelete, filePath, old, nil)
+	}
+	return err
+}
+
+// remember records the contents of a file at a version.
+func (ac *auditConn) remember(filePath string, version Version, contents []byte) {
+	if version == nil {
+		return
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if _, ok := ac.known[filePath]; !ok && len(ac.known) >= auditKnownContentsSize {
+		for p := range ac.known {
+			delete(ac.known, p)
+			break
+		}
+	}
+	ac.known[filePath] = knownContents{version: version.String(), contents: contents}
+}
+
+// oldContents returns the contents a write replaces, if they were read
+// or written through this connection before. A write conditional on a
+// version replaces the contents at that version. An unconditional write
+// replaces the last known contents, unless another writer changed the
+// file since.
+func (ac *auditConn) oldContents(filePath string, version Version) []byte {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	known, ok := ac.known[filePath]
+	if !ok || (version != nil && version.String() != known.version) {
+		return nil
+	}
+	return known.contents
+}
+
+// record sends an entry to the audit log. Failures are logged, and
+// don't fail the write.
+func (ac *auditConn) record(ctx context.Context, op AuditOperation, filePath string, oldContents, newContents []byte) {
+	ac.sink.enqueue(newAuditEntry(ctx, ac.cell, op, filePath), oldContents, newContents)
+}
+
+// pendingAuditEntry is an entry waiting to be written to a sink, with
+// the contents its values are decoded from. flushed is set, and the
+// other fields are not, for the markers sent by flush.
+type pendingAuditEntry struct {
+	entry       *AuditEntry
+	oldContents []byte
+	newContents []byte
+	flushed     chan struct{}
+}
+
+// asyncAuditSink writes the entries to a sink in the background, so the
+// topo writes don't wait for the audit log. At most auditBufferSize
+// entries wait to be written, and the others are dropped. The number of
+// dropped entries is written to the sink in an AuditDropped entry once
+// the buffer is empty again, so the gaps show in the audit log.
+type asyncAuditSink struct {
+	sink auditSink
+	// globalOnly is set if the sink only records the writes to the
+	// global topo.
+	globalOnly bool
+	// dropped is the number of entries dropped since the last
+	// AuditDropped entry.
+	dropped atomic.Int64
+
+	pending chan *pendingAuditEntry
+	done    chan struct{}
+	stopped chan struct{}
+	close   sync.Once
+}
+
+func newAsyncAuditSink(sink auditSink) *asyncAuditSink {
+	s := &asyncAuditSink{
+		sink:    sink,
+		pending: make(chan *pendingAuditEntry, auditBufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *asyncAuditSink) run() {
+	defer close(s.stopped)
+	for {
+		select {
+		case p := <-s.pending:
+			s.write(p)
+			if len(s.pending) == 0 {
+				s.writeDropped()
+			}
+		case <-s.done:
+			// Write the entries that were sent before the sink was
+			// closed.
+			for {
+				select {
+				case p := <-s.pending:
+					s.write(p)
+				default:
+					s.writeDropped()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *asyncAuditSink) write(p *pendingAuditEntry) {
+	if p.flushed != nil {
+		s.writeDropped()
+		close(p.flushed)
+		return
+	}
+	entry := p.entry
+	statsKey := []string{string(entry.Operation), entry.Cell}
+	entry.setValues(p.oldContents, p.newContents)
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteOperationTimeout)
+	defer cancel()
+	if err := s.sink.write(ctx, entry); err != nil {
+		topoAuditErrors.Add(statsKey, 1)
+		log.Warningf("cannot write topo audit entry for %v %v/%v: %v", entry.Operation, entry.Cell, entry.Path, err)
+		return
+	}
+	topoAuditEntries.Add(statsKey, 1)
+}
+
+// writeDropped writes an AuditDropped entry if entries were dropped
+// since the last one.
+func (s *asyncAuditSink) writeDropped() {
+	dropped := s.dropped.Swap(0)
+	if dropped == 0 {
+		return
+	}
+	entry := newAuditEntry(context.Background(), "", AuditDropped, "")
+	entry.Path = ""
+	entry.Dropped = dropped
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteOperationTimeout)
+	defer cancel()
+	if err := s.sink.write(ctx, entry); err != nil {
+		// Report them with the next entry.
+		s.dropped.Add(dropped)
+		log.Warningf("cannot write topo audit entry for %v dropped entries: %v", dropped, err)
+		return
+	}
+	log.Warningf("dropped %v topo audit entries, the audit log buffer was full", dropped)
+}
+
+// enqueue sends an entry to the sink, or drops it if the buffer is
+// full or the sink is closed.
+func (s *asyncAuditSink) enqueue(entry *AuditEntry, oldContents, newContents []byte) {
+	select {
+	case <-s.done:
+	case s.pending <- &pendingAuditEntry{entry: entry, oldContents: oldContents, newContents: newContents}:
+		return
+	default:
+	}
+	topoAuditDropped.Add([]string{string(entry.Operation), entry.Cell}, 1)
+	s.dropped.Add(1)
+}
+
+// flush waits until the entries sent before are written.
+func (s *asyncAuditSink) flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	select {
+	case s.pending <- &pendingAuditEntry{flushed: flushed}:
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// read returns the entries of the audit log selected by the filter,
+// including the ones sent before the read.
+func (s *asyncAuditSink) read(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error) {
+	if err := s.flush(ctx); err != nil {
+		return nil, err
+	}
+	return s.sink.read(ctx, filter)
+}
+
+// Close writes the pending entries, and stops the sink.
+func (s *asyncAuditSink) Close() {
+	s.close.Do(func() {
+		close(s.done)
+	})
+	<-s.stopped
+}
+
+var (
+	auditProcessOnce sync.Once
+	auditBinary      string
+	auditHostname    string
+	auditUser        string
+)
+
+// newAuditEntry returns the entry of a write, without its values.
+func newAuditEntry(ctx context.Context, cell string, op AuditOperation, filePath string) *AuditEntry {
+	auditProcessOnce.Do(func() {
+		auditBinary = filepath.Base(os.Args[0])
+		auditHostname, _ = os.Hostname()
+		if u, err := user.Current(); err == nil {
+			auditUser = u.Username
+		}
+	})
+
+	filePath = path.Join("/", filePath)
+	entry := &AuditEntry{
+		Time:      time.Now().UTC(),
+		Cell:      cell,
+		Path:      filePath,
+		Operation: op,
+		Binary:    auditBinary,
+		Hostname:  auditHostname,
+		PID:       os.Getpid(),
+		User:      auditUser,
+	}
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil {
+		entry.Caller = callerid.GetPrincipal(ef)
+	} else if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		entry.Caller = callerid.GetUsername(im)
+	}
+	return entry
+}
+
+// setValues sets the values and the diff of an entry from the contents
+// of the file before and after the write. The diff is only set if the
+// contents before the write are known, or the write created the file.
+func (entry *AuditEntry) setValues(oldContents, newContents []byte) {
+	entry.OldValue = auditValue(entry.Path, oldContents)
+	entry.NewValue = auditValue(entry.Path, newContents)
+	if entry.OldValue != entry.NewValue && (oldContents != nil || entry.Operation == AuditCreate) {
+		entry.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(entry.OldValue),
+			B:        difflib.SplitLines(entry.NewValue),
+			FromFile: "old",
+			ToFile:   "new",
+			Context:  3,
+		})
+	}
+}
+
+// auditValue returns the contents of a file as JSON if it is a known
+// topo record, and as is otherwise.
+func auditValue(filePath string, contents []byte) string {
+	if contents == nil {
+		return ""
+	}
+	value, err := DecodeContent(filePath, contents, true /* json */)
+	if err != nil {
+		return string(contents)
+	}
+	return value
+}
+
+// fileAuditSink writes the audit log to a local file, one JSON entry
+// per line. The file is rotated to <file>.1, <file>.2, ... when it
+// reaches its maximum size.
+type fileAuditSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	// mu protects the following fields.
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func (s *fileAuditSink) write(ctx context.Context, entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileAuditSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileAuditSink) rotate() error {
+	s.file.Close()
+	s.file = nil
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return s.open()
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.open()
+}
+
+func (s *fileAuditSink) backupPath(i int) string {
+	return fmt.Sprintf("%v.%v", s.path, i)
+}
+
+func (s *fileAuditSink) read(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Read the files from the oldest to the most recent, and keep the
+	// last matching entries.
+	limit := filter.limit()
+	var entries []*AuditEntry
+	for i := s.maxBackups; i >= 0; i-- {
+		name := s.path
+		if i > 0 {
+			name = s.backupPath(i)
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		dec := json.NewDecoder(file)
+		for {
+			if err := ctx.Err(); err != nil {
+				file.Close()
+				return nil, err
+			}
+			entry := &AuditEntry{}
+			if err := dec.Decode(entry); err != nil {
+				if !errors.Is(err, io.EOF) {
+					log.Warningf("cannot decode topo audit file %v: %v", name, err)
+				}
+				break
+			}
+			if !filter.matches(entry) {
+				continue
+			}
+			entries = append(entries, entry)
+			if len(entries) > limit {
+				entries = entries[1:]
+			}
+		}
+		file.Close()
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// topoAuditSink writes the audit log to the global topo. Each entry
+// is a file in the directory of its day, named after the time of the
+// write and the process that made it, so the entries of a day sort by
+// time.
+type topoAuditSink struct {
+	conn      Conn
+	root      string
+	retention time.Duration
+	seq       atomic.Int64
+
+	// mu protects lastPrune.
+	mu        sync.Mutex
+	lastPrune time.Time
+}
+
+func (s *topoAuditSink) write(ctx context.Context, entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if len(data) > auditTopoMaxEntrySize {
+		omitted := *entry
+		omitted.OldValue, omitted.NewValue, omitted.Diff = "", "", ""
+		omitted.ValuesOmitted = true
+		if data, err = json.Marshal(&omitted); err != nil {
+			return err
+		}
+	}
+	name := fmt.Sprintf("%v-%v-%v-%v", entry.Time.Format("150405.000000000"), entry.Hostname, entry.PID, s.seq.Add(1))
+	if _, err := s.conn.Create(ctx, path.Join(s.root, entry.Time.Format(auditDayFormat), name), data); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.retention > 0 && time.Since(s.lastPrune) > auditPruneInterval {
+		s.lastPrune = time.Now()
+		go s.prune(entry.Time.Add(-s.retention))
+	}
+	return nil
+}
+
+// prune deletes the days of the audit log before the one of the
+// provided time.
+func (s *topoAuditSink) prune(before time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteOperationTimeout)
+	defer cancel()
+	days, err := s.conn.ListDir(ctx, s.root, false /* full */)
+	if err != nil {
+		if !IsErrType(err, NoNode) {
+			log.Warningf("cannot list topo audit days: %v", err)
+		}
+		return
+	}
+	cutoff := before.Format(auditDayFormat)
+	for _, day := range days {
+		if day.Name >= cutoff {
+			continue
+		}
+		dayPath := path.Join(s.root, day.Name)
+		entries, err := s.conn.ListDir(ctx, dayPath, false /* full */)
+		if err != nil {
+			log.Warningf("cannot list topo audit entries of %v: %v", day.Name, err)
+			continue
+		}
+		for _, e := range entries {
+			if err := s.conn.Delete(ctx, path.Join(dayPath, e.Name), nil); err != nil && !IsErrType(err, NoNode) {
+				log.Warningf("cannot delete topo audit entry %v/%v: %v", day.Name, e.Name, err)
+			}
+		}
+	}
+}
+
+func (s *topoAuditSink) read(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error) {
+	return ReadTopoAuditLog(ctx, &connAuditLogReader{s.conn}, s.root, filter)
+}
+
+// AuditLogReader reads the files of a topo audit sink, so the audit
+// log can be read through a topo.Conn or through a remote API.
+type AuditLogReader interface {
+	// ListDir returns the names of the entries of a directory, or a
+	// NoNode error if it doesn't exist.
+	ListDir(ctx context.Context, dirPath string) ([]string, error)
+	// Get returns the contents of a file, or a NoNode error if it
+	// doesn't exist.
+	Get(ctx context.Context, filePath string) ([]byte, error)
+}
+
+// ReadTopoAuditLog returns the entries of the topo audit sink stored
+// in the root directory of the global topo, selected by the filter,
+// most recent first.
+func ReadTopoAuditLog(ctx context.Context, r AuditLogReader, root string, filter *AuditFilter) ([]*AuditEntry, error) {
+	if filter == nil {
+		filter = &AuditFilter{}
+	}
+	days, err := r.ListDir(ctx, root)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+
+	limit := filter.limit()
+	var entries []*AuditEntry
+	for _, day := range days {
+		if !filter.Until.IsZero() && day > filter.Until.UTC().Format(auditDayFormat) {
+			continue
+		}
+		if !filter.Since.IsZero() && day < filter.Since.UTC().Format(auditDayFormat) {
+			break
+		}
+		dayPath := path.Join(root, day)
+		names, err := r.ListDir(ctx, dayPath)
+		if err != nil {
+			if IsErrType(err, NoNode) {
+				continue
+			}
+			return nil, err
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+		for _, name := range names {
+			data, err := r.Get(ctx, path.Join(dayPath, name))
+			if err != nil {
+				if IsErrType(err, NoNode) {
+					// Pruned since we listed the directory.
+					continue
+				}
+				return nil, err
+			}
+			entry := &AuditEntry{}
+			if err := json.Unmarshal(data, entry); err != nil {
+				log.Warningf("cannot decode topo audit entry %v/%v: %v", day, name, err)
+				continue
+			}
+			if !filter.matches(entry) {
+				continue
+			}
+			entries = append(entries, entry)
+			if len(entries) == limit {
+				return entries, nil
+			}
+		}
+	}
+	return entries, nil
+}
+
+// connAuditLogReader is an AuditLogReader for a Conn.
+type connAuditLogReader struct {
+	conn Conn
+}
+
+func (r *connAuditLogReader) ListDir(ctx context.Context, dirPath string) ([]string, error) {
+	entries, err := r.conn.ListDir(ctx, dirPath, false /* full */)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name
+	}
+	return names, nil
+}
+
+func (r *connAuditLogReader) Get(ctx context.Context, filePath string) ([]byte, error) {
+	data, _, err := r.conn.Get(ctx, filePath)
+	return data, err
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingAuditSink is a sink whose writes wait until unblock is closed.
+type blockingAuditSink struct {
+	started chan struct{}
+	unblock chan struct{}
+	entries []*AuditEntry
+}
+
+func (s *blockingAuditSink) write(ctx context.Context, entry *AuditEntry) error {
+	select {
+	case s.started <- struct{}{}:
+	default:
+	}
+	<-s.unblock
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *blockingAuditSink) read(ctx context.Context, filter *AuditFilter) ([]*AuditEntry, error) {
+	return s.entries, nil
+}
+
+func TestAsyncAuditSinkDropsWhenFull(t *testing.T) {
+	ctx := context.Background()
+	sink := &blockingAuditSink{
+		started: make(chan struct{}, 1),
+		unblock: make(chan struct{}),
+	}
+	s := newAsyncAuditSink(sink)
+	defer s.Close()
+
+	// The first entry is being written, the next auditBufferSize wait in
+	// the buffer, and the others are dropped.
+	droppedCount := topoAuditDropped.Counts()["update.test_cell"]
+	s.enqueue(newAuditEntry(ctx, "test_cell", AuditUpdate, "/test/file"), nil, []byte("value"))
+	<-sink.started
+	for i := 0; i < auditBufferSize+10; i++ {
+		s.enqueue(newAuditEntry(ctx, "test_cell", AuditUpdate, "/test/file"), nil, []byte("value"))
+	}
+	assert.Equal(t, droppedCount+10, topoAuditDropped.Counts()["update.test_cell"])
+
+	close(sink.unblock)
+	entries, err := s.read(ctx, &AuditFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, auditBufferSize+2, len(entries))
+	assert.Equal(t, "value", entries[0].NewValue)
+	// The dropped entries are reported after the ones written before.
+	dropped := entries[len(entries)-1]
+	assert.Equal(t, AuditDropped, dropped.Operation)
+	assert.EqualValues(t, 10, dropped.Dropped)
+	// They match any cell and path.
+	assert.True(t, (&AuditFilter{Cell: "other_cell", PathPrefix: "/other/"}).matches(dropped))
+}
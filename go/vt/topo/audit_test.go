@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestAuditLogTopoSink(t *testing.T) {
+	defer topo.SetAuditFlagsForTest(topo.AuditSinkTopo, "", 0, 0)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	ctx = callerid.NewContext(ctx, callerid.NewEffectiveCallerID("alice", "", ""), nil)
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     "ks",
+		Keyspace: &vschemapb.Keyspace{Sharded: false},
+	}))
+	require.NoError(t, ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     "ks",
+		Keyspace: &vschemapb.Keyspace{Sharded: true},
+	}))
+	require.NoError(t, ts.DeleteKeyspace(ctx, "ks"))
+
+	entries, err := ts.GetAuditLog(ctx, &topo.AuditFilter{PathPrefix: "/keyspaces/ks/"})
+	require.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, fmt.Sprintf("%v %v", e.Operation, e.Path))
+		assert.Equal(t, topo.GlobalCell, e.Cell)
+		assert.Equal(t, "alice", e.Caller)
+		assert.Equal(t, os.Getpid(), e.PID)
+	}
+	// Most recent first.
+	assert.Equal(t, []string{
+		"delete /keyspaces/ks/VSchema",
+		"delete /keyspaces/ks/Keyspace",
+		"update /keyspaces/ks/VSchema",
+		"update /keyspaces/ks/VSchema",
+		"create /keyspaces/ks/Keyspace",
+	}, got)
+
+	change := entries[2]
+	assert.Equal(t, "{}", change.OldValue)
+	assert.JSONEq(t, `{"sharded": true}`, change.NewValue)
+	assert.Contains(t, change.Diff, "-{}\n")
+	assert.Contains(t, change.Diff, `+  "sharded":`)
+	assert.Empty(t, entries[0].NewValue)
+	assert.Equal(t, change.NewValue, entries[0].OldValue)
+
+	// The cell topo writes are not recorded in the global topo.
+	_, err = ts.GetOrCreateShard(context.Background(), "ks2", "0")
+	require.NoError(t, err)
+	require.NoError(t, ts.UpdateShardReplicationFields(ctx, "zone1", "ks2", "0", func(sr *topodatapb.ShardReplication) error {
+		sr.Nodes = append(sr.Nodes, &topodatapb.ShardReplication_Node{TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 1}})
+		return nil
+	}))
+	entries, err = ts.GetAuditLog(ctx, &topo.AuditFilter{Cell: "zone1"})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// The values of the entries too large for the topo are left out.
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	_, err = conn.Create(ctx, "test/large", []byte(strings.Repeat("x", 300*1024)))
+	require.NoError(t, err)
+	entries, err = ts.GetAuditLog(ctx, &topo.AuditFilter{PathPrefix: "/test/"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].ValuesOmitted)
+	assert.Empty(t, entries[0].NewValue)
+	assert.Empty(t, entries[0].Diff)
+
+	// The time range is applied.
+	entries, err = ts.GetAuditLog(ctx, &topo.AuditFilter{Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestAuditLogFileSink(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "topo_audit.log")
+	defer topo.SetAuditFlagsForTest(topo.AuditSinkFile, file, 2048, 1)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts, factory := memorytopo.NewServerAndFactory(ctx, "zone1")
+	defer ts.Close()
+
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	gets := factory.GetCallStats().Counts()["Get"]
+	for i := 0; i < 50; i++ {
+		_, err := conn.Update(ctx, "test/file", []byte(fmt.Sprintf("value %v", i)), nil)
+		require.NoError(t, err)
+	}
+	// The writes don't read the contents they replace.
+	assert.Equal(t, gets, factory.GetCallStats().Counts()["Get"])
+
+	entries, err := ts.GetAuditLog(ctx, &topo.AuditFilter{PathPrefix: "/test/", Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "value 49", entries[0].NewValue)
+	assert.Equal(t, "value 48", entries[0].OldValue)
+	assert.Equal(t, "value 48", entries[1].NewValue)
+
+	// The file was rotated, and only one backup is kept.
+	_, err = os.Stat(file + ".1")
+	require.NoError(t, err)
+	_, err = os.Stat(file + ".2")
+	assert.True(t, os.IsNotExist(err))
+
+	// The older entries were rotated away.
+	entries, err = ts.GetAuditLog(ctx, &topo.AuditFilter{PathPrefix: "/test/", Limit: 100})
+	require.NoError(t, err)
+	assert.Less(t, len(entries), 50)
+	assert.Equal(t, "value 49", entries[0].NewValue)
+}
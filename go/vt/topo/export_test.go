@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+// SetAuditFlagsForTest sets the flags of the audit log, and returns a
+// function restoring them.
+func SetAuditFlagsForTest(sinkType, file string, maxSize int64, maxBackups int) func() {
+	oldSinkType, oldFile, oldMaxSize, oldMaxBackups := topoAuditSinkType, topoAuditFile, topoAuditFileMaxSize, topoAuditFileMaxBackups
+	topoAuditSinkType, topoAuditFile, topoAuditFileMaxSize, topoAuditFileMaxBackups = sinkType, file, maxSize, maxBackups
+	return func() {
+		topoAuditSinkType, topoAuditFile, topoAuditFileMaxSize, topoAuditFileMaxBackups = oldSinkType, oldFile, oldMaxSize, oldMaxBackups
+	}
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// TopoArchiveVersion is the version of the archive format written by
+// WriteTopoArchive. ReadTopoArchive rejects archives with a different
+// version.
+const TopoArchiveVersion = 1
+
+// TopoArchive is a point-in-time copy of the global topo and of all
+// the cell topos. It contains the raw contents of every file, so it
+// covers all the records stored in the topo (keyspaces, shards,
+// tablets, vschemas, routing rules, ...) without having to know
+// about them.
+type TopoArchive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Cells maps the cell name to its files. The global topo uses
+	// topo.GlobalCell as its name.
+	Cells map[string][]*TopoArchiveFile `json:"cells"`
+}
+
+// TopoArchiveFile is a file in a TopoArchive.
+type TopoArchiveFile struct {
+	Path string `json:"path"`
+	Data []byte `json:"data"`
+}
+
+// TopoArchiveDiffType describes how a file differs between an archive
+// and the live topo.
+type TopoArchiveDiffType string
+
+const (
+	// TopoArchiveFileMissing is a file in the archive that is not in
+	// the live topo.
+	TopoArchiveFileMissing = TopoArchiveDiffType("missing")
+	// TopoArchiveFileChanged is a file whose contents differ.
+	TopoArchiveFileChanged = TopoArchiveDiffType("changed")
+	// TopoArchiveFileExtra is a file in the live topo that is not in
+	// the archive.
+	TopoArchiveFileExtra = TopoArchiveDiffType("extra")
+)
+
+// TopoArchiveDiff is a file that differs between an archive and the
+// live topo.
+type TopoArchiveDiff struct {
+	Cell string              `json:"cell"`
+	Path string              `json:"path"`
+	Type TopoArchiveDiffType `json:"type"`
+}
+
+// BackupTopo reads the global topo and all the cell topos into an
+// archive. Ephemeral files, like locks and elections, are skipped.
+func BackupTopo(ctx context.Context, ts *topo.Server) (*TopoArchive, error) {
+	archive := &TopoArchive{
+		Version:   TopoArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+		Cells:     make(map[string][]*TopoArchiveFile),
+	}
+
+	cells, err := archiveCells(ctx, ts, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, cell := range cells {
+		files, err := readCellFiles(ctx, ts, cell)
+		if err != nil {
+			return nil, err
+		}
+		archive.Cells[cell] = files
+	}
+	return archive, nil
+}
+
+// DiffTopoArchive compares an archive with the live topo, and returns
+// the files that differ, sorted by cell and path.
+func DiffTopoArchive(ctx context.Context, ts *topo.Server, archive *TopoArchive) ([]*TopoArchiveDiff, error) {
+	cells, err := archiveCells(ctx, ts, archive)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []*TopoArchiveDiff
+	for _, cell := range cells {
+		live, err := readCellFiles(ctx, ts, cell)
+		if err != nil && !topo.IsErrType(err, topo.NoNode) {
+			return nil, err
+		}
+		diffs = append(diffs, diffCellFiles(cell, archive.Cells[cell], live)...)
+	}
+	return diffs, nil
+}
+
+// RestoreTopo writes the files of an archive to the live topo, and
+// returns the differences it fixed. The global topo is restored first,
+// so the cells it defines can be restored next. Files that are in the
+// live topo but not in the archive are only deleted if prune is true.
+//
+// The files of a keyspace, in the global topo and in the cells, are
+// written while holding the lock of the keyspace, or of the shard for
+// the files of a shard, like any other change to them. Keyspaces and
+// shards that do not exist in the live topo cannot be locked, and are
+// restored without a lock.
+func RestoreTopo(ctx context.Context, ts *topo.Server, archive *TopoArchive, prune bool) ([]*TopoArchiveDiff, error) {
+	var restored []*TopoArchiveDiff
+
+	restoreCell := func(cell string) error {
+		conn, err := ts.ConnForCell(ctx, cell)
+		if err != nil {
+			return fmt.Errorf("ConnForCell(%v): %w", cell, err)
+		}
+		live, err := readCellFiles(ctx, ts, cell)
+		if err != nil && !topo.IsErrType(err, topo.NoNode) {
+			return err
+		}
+		data := make(map[string][]byte, len(archive.Cells[cell]))
+		for _, f := range archive.Cells[cell] {
+			data[f.Path] = f.Data
+		}
+
+		// Group the differences by the lock that protects them.
+		var targets []restoreLockTarget
+		diffs := make(map[restoreLockTarget][]*TopoArchiveDiff)
+		for _, diff := range diffCellFiles(cell, archive.Cells[cell], live) {
+			if diff.Type == TopoArchiveFileExtra && !prune {
+				continue
+			}
+			target := lockTargetForPath(diff.Path)
+			if _, ok := diffs[target]; !ok {
+				targets = append(targets, target)
+			}
+			diffs[target] = append(diffs[target], diff)
+		}
+
+		for _, target := range targets {
+			err := target.withLock(ctx, ts, func(ctx context.Context) error {
+				for _, diff := range diffs[target] {
+					switch diff.Type {
+					case TopoArchiveFileMissing, TopoArchiveFileChanged:
+						if _, err := conn.Update(ctx, diff.Path, data[diff.Path], nil); err != nil {
+							return fmt.Errorf("Update(%v, %v): %w", cell, diff.Path, err)
+						}
+					case TopoArchiveFileExtra:
+						if err := conn.Delete(ctx, diff.Path, nil); err != nil && !topo.IsErrType(err, topo.NoNode) {
+							return fmt.Errorf("Delete(%v, %v): %w", cell, diff.Path, err)
+						}
+					}
+					restored = append(restored, diff)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := restoreCell(topo.GlobalCell); err != nil {
+		return restored, err
+	}
+	cells, err := archiveCells(ctx, ts, archive)
+	if err != nil {
+		return restored, err
+	}
+	for _, cell := range cells {
+		if cell == topo.GlobalCell {
+			continue
+		}
+		if err := restoreCell(cell); err != nil {
+			return restored, err
+		}
+	}
+	return restored, nil
+}
+
+// restoreLockTarget is the keyspace, or the shard if shard is set, whose
+// lock protects a file. Files outside of the keyspaces are not protected
+// by a lock, and have an empty target.
+type restoreLockTarget struct {
+	keyspace string
+	shard    string
+}
+
+// lockTargetForPath returns the lock target of the file at filePath, in
+// the global topo or in a cell.
+func lockTargetForPath(filePath string) restoreLockTarget {
+	parts := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	if len(parts) < 3 || parts[0] != topo.KeyspacesPath {
+		return restoreLockTarget{}
+	}
+	if len(parts) >= 5 && parts[2] == topo.ShardsPath {
+		return restoreLockTarget{keyspace: parts[1], shard: parts[3]}
+	}
+	return restoreLockTarget{keyspace: parts[1]}
+}
+
+// withLock calls f while holding the lock of the target, if any.
+func (t restoreLockTarget) withLock(ctx context.Context, ts *topo.Server, f func(ctx context.Context) error) (err error) {
+	if t.keyspace == "" {
+		return f(ctx)
+	}
+	var (
+		lockCtx context.Context
+		unlock  func(*error)
+	)
+	if t.shard == "" {
+		lockCtx, unlock, err = ts.LockKeyspace(ctx, t.keyspace, "RestoreTopo")
+	} else {
+		lockCtx, unlock, err = ts.LockShard(ctx, t.keyspace, t.shard, "RestoreTopo")
+	}
+	if topo.IsErrType(err, topo.NoNode) {
+		// The keyspace or shard does not exist yet.
+		return f(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer unlock(&err)
+	return f(lockCtx)
+}
+
+// WriteTopoArchive writes a gzipped JSON archive.
+func WriteTopoArchive(w io.Writer, archive *TopoArchive) error {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadTopoArchive reads an archive written by WriteTopoArchive.
+func ReadTopoArchive(r io.Reader) (*TopoArchive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read topo archive: %w", err)
+	}
+	defer gz.Close()
+	archive := &TopoArchive{}
+	if err := json.NewDecoder(gz).Decode(archive); err != nil {
+		return nil, fmt.Errorf("cannot decode topo archive: %w", err)
+	}
+	if archive.Version != TopoArchiveVersion {
+		return nil, fmt.Errorf("unsupported topo archive version %v, expected %v", archive.Version, TopoArchiveVersion)
+	}
+	if _, ok := archive.Cells[topo.GlobalCell]; !ok {
+		return nil, fmt.Errorf("topo archive has no %v cell", topo.GlobalCell)
+	}
+	return archive, nil
+}
+
+// archiveCells returns the global cell followed by the sorted names of
+// the live cells, and of the archive cells if archive is not nil.
+func archiveCells(ctx context.Context, ts *topo.Server, archive *TopoArchive) ([]string, error) {
+	names, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetCellInfoNames: %w", err)
+	}
+	set := make(map[string]bool)
+	for _, name := range names {
+		set[name] = true
+	}
+	if archive != nil {
+		for name := range archive.Cells {
+			set[name] = true
+		}
+	}
+	delete(set, topo.GlobalCell)
+
+	cells := make([]string, 0, len(set)+1)
+	for name := range set {
+		cells = append(cells, name)
+	}
+	sort.Strings(cells)
+	return append([]string{topo.GlobalCell}, cells...), nil
+}
+
+// readCellFiles returns all the non-ephemeral files of a cell, sorted
+// by path.
+func readCellFiles(ctx context.Context, ts *topo.Server, cell string) ([]*TopoArchiveFile, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, fmt.Errorf("ConnForCell(%v): %w", cell, err)
+	}
+	var files []*TopoArchiveFile
+	var walk func(dirPath string) error
+	walk = func(dirPath string) error {
+		entries, err := conn.ListDir(ctx, dirPath, true /* full */)
+		if err != nil {
+			if topo.IsErrType(err, topo.NoNode) {
+				return nil
+			}
+			return fmt.Errorf("ListDir(%v, %v): %w", cell, dirPath, err)
+		}
+		for _, e := range entries {
+			if e.Ephemeral {
+				continue
+			}
+			p := path.Join(dirPath, e.Name)
+			if e.Type == topo.TypeDirectory {
+				if err := walk(p); err != nil {
+					return err
+				}
+				continue
+			}
+			data, _, err := conn.Get(ctx, p)
+			if err != nil {
+				if topo.IsErrType(err, topo.NoNode) {
+					// Deleted since we listed the directory.
+					continue
+				}
+				return fmt.Errorf("Get(%v, %v): %w", cell, p, err)
+			}
+			files = append(files, &TopoArchiveFile{Path: p, Data: data})
+		}
+		return nil
+	}
+	if err := walk("/"); err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// diffCellFiles compares the archive and live files of a cell.
+func diffCellFiles(cell string, archived, live []*TopoArchiveFile) []*TopoArchiveDiff {
+	liveData := make(map[string][]byte, len(live))
+	for _, f := range live {
+		liveData[f.Path] = f.Data
+	}
+	var diffs []*TopoArchiveDiff
+	for _, f := range archived {
+		data, ok := liveData[f.Path]
+		switch {
+		case !ok:
+			diffs = append(diffs, &TopoArchiveDiff{Cell: cell, Path: f.Path, Type: TopoArchiveFileMissing})
+		case !bytes.Equal(data, f.Data):
+			diffs = append(diffs, &TopoArchiveDiff{Cell: cell, Path: f.Path, Type: TopoArchiveFileChanged})
+		}
+		delete(liveData, f.Path)
+	}
+	for _, f := range live {
+		if _, ok := liveData[f.Path]; ok {
+			diffs = append(diffs, &TopoArchiveDiff{Cell: cell, Path: f.Path, Type: TopoArchiveFileExtra})
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
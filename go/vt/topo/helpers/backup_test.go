@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestBackupRestoreTopo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fromTS, _ := createSetup(ctx, t)
+	err := fromTS.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     "test_keyspace",
+		Keyspace: &vschemapb.Keyspace{Sharded: false},
+	})
+	require.NoError(t, err)
+
+	archive, err := BackupTopo(ctx, fromTS)
+	require.NoError(t, err)
+	assert.Equal(t, TopoArchiveVersion, archive.Version)
+	var paths []string
+	for _, f := range archive.Cells["test_cell"] {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{
+		"/keyspaces/test_keyspace/shards/0/ShardReplication",
+		"/tablets/test_cell-0000000123/Tablet",
+		"/tablets/test_cell-0000000234/Tablet",
+	}, paths)
+
+	// Round trip through the archive format.
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTopoArchive(buf, archive))
+	archive, err = ReadTopoArchive(buf)
+	require.NoError(t, err)
+
+	// The archive matches the topo it was taken from.
+	diffs, err := DiffTopoArchive(ctx, fromTS, archive)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	// Restore into a new topo server, whose global topo only has
+	// the definition of a different cell.
+	toTS := memorytopo.NewServer(ctx, "test_cell", "other_cell")
+	require.NoError(t, toTS.DeleteCellInfo(ctx, "test_cell", true))
+	diffs, err = DiffTopoArchive(ctx, toTS, archive)
+	require.NoError(t, err)
+	assert.Contains(t, diffs, &TopoArchiveDiff{Cell: topo.GlobalCell, Path: "/keyspaces/test_keyspace/Keyspace", Type: TopoArchiveFileMissing})
+	assert.Contains(t, diffs, &TopoArchiveDiff{Cell: topo.GlobalCell, Path: "/cells/other_cell/CellInfo", Type: TopoArchiveFileExtra})
+	assert.Contains(t, diffs, &TopoArchiveDiff{Cell: "test_cell", Path: "/tablets/test_cell-0000000123/Tablet", Type: TopoArchiveFileMissing})
+
+	restored, err := RestoreTopo(ctx, toTS, archive, false /* prune */)
+	require.NoError(t, err)
+	assert.Len(t, restored, len(diffs)-1)
+
+	tablet, err := toTS.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "test_cell", Uid: 123})
+	require.NoError(t, err)
+	assert.Equal(t, "primaryhost", tablet.Hostname)
+	vschema, err := toTS.GetVSchema(ctx, "test_keyspace")
+	require.NoError(t, err)
+	assert.False(t, vschema.Sharded)
+
+	// Without pruning, the other cell is still there.
+	diffs, err = DiffTopoArchive(ctx, toTS, archive)
+	require.NoError(t, err)
+	assert.Equal(t, []*TopoArchiveDiff{{Cell: topo.GlobalCell, Path: "/cells/other_cell/CellInfo", Type: TopoArchiveFileExtra}}, diffs)
+
+	// Change a file, the restore puts it back and prunes the
+	// other cell.
+	_, err = toTS.UpdateShardFields(ctx, "test_keyspace", "0", func(si *topo.ShardInfo) error {
+		si.IsPrimaryServing = false
+		return nil
+	})
+	require.NoError(t, err)
+	restored, err = RestoreTopo(ctx, toTS, archive, true /* prune */)
+	require.NoError(t, err)
+	assert.Equal(t, []*TopoArchiveDiff{
+		{Cell: topo.GlobalCell, Path: "/cells/other_cell/CellInfo", Type: TopoArchiveFileExtra},
+		{Cell: topo.GlobalCell, Path: "/keyspaces/test_keyspace/shards/0/Shard", Type: TopoArchiveFileChanged},
+	}, restored)
+
+	diffs, err = DiffTopoArchive(ctx, toTS, archive)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	// The files of a shard are only restored while holding its lock.
+	_, err = toTS.UpdateShardFields(ctx, "test_keyspace", "0", func(si *topo.ShardInfo) error {
+		si.IsPrimaryServing = false
+		return nil
+	})
+	require.NoError(t, err)
+	_, unlock, err := toTS.LockShard(ctx, "test_keyspace", "0", "test")
+	require.NoError(t, err)
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer timeoutCancel()
+	_, err = RestoreTopo(timeoutCtx, toTS, archive, true /* prune */)
+	require.Error(t, err)
+	si, err := toTS.GetShard(ctx, "test_keyspace", "0")
+	require.NoError(t, err)
+	assert.False(t, si.IsPrimaryServing)
+
+	unlock(&err)
+	require.NoError(t, err)
+	restored, err = RestoreTopo(ctx, toTS, archive, true /* prune */)
+	require.NoError(t, err)
+	assert.Equal(t, []*TopoArchiveDiff{
+		{Cell: topo.GlobalCell, Path: "/keyspaces/test_keyspace/shards/0/Shard", Type: TopoArchiveFileChanged},
+	}, restored)
+}
+
+func TestReadTopoArchiveVersion(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTopoArchive(buf, &TopoArchive{
+		Version: TopoArchiveVersion + 1,
+		Cells:   map[string][]*TopoArchiveFile{topo.GlobalCell: nil},
+	}))
+	_, err := ReadTopoArchive(buf)
+	assert.ErrorContains(t, err, "unsupported topo archive version")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// Create is part of the topo.Conn interface.
+func (s *Server) Create(ctx context.Context, filePath string, contents []byte) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	resp, err := s.apply(ctx, nodePath, &command{
+		Op:    opCreate,
+		Key:   nodePath,
+		Value: contents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return RaftVersion(resp.Header.Revision), nil
+}
+
+// Update is part of the topo.Conn interface.
+func (s *Server) Update(ctx context.Context, filePath string, contents []byte, version topo.Version) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	cmd := &command{
+		Op:    opUpdate,
+		Key:   nodePath,
+		Value: contents,
+	}
+	if version != nil {
+		cmd.Version = int64(version.(RaftVersion))
+	}
+	resp, err := s.apply(ctx, nodePath, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return RaftVersion(resp.Header.Revision), nil
+}
+
+// Get is part of the topo.Conn interface.
+func (s *Server) Get(ctx context.Context, filePath string) ([]byte, topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	resp, err := s.rangeKeys(ctx, nodePath, false /* prefix */)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Kvs) != 1 {
+		return nil, nil, topo.NewError(topo.NoNode, nodePath)
+	}
+	return resp.Kvs[0].Value, RaftVersion(resp.Kvs[0].ModRevision), nil
+}
+
+// GetVersion is part of the topo.Conn interface.
+// The group only keeps the latest version of each file.
+func (s *Server) GetVersion(ctx context.Context, filePath string, version int64) ([]byte, error) {
+	return nil, topo.NewError(topo.NoImplementation, "GetVersion not supported in raft topo")
+}
+
+// List is part of the topo.Conn interface.
+func (s *Server) List(ctx context.Context, filePathPrefix string) ([]topo.KVInfo, error) {
+	nodePathPrefix := path.Join(s.root, filePathPrefix)
+
+	resp, err := s.rangeKeys(ctx, nodePathPrefix, true /* prefix */)
+	if err != nil {
+		return []topo.KVInfo{}, err
+	}
+	if len(resp.Kvs) == 0 {
+		return []topo.KVInfo{}, topo.NewError(topo.NoNode, nodePathPrefix)
+	}
+	results := make([]topo.KVInfo, len(resp.Kvs))
+	for i, kv := range resp.Kvs {
+		results[i].Key = []byte(kv.Key)
+		results[i].Value = kv.Value
+		results[i].Version = RaftVersion(kv.ModRevision)
+	}
+	return results, nil
+}
+
+// Delete is part of the topo.Conn interface.
+func (s *Server) Delete(ctx context.Context, filePath string, version topo.Version) error {
+	nodePath := path.Join(s.root, filePath)
+
+	cmd := &command{
+		Op:  opDelete,
+		Key: nodePath,
+	}
+	if version != nil {
+		cmd.Version = int64(version.(RaftVersion))
+	}
+	_, err := s.apply(ctx, nodePath, cmd)
+	return err
+}
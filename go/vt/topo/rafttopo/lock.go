@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+const (
+	// locksPath is the directory holding the lock files, in each
+	// locked directory.
+	locksPath = "locks"
+
+	// electionsPath is the directory holding the elections.
+	electionsPath = "elections"
+)
+
+// waitOnLastRev waits until the most recent file in the provided
+// directory created before the provided revision is deleted.
+// It returns true only if there is no more other older files.
+func (s *Server) waitOnLastRev(ctx context.Context, nodePath string, revision int64) (bool, error) {
+	// Get the key that is blocking us, if any.
+	resp, err := s.rangeKeys(ctx, nodePath+"/", true /* prefix */)
+	if err != nil {
+		return false, err
+	}
+	var blocking *rafttopopb.KeyValue
+	for _, kv := range resp.Kvs {
+		if kv.CreateRevision < revision && (blocking == nil || kv.CreateRevision > blocking.CreateRevision) {
+			blocking = kv
+		}
+	}
+	if blocking == nil {
+		// No older key, we're done waiting.
+		return true, nil
+	}
+
+	// Wait for release on blocking key.
+	rev := resp.Header.Revision
+	for {
+		wresp, err := s.watchKeys(ctx, blocking.Key, false /* prefix */, rev)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, convertError(ctx.Err(), nodePath)
+			}
+			// The watch failed, we're not sure if there are
+			// more items.
+			return false, nil
+		}
+		for _, ev := range wresp.Events {
+			if ev.Deleted {
+				// There might still be older keys,
+				// but not this one.
+				return false, nil
+			}
+		}
+		rev = wresp.Header.Revision
+	}
+}
+
+// raftLockDescriptor implements topo.LockDescriptor.
+type raftLockDescriptor struct {
+	s       *Server
+	leaseID int64
+	stop    context.CancelFunc
+}
+
+// TryLock is part of the topo.Conn interface.
+func (s *Server) TryLock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list all the entries under dirPath
+	entries, err := s.ListDir(ctx, dirPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there is a folder '/locks' with some entries in it then we can assume that someone else already has a lock.
+	// Throw error in this case
+	for _, e := range entries {
+		if e.Name == locksPath && e.Type == topo.TypeDirectory && e.Ephemeral {
+			return nil, topo.NewError(topo.NodeExists, "lock already exists at path "+dirPath)
+		}
+	}
+
+	// everything is good let's acquire the lock.
+	return s.lock(ctx, dirPath, contents, leaseTTL)
+}
+
+// Lock is part of the topo.Conn interface.
+func (s *Server) Lock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, dirPath, contents, leaseTTL)
+}
+
+// LockWithTTL is part of the topo.Conn interface.
+func (s *Server) LockWithTTL(ctx context.Context, dirPath, contents string, ttl time.Duration) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, dirPath, contents, int(ttl.Seconds()))
+}
+
+// LockName is part of the topo.Conn interface.
+func (s *Server) LockName(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	return s.lock(ctx, dirPath, contents, int(topo.NamedLockTTL.Seconds()))
+}
+
+// lock is used by both Lock() and primary election.
+func (s *Server) lock(ctx context.Context, nodePath, contents string, ttl int) (topo.LockDescriptor, error) {
+	nodePath = path.Join(s.root, nodePath, locksPath)
+
+	// Get a lease, and keep it alive until we unlock.
+	grant, err := s.apply(ctx, nodePath, &command{Op: opGrant, TTL: int64(ttl)})
+	if err != nil {
+		return nil, err
+	}
+	ld := &raftLockDescriptor{
+		s:       s,
+		leaseID: grant.Lease,
+	}
+	var keepAliveCtx context.Context
+	keepAliveCtx, ld.stop = context.WithCancel(context.Background())
+	go s.keepAliveLoop(keepAliveCtx, ld.leaseID, time.Duration(ttl)*time.Second)
+
+	// Create an ephemeral node in the locks directory. Use the lease
+	// ID as the file name, so it's guaranteed unique.
+	key := fmt.Sprintf("%v/%v", nodePath, ld.leaseID)
+	create, err := s.apply(ctx, key, &command{
+		Op:    opCreate,
+		Key:   key,
+		Value: []byte(contents),
+		Lease: ld.leaseID,
+	})
+	if err != nil {
+		ld.revoke(key)
+		return nil, err
+	}
+
+	// Wait until all older nodes in the locks directory are gone.
+	for {
+		done, err := s.waitOnLastRev(ctx, nodePath, create.Header.Revision)
+		if err != nil {
+			// We had an error waiting on the last node.
+			// Revoke our lease, this will delete the file.
+			ld.revoke(key)
+			return nil, err
+		}
+		if done {
+			// No more older nodes, we're it!
+			return ld, nil
+		}
+	}
+}
+
+// keepAliveLoop refreshes a lease until ctx is done.
+func (s *Server) keepAliveLoop(ctx context.Context, leaseID int64, ttl time.Duration) {
+	interval := ttl / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.running:
+			return
+		case <-ticker.C:
+		}
+		kaCtx, kaCancel := context.WithTimeout(ctx, interval)
+		err := s.keepAlive(kaCtx, leaseID)
+		kaCancel()
+		if topo.IsErrType(err, topo.NoNode) {
+			// The lease expired, nothing to keep alive anymore.
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Warningf("raft topo: cannot keep lease %v alive: %v", leaseID, err)
+		}
+	}
+}
+
+// revoke stops the keep-alive, and revokes the lease after a failure
+// to get the lock.
+func (ld *raftLockDescriptor) revoke(key string) {
+	ld.stop()
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	if _, err := ld.s.apply(ctx, key, &command{Op: opRevoke, Lease: ld.leaseID}); err != nil {
+		log.Warningf("Revoke(%d) failed, may have left %v behind: %v", ld.leaseID, key, err)
+	}
+}
+
+// Check is part of the topo.LockDescriptor interface.
+// We use a keep-alive to make sure the lease is still active and well.
+func (ld *raftLockDescriptor) Check(ctx context.Context) error {
+	return ld.s.keepAlive(ctx, ld.leaseID)
+}
+
+// Unlock is part of the topo.LockDescriptor interface.
+func (ld *raftLockDescriptor) Unlock(ctx context.Context) error {
+	ld.stop()
+	_, err := ld.s.apply(ctx, "lease", &command{Op: opRevoke, Lease: ld.leaseID})
+	return err
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"go.etcd.io/bbolt"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+var (
+	// logsBucket has the Raft log entries, keyed by index.
+	logsBucket = []byte("logs")
+	// stableBucket has the Raft stable state, like the current term.
+	stableBucket = []byte("stable")
+
+	// errKeyNotFound is returned by Get and GetUint64 for unknown
+	// keys. Raft checks for this exact message.
+	errKeyNotFound = errors.New("not found")
+)
+
+// boltStore implements raft.LogStore and raft.StableStore on a bbolt
+// database. Log entries are stored in their protobuf encoding.
+type boltStore struct {
+	db *bbolt.DB
+}
+
+var (
+	_ raft.LogStore    = (*boltStore)(nil)
+	_ raft.StableStore = (*boltStore)(nil)
+)
+
+// newBoltStore opens, or creates, the database at path.
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(logsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(stableBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+// Close closes the database.
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// FirstIndex is part of the raft.LogStore interface.
+func (b *boltStore) FirstIndex() (uint64, error) {
+	var index uint64
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		if k, _ := tx.Bucket(logsBucket).Cursor().First(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+// LastIndex is part of the raft.LogStore interface.
+func (b *boltStore) LastIndex() (uint64, error) {
+	var index uint64
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		if k, _ := tx.Bucket(logsBucket).Cursor().Last(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+// GetLog is part of the raft.LogStore interface.
+func (b *boltStore) GetLog(index uint64, log *raft.Log) error {
+	return b.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(logsBucket).Get(uint64Key(index))
+		if v == nil {
+			return raft.ErrLogNotFound
+		}
+		// UnmarshalVT copies the bytes, so the entry stays valid
+		// after the transaction.
+		entry := &rafttopopb.Log{}
+		if err := entry.UnmarshalVT(v); err != nil {
+			return err
+		}
+		*log = *logFromProto(entry)
+		return nil
+	})
+}
+
+// StoreLog is part of the raft.LogStore interface.
+func (b *boltStore) StoreLog(log *raft.Log) error {
+	return b.StoreLogs([]*raft.Log{log})
+}
+
+// StoreLogs is part of the raft.LogStore interface.
+func (b *boltStore) StoreLogs(logs []*raft.Log) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(logsBucket)
+		for _, log := range logs {
+			v, err := logToProto(log).MarshalVT()
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(uint64Key(log.Index), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteRange is part of the raft.LogStore interface.
+func (b *boltStore) DeleteRange(min, max uint64) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		// Deleting through the cursor can make it skip keys, so we
+		// collect them first.
+		bucket := tx.Bucket(logsBucket)
+		var keys [][]byte
+		cursor := bucket.Cursor()
+		for k, _ := cursor.Seek(uint64Key(min)); k != nil && binary.BigEndian.Uint64(k) <= max; k, _ = cursor.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Set is part of the raft.StableStore interface.
+func (b *boltStore) Set(key []byte, val []byte) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(stableBucket).Put(key, val)
+	})
+}
+
+// Get is part of the raft.StableStore interface.
+func (b *boltStore) Get(key []byte) ([]byte, error) {
+	var val []byte
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(stableBucket).Get(key)
+		if v == nil {
+			return errKeyNotFound
+		}
+		val = append([]byte(nil), v...)
+		return nil
+	})
+	return val, err
+}
+
+// SetUint64 is part of the raft.StableStore interface.
+func (b *boltStore) SetUint64(key []byte, val uint64) error {
+	return b.Set(key, uint64Key(val))
+}
+
+// GetUint64 is part of the raft.StableStore interface.
+func (b *boltStore) GetUint64(key []byte) (uint64, error) {
+	val, err := b.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+// uint64Key encodes a uint64 so keys sort in numerical order.
+func uint64Key(v uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, v)
+	return key
+}
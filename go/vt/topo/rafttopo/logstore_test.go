@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raft.db")
+	b, err := newBoltStore(path)
+	require.NoError(t, err)
+
+	// An empty store has no index, and no keys.
+	index, err := b.FirstIndex()
+	require.NoError(t, err)
+	assert.Zero(t, index)
+	_, err = b.GetUint64([]byte("CurrentTerm"))
+	assert.EqualError(t, err, "not found")
+
+	appendedAt := time.Unix(1700000000, 42)
+	var logs []*raft.Log
+	for i := uint64(1); i <= 5; i++ {
+		logs = append(logs, &raft.Log{
+			Index:      i,
+			Term:       1,
+			Type:       raft.LogCommand,
+			Data:       []byte{byte(i)},
+			AppendedAt: appendedAt,
+		})
+	}
+	require.NoError(t, b.StoreLogs(logs))
+	require.NoError(t, b.SetUint64([]byte("CurrentTerm"), 7))
+	require.NoError(t, b.DeleteRange(1, 2))
+
+	// Everything survives a restart.
+	require.NoError(t, b.Close())
+	b, err = newBoltStore(path)
+	require.NoError(t, err)
+	defer b.Close()
+
+	index, err = b.FirstIndex()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, index)
+	index, err = b.LastIndex()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, index)
+
+	var got raft.Log
+	assert.Equal(t, raft.ErrLogNotFound, b.GetLog(2, &got))
+	require.NoError(t, b.GetLog(4, &got))
+	assert.EqualValues(t, 4, got.Index)
+	assert.Equal(t, []byte{4}, got.Data)
+	assert.True(t, appendedAt.Equal(got.AppendedAt))
+
+	term, err := b.GetUint64([]byte("CurrentTerm"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, term)
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/log"
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+const (
+	// applyTimeout is the maximum time we wait for a command to be
+	// committed and applied.
+	applyTimeout = 10 * time.Second
+
+	// leaseCheckInterval is how often the leader looks for expired
+	// leases.
+	leaseCheckInterval = 500 * time.Millisecond
+)
+
+// NodeConfig describes a member of a Raft group.
+type NodeConfig struct {
+	// Address is the host:port this node listens on. It is also its
+	// identity in the group, and must be one of Peers.
+	Address string
+
+	// Peers is the address of all members of the group. They are used
+	// to bootstrap the group the first time it starts.
+	Peers []string
+
+	// DataDir is where the Raft log and snapshots are persisted. If
+	// empty, the node keeps everything in memory, which is only useful
+	// for tests.
+	DataDir string
+
+	// TLS holds the certificates used to accept connections, and to
+	// connect to the other nodes.
+	TLS TLSConfig
+}
+
+// Node is a member of the Raft group that stores a topo tree. It
+// replicates the tree through the Raft log, and serves requests from
+// Server instances in this process and others.
+type Node struct {
+	config NodeConfig
+
+	store      *store
+	raft       *raft.Raft
+	transport  *transport
+	grpcServer *grpc.Server
+	closers    []io.Closer
+
+	// ready is true while this node is the leader, and has applied
+	// all entries committed by the previous leaders.
+	ready atomic.Bool
+
+	// leaseMu protects leaseDeadlines.
+	leaseMu sync.Mutex
+	// leaseDeadlines is when each lease expires, if not kept alive.
+	// It is only maintained on the leader.
+	leaseDeadlines map[int64]time.Time
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewNode starts a node listening on config.Address.
+func NewNode(config NodeConfig) (*Node, error) {
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return nil, err
+	}
+	n, err := newNode(config, listener)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func newNode(config NodeConfig, listener net.Listener) (*Node, error) {
+	if !slices.Contains(config.Peers, config.Address) {
+		return nil, fmt.Errorf("raft topo node address %v is not one of the peers %v", config.Address, config.Peers)
+	}
+	serverOption, err := config.TLS.serverOption()
+	if err != nil {
+		return nil, err
+	}
+	dialOption, err := config.TLS.dialOption()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Node{
+		config:         config,
+		store:          newStore(),
+		transport:      newTransport(config.Address, dialOption),
+		leaseDeadlines: make(map[int64]time.Time),
+		done:           make(chan struct{}),
+	}
+	msgSize := grpccommon.MaxMessageSize()
+	n.grpcServer = grpc.NewServer(
+		serverOption,
+		grpc.MaxRecvMsgSize(msgSize),
+		grpc.MaxSendMsgSize(msgSize),
+	)
+	rafttopopb.RegisterRaftTopoServer(n.grpcServer, &grpcService{n: n})
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "rafttopo",
+		Level:  hclog.Warn,
+		Output: logWriter{},
+	})
+
+	var (
+		logs   raft.LogStore
+		stable raft.StableStore
+		snaps  raft.SnapshotStore
+	)
+	if config.DataDir == "" {
+		inmem := raft.NewInmemStore()
+		logs, stable, snaps = inmem, inmem, raft.NewInmemSnapshotStore()
+	} else {
+		if err := os.MkdirAll(config.DataDir, 0o700); err != nil {
+			return nil, err
+		}
+		bolt, err := newBoltStore(filepath.Join(config.DataDir, "raft.db"))
+		if err != nil {
+			return nil, err
+		}
+		n.closers = append(n.closers, bolt)
+		fileSnaps, err := raft.NewFileSnapshotStore(config.DataDir, 2, logWriter{})
+		if err != nil {
+			n.closeStores()
+			return nil, err
+		}
+		logs, stable, snaps = bolt, bolt, fileSnaps
+	}
+
+	raftConfig := raft.DefaultConfig()
+	raftConfig.LocalID = raft.ServerID(config.Address)
+	raftConfig.Logger = logger
+
+	hasState, err := raft.HasExistingState(logs, stable, snaps)
+	if err != nil {
+		n.transport.Close()
+		n.closeStores()
+		return nil, err
+	}
+	if !hasState {
+		// All peers bootstrap with the same configuration, so it
+		// doesn't matter which one comes up first.
+		configuration := raft.Configuration{}
+		for _, peer := range config.Peers {
+			configuration.Servers = append(configuration.Servers, raft.Server{
+				ID:      raft.ServerID(peer),
+				Address: raft.ServerAddress(peer),
+			})
+		}
+		if err := raft.BootstrapCluster(raftConfig, logs, stable, snaps, n.transport, configuration); err != nil {
+			n.transport.Close()
+			n.closeStores()
+			return nil, err
+		}
+	}
+
+	n.raft, err = raft.NewRaft(raftConfig, n.store, logs, stable, snaps, n.transport)
+	if err != nil {
+		n.transport.Close()
+		n.closeStores()
+		return nil, err
+	}
+
+	// The members and the clients only reach us once Raft is running.
+	go func() {
+		if err := n.grpcServer.Serve(listener); err != nil {
+			log.Warningf("raft topo: grpc server on %v stopped: %v", config.Address, err)
+		}
+	}()
+
+	n.wg.Add(1)
+	go n.leaderLoop()
+	return n, nil
+}
+
+// Close shuts down the node.
+func (n *Node) Close() error {
+	var err error
+	n.closeOnce.Do(func() {
+		close(n.done)
+		err = n.raft.Shutdown().Error()
+		n.grpcServer.Stop()
+		n.transport.Close()
+		n.wg.Wait()
+		n.closeStores()
+	})
+	return err
+}
+
+func (n *Node) closeStores() {
+	for _, c := range n.closers {
+		if err := c.Close(); err != nil {
+			log.Warningf("raft topo: cannot close store: %v", err)
+		}
+	}
+}
+
+// checkLeader returns true if this node can serve requests that need
+// the leader. Otherwise, it fills in the header to redirect the client.
+func (n *Node) checkLeader(header *rafttopopb.ResponseHeader) bool {
+	if n.ready.Load() {
+		return true
+	}
+	header.Status = rafttopopb.ResponseHeader_NOT_LEADER
+	if addr, _ := n.raft.LeaderWithID(); addr != "" && string(addr) != n.config.Address {
+		header.Leader = string(addr)
+	}
+	return false
+}
+
+// apply commits a command through the Raft log, and returns the
+// result of applying it to the store.
+func (n *Node) apply(cmd *command) (*applyResult, error) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+	future := n.raft.Apply(data, applyTimeout)
+	if err := future.Error(); err != nil {
+		if errors.Is(err, raft.ErrEnqueueTimeout) {
+			return nil, topo.NewError(topo.Timeout, cmd.Key)
+		}
+		return nil, err
+	}
+	res := future.Response().(*applyResult)
+	if res.Err == nil {
+		switch cmd.Op {
+		case opGrant:
+			n.refreshLease(res.Lease, time.Duration(cmd.TTL)*time.Second)
+		case opRevoke:
+			n.leaseMu.Lock()
+			delete(n.leaseDeadlines, cmd.Lease)
+			n.leaseMu.Unlock()
+		}
+	}
+	return res, nil
+}
+
+// keepAlive refreshes a lease, if it still exists.
+func (n *Node) keepAlive(id int64) error {
+	ttl, ok := n.store.leaseTTLs()[id]
+	if !ok {
+		return topo.NewError(topo.NoNode, fmt.Sprintf("lease %v", id))
+	}
+	n.refreshLease(id, ttl)
+	return nil
+}
+
+func (n *Node) refreshLease(id int64, ttl time.Duration) {
+	n.leaseMu.Lock()
+	defer n.leaseMu.Unlock()
+	n.leaseDeadlines[id] = time.Now().Add(ttl)
+}
+
+// leaderLoop tracks leadership changes. While this node is the leader,
+// it revokes the leases that were not kept alive.
+func (n *Node) leaderLoop() {
+	defer n.wg.Done()
+	ticker := time.NewTicker(leaseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.done:
+			return
+		case isLeader := <-n.raft.LeaderCh():
+			n.ready.Store(false)
+			if isLeader {
+				n.becomeLeader()
+			}
+		case <-ticker.C:
+			if n.ready.Load() {
+				n.expireLeases()
+			}
+		}
+	}
+}
+
+// becomeLeader waits until all previous entries are applied, and
+// gives all leases a full TTL since keep-alives went to the previous
+// leader.
+func (n *Node) becomeLeader() {
+	if err := n.raft.Barrier(applyTimeout).Error(); err != nil {
+		log.Warningf("raft topo: barrier failed after becoming leader: %v", err)
+		return
+	}
+	now := time.Now()
+	n.leaseMu.Lock()
+	n.leaseDeadlines = make(map[int64]time.Time)
+	for id, ttl := range n.store.leaseTTLs() {
+		n.leaseDeadlines[id] = now.Add(ttl)
+	}
+	n.leaseMu.Unlock()
+	n.ready.Store(true)
+}
+
+// expireLeases revokes all leases past their deadline.
+func (n *Node) expireLeases() {
+	now := time.Now()
+	var expired []int64
+	n.leaseMu.Lock()
+	for id, ttl := range n.store.leaseTTLs() {
+		deadline, ok := n.leaseDeadlines[id]
+		if !ok {
+			// Granted by a previous leader right before we
+			// took over.
+			n.leaseDeadlines[id] = now.Add(ttl)
+			continue
+		}
+		if now.After(deadline) {
+			expired = append(expired, id)
+		}
+	}
+	n.leaseMu.Unlock()
+
+	for _, id := range expired {
+		res, err := n.apply(&command{Op: opRevoke, Lease: id})
+		if err == nil {
+			err = res.Err
+		}
+		if err != nil && !topo.IsErrType(err, topo.NoNode) {
+			log.Warningf("raft topo: cannot revoke expired lease %v: %v", id, err)
+		}
+	}
+}
+
+// logWriter sends the Raft library logs to our log.
+type logWriter struct{}
+
+func (logWriter) Write(p []byte) (int, error) {
+	log.Info(string(p))
+	return len(p), nil
+}
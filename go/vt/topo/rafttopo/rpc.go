@@ -0,0 +1,327 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// watchPollTimeout is how long a Watch request waits on the node for
+// new events before returning an empty response.
+const watchPollTimeout = 10 * time.Second
+
+// headerErr returns the error described by a response header, if any.
+// Topo errors are rebuilt for the provided node path.
+func headerErr(h *rafttopopb.ResponseHeader, nodePath string) error {
+	switch h.GetStatus() {
+	case rafttopopb.ResponseHeader_OK:
+		return nil
+	case rafttopopb.ResponseHeader_TOPO_ERROR:
+		return topo.NewError(topo.ErrorCode(h.Code), nodePath)
+	case rafttopopb.ResponseHeader_COMPACTED:
+		return errCompacted
+	default:
+		return errors.New(h.GetMessage())
+	}
+}
+
+// setHeaderErr fills in the header for a failed request.
+func setHeaderErr(h *rafttopopb.ResponseHeader, err error) {
+	if code, ok := topoErrorCode(err); ok {
+		h.Status = rafttopopb.ResponseHeader_TOPO_ERROR
+		h.Code = int32(code)
+		h.Message = err.Error()
+		return
+	}
+	switch {
+	case errors.Is(err, errCompacted):
+		h.Status = rafttopopb.ResponseHeader_COMPACTED
+	default:
+		h.Status = rafttopopb.ResponseHeader_ERROR
+		h.Message = err.Error()
+	}
+}
+
+// topoErrorCode returns the code of a topo error.
+func topoErrorCode(err error) (topo.ErrorCode, bool) {
+	for code := topo.NodeExists; code <= topo.ResourceExhausted; code++ {
+		if topo.IsErrType(err, code) {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+func commandToProto(cmd *command) *rafttopopb.Command {
+	return &rafttopopb.Command{
+		Op:      cmd.Op,
+		Key:     cmd.Key,
+		Value:   cmd.Value,
+		Version: cmd.Version,
+		Lease:   cmd.Lease,
+		Ttl:     cmd.TTL,
+	}
+}
+
+func commandFromProto(cmd *rafttopopb.Command) *command {
+	return &command{
+		Op:      cmd.GetOp(),
+		Key:     cmd.GetKey(),
+		Value:   cmd.GetValue(),
+		Version: cmd.GetVersion(),
+		Lease:   cmd.GetLease(),
+		TTL:     cmd.GetTtl(),
+	}
+}
+
+func keyValueToProto(kv *keyValue) *rafttopopb.KeyValue {
+	return &rafttopopb.KeyValue{
+		Key:            kv.Key,
+		Value:          kv.Value,
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Lease:          kv.Lease,
+	}
+}
+
+// grpcService exposes a node through gRPC, over the mutual TLS
+// connections accepted by the node. Requests from clients that need
+// the leader are rejected with NOT_LEADER on followers, and the client
+// retries them on the leader. The Raft calls between members are
+// implemented in transport.go.
+type grpcService struct {
+	rafttopopb.UnimplementedRaftTopoServer
+
+	n *Node
+}
+
+// Apply is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) Apply(ctx context.Context, req *rafttopopb.ApplyRequest) (*rafttopopb.ApplyResponse, error) {
+	resp := &rafttopopb.ApplyResponse{Header: &rafttopopb.ResponseHeader{}}
+	if !gs.n.checkLeader(resp.Header) {
+		return resp, nil
+	}
+	res, err := gs.n.apply(commandFromProto(req.GetCommand()))
+	if errors.Is(err, raft.ErrNotLeader) {
+		// We lost the leadership before the command was sent.
+		resp.Header.Status = rafttopopb.ResponseHeader_NOT_LEADER
+		return resp, nil
+	}
+	if err != nil {
+		setHeaderErr(resp.Header, err)
+		return resp, nil
+	}
+	resp.Header.Revision = res.Revision
+	resp.Lease = res.Lease
+	if res.Err != nil {
+		setHeaderErr(resp.Header, res.Err)
+	}
+	return resp, nil
+}
+
+// Range is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) Range(ctx context.Context, req *rafttopopb.RangeRequest) (*rafttopopb.RangeResponse, error) {
+	resp := &rafttopopb.RangeResponse{Header: &rafttopopb.ResponseHeader{}}
+	if !gs.n.checkLeader(resp.Header) {
+		return resp, nil
+	}
+	if err := gs.n.raft.VerifyLeader().Error(); err != nil {
+		resp.Header.Status = rafttopopb.ResponseHeader_NOT_LEADER
+		return resp, nil
+	}
+	var kvs []*keyValue
+	if req.Prefix {
+		kvs, resp.Header.Revision = gs.n.store.rangePrefix(req.Key)
+	} else {
+		var kv *keyValue
+		kv, resp.Header.Revision = gs.n.store.get(req.Key)
+		if kv != nil {
+			kvs = []*keyValue{kv}
+		}
+	}
+	for _, kv := range kvs {
+		resp.Kvs = append(resp.Kvs, keyValueToProto(kv))
+	}
+	return resp, nil
+}
+
+// Watch is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) Watch(ctx context.Context, req *rafttopopb.WatchRequest) (*rafttopopb.WatchResponse, error) {
+	resp := &rafttopopb.WatchResponse{Header: &rafttopopb.ResponseHeader{}}
+	if !gs.n.checkLeader(resp.Header) {
+		return resp, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-gs.n.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	events, revision, err := gs.n.store.waitEvents(ctx, req.Key, req.Prefix, req.After, watchPollTimeout)
+	if err != nil {
+		setHeaderErr(resp.Header, err)
+		return resp, nil
+	}
+	resp.Header.Revision = revision
+	for _, ev := range events {
+		resp.Events = append(resp.Events, &rafttopopb.Event{
+			Key:      ev.Key,
+			Value:    ev.Value,
+			Revision: ev.Revision,
+			Deleted:  ev.Deleted,
+		})
+	}
+	return resp, nil
+}
+
+// KeepAlive is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) KeepAlive(ctx context.Context, req *rafttopopb.KeepAliveRequest) (*rafttopopb.KeepAliveResponse, error) {
+	resp := &rafttopopb.KeepAliveResponse{Header: &rafttopopb.ResponseHeader{}}
+	if !gs.n.checkLeader(resp.Header) {
+		return resp, nil
+	}
+	if err := gs.n.keepAlive(req.Lease); err != nil {
+		setHeaderErr(resp.Header, err)
+	}
+	return resp, nil
+}
+
+// client sends requests to the nodes of a cluster, following the
+// leader as it changes.
+type client struct {
+	addrs      []string
+	dialOption grpc.DialOption
+
+	mu sync.Mutex
+	// next is the index in addrs of the node to try next, when we
+	// don't know which node is the leader.
+	next int
+	// leader is the address we are currently talking to, empty if
+	// we have to pick the next one.
+	leader string
+	// conns has the connection to each node we talked to.
+	conns  map[string]*grpc.ClientConn
+	closed bool
+}
+
+func newClient(addrs []string, dialOption grpc.DialOption) *client {
+	return &client{
+		addrs:      addrs,
+		dialOption: dialOption,
+		conns:      make(map[string]*grpc.ClientConn),
+	}
+}
+
+// errClientClosed is returned by calls on a closed client.
+var errClientClosed = errors.New("raft topo client closed")
+
+// getConn returns the address of the node to talk to, and the
+// connection to it.
+func (c *client) getConn(ctx context.Context) (string, *grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return "", nil, errClientClosed
+	}
+	if c.leader == "" {
+		c.leader = c.addrs[c.next%len(c.addrs)]
+		c.next++
+	}
+	addr := c.leader
+	if conn, ok := c.conns[addr]; ok {
+		return addr, conn, nil
+	}
+	// Fail fast, so we move on to the next node when this one is down.
+	conn, err := grpcclient.DialContext(ctx, addr, grpcclient.FailFast(true), c.dialOption)
+	if err != nil {
+		c.leader = ""
+		return "", nil, err
+	}
+	c.conns[addr] = conn
+	return addr, conn, nil
+}
+
+// redirect remembers the leader to try next, after addr didn't answer
+// or isn't the leader.
+func (c *client) redirect(addr, leader string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.leader != addr {
+		// Someone else already moved on.
+		return
+	}
+	c.leader = leader
+}
+
+// close closes the client. Calls in flight return errClientClosed.
+func (c *client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for addr, conn := range c.conns {
+		conn.Close()
+		delete(c.conns, addr)
+	}
+}
+
+// call sends a request to the leader with send, retrying on other nodes
+// until it gets an answer or ctx is done. send returns the header of
+// the response. Topo errors in the response are returned for nodePath.
+func (c *client) call(ctx context.Context, nodePath string, send func(rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error)) error {
+	for {
+		addr, conn, err := c.getConn(ctx)
+		if err == nil {
+			var header *rafttopopb.ResponseHeader
+			header, err = send(rafttopopb.NewRaftTopoClient(conn))
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err == nil {
+				if header.GetStatus() != rafttopopb.ResponseHeader_NOT_LEADER {
+					return headerErr(header, nodePath)
+				}
+				c.redirect(addr, header.GetLeader())
+			} else {
+				c.redirect(addr, "")
+			}
+		}
+		if errors.Is(err, errClientClosed) {
+			return context.Canceled
+		}
+
+		// Back off a bit before trying the next node, to leave time
+		// for an election to complete.
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package rafttopo implements topo.Server with an embedded Raft group as
+the backend, so small deployments don't need to run an external topo
+service.
+
+The server address of a cell using this implementation is the
+comma-separated list of the host:port of the members of the group. A
+process becomes a member of the group when --topo-raft-listen-addr is
+one of these addresses: it then runs a Node, which replicates the topo
+tree through the Raft log and persists it in --topo-raft-data-dir. All
+other processes are clients of the group.
+
+We follow these conventions within this package:
+  - The tree is stored as a flat key space, where every mutation
+    increments a global revision. A file version is the revision of
+    its last modification, like in etcd2topo.
+  - Directories are implicit, and exist as long as they contain files.
+  - Locks and elections use ephemeral files attached to leases, which
+    the leader revokes when they are not kept alive.
+  - Clients send all their requests to the leader, and follow
+    redirections when the leader changes.
+  - Members expose the RaftTopo gRPC service, which serves both the
+    requests of the clients and the Raft traffic between members.
+  - All connections use mutual TLS, with the certificates set by the
+    --topo-raft-tls-* flags. Nodes only accept connections from
+    processes that present a certificate signed by the same CA.
+  - Call convertError(err) on any errors returned from the client.
+    Functions defined in this package can be assumed to have already
+    converted errors as necessary.
+*/
+package rafttopo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/utils"
+)
+
+var (
+	listenAddr string
+	dataDir    string
+	leaseTTL   = 30
+
+	certPath string
+	keyPath  string
+	caPath   string
+
+	// localNode is the node this process runs, if it is a member of
+	// a group.
+	localNodeMu sync.Mutex
+	localNode   *Node
+)
+
+// Factory is the raft topo.Factory implementation.
+type Factory struct{}
+
+// HasGlobalReadOnlyCell is part of the topo.Factory interface.
+func (f Factory) HasGlobalReadOnlyCell(serverAddr, root string) bool {
+	return false
+}
+
+// Create is part of the topo.Factory interface.
+func (f Factory) Create(cell, serverAddr, root string) (topo.Conn, error) {
+	peers := parsePeers(serverAddr)
+	if err := startLocalNode(peers); err != nil {
+		return nil, err
+	}
+	return NewServer(serverAddr, root)
+}
+
+// Server is the implementation of topo.Server for an embedded Raft group.
+type Server struct {
+	// cli sends the requests to the group.
+	cli *client
+
+	// root is the root path for this client.
+	root string
+
+	running chan struct{}
+}
+
+func init() {
+	for _, cmd := range topo.FlagBinaries {
+		servenv.OnParseFor(cmd, registerRaftTopoFlags)
+	}
+	topo.RegisterFactory("raft", Factory{})
+}
+
+func registerRaftTopoFlags(fs *pflag.FlagSet) {
+	utils.SetFlagStringVar(fs, &listenAddr, "topo-raft-listen-addr", listenAddr, "host:port this process listens on as a member of the raft topo group. It must be one of the addresses of the raft topo server address. Leave empty to only be a client of the group.")
+	utils.SetFlagStringVar(fs, &dataDir, "topo-raft-data-dir", dataDir, "directory where a member of the raft topo group persists its log and snapshots")
+	utils.SetFlagIntVar(fs, &leaseTTL, "topo-raft-lease-ttl", leaseTTL, "Lease TTL in seconds for locks and leader election in the raft topo. The client keeps the lease alive while it holds the lock.")
+	utils.SetFlagStringVar(fs, &certPath, "topo-raft-tls-cert", certPath, "path to the cert presented to the members of the raft topo group, by members and clients of the group. Required, the raft topo only uses mutual TLS.")
+	utils.SetFlagStringVar(fs, &keyPath, "topo-raft-tls-key", keyPath, "path to the key of the cert presented to the members of the raft topo group")
+	utils.SetFlagStringVar(fs, &caPath, "topo-raft-tls-ca", caPath, "path to the ca that signs the certs of the members and clients of the raft topo group. Members only accept connections with a cert signed by this ca.")
+}
+
+// parsePeers returns the addresses in a server address.
+func parsePeers(serverAddr string) []string {
+	var peers []string
+	for _, addr := range strings.Split(serverAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			peers = append(peers, addr)
+		}
+	}
+	return peers
+}
+
+// startLocalNode starts the node for this process, if it is one of
+// the peers and it is not running yet.
+func startLocalNode(peers []string) error {
+	if listenAddr == "" || !slices.Contains(peers, listenAddr) {
+		return nil
+	}
+
+	localNodeMu.Lock()
+	defer localNodeMu.Unlock()
+	if localNode != nil {
+		if !slices.Equal(sortedCopy(localNode.config.Peers), sortedCopy(peers)) {
+			return fmt.Errorf("raft topo node %v is already a member of %v, cannot also join %v", listenAddr, localNode.config.Peers, peers)
+		}
+		return nil
+	}
+	n, err := NewNode(NodeConfig{
+		Address: listenAddr,
+		Peers:   peers,
+		DataDir: dataDir,
+		TLS:     flagTLSConfig(),
+	})
+	if err != nil {
+		return err
+	}
+	localNode = n
+	servenv.OnClose(func() {
+		localNodeMu.Lock()
+		defer localNodeMu.Unlock()
+		n.Close()
+		localNode = nil
+	})
+	return nil
+}
+
+func sortedCopy(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+// NewServerWithOpts creates a new server with the provided TLS options.
+func NewServerWithOpts(serverAddr, root string, tlsConfig TLSConfig) (*Server, error) {
+	peers := parsePeers(serverAddr)
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("invalid raft topo server address %q", serverAddr)
+	}
+	dialOption, err := tlsConfig.dialOption()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		cli:     newClient(peers, dialOption),
+		root:    root,
+		running: make(chan struct{}),
+	}, nil
+}
+
+// NewServer returns a new rafttopo.Server, using the process-wide TLS
+// settings.
+func NewServer(serverAddr, root string) (*Server, error) {
+	return NewServerWithOpts(serverAddr, root, flagTLSConfig())
+}
+
+// Close implements topo.Server.Close.
+func (s *Server) Close() {
+	close(s.running)
+	s.cli.close()
+}
+
+// apply sends a command to the leader.
+func (s *Server) apply(ctx context.Context, nodePath string, cmd *command) (*rafttopopb.ApplyResponse, error) {
+	var resp *rafttopopb.ApplyResponse
+	err := s.cli.call(ctx, nodePath, func(c rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error) {
+		var err error
+		resp, err = c.Apply(ctx, &rafttopopb.ApplyRequest{Command: commandToProto(cmd)})
+		return resp.GetHeader(), err
+	})
+	if err != nil {
+		return nil, convertError(err, nodePath)
+	}
+	return resp, nil
+}
+
+// rangeKeys reads one key, or all keys with a prefix, from the leader.
+func (s *Server) rangeKeys(ctx context.Context, key string, prefix bool) (*rafttopopb.RangeResponse, error) {
+	var resp *rafttopopb.RangeResponse
+	err := s.cli.call(ctx, key, func(c rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error) {
+		var err error
+		resp, err = c.Range(ctx, &rafttopopb.RangeRequest{Key: key, Prefix: prefix})
+		return resp.GetHeader(), err
+	})
+	if err != nil {
+		return nil, convertError(err, key)
+	}
+	return resp, nil
+}
+
+// watchKeys waits for the changes to one key, or all keys with a
+// prefix, after the provided revision. It returns errCompacted if
+// the revision is too old.
+func (s *Server) watchKeys(ctx context.Context, key string, prefix bool, after int64) (*rafttopopb.WatchResponse, error) {
+	var resp *rafttopopb.WatchResponse
+	err := s.cli.call(ctx, key, func(c rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error) {
+		var err error
+		resp, err = c.Watch(ctx, &rafttopopb.WatchRequest{Key: key, Prefix: prefix, After: after})
+		return resp.GetHeader(), err
+	})
+	if err != nil {
+		if errors.Is(err, errCompacted) {
+			return nil, err
+		}
+		return nil, convertError(err, key)
+	}
+	return resp, nil
+}
+
+// keepAlive refreshes a lease on the leader.
+func (s *Server) keepAlive(ctx context.Context, id int64) error {
+	nodePath := fmt.Sprintf("lease %v", id)
+	err := s.cli.call(ctx, nodePath, func(c rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error) {
+		resp, err := c.KeepAlive(ctx, &rafttopopb.KeepAliveRequest{Lease: id})
+		return resp.GetHeader(), err
+	})
+	return convertError(err, nodePath)
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/test"
+)
+
+// createTLSConfig creates a CA and a cert signed by it, and returns
+// the TLS config using them.
+func createTLSConfig(t *testing.T, name string) TLSConfig {
+	root := t.TempDir()
+	tlstest.CreateCA(root)
+	tlstest.CreateSignedCert(root, tlstest.CA, "01", name, name+".example.com")
+	return TLSConfig{
+		CertPath: filepath.Join(root, name+"-cert.pem"),
+		KeyPath:  filepath.Join(root, name+"-key.pem"),
+		CAPath:   filepath.Join(root, "ca-cert.pem"),
+	}
+}
+
+// setFlagTLSConfig sets the process-wide TLS settings for the duration
+// of the test.
+func setFlagTLSConfig(t *testing.T, config TLSConfig) {
+	oldCert, oldKey, oldCA := certPath, keyPath, caPath
+	certPath, keyPath, caPath = config.CertPath, config.KeyPath, config.CAPath
+	t.Cleanup(func() {
+		certPath, keyPath, caPath = oldCert, oldKey, oldCA
+	})
+}
+
+// startCluster starts a group of n nodes on local ports, and returns
+// them with the server address of the group. The nodes, and the
+// clients created from the flags, use a cert signed by a new CA.
+func startCluster(t *testing.T, n int) ([]*Node, string) {
+	tlsConfig := createTLSConfig(t, "node")
+	setFlagTLSConfig(t, tlsConfig)
+
+	var listeners []net.Listener
+	var peers []string
+	for i := 0; i < n; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners = append(listeners, listener)
+		peers = append(peers, listener.Addr().String())
+	}
+
+	dir := t.TempDir()
+	var nodes []*Node
+	for i, listener := range listeners {
+		node, err := newNode(NodeConfig{
+			Address: peers[i],
+			Peers:   peers,
+			DataDir: filepath.Join(dir, fmt.Sprintf("node%v", i)),
+			TLS:     tlsConfig,
+		}, listener)
+		require.NoError(t, err)
+		nodes = append(nodes, node)
+		t.Cleanup(func() {
+			node.Close()
+		})
+	}
+	return nodes, strings.Join(peers, ",")
+}
+
+// waitForLeader waits until one of the nodes is ready to serve
+// requests, and returns it.
+func waitForLeader(t *testing.T, nodes []*Node) *Node {
+	start := time.Now()
+	for time.Since(start) < 30*time.Second {
+		for _, node := range nodes {
+			if node.ready.Load() {
+				return node
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no leader elected in time")
+	return nil
+}
+
+func TestRaftTopo(t *testing.T) {
+	nodes, serverAddr := startCluster(t, 3)
+	waitForLeader(t, nodes)
+
+	testIndex := 0
+	newServer := func() *topo.Server {
+		// Each test will use its own sub-directories.
+		testRoot := fmt.Sprintf("/test-%v", testIndex)
+		testIndex++
+
+		// Create the server on the new root.
+		ts, err := topo.OpenServer("raft", serverAddr, path.Join(testRoot, topo.GlobalCell))
+		require.NoError(t, err)
+
+		// Create the CellInfo.
+		err = ts.CreateCellInfo(context.Background(), test.LocalCellName, &topodatapb.CellInfo{
+			ServerAddress: serverAddr,
+			Root:          path.Join(testRoot, test.LocalCellName),
+		})
+		require.NoError(t, err)
+
+		return ts
+	}
+
+	// Run the TopoServerTestSuite tests.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	test.TopoServerTestSuite(t, ctx, func() *topo.Server {
+		return newServer()
+	}, []string{})
+}
+
+// TestRaftTopoLeaderFailover checks the group keeps serving requests,
+// and expires the locks held through the old leader, when the leader
+// goes away.
+func TestRaftTopoLeaderFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	nodes, serverAddr := startCluster(t, 3)
+	leader := waitForLeader(t, nodes)
+
+	conn, err := NewServer(serverAddr, "/failover")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	version, err := conn.Create(ctx, "keyspaces/ks/Keyspace", []byte("v1"))
+	require.NoError(t, err)
+
+	// Hold a lock with a short TTL, and stop keeping it alive.
+	ld, err := conn.LockWithTTL(ctx, "keyspaces/ks", "lock", 2*time.Second)
+	require.NoError(t, err)
+	ld.(*raftLockDescriptor).stop()
+
+	// Stop the leader, another node takes over.
+	require.NoError(t, leader.Close())
+	var others []*Node
+	for _, node := range nodes {
+		if node != leader {
+			others = append(others, node)
+		}
+	}
+	newLeader := waitForLeader(t, others)
+	assert.NotEqual(t, leader.config.Address, newLeader.config.Address)
+
+	// Reads and conditional writes still work, through the new leader.
+	contents, got, err := conn.Get(ctx, "keyspaces/ks/Keyspace")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(contents))
+	assert.Equal(t, version, got)
+	_, err = conn.Update(ctx, "keyspaces/ks/Keyspace", []byte("v2"), version)
+	require.NoError(t, err)
+
+	// The lease is not kept alive anymore, so the lock goes away and
+	// someone else can take it.
+	lockCtx, lockCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer lockCancel()
+	ld2, err := conn.Lock(lockCtx, "keyspaces/ks", "lock2")
+	require.NoError(t, err)
+	assert.Error(t, ld.Check(ctx))
+	require.NoError(t, ld2.Unlock(ctx))
+}
+
+// TestRaftTopoRestart checks the tree is persisted in the data
+// directory, and survives a restart.
+func TestRaftTopoRestart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	dir := t.TempDir()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	tlsConfig := createTLSConfig(t, "node")
+	setFlagTLSConfig(t, tlsConfig)
+	config := NodeConfig{
+		Address: addr,
+		Peers:   []string{addr},
+		DataDir: dir,
+		TLS:     tlsConfig,
+	}
+	node, err := newNode(config, listener)
+	require.NoError(t, err)
+	waitForLeader(t, []*Node{node})
+
+	conn, err := NewServer(addr, "/restart")
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Create(ctx, "cells/test/CellInfo", []byte("cell"))
+	require.NoError(t, err)
+
+	// Take a snapshot before the last update, so the restart goes
+	// through both the snapshot and the log.
+	require.NoError(t, node.raft.Snapshot().Error())
+	version, err := conn.Update(ctx, "cells/test/CellInfo", []byte("cell2"), nil)
+	require.NoError(t, err)
+	require.NoError(t, node.Close())
+
+	node, err = NewNode(config)
+	require.NoError(t, err)
+	defer node.Close()
+	waitForLeader(t, []*Node{node})
+	assert.Equal(t, raft.Leader, node.raft.State())
+
+	contents, got, err := conn.Get(ctx, "cells/test/CellInfo")
+	require.NoError(t, err)
+	assert.Equal(t, "cell2", string(contents))
+	assert.Equal(t, version, got)
+}
+
+// TestRaftTopoMutualTLS checks nodes only accept connections from
+// clients that present a cert signed by their CA.
+func TestRaftTopoMutualTLS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	nodes, serverAddr := startCluster(t, 1)
+	waitForLeader(t, nodes)
+
+	_, err := NewServerWithOpts(serverAddr, "/tls", TLSConfig{})
+	assert.ErrorContains(t, err, "the raft topo requires mutual TLS")
+
+	// A plaintext client cannot talk to the node.
+	plaintext := newClient(parsePeers(serverAddr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer plaintext.close()
+	plaintextCtx, plaintextCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer plaintextCancel()
+	err = plaintext.call(plaintextCtx, "/tls", func(c rafttopopb.RaftTopoClient) (*rafttopopb.ResponseHeader, error) {
+		resp, err := c.Range(plaintextCtx, &rafttopopb.RangeRequest{Key: "/tls/", Prefix: true})
+		return resp.GetHeader(), err
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Neither can a client with a cert signed by another CA.
+	other, err := NewServerWithOpts(serverAddr, "/tls", createTLSConfig(t, "other"))
+	require.NoError(t, err)
+	defer other.Close()
+	otherCtx, otherCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer otherCancel()
+	_, err = other.Create(otherCtx, "cells/test/CellInfo", []byte("cell"))
+	assert.Error(t, err)
+
+	good, err := NewServer(serverAddr, "/tls")
+	require.NoError(t, err)
+	defer good.Close()
+	_, err = good.Create(ctx, "cells/test/CellInfo", []byte("cell"))
+	require.NoError(t, err)
+}
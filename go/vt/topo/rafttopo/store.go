@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// Operations that can be applied to the store through the Raft log.
+const (
+	opCreate = "create"
+	opUpdate = "update"
+	opDelete = "delete"
+	opGrant  = "grant"
+	opRevoke = "revoke"
+)
+
+// eventHistorySize is the number of events the store keeps around for
+// watchers. Watchers that fall further behind have to re-read the
+// current state.
+const eventHistorySize = 10000
+
+// command is a single mutation of the store, serialized into the Raft log.
+type command struct {
+	Op  string `json:"op"`
+	Key string `json:"key,omitempty"`
+	// Value is the new value for create and update.
+	Value []byte `json:"value,omitempty"`
+	// Version is the expected modification revision for update and
+	// delete. Zero means unconditional.
+	Version int64 `json:"version,omitempty"`
+	// Lease is the lease the key is attached to for create, or the
+	// lease to revoke.
+	Lease int64 `json:"lease,omitempty"`
+	// TTL is the lease time-to-live in seconds, for grant.
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// applyResult is the result of applying a command to the store.
+type applyResult struct {
+	Revision int64
+	Lease    int64
+	Err      error
+}
+
+// keyValue is a single entry in the store.
+type keyValue struct {
+	Key            string `json:"key"`
+	Value          []byte `json:"value"`
+	CreateRevision int64  `json:"create_revision"`
+	ModRevision    int64  `json:"mod_revision"`
+	// Lease is non-zero for ephemeral entries, which are deleted when
+	// their lease expires or is revoked.
+	Lease int64 `json:"lease,omitempty"`
+}
+
+// lease groups ephemeral keys that share a time-to-live.
+type lease struct {
+	ID   int64           `json:"id"`
+	TTL  int64           `json:"ttl"`
+	Keys map[string]bool `json:"keys"`
+}
+
+// event is a change to a key, as seen by watchers. Value is nil
+// for deletions.
+type event struct {
+	Key      string
+	Value    []byte
+	Revision int64
+	Deleted  bool
+}
+
+// storeState is the replicated part of the store, and the format of
+// its snapshots.
+type storeState struct {
+	Revision  int64                `json:"revision"`
+	NextLease int64                `json:"next_lease"`
+	KVs       map[string]*keyValue `json:"kvs"`
+	Leases    map[int64]*lease     `json:"leases"`
+}
+
+// store is the Raft finite state machine holding the topo tree.
+// It is a flat key space where each mutation increments a global
+// revision, like etcd. It also keeps a bounded history of events so
+// watchers can catch up on what they missed.
+type store struct {
+	mu    sync.Mutex
+	state storeState
+
+	// events is the recent history, ordered by revision.
+	events []event
+	// compactedRevision is the highest revision no longer in events.
+	compactedRevision int64
+	// changed is closed and replaced every time the store changes.
+	changed chan struct{}
+}
+
+func newStore() *store {
+	return &store{
+		state: storeState{
+			KVs:    make(map[string]*keyValue),
+			Leases: make(map[int64]*lease),
+		},
+		changed: make(chan struct{}),
+	}
+}
+
+// Apply is part of the raft.FSM interface.
+func (s *store) Apply(l *raft.Log) any {
+	var cmd command
+	if err := json.Unmarshal(l.Data, &cmd); err != nil {
+		return &applyResult{Err: fmt.Errorf("cannot decode command at index %v: %v", l.Index, err)}
+	}
+	return s.apply(&cmd)
+}
+
+func (s *store) apply(cmd *command) *applyResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []event
+	var err error
+	switch cmd.Op {
+	case opCreate:
+		events, err = s.create(cmd)
+	case opUpdate:
+		events, err = s.update(cmd)
+	case opDelete:
+		events, err = s.deleteKey(cmd)
+	case opGrant:
+		s.state.NextLease++
+		id := s.state.NextLease
+		s.state.Leases[id] = &lease{ID: id, TTL: cmd.TTL, Keys: make(map[string]bool)}
+		return &applyResult{Revision: s.state.Revision, Lease: id}
+	case opRevoke:
+		events, err = s.revoke(cmd)
+	default:
+		err = fmt.Errorf("unknown operation %q", cmd.Op)
+	}
+	if err != nil {
+		return &applyResult{Revision: s.state.Revision, Err: err}
+	}
+	s.recordLocked(events)
+	return &applyResult{Revision: s.state.Revision, Lease: cmd.Lease}
+}
+
+func (s *store) create(cmd *command) ([]event, error) {
+	if _, ok := s.state.KVs[cmd.Key]; ok {
+		return nil, topo.NewError(topo.NodeExists, cmd.Key)
+	}
+	var l *lease
+	if cmd.Lease != 0 {
+		var ok bool
+		if l, ok = s.state.Leases[cmd.Lease]; !ok {
+			return nil, topo.NewError(topo.NoNode, fmt.Sprintf("lease %v", cmd.Lease))
+		}
+	}
+	s.state.Revision++
+	s.state.KVs[cmd.Key] = &keyValue{
+		Key:            cmd.Key,
+		Value:          cmd.Value,
+		CreateRevision: s.state.Revision,
+		ModRevision:    s.state.Revision,
+		Lease:          cmd.Lease,
+	}
+	if l != nil {
+		l.Keys[cmd.Key] = true
+	}
+	return []event{{Key: cmd.Key, Value: cmd.Value, Revision: s.state.Revision}}, nil
+}
+
+func (s *store) update(cmd *command) ([]event, error) {
+	kv, ok := s.state.KVs[cmd.Key]
+	if cmd.Version != 0 && (!ok || kv.ModRevision != cmd.Version) {
+		return nil, topo.NewError(topo.BadVersion, cmd.Key)
+	}
+	s.state.Revision++
+	if !ok {
+		kv = &keyValue{Key: cmd.Key, CreateRevision: s.state.Revision}
+		s.state.KVs[cmd.Key] = kv
+	}
+	kv.Value = cmd.Value
+	kv.ModRevision = s.state.Revision
+	return []event{{Key: cmd.Key, Value: cmd.Value, Revision: s.state.Revision}}, nil
+}
+
+func (s *store) deleteKey(cmd *command) ([]event, error) {
+	kv, ok := s.state.KVs[cmd.Key]
+	if !ok {
+		return nil, topo.NewError(topo.NoNode, cmd.Key)
+	}
+	if cmd.Version != 0 && kv.ModRevision != cmd.Version {
+		return nil, topo.NewError(topo.BadVersion, cmd.Key)
+	}
+	s.state.Revision++
+	s.removeLocked(kv)
+	return []event{{Key: cmd.Key, Revision: s.state.Revision, Deleted: true}}, nil
+}
+
+func (s *store) revoke(cmd *command) ([]event, error) {
+	l, ok := s.state.Leases[cmd.Lease]
+	if !ok {
+		return nil, topo.NewError(topo.NoNode, fmt.Sprintf("lease %v", cmd.Lease))
+	}
+	delete(s.state.Leases, cmd.Lease)
+	if len(l.Keys) == 0 {
+		return nil, nil
+	}
+	s.state.Revision++
+	keys := make([]string, 0, len(l.Keys))
+	for key := range l.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	events := make([]event, 0, len(keys))
+	for _, key := range keys {
+		if kv, ok := s.state.KVs[key]; ok {
+			s.removeLocked(kv)
+			events = append(events, event{Key: key, Revision: s.state.Revision, Deleted: true})
+		}
+	}
+	return events, nil
+}
+
+// removeLocked deletes a key, and detaches it from its lease.
+func (s *store) removeLocked(kv *keyValue) {
+	delete(s.state.KVs, kv.Key)
+	if kv.Lease != 0 {
+		if l, ok := s.state.Leases[kv.Lease]; ok {
+			delete(l.Keys, kv.Key)
+		}
+	}
+}
+
+// recordLocked appends events to the history and wakes up watchers.
+func (s *store) recordLocked(events []event) {
+	if len(events) == 0 {
+		return
+	}
+	s.events = append(s.events, events...)
+	if extra := len(s.events) - eventHistorySize; extra > 0 {
+		s.compactedRevision = s.events[extra-1].Revision
+		s.events = append([]event(nil), s.events[extra:]...)
+	}
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// get returns a copy of a single entry, or nil, and the current revision.
+func (s *store) get(key string) (*keyValue, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kv, ok := s.state.KVs[key]
+	if !ok {
+		return nil, s.state.Revision
+	}
+	c := *kv
+	return &c, s.state.Revision
+}
+
+// rangePrefix returns copies of all entries whose key starts with
+// prefix, sorted by key, and the current revision.
+func (s *store) rangePrefix(prefix string) ([]*keyValue, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []*keyValue
+	for key, kv := range s.state.KVs {
+		if strings.HasPrefix(key, prefix) {
+			c := *kv
+			result = append(result, &c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, s.state.Revision
+}
+
+// hasLease returns true if the lease exists.
+func (s *store) hasLease(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.state.Leases[id]
+	return ok
+}
+
+// leaseTTLs returns the time-to-live of all leases.
+func (s *store) leaseTTLs() map[int64]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make(map[int64]time.Duration, len(s.state.Leases))
+	for id, l := range s.state.Leases {
+		result[id] = time.Duration(l.TTL) * time.Second
+	}
+	return result
+}
+
+// errCompacted is returned by waitEvents when the requested revision
+// is no longer in the history.
+var errCompacted = fmt.Errorf("required revision has been compacted")
+
+// waitEvents returns the events after the provided revision for either
+// a single key, or all keys with the given prefix. It blocks until
+// there is at least one such event, or the timeout expires, and also
+// returns the revision the caller should continue watching from.
+func (s *store) waitEvents(ctx context.Context, key string, prefix bool, after int64, timeout time.Duration) ([]event, int64, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		if after < s.compactedRevision {
+			rev := s.state.Revision
+			s.mu.Unlock()
+			return nil, rev, errCompacted
+		}
+		var result []event
+		// Events are ordered by revision, find the first one we need.
+		i := sort.Search(len(s.events), func(i int) bool {
+			return s.events[i].Revision > after
+		})
+		for _, ev := range s.events[i:] {
+			if ev.Key == key || (prefix && strings.HasPrefix(ev.Key, key)) {
+				result = append(result, ev)
+			}
+		}
+		rev := s.state.Revision
+		changed := s.changed
+		s.mu.Unlock()
+
+		if len(result) > 0 {
+			return result, rev, nil
+		}
+		if rev > after {
+			after = rev
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil, after, nil
+		case <-ctx.Done():
+			return nil, after, ctx.Err()
+		}
+	}
+}
+
+// Snapshot is part of the raft.FSM interface.
+func (s *store) Snapshot() (raft.FSMSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(&s.state)
+	if err != nil {
+		return nil, err
+	}
+	return &storeSnapshot{data: data}, nil
+}
+
+// Restore is part of the raft.FSM interface.
+func (s *store) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	state := storeState{}
+	if err := json.NewDecoder(rc).Decode(&state); err != nil {
+		return err
+	}
+	if state.KVs == nil {
+		state.KVs = make(map[string]*keyValue)
+	}
+	if state.Leases == nil {
+		state.Leases = make(map[int64]*lease)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	// We don't know what changed, so all watchers have to re-read
+	// the current state.
+	s.events = nil
+	s.compactedRevision = state.Revision
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return nil
+}
+
+// storeSnapshot implements raft.FSMSnapshot.
+type storeSnapshot struct {
+	data []byte
+}
+
+// Persist is part of the raft.FSMSnapshot interface.
+func (ss *storeSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(ss.data); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+// Release is part of the raft.FSMSnapshot interface.
+func (ss *storeSnapshot) Release() {}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+// TLSConfig holds the paths to the certificates that secure the
+// connections of a group. All connections, between nodes and from
+// clients, use mutual TLS: both ends present a certificate signed by
+// the CA, and the certificate of a node must be valid for its address.
+type TLSConfig struct {
+	CertPath string
+	KeyPath  string
+	CAPath   string
+}
+
+// flagTLSConfig returns the TLS config set on the command line.
+func flagTLSConfig() TLSConfig {
+	return TLSConfig{
+		CertPath: certPath,
+		KeyPath:  keyPath,
+		CAPath:   caPath,
+	}
+}
+
+func (c TLSConfig) validate() error {
+	if c.CertPath == "" || c.KeyPath == "" || c.CAPath == "" {
+		return fmt.Errorf("the raft topo requires mutual TLS, --topo-raft-tls-cert, --topo-raft-tls-key and --topo-raft-tls-ca must be set")
+	}
+	return nil
+}
+
+// serverOption returns the credentials of the node gRPC servers, which
+// only accept connections with a certificate signed by the CA.
+func (c TLSConfig) serverOption() (grpc.ServerOption, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	config, err := vttls.ServerConfig(c.CertPath, c.KeyPath, c.CAPath, "", "", tls.VersionTLS12)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return grpc.Creds(credentials.NewTLS(config)), nil
+}
+
+// dialOption returns the option used to connect to nodes, by clients
+// and by the other nodes.
+func (c TLSConfig) dialOption() (grpc.DialOption, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return grpcclient.SecureDialOption(c.CertPath, c.KeyPath, c.CAPath, "", "")
+}
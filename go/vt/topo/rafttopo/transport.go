@@ -0,0 +1,543 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+const (
+	// transportTimeout is the maximum time a Raft call between
+	// members can take.
+	transportTimeout = 10 * time.Second
+
+	// snapshotTimeoutScale is the amount of snapshot data we allow
+	// to be sent within transportTimeout, larger snapshots get more
+	// time.
+	snapshotTimeoutScale = 256 * 1024
+
+	// snapshotChunkSize is the size of the data in each message of
+	// an InstallSnapshot stream.
+	snapshotChunkSize = 256 * 1024
+)
+
+// transport implements raft.Transport with the RaftTopo gRPC service.
+// It sends the calls to the other members through their gRPC servers,
+// and gets the calls from them through grpcService.
+type transport struct {
+	localAddr  raft.ServerAddress
+	dialOption grpc.DialOption
+	consumer   chan raft.RPC
+	done       chan struct{}
+	closeOnce  sync.Once
+
+	mu sync.Mutex
+	// heartbeatFn is called directly for heartbeats, so they are not
+	// delayed by the processing of other calls.
+	heartbeatFn func(raft.RPC)
+	// conns has the connection to each member we talked to.
+	conns map[raft.ServerAddress]*grpc.ClientConn
+}
+
+var (
+	_ raft.Transport   = (*transport)(nil)
+	_ raft.WithPreVote = (*transport)(nil)
+	_ raft.WithClose   = (*transport)(nil)
+)
+
+func newTransport(localAddr string, dialOption grpc.DialOption) *transport {
+	return &transport{
+		localAddr:  raft.ServerAddress(localAddr),
+		dialOption: dialOption,
+		consumer:   make(chan raft.RPC),
+		done:       make(chan struct{}),
+		conns:      make(map[raft.ServerAddress]*grpc.ClientConn),
+	}
+}
+
+// Consumer is part of the raft.Transport interface.
+func (t *transport) Consumer() <-chan raft.RPC {
+	return t.consumer
+}
+
+// LocalAddr is part of the raft.Transport interface.
+func (t *transport) LocalAddr() raft.ServerAddress {
+	return t.localAddr
+}
+
+// AppendEntriesPipeline is part of the raft.Transport interface.
+// Entries are sent one call at a time.
+func (t *transport) AppendEntriesPipeline(id raft.ServerID, target raft.ServerAddress) (raft.AppendPipeline, error) {
+	return nil, raft.ErrPipelineReplicationNotSupported
+}
+
+// EncodePeer is part of the raft.Transport interface.
+func (t *transport) EncodePeer(id raft.ServerID, addr raft.ServerAddress) []byte {
+	return []byte(addr)
+}
+
+// DecodePeer is part of the raft.Transport interface.
+func (t *transport) DecodePeer(buf []byte) raft.ServerAddress {
+	return raft.ServerAddress(buf)
+}
+
+// SetHeartbeatHandler is part of the raft.Transport interface.
+func (t *transport) SetHeartbeatHandler(cb func(rpc raft.RPC)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.heartbeatFn = cb
+}
+
+// Close is part of the raft.WithClose interface. Raft calls it when it
+// shuts down, and so does the node.
+func (t *transport) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for addr, conn := range t.conns {
+			conn.Close()
+			delete(t.conns, addr)
+		}
+	})
+	return nil
+}
+
+// getClient returns the client of the member at target.
+func (t *transport) getClient(target raft.ServerAddress) (rafttopopb.RaftTopoClient, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	select {
+	case <-t.done:
+		return nil, raft.ErrTransportShutdown
+	default:
+	}
+	conn, ok := t.conns[target]
+	if !ok {
+		var err error
+		conn, err = grpcclient.DialContext(context.Background(), string(target), grpcclient.FailFast(true), t.dialOption)
+		if err != nil {
+			return nil, err
+		}
+		t.conns[target] = conn
+	}
+	return rafttopopb.NewRaftTopoClient(conn), nil
+}
+
+// AppendEntries is part of the raft.Transport interface.
+func (t *transport) AppendEntries(id raft.ServerID, target raft.ServerAddress, args *raft.AppendEntriesRequest, resp *raft.AppendEntriesResponse) error {
+	c, err := t.getClient(target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), transportTimeout)
+	defer cancel()
+	out, err := c.AppendEntries(ctx, appendEntriesRequestToProto(args))
+	if err != nil {
+		return err
+	}
+	*resp = raft.AppendEntriesResponse{
+		RPCHeader:      rpcHeaderFromProto(out.GetRpcHeader()),
+		Term:           out.GetTerm(),
+		LastLog:        out.GetLastLog(),
+		Success:        out.GetSuccess(),
+		NoRetryBackoff: out.GetNoRetryBackoff(),
+	}
+	return nil
+}
+
+// RequestVote is part of the raft.Transport interface.
+func (t *transport) RequestVote(id raft.ServerID, target raft.ServerAddress, args *raft.RequestVoteRequest, resp *raft.RequestVoteResponse) error {
+	c, err := t.getClient(target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), transportTimeout)
+	defer cancel()
+	out, err := c.RequestVote(ctx, &rafttopopb.RequestVoteRequest{
+		RpcHeader:          rpcHeaderToProto(args.RPCHeader),
+		Term:               args.Term,
+		Candidate:          args.Candidate,
+		LastLogIndex:       args.LastLogIndex,
+		LastLogTerm:        args.LastLogTerm,
+		LeadershipTransfer: args.LeadershipTransfer,
+	})
+	if err != nil {
+		return err
+	}
+	*resp = raft.RequestVoteResponse{
+		RPCHeader: rpcHeaderFromProto(out.GetRpcHeader()),
+		Term:      out.GetTerm(),
+		Peers:     out.GetPeers(),
+		Granted:   out.GetGranted(),
+	}
+	return nil
+}
+
+// RequestPreVote is part of the raft.WithPreVote interface.
+func (t *transport) RequestPreVote(id raft.ServerID, target raft.ServerAddress, args *raft.RequestPreVoteRequest, resp *raft.RequestPreVoteResponse) error {
+	c, err := t.getClient(target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), transportTimeout)
+	defer cancel()
+	out, err := c.RequestPreVote(ctx, &rafttopopb.RequestPreVoteRequest{
+		RpcHeader:    rpcHeaderToProto(args.RPCHeader),
+		Term:         args.Term,
+		LastLogIndex: args.LastLogIndex,
+		LastLogTerm:  args.LastLogTerm,
+	})
+	if err != nil {
+		return err
+	}
+	*resp = raft.RequestPreVoteResponse{
+		RPCHeader: rpcHeaderFromProto(out.GetRpcHeader()),
+		Term:      out.GetTerm(),
+		Granted:   out.GetGranted(),
+	}
+	return nil
+}
+
+// TimeoutNow is part of the raft.Transport interface.
+func (t *transport) TimeoutNow(id raft.ServerID, target raft.ServerAddress, args *raft.TimeoutNowRequest, resp *raft.TimeoutNowResponse) error {
+	c, err := t.getClient(target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), transportTimeout)
+	defer cancel()
+	out, err := c.TimeoutNow(ctx, &rafttopopb.TimeoutNowRequest{
+		RpcHeader: rpcHeaderToProto(args.RPCHeader),
+	})
+	if err != nil {
+		return err
+	}
+	*resp = raft.TimeoutNowResponse{
+		RPCHeader: rpcHeaderFromProto(out.GetRpcHeader()),
+	}
+	return nil
+}
+
+// InstallSnapshot is part of the raft.Transport interface. The snapshot
+// is streamed in chunks after its metadata.
+func (t *transport) InstallSnapshot(id raft.ServerID, target raft.ServerAddress, args *raft.InstallSnapshotRequest, resp *raft.InstallSnapshotResponse, data io.Reader) error {
+	c, err := t.getClient(target)
+	if err != nil {
+		return err
+	}
+	timeout := transportTimeout * time.Duration(args.Size/snapshotTimeoutScale)
+	if timeout < transportTimeout {
+		timeout = transportTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stream, err := c.InstallSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&rafttopopb.InstallSnapshotRequest{
+		RpcHeader:          rpcHeaderToProto(args.RPCHeader),
+		SnapshotVersion:    int64(args.SnapshotVersion),
+		Term:               args.Term,
+		Leader:             args.Leader,
+		LastLogIndex:       args.LastLogIndex,
+		LastLogTerm:        args.LastLogTerm,
+		Peers:              args.Peers,
+		Configuration:      args.Configuration,
+		ConfigurationIndex: args.ConfigurationIndex,
+		Size:               args.Size,
+	}); err != nil {
+		return err
+	}
+	buf := make([]byte, snapshotChunkSize)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&rafttopopb.InstallSnapshotRequest{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	out, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	*resp = raft.InstallSnapshotResponse{
+		RPCHeader: rpcHeaderFromProto(out.GetRpcHeader()),
+		Term:      out.GetTerm(),
+		Success:   out.GetSuccess(),
+	}
+	return nil
+}
+
+// dispatch hands a call received from another member to Raft, and
+// waits for its response.
+func (t *transport) dispatch(ctx context.Context, command any, data io.Reader, heartbeat bool) (any, error) {
+	respCh := make(chan raft.RPCResponse, 1)
+	rpc := raft.RPC{
+		Command:  command,
+		Reader:   data,
+		RespChan: respCh,
+	}
+
+	var heartbeatFn func(raft.RPC)
+	if heartbeat {
+		t.mu.Lock()
+		heartbeatFn = t.heartbeatFn
+		t.mu.Unlock()
+	}
+	if heartbeatFn != nil {
+		heartbeatFn(rpc)
+	} else {
+		select {
+		case t.consumer <- rpc:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.done:
+			return nil, raft.ErrTransportShutdown
+		}
+	}
+
+	select {
+	case resp := <-respCh:
+		return resp.Response, resp.Error
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.done:
+		return nil, raft.ErrTransportShutdown
+	}
+}
+
+// AppendEntries is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) AppendEntries(ctx context.Context, req *rafttopopb.AppendEntriesRequest) (*rafttopopb.AppendEntriesResponse, error) {
+	args := appendEntriesRequestFromProto(req)
+	leaderAddr := args.Addr
+	if len(leaderAddr) == 0 {
+		leaderAddr = args.Leader
+	}
+	heartbeat := args.Term != 0 && len(leaderAddr) != 0 &&
+		args.PrevLogEntry == 0 && args.PrevLogTerm == 0 &&
+		len(args.Entries) == 0 && args.LeaderCommitIndex == 0
+	res, err := gs.n.transport.dispatch(ctx, args, nil, heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	resp := res.(*raft.AppendEntriesResponse)
+	return &rafttopopb.AppendEntriesResponse{
+		RpcHeader:      rpcHeaderToProto(resp.RPCHeader),
+		Term:           resp.Term,
+		LastLog:        resp.LastLog,
+		Success:        resp.Success,
+		NoRetryBackoff: resp.NoRetryBackoff,
+	}, nil
+}
+
+// RequestVote is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) RequestVote(ctx context.Context, req *rafttopopb.RequestVoteRequest) (*rafttopopb.RequestVoteResponse, error) {
+	res, err := gs.n.transport.dispatch(ctx, &raft.RequestVoteRequest{
+		RPCHeader:          rpcHeaderFromProto(req.GetRpcHeader()),
+		Term:               req.GetTerm(),
+		Candidate:          req.GetCandidate(),
+		LastLogIndex:       req.GetLastLogIndex(),
+		LastLogTerm:        req.GetLastLogTerm(),
+		LeadershipTransfer: req.GetLeadershipTransfer(),
+	}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := res.(*raft.RequestVoteResponse)
+	return &rafttopopb.RequestVoteResponse{
+		RpcHeader: rpcHeaderToProto(resp.RPCHeader),
+		Term:      resp.Term,
+		Peers:     resp.Peers,
+		Granted:   resp.Granted,
+	}, nil
+}
+
+// RequestPreVote is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) RequestPreVote(ctx context.Context, req *rafttopopb.RequestPreVoteRequest) (*rafttopopb.RequestPreVoteResponse, error) {
+	res, err := gs.n.transport.dispatch(ctx, &raft.RequestPreVoteRequest{
+		RPCHeader:    rpcHeaderFromProto(req.GetRpcHeader()),
+		Term:         req.GetTerm(),
+		LastLogIndex: req.GetLastLogIndex(),
+		LastLogTerm:  req.GetLastLogTerm(),
+	}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := res.(*raft.RequestPreVoteResponse)
+	return &rafttopopb.RequestPreVoteResponse{
+		RpcHeader: rpcHeaderToProto(resp.RPCHeader),
+		Term:      resp.Term,
+		Granted:   resp.Granted,
+	}, nil
+}
+
+// TimeoutNow is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) TimeoutNow(ctx context.Context, req *rafttopopb.TimeoutNowRequest) (*rafttopopb.TimeoutNowResponse, error) {
+	res, err := gs.n.transport.dispatch(ctx, &raft.TimeoutNowRequest{
+		RPCHeader: rpcHeaderFromProto(req.GetRpcHeader()),
+	}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := res.(*raft.TimeoutNowResponse)
+	return &rafttopopb.TimeoutNowResponse{
+		RpcHeader: rpcHeaderToProto(resp.RPCHeader),
+	}, nil
+}
+
+// InstallSnapshot is part of the rafttopopb.RaftTopoServer interface.
+func (gs *grpcService) InstallSnapshot(stream rafttopopb.RaftTopo_InstallSnapshotServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	res, err := gs.n.transport.dispatch(stream.Context(), &raft.InstallSnapshotRequest{
+		RPCHeader:          rpcHeaderFromProto(req.GetRpcHeader()),
+		SnapshotVersion:    raft.SnapshotVersion(req.GetSnapshotVersion()),
+		Term:               req.GetTerm(),
+		Leader:             req.GetLeader(),
+		LastLogIndex:       req.GetLastLogIndex(),
+		LastLogTerm:        req.GetLastLogTerm(),
+		Peers:              req.GetPeers(),
+		Configuration:      req.GetConfiguration(),
+		ConfigurationIndex: req.GetConfigurationIndex(),
+		Size:               req.GetSize(),
+	}, &snapshotReader{stream: stream}, false)
+	if err != nil {
+		return err
+	}
+	resp := res.(*raft.InstallSnapshotResponse)
+	return stream.SendAndClose(&rafttopopb.InstallSnapshotResponse{
+		RpcHeader: rpcHeaderToProto(resp.RPCHeader),
+		Term:      resp.Term,
+		Success:   resp.Success,
+	})
+}
+
+// snapshotReader reads the snapshot data from an InstallSnapshot stream.
+type snapshotReader struct {
+	stream rafttopopb.RaftTopo_InstallSnapshotServer
+	buf    []byte
+}
+
+// Read is part of the io.Reader interface. It returns io.EOF when the
+// sender closes the stream.
+func (r *snapshotReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func rpcHeaderToProto(h raft.RPCHeader) *rafttopopb.RPCHeader {
+	return &rafttopopb.RPCHeader{
+		ProtocolVersion: int64(h.ProtocolVersion),
+		Id:              h.ID,
+		Addr:            h.Addr,
+	}
+}
+
+func rpcHeaderFromProto(h *rafttopopb.RPCHeader) raft.RPCHeader {
+	return raft.RPCHeader{
+		ProtocolVersion: raft.ProtocolVersion(h.GetProtocolVersion()),
+		ID:              h.GetId(),
+		Addr:            h.GetAddr(),
+	}
+}
+
+func appendEntriesRequestToProto(args *raft.AppendEntriesRequest) *rafttopopb.AppendEntriesRequest {
+	req := &rafttopopb.AppendEntriesRequest{
+		RpcHeader:         rpcHeaderToProto(args.RPCHeader),
+		Term:              args.Term,
+		Leader:            args.Leader,
+		PrevLogEntry:      args.PrevLogEntry,
+		PrevLogTerm:       args.PrevLogTerm,
+		Entries:           make([]*rafttopopb.Log, 0, len(args.Entries)),
+		LeaderCommitIndex: args.LeaderCommitIndex,
+	}
+	for _, entry := range args.Entries {
+		req.Entries = append(req.Entries, logToProto(entry))
+	}
+	return req
+}
+
+func appendEntriesRequestFromProto(req *rafttopopb.AppendEntriesRequest) *raft.AppendEntriesRequest {
+	args := &raft.AppendEntriesRequest{
+		RPCHeader:         rpcHeaderFromProto(req.GetRpcHeader()),
+		Term:              req.GetTerm(),
+		Leader:            req.GetLeader(),
+		PrevLogEntry:      req.GetPrevLogEntry(),
+		PrevLogTerm:       req.GetPrevLogTerm(),
+		LeaderCommitIndex: req.GetLeaderCommitIndex(),
+	}
+	for _, entry := range req.GetEntries() {
+		args.Entries = append(args.Entries, logFromProto(entry))
+	}
+	return args
+}
+
+func logToProto(l *raft.Log) *rafttopopb.Log {
+	out := &rafttopopb.Log{
+		Index:      l.Index,
+		Term:       l.Term,
+		Type:       uint32(l.Type),
+		Data:       l.Data,
+		Extensions: l.Extensions,
+	}
+	if !l.AppendedAt.IsZero() {
+		out.AppendedAt = l.AppendedAt.UnixNano()
+	}
+	return out
+}
+
+func logFromProto(l *rafttopopb.Log) *raft.Log {
+	out := &raft.Log{
+		Index:      l.GetIndex(),
+		Term:       l.GetTerm(),
+		Type:       raft.LogType(l.GetType()),
+		Data:       l.GetData(),
+		Extensions: l.GetExtensions(),
+	}
+	if l.GetAppendedAt() != 0 {
+		out.AppendedAt = time.Unix(0, l.GetAppendedAt())
+	}
+	return out
+}
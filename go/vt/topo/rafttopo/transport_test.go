@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// TestTransportInstallSnapshot checks snapshots larger than a chunk
+// are streamed to the other member.
+func TestTransportInstallSnapshot(t *testing.T) {
+	tlsConfig := createTLSConfig(t, "node")
+	serverOption, err := tlsConfig.serverOption()
+	require.NoError(t, err)
+	dialOption, err := tlsConfig.dialOption()
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	target := newTransport(listener.Addr().String(), dialOption)
+	defer target.Close()
+	server := grpc.NewServer(serverOption)
+	rafttopopb.RegisterRaftTopoServer(server, &grpcService{n: &Node{transport: target}})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	data := bytes.Repeat([]byte("snapshot"), snapshotChunkSize/4)
+	received := make(chan []byte, 1)
+	go func() {
+		rpc := <-target.Consumer()
+		req := rpc.Command.(*raft.InstallSnapshotRequest)
+		buf, err := io.ReadAll(io.LimitReader(rpc.Reader, req.Size))
+		if err != nil {
+			rpc.Respond(nil, err)
+			return
+		}
+		received <- buf
+		rpc.Respond(&raft.InstallSnapshotResponse{Term: req.Term, Success: true}, nil)
+	}()
+
+	source := newTransport("source", dialOption)
+	defer source.Close()
+	var resp raft.InstallSnapshotResponse
+	err = source.InstallSnapshot("target", target.LocalAddr(), &raft.InstallSnapshotRequest{
+		Term: 3,
+		Size: int64(len(data)),
+	}, &resp, bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.EqualValues(t, 3, resp.Term)
+	assert.Equal(t, data, <-received)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// watchRetryDelay is how long we wait before retrying a failed watch.
+const watchRetryDelay = time.Second
+
+// Watch is part of the topo.Conn interface.
+func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	// Get the initial version of the file.
+	initialCtx, initialCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer initialCancel()
+	initial, err := s.rangeKeys(initialCtx, nodePath, false /* prefix */)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(initial.Kvs) != 1 {
+		return nil, nil, topo.NewError(topo.NoNode, nodePath)
+	}
+	wd := &topo.WatchData{
+		Contents: initial.Kvs[0].Value,
+		Version:  RaftVersion(initial.Kvs[0].ModRevision),
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchData, 10)
+	go func() {
+		defer close(notifications)
+
+		rev := initial.Header.Revision
+		for {
+			resp, err := s.watchKeys(ctx, nodePath, false /* prefix */, rev)
+			switch {
+			case err == nil:
+			case errors.Is(err, errCompacted):
+				// We missed some changes, send the current value.
+				current, err := s.rangeKeys(ctx, nodePath, false /* prefix */)
+				if err != nil {
+					if !s.waitRetry(ctx, nodePath, err) {
+						notifications <- &topo.WatchData{Err: stopError(ctx, nodePath, err)}
+						return
+					}
+					continue
+				}
+				if len(current.Kvs) != 1 {
+					notifications <- &topo.WatchData{Err: topo.NewError(topo.NoNode, nodePath)}
+					return
+				}
+				notifications <- &topo.WatchData{
+					Contents: current.Kvs[0].Value,
+					Version:  RaftVersion(current.Kvs[0].ModRevision),
+				}
+				rev = current.Header.Revision
+				continue
+			default:
+				if !s.waitRetry(ctx, nodePath, err) {
+					// This includes context cancellation errors.
+					notifications <- &topo.WatchData{Err: stopError(ctx, nodePath, err)}
+					return
+				}
+				continue
+			}
+
+			rev = resp.Header.Revision
+			for _, ev := range resp.Events {
+				if ev.Deleted {
+					// Node is gone, send a final notice.
+					notifications <- &topo.WatchData{Err: topo.NewError(topo.NoNode, nodePath)}
+					return
+				}
+				notifications <- &topo.WatchData{
+					Contents: ev.Value,
+					Version:  RaftVersion(ev.Revision),
+				}
+			}
+		}
+	}()
+
+	return wd, notifications, nil
+}
+
+// WatchRecursive is part of the topo.Conn interface.
+func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
+	nodePath := path.Join(s.root, dirpath)
+	if !strings.HasSuffix(nodePath, "/") {
+		nodePath = nodePath + "/"
+	}
+
+	// Get the initial version of the files.
+	initial, err := s.rangeKeys(ctx, nodePath, true /* prefix */)
+	if err != nil {
+		return nil, nil, err
+	}
+	initialwd := recursiveWatchData(initial.Kvs)
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+
+		rev := initial.Header.Revision
+		for {
+			resp, err := s.watchKeys(ctx, nodePath, true /* prefix */, rev)
+			switch {
+			case err == nil:
+			case errors.Is(err, errCompacted):
+				// We missed some changes, send the current value
+				// of all the files.
+				current, err := s.rangeKeys(ctx, nodePath, true /* prefix */)
+				if err != nil {
+					if !s.waitRetry(ctx, nodePath, err) {
+						notifications <- &topo.WatchDataRecursive{
+							WatchData: topo.WatchData{Err: stopError(ctx, nodePath, err)},
+						}
+						return
+					}
+					continue
+				}
+				for _, wd := range recursiveWatchData(current.Kvs) {
+					notifications <- wd
+				}
+				rev = current.Header.Revision
+				continue
+			default:
+				if !s.waitRetry(ctx, nodePath, err) {
+					// This includes context cancellation errors.
+					notifications <- &topo.WatchDataRecursive{
+						WatchData: topo.WatchData{Err: stopError(ctx, nodePath, err)},
+					}
+					return
+				}
+				continue
+			}
+
+			rev = resp.Header.Revision
+			for _, ev := range resp.Events {
+				if ev.Deleted {
+					notifications <- &topo.WatchDataRecursive{
+						Path: ev.Key,
+						WatchData: topo.WatchData{
+							Err: topo.NewError(topo.NoNode, ev.Key),
+						},
+					}
+					continue
+				}
+				notifications <- &topo.WatchDataRecursive{
+					Path: ev.Key,
+					WatchData: topo.WatchData{
+						Contents: ev.Value,
+						Version:  RaftVersion(ev.Revision),
+					},
+				}
+			}
+		}
+	}()
+
+	return initialwd, notifications, nil
+}
+
+func recursiveWatchData(kvs []*rafttopopb.KeyValue) []*topo.WatchDataRecursive {
+	result := make([]*topo.WatchDataRecursive, 0, len(kvs))
+	for _, kv := range kvs {
+		result = append(result, &topo.WatchDataRecursive{
+			Path: kv.Key,
+			WatchData: topo.WatchData{
+				Contents: kv.Value,
+				Version:  RaftVersion(kv.ModRevision),
+			},
+		})
+	}
+	return result
+}
+
+// waitRetry waits before retrying a failed watch request. It returns
+// false if the watch should stop instead, because ctx is done or the
+// server was closed.
+func (s *Server) waitRetry(ctx context.Context, nodePath string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	log.Warningf("raft topo watch on %v failed, retrying: %v", nodePath, err)
+	t := time.NewTimer(watchRetryDelay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-s.running:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// stopError returns the error to send when a watch stops after err.
+func stopError(ctx context.Context, nodePath string, err error) error {
+	if ctx.Err() != nil {
+		return convertError(ctx.Err(), nodePath)
+	}
+	return err
+}
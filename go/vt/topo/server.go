@@ -70,20 +70,22 @@ const (
 
 // Filenames for all object types.
 const (
-	CellInfoFile           = "CellInfo"
-	CellsAliasFile         = "CellsAlias"
-	KeyspaceFile           = "Keyspace"
-	ShardFile              = "Shard"
-	VSchemaFile            = "VSchema"
-	ShardReplicationFile   = "ShardReplication"
-	TabletFile             = "Tablet"
-	SrvVSchemaFile         = "SrvVSchema"
-	SrvKeyspaceFile        = "SrvKeyspace"
-	RoutingRulesFile       = "RoutingRules"
-	ExternalClustersFile   = "ExternalClusters"
-	ShardRoutingRulesFile  = "ShardRoutingRules"
-	CommonRoutingRulesFile = "Rules"
-	MirrorRulesFile        = "MirrorRules"
+	CellInfoFile             = "CellInfo"
+	CellsAliasFile           = "CellsAlias"
+	KeyspaceFile             = "Keyspace"
+	ShardFile                = "Shard"
+	VSchemaFile              = "VSchema"
+	ShardReplicationFile     = "ShardReplication"
+	TabletFile               = "Tablet"
+	SrvVSchemaFile           = "SrvVSchema"
+	SrvKeyspaceFile          = "SrvKeyspace"
+	RoutingRulesFile         = "RoutingRules"
+	ExternalClustersFile     = "ExternalClusters"
+	ShardRoutingRulesFile    = "ShardRoutingRules"
+	CommonRoutingRulesFile   = "Rules"
+	MirrorRulesFile          = "MirrorRules"
+	WeightedRoutingRulesFile = "WeightedRoutingRules"
+	QueryDenylistFile        = "QueryDenylist"
 )
 
 // Path for all object types.
@@ -149,6 +151,11 @@ type Server struct {
 	// will read the list of addresses for that cell from the
 	// global cluster and create clients as needed.
 	cellConns map[string]cellConn
+
+	// auditSink records the writes to the global topo, and to the
+	// cell topos unless it is globalOnly, if the audit log is enabled.
+	// It is set at construction time.
+	auditSink *asyncAuditSink
 }
 
 type cellConn struct {
@@ -199,6 +206,7 @@ func registerTopoFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &topoGlobalServerAddress, "topo-global-server-address", topoGlobalServerAddress, "the address of the global topology server")
 	utils.SetFlagStringVar(fs, &topoGlobalRoot, "topo-global-root", topoGlobalRoot, "the path of the global topology data in the global topology server")
 	utils.SetFlagInt64Var(fs, &DefaultReadConcurrency, "topo-read-concurrency", DefaultReadConcurrency, "Maximum concurrency of topo reads per global or local cell.")
+	registerTopoAuditFlags(fs)
 }
 
 // RegisterFactory registers a Factory for an implementation for a Server.
@@ -219,6 +227,14 @@ func NewWithFactory(factory Factory, serverAddress, root string) (*Server, error
 	if err != nil {
 		return nil, err
 	}
+	sink, err := newAuditSink(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if sink != nil {
+		conn = newAuditConn(GlobalCell, conn, sink)
+	}
 	conn = NewStatsConn(GlobalCell, conn, globalReadSem)
 
 	var connReadOnly Conn
@@ -237,6 +253,7 @@ func NewWithFactory(factory Factory, serverAddress, root string) (*Server, error
 		globalReadOnlyCell: connReadOnly,
 		factory:            factory,
 		cellConns:          make(map[string]cellConn),
+		auditSink:          sink,
 	}, nil
 }
 
@@ -310,6 +327,9 @@ func (ts *Server) ConnForCell(ctx context.Context, cell string) (Conn, error) {
 	switch {
 	case err == nil:
 		cellReadSem := semaphore.NewWeighted(DefaultReadConcurrency)
+		if ts.auditSink != nil && !ts.auditSink.globalOnly {
+			conn = newAuditConn(cell, conn, ts.auditSink)
+		}
 		conn = NewStatsConn(cell, conn, cellReadSem)
 		ts.cellConns[cell] = cellConn{ci, conn}
 		return conn, nil
@@ -352,6 +372,11 @@ func GetAliasByCell(ctx context.Context, ts *Server, cell string) string {
 // Close will close all connections to underlying topo Server.
 // It will nil all member variables, so any further access will panic.
 func (ts *Server) Close() {
+	// The file sinks are shared by the servers of the process, only
+	// the topo sink belongs to this server.
+	if ts.auditSink != nil && topoAuditSinkType == AuditSinkTopo {
+		ts.auditSink.Close()
+	}
 	if ts.globalCell != nil {
 		ts.globalCell.Close()
 	}
@@ -219,6 +219,18 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	srvVSchema.MirrorRules = mr
 
+	wrr, err := ts.GetWeightedRoutingRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetWeightedRoutingRules failed: %v", err)
+	}
+	srvVSchema.WeightedRoutingRules = wrr
+
+	qd, err := ts.GetQueryDenylist(ctx)
+	if err != nil {
+		return fmt.Errorf("GetQueryDenylist failed: %v", err)
+	}
+	srvVSchema.QueryDenylist = qd
+
 	// now save the SrvVSchema in all cells in parallel
 	for _, cell := range cells {
 		wg.Add(1)
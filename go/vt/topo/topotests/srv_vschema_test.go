@@ -30,9 +30,11 @@ import (
 
 func TestRebuildVSchema(t *testing.T) {
 	emptySrvVSchema := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:          &vschemapb.MirrorRules{},
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{},
+		QueryDenylist:        &vschemapb.QueryDenylist{},
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
 	}
 
 	// Set up topology.
@@ -54,9 +56,11 @@ func TestRebuildVSchema(t *testing.T) {
 
 	// create a keyspace, rebuild, should see an empty entry
 	emptyKs1SrvVSchema := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:          &vschemapb.MirrorRules{},
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{},
+		QueryDenylist:        &vschemapb.QueryDenylist{},
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": {},
 		},
@@ -87,9 +91,11 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted1 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:          &vschemapb.MirrorRules{},
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{},
+		QueryDenylist:        &vschemapb.QueryDenylist{},
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 		},
@@ -132,9 +138,11 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted2 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:          &vschemapb.MirrorRules{},
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{},
+		QueryDenylist:        &vschemapb.QueryDenylist{},
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 			"ks2": keyspace2,
@@ -171,9 +179,11 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted3 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      rr,
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:          &vschemapb.MirrorRules{},
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{},
+		QueryDenylist:        &vschemapb.QueryDenylist{},
+		RoutingRules:         rr,
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 			"ks2": keyspace2,
@@ -327,3 +327,89 @@ func (ts *Server) SaveMirrorRules(ctx context.Context, mirrorRules *vschemapb.Mi
 	_, err = ts.globalCell.Update(ctx, MirrorRulesFile, data, nil)
 	return err
 }
+
+// GetWeightedRoutingRules fetches the weighted routing rules from the topo.
+func (ts *Server) GetWeightedRoutingRules(ctx context.Context) (*vschemapb.WeightedRoutingRules, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rr := &vschemapb.WeightedRoutingRules{}
+	data, _, err := ts.globalCell.Get(ctx, WeightedRoutingRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return rr, nil
+		}
+		return nil, err
+	}
+	err = rr.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "bad weighted routing rules data: %q", data)
+	}
+	return rr, nil
+}
+
+// SaveWeightedRoutingRules saves the weighted routing rules into the topo.
+func (ts *Server) SaveWeightedRoutingRules(ctx context.Context, weightedRoutingRules *vschemapb.WeightedRoutingRules) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := weightedRoutingRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, WeightedRoutingRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, WeightedRoutingRulesFile, data, nil)
+	return err
+}
+
+// GetQueryDenylist fetches the vtgate query denylist from the topo.
+func (ts *Server) GetQueryDenylist(ctx context.Context) (*vschemapb.QueryDenylist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	qd := &vschemapb.QueryDenylist{}
+	data, _, err := ts.globalCell.Get(ctx, QueryDenylistFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return qd, nil
+		}
+		return nil, err
+	}
+	err = qd.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "bad query denylist data: %q", data)
+	}
+	return qd, nil
+}
+
+// SaveQueryDenylist saves the vtgate query denylist into the topo.
+func (ts *Server) SaveQueryDenylist(ctx context.Context, queryDenylist *vschemapb.QueryDenylist) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := queryDenylist.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, QueryDenylistFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, QueryDenylistFile, data, nil)
+	return err
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// GetWeightedRoutingRulesMap returns a mapping of fromTable=>rule.
+func GetWeightedRoutingRulesMap(rules *vschemapb.WeightedRoutingRules) map[string]*vschemapb.WeightedRoutingRule {
+	if rules == nil {
+		return nil
+	}
+	rulesMap := make(map[string]*vschemapb.WeightedRoutingRule, len(rules.Rules))
+	for _, wr := range rules.Rules {
+		rulesMap[wr.FromTable] = wr
+	}
+	return rulesMap
+}
+
+// GetWeightedRoutingRules fetches weighted routing rules from the topology
+// server and returns a mapping of fromTable=>rule.
+func GetWeightedRoutingRules(ctx context.Context, ts *topo.Server) (map[string]*vschemapb.WeightedRoutingRule, error) {
+	wrs, err := ts.GetWeightedRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := GetWeightedRoutingRulesMap(wrs)
+
+	return rules, nil
+}
+
+// SaveWeightedRoutingRules converts a mapping of fromTable=>rule into a
+// vschemapb.WeightedRoutingRules protobuf message and saves it in the
+// topology. The weighted shard routing rules are kept.
+func SaveWeightedRoutingRules(ctx context.Context, ts *topo.Server, rules map[string]*vschemapb.WeightedRoutingRule) error {
+	log.V(2).Infof("Saving weighted routing rules %v\n", rules)
+
+	wrs, err := ts.GetWeightedRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	wrs.Rules = make([]*vschemapb.WeightedRoutingRule, 0, len(rules))
+	for fromTable, rule := range rules {
+		wrs.Rules = append(wrs.Rules, &vschemapb.WeightedRoutingRule{
+			FromTable:       fromTable,
+			ToTable:         rule.ToTable,
+			Percent:         rule.Percent,
+			MaxErrorPercent: rule.MaxErrorPercent,
+		})
+	}
+
+	return ts.SaveWeightedRoutingRules(ctx, wrs)
+}
+
+// GetWeightedShardRoutingRulesMap returns a mapping of fromKeyspace=>rule.
+func GetWeightedShardRoutingRulesMap(rules *vschemapb.WeightedRoutingRules) map[string]*vschemapb.WeightedShardRoutingRule {
+	if rules == nil {
+		return nil
+	}
+	rulesMap := make(map[string]*vschemapb.WeightedShardRoutingRule, len(rules.ShardRules))
+	for _, wr := range rules.ShardRules {
+		rulesMap[wr.FromKeyspace] = wr
+	}
+	return rulesMap
+}
+
+// GetWeightedShardRoutingRules fetches the weighted shard routing rules from
+// the topology server and returns a mapping of fromKeyspace=>rule.
+func GetWeightedShardRoutingRules(ctx context.Context, ts *topo.Server) (map[string]*vschemapb.WeightedShardRoutingRule, error) {
+	wrs, err := ts.GetWeightedRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := GetWeightedShardRoutingRulesMap(wrs)
+
+	return rules, nil
+}
+
+// SaveWeightedShardRoutingRules converts a mapping of fromKeyspace=>rule
+// into the shard rules of a vschemapb.WeightedRoutingRules protobuf message
+// and saves it in the topology. The weighted routing rules of the tables
+// are kept.
+func SaveWeightedShardRoutingRules(ctx context.Context, ts *topo.Server, rules map[string]*vschemapb.WeightedShardRoutingRule) error {
+	log.V(2).Infof("Saving weighted shard routing rules %v\n", rules)
+
+	wrs, err := ts.GetWeightedRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	wrs.ShardRules = make([]*vschemapb.WeightedShardRoutingRule, 0, len(rules))
+	for fromKeyspace, rule := range rules {
+		wrs.ShardRules = append(wrs.ShardRules, &vschemapb.WeightedShardRoutingRule{
+			FromKeyspace:    fromKeyspace,
+			ToShards:        rule.ToShards,
+			Percent:         rule.Percent,
+			MaxErrorPercent: rule.MaxErrorPercent,
+		})
+	}
+
+	return ts.SaveWeightedRoutingRules(ctx, wrs)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestWeightedRoutingRulesRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	rules := map[string]*vschemapb.WeightedRoutingRule{
+		"k1.t1@replica": {
+			FromTable:       "k1.t1@replica",
+			ToTable:         "k2.t1",
+			Percent:         10,
+			MaxErrorPercent: 5,
+		},
+		"k1.t1@rdonly": {
+			FromTable: "k1.t1@rdonly",
+			ToTable:   "k2.t1",
+			Percent:   10,
+		},
+	}
+
+	err := SaveWeightedRoutingRules(ctx, ts, rules)
+	require.NoError(t, err, "could not save weighted routing rules to topo %v", rules)
+
+	roundtripRules, err := GetWeightedRoutingRules(ctx, ts)
+	require.NoError(t, err, "could not fetch weighted routing rules from topo")
+	utils.MustMatch(t, rules, roundtripRules)
+
+	// Saving no rules removes them.
+	err = SaveWeightedRoutingRules(ctx, ts, nil)
+	require.NoError(t, err)
+	roundtripRules, err = GetWeightedRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Empty(t, roundtripRules)
+}
+
+func TestWeightedShardRoutingRulesRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	tableRules := map[string]*vschemapb.WeightedRoutingRule{
+		"k1.t1@replica": {
+			FromTable: "k1.t1@replica",
+			ToTable:   "k2.t1",
+			Percent:   10,
+		},
+	}
+	require.NoError(t, SaveWeightedRoutingRules(ctx, ts, tableRules))
+
+	rules := map[string]*vschemapb.WeightedShardRoutingRule{
+		"k3@replica": {
+			FromKeyspace: "k3@replica",
+			ToShards: []*topodatapb.ShardReference{
+				{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+				{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+			},
+			Percent:         10,
+			MaxErrorPercent: 5,
+		},
+	}
+	require.NoError(t, SaveWeightedShardRoutingRules(ctx, ts, rules))
+
+	roundtripRules, err := GetWeightedShardRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, roundtripRules)
+
+	// The rules of the tables and of the shards are saved independently.
+	roundtripTableRules, err := GetWeightedRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	utils.MustMatch(t, tableRules, roundtripTableRules)
+	require.NoError(t, SaveWeightedRoutingRules(ctx, ts, nil))
+	roundtripRules, err = GetWeightedShardRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, roundtripRules)
+
+	// Saving no rules removes them.
+	require.NoError(t, SaveWeightedShardRoutingRules(ctx, ts, nil))
+	roundtripRules, err = GetWeightedShardRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Empty(t, roundtripRules)
+}
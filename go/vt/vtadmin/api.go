@@ -106,6 +106,9 @@ type Options struct {
 	// EnableDynamicClusters makes it so that clients can pass clusters dynamically
 	// in a session-like way, either via HTTP cookies or gRPC metadata.
 	EnableDynamicClusters bool
+	// SchemaMigrationsStreamInterval is how often each schema migrations stream
+	// polls the clusters for changes. Defaults to one second.
+	SchemaMigrationsStreamInterval time.Duration
 }
 
 // NewAPI returns a new API, configured to service the given set of clusters,
@@ -375,12 +378,14 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/cells_aliases", httpAPI.Adapt(vtadminhttp.GetCellsAliases)).Name("API.GetCellsAliases")
 	router.HandleFunc("/clusters", httpAPI.Adapt(vtadminhttp.GetClusters)).Name("API.GetClusters")
 	router.HandleFunc("/cluster/{cluster_id}/topology", httpAPI.Adapt(vtadminhttp.GetTopologyPath)).Name("API.GetTopologyPath")
+	router.HandleFunc("/cluster/{cluster_id}/topology/audit", httpAPI.Adapt(vtadminhttp.GetTopologyAuditLog)).Name("API.GetTopologyAuditLog")
 	router.HandleFunc("/cluster/{cluster_id}/validate", httpAPI.Adapt(vtadminhttp.Validate)).Name("API.Validate").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/gates", httpAPI.Adapt(vtadminhttp.GetGates)).Name("API.GetGates")
 	router.HandleFunc("/keyspace/{cluster_id}", httpAPI.Adapt(vtadminhttp.CreateKeyspace)).Name("API.CreateKeyspace").Methods("POST")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}", httpAPI.Adapt(vtadminhttp.DeleteKeyspace)).Name("API.DeleteKeyspace").Methods("DELETE")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}", httpAPI.Adapt(vtadminhttp.GetKeyspace)).Name("API.GetKeyspace")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/rebuild_keyspace_graph", httpAPI.Adapt(vtadminhttp.RebuildKeyspaceGraph)).Name("API.RebuildKeyspaceGraph").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/keyspace/{cluster_id}/{name}/schema_drift", httpAPI.Adapt(vtadminhttp.GetSchemaDrift)).Name("API.GetSchemaDrift").Methods("GET")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/remove_keyspace_cell", httpAPI.Adapt(vtadminhttp.RemoveKeyspaceCell)).Name("API.RemoveKeyspaceCell").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/validate", httpAPI.Adapt(vtadminhttp.ValidateKeyspace)).Name("API.ValidateKeyspace").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/keyspace/{cluster_id}/{name}/validate/schema", httpAPI.Adapt(vtadminhttp.ValidateSchemaKeyspace)).Name("API.ValidateSchemaKeyspace").Methods("PUT", "OPTIONS")
@@ -392,6 +397,8 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/migration/{cluster_id}/{keyspace}/complete", httpAPI.Adapt(vtadminhttp.CompleteSchemaMigration)).Name("API.CompleteSchemaMigration").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/migration/{cluster_id}/{keyspace}/launch", httpAPI.Adapt(vtadminhttp.LaunchSchemaMigration)).Name("API.LaunchSchemaMigration").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/migration/{cluster_id}/{keyspace}/retry", httpAPI.Adapt(vtadminhttp.RetrySchemaMigration)).Name("API.RetrySchemaMigration").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/stream", httpAPI.AdaptStream(vtadminhttp.StreamSchemaMigrations)).Name("API.StreamSchemaMigrations").Methods("GET")
+	router.HandleFunc("/migration/{cluster_id}/{keyspace}/stream", vtadminhttp.Preflight).Name("API.StreamSchemaMigrations.Preflight").Methods("OPTIONS")
 	router.HandleFunc("/migrations/", httpAPI.Adapt(vtadminhttp.GetSchemaMigrations)).Name("API.GetSchemaMigrations")
 	router.HandleFunc("/movetables/{cluster_id}/complete", httpAPI.Adapt(vtadminhttp.MoveTablesComplete)).Name("API.MoveTablesComplete")
 	router.HandleFunc("/schema/{table}", httpAPI.Adapt(vtadminhttp.FindSchema)).Name("API.FindSchema")
@@ -1125,6 +1132,32 @@ func (api *API) GetSchema(ctx context.Context, req *vtadminpb.GetSchemaRequest)
 	return schema, nil
 }
 
+// GetSchemaDrift is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetSchemaDrift(ctx context.Context, req *vtadminpb.GetSchemaDriftRequest) (*vtctldatapb.GetSchemaDriftResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetSchemaDrift")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("reference_shard", req.ReferenceShard)
+	span.Annotate("include_views", req.IncludeViews)
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !api.authz.IsAuthorized(ctx, c.ID, rbac.SchemaResource, rbac.GetAction) {
+		return nil, nil
+	}
+
+	return c.Vtctld.GetSchemaDrift(ctx, &vtctldatapb.GetSchemaDriftRequest{
+		Keyspace:       req.Keyspace,
+		ReferenceShard: req.ReferenceShard,
+		IncludeViews:   req.IncludeViews,
+	})
+}
+
 // GetSchemas is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetSchemas(ctx context.Context, req *vtadminpb.GetSchemasRequest) (*vtadminpb.GetSchemasResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetSchemas")
@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/vtadmin/cache"
+	"vitess.io/vitess/go/vt/vtadmin/errors"
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
 
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
@@ -93,6 +94,40 @@ func (api *API) Adapt(handler VTAdminHandler) http.HandlerFunc {
 	}
 }
 
+// VTAdminStreamHandler is an HTTP endpoint handler that streams its response
+// to the client as server-sent events, until the client goes away or the
+// stream ends.
+type VTAdminStreamHandler func(ctx context.Context, r Request, w *EventStream, api *API)
+
+// AdaptStream converts a VTAdminStreamHandler into an http.HandlerFunc. Like
+// Adapt, it propagates any span and actor set by upstream middlewares, but the
+// handler context is canceled when the client disconnects.
+func (api *API) AdaptStream(handler VTAdminStreamHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		actor, _ := rbac.FromContext(r.Context())
+		if actor != nil {
+			ctx = rbac.NewContext(ctx, actor)
+		}
+
+		stream, err := NewEventStream(w)
+		if err != nil {
+			NewJSONResponse(nil, &errors.Internal{Err: err}).Write(w)
+			return
+		}
+
+		handler(ctx, Request{r}, stream, api)
+	}
+}
+
+// Preflight answers CORS preflight (OPTIONS) requests for routes whose
+// handlers must not run on them, such as streaming routes. The CORS middleware,
+// when configured, sets the access control headers.
+func Preflight(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Options returns a copy of the Options this API was configured with.
 func (api *API) Options() Options {
 	return api.opts
@@ -19,11 +19,18 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 
-	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtadmin/errors"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 // GetClusters implements the http wrapper for /clusters
@@ -47,6 +54,113 @@ func GetTopologyPath(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(result, err)
 }
 
+// GetTopologyAuditLog implements the http wrapper for
+// /cluster/{cluster_id}/topology/audit. It reads the audit log written by
+// the binaries of the cluster with the topo audit sink, through the
+// topology browsing API.
+//
+// Only the 'topo' sink can be read this way: the 'file' sink writes to the
+// local files of every binary, which vtadmin cannot reach. When no audit log
+// is found under root, most likely because the cluster runs with another
+// sink, a bad request error is returned.
+//
+// Query params:
+//   - root: string, the directory of the topo audit sink, defaults to
+//     topo.DefaultAuditTopoPath.
+//   - cell: string
+//   - path: string, a prefix of the paths to return the writes of.
+//   - since, until: RFC 3339 times.
+//   - limit: int32
+func GetTopologyAuditLog(ctx context.Context, r Request, api *API) *JSONResponse {
+	query := r.URL.Query()
+
+	filter := &topo.AuditFilter{
+		Cell:       query.Get("cell"),
+		PathPrefix: query.Get("path"),
+	}
+	for name, value := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := query.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return NewJSONResponse(nil, &errors.BadRequest{
+					Err: fmt.Errorf("invalid %s: %w", name, err),
+				})
+			}
+			*value = t
+		}
+	}
+	limit, err := r.ParseQueryParamAsInt32("limit", 0)
+	if err != nil {
+		return NewJSONResponse(nil, &errors.BadRequest{Err: err})
+	}
+	filter.Limit = int(limit)
+
+	root := query.Get("root")
+	if root == "" {
+		root = topo.DefaultAuditTopoPath
+	}
+
+	reader := &topologyAuditLogReader{api: api, clusterID: r.Vars()["cluster_id"]}
+	if _, err := reader.ListDir(ctx, root); err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return NewJSONResponse(nil, &errors.BadRequest{
+				Err: fmt.Errorf("no topology audit log in %s: vtadmin can only read the audit log of clusters running with --topo-audit-sink=topo", root),
+			})
+		}
+		return NewJSONResponse(nil, err)
+	}
+	entries, err := topo.ReadTopoAuditLog(ctx, reader, root, filter)
+	if entries == nil {
+		entries = []*topo.AuditEntry{}
+	}
+	return NewJSONResponse(entries, err)
+}
+
+// topologyAuditLogReader implements topo.AuditLogReader on top of the
+// GetTopologyPath API, for the global topo of a cluster.
+type topologyAuditLogReader struct {
+	api       *API
+	clusterID string
+}
+
+// ListDir is part of the topo.AuditLogReader interface.
+func (r *topologyAuditLogReader) ListDir(ctx context.Context, dirPath string) ([]string, error) {
+	resp, err := r.api.server.GetTopologyPath(ctx, &vtadminpb.GetTopologyPathRequest{
+		ClusterId: r.clusterID,
+		Path:      path.Join("/", topo.GlobalCell, dirPath),
+	})
+	if err != nil {
+		if vterrors.Code(err) == vtrpcpb.Code_FAILED_PRECONDITION {
+			// The directory has no children.
+			return nil, topo.NewError(topo.NoNode, dirPath)
+		}
+		return nil, err
+	}
+	if resp == nil || resp.Cell == nil {
+		// Not authorized to read the topology of the cluster.
+		return nil, nil
+	}
+	return resp.Cell.Children, nil
+}
+
+// Get is part of the topo.AuditLogReader interface.
+func (r *topologyAuditLogReader) Get(ctx context.Context, filePath string) ([]byte, error) {
+	resp, err := r.api.server.GetTopologyPath(ctx, &vtadminpb.GetTopologyPathRequest{
+		ClusterId: r.clusterID,
+		Path:      path.Join("/", topo.GlobalCell, filePath),
+	})
+	if err != nil {
+		if vterrors.Code(err) == vtrpcpb.Code_FAILED_PRECONDITION {
+			return nil, topo.NewError(topo.NoNode, filePath)
+		}
+		return nil, err
+	}
+	if resp == nil || resp.Cell == nil || resp.Cell.Data == "" {
+		return nil, topo.NewError(topo.NoNode, filePath)
+	}
+	return []byte(resp.Cell.Data), nil
+}
+
 // Validate implements the http wrapper for /cluster/{cluster_id}/validate
 func Validate(ctx context.Context, r Request, api *API) *JSONResponse {
 	vars := mux.Vars(r.Request)
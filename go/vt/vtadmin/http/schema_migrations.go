@@ -19,6 +19,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/gorilla/mux"
@@ -149,3 +150,50 @@ func RetrySchemaMigration(ctx context.Context, r Request, api *API) *JSONRespons
 
 	return NewJSONResponse(resp, err)
 }
+
+// SchemaMigrationsWatcher is implemented by VTAdminServer implementations that
+// can stream changes in the progress of schema migrations.
+type SchemaMigrationsWatcher interface {
+	WatchSchemaMigrations(ctx context.Context, req *vtadminpb.GetSchemaMigrationsRequest, send func(*vtadminpb.GetSchemaMigrationsResponse) error) error
+}
+
+// StreamSchemaMigrations implements the http wrapper for
+// /migration/{cluster_id}/{keyspace}/stream[?uuid].
+//
+// It streams the schema migrations of the keyspace, across all of its shards,
+// as server-sent "migrations" events: first all migrations, then the migrations
+// whose status, progress, ETA or cut-over attempts changed, and the migrations
+// that no longer exist, with an UNKNOWN status. If streaming fails, a final
+// "error" event is sent.
+func StreamSchemaMigrations(ctx context.Context, r Request, w *EventStream, api *API) {
+	const (
+		migrationsEvent = "migrations"
+		errorEvent      = "error"
+	)
+
+	watcher, ok := api.server.(SchemaMigrationsWatcher)
+	if !ok {
+		_ = w.Send(errorEvent, nil, &errors.Internal{Err: fmt.Errorf("%T does not support streaming schema migrations", api.server)})
+		return
+	}
+
+	vars := mux.Vars(r.Request)
+	req := &vtadminpb.GetSchemaMigrationsRequest{
+		ClusterRequests: []*vtadminpb.GetSchemaMigrationsRequest_ClusterRequest{
+			{
+				ClusterId: vars["cluster_id"],
+				Request: &vtctldatapb.GetSchemaMigrationsRequest{
+					Keyspace: vars["keyspace"],
+					Uuid:     r.URL.Query().Get("uuid"),
+				},
+			},
+		},
+	}
+
+	err := watcher.WatchSchemaMigrations(ctx, req, func(resp *vtadminpb.GetSchemaMigrationsResponse) error {
+		return w.Send(migrationsEvent, resp, nil)
+	})
+	if err != nil && ctx.Err() == nil {
+		_ = w.Send(errorEvent, nil, err)
+	}
+}
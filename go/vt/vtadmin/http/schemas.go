@@ -66,6 +66,26 @@ func GetSchema(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(schema, err)
 }
 
+// GetSchemaDrift implements the http wrapper for the
+// /keyspace/{cluster_id}/{name}/schema_drift[?reference_shard=&include_views=] route.
+func GetSchemaDrift(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	includeViews, err := r.ParseQueryParamAsBool("include_views", false)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	drift, err := api.server.GetSchemaDrift(ctx, &vtadminpb.GetSchemaDriftRequest{
+		ClusterId:      vars["cluster_id"],
+		Keyspace:       vars["name"],
+		ReferenceShard: r.URL.Query().Get("reference_shard"),
+		IncludeViews:   includeViews,
+	})
+
+	return NewJSONResponse(drift, err)
+}
+
 // GetSchemas implements the http wrapper for the /schemas[?cluster_id=[&cluster_id=]
 // route.
 func GetSchemas(ctx context.Context, r Request, api *API) *JSONResponse {
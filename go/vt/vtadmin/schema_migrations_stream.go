@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtadmin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/trace"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// defaultSchemaMigrationsStreamInterval is how often StreamSchemaMigrations
+// polls for changes in schema migrations, unless configured otherwise with
+// Options.SchemaMigrationsStreamInterval.
+const defaultSchemaMigrationsStreamInterval = time.Second
+
+// WatchSchemaMigrations polls the schema migrations selected by req, which are
+// read from _vt.schema_migrations on all shards of the requested keyspaces, and
+// calls send with the migrations whose progress changed since the previous
+// poll. The first call to send contains all selected migrations. Progress
+// changes are state transitions, as well as changes in the number of rows
+// copied, the ETA and the number of cut-over attempts. Migrations that were
+// sent before and are no longer returned, e.g. because they were cleaned up,
+// are sent once more with only their cluster, keyspace, shard and uuid set,
+// and an UNKNOWN status, so that clients can drop them.
+//
+// It returns when ctx is done, or when either polling or send fail.
+func (api *API) WatchSchemaMigrations(ctx context.Context, req *vtadminpb.GetSchemaMigrationsRequest, send func(*vtadminpb.GetSchemaMigrationsResponse) error) error {
+	span, ctx := trace.NewSpan(ctx, "API.WatchSchemaMigrations")
+	defer span.Finish()
+
+	interval := api.options.SchemaMigrationsStreamInterval
+	if interval <= 0 {
+		interval = defaultSchemaMigrationsStreamInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last map[string]*vtadminpb.SchemaMigration
+	for {
+		resp, err := api.GetSchemaMigrations(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[string]*vtadminpb.SchemaMigration, len(resp.SchemaMigrations))
+		var changed []*vtadminpb.SchemaMigration
+		for _, m := range resp.SchemaMigrations {
+			key := schemaMigrationKey(m)
+			current[key] = m
+			if last == nil || schemaMigrationProgressChanged(last[key].GetSchemaMigration(), m.SchemaMigration) {
+				changed = append(changed, m)
+			}
+		}
+		for key, m := range last {
+			if _, ok := current[key]; !ok {
+				changed = append(changed, removedSchemaMigration(m))
+			}
+		}
+		if last == nil || len(changed) > 0 {
+			if err := send(&vtadminpb.GetSchemaMigrationsResponse{SchemaMigrations: changed}); err != nil {
+				return err
+			}
+		}
+		last = current
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func schemaMigrationKey(m *vtadminpb.SchemaMigration) string {
+	return fmt.Sprintf("%s/%s/%s/%s", m.GetCluster().GetId(), m.GetSchemaMigration().GetKeyspace(), m.GetSchemaMigration().GetShard(), m.GetSchemaMigration().GetUuid())
+}
+
+// removedSchemaMigration returns the identifying fields of a migration that is
+// no longer returned, with an UNKNOWN status.
+func removedSchemaMigration(m *vtadminpb.SchemaMigration) *vtadminpb.SchemaMigration {
+	return &vtadminpb.SchemaMigration{
+		Cluster: m.GetCluster(),
+		SchemaMigration: &vtctldatapb.SchemaMigration{
+			Uuid:     m.GetSchemaMigration().GetUuid(),
+			Keyspace: m.GetSchemaMigration().GetKeyspace(),
+			Shard:    m.GetSchemaMigration().GetShard(),
+			Status:   vtctldatapb.SchemaMigration_UNKNOWN,
+		},
+	}
+}
+
+// schemaMigrationProgressChanged returns whether any of the fields clients
+// follow the progress of a migration with differ between prev and cur. A nil
+// prev means the migration was not seen before.
+func schemaMigrationProgressChanged(prev, cur *vtctldatapb.SchemaMigration) bool {
+	if prev == nil {
+		return true
+	}
+	return prev.GetStatus() != cur.GetStatus() ||
+		prev.GetProgress() != cur.GetProgress() ||
+		prev.GetEtaSeconds() != cur.GetEtaSeconds() ||
+		prev.GetRowsCopied() != cur.GetRowsCopied() ||
+		prev.GetTableRows() != cur.GetTableRows() ||
+		prev.GetCutoverAttempts() != cur.GetCutoverAttempts() ||
+		prev.GetStage() != cur.GetStage() ||
+		prev.GetMessage() != cur.GetMessage() ||
+		prev.GetReadyToComplete() != cur.GetReadyToComplete()
+}
+
+// StreamSchemaMigrations is part of the vtadminpb.VTAdminServer interface.
+func (api *API) StreamSchemaMigrations(req *vtadminpb.GetSchemaMigrationsRequest, stream vtadminpb.VTAdmin_StreamSchemaMigrationsServer) error {
+	return api.WatchSchemaMigrations(stream.Context(), req, stream.Send)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtadmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/vt/vtadmin/cluster"
+	vtadmintestutil "vitess.io/vitess/go/vt/vtadmin/testutil"
+	"vitess.io/vitess/go/vt/vtadmin/vtctldclient/fakevtctldclient"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// progressingVtctldClient returns schema migrations whose row-copy progress
+// advances on every call, until the migration completes.
+type progressingVtctldClient struct {
+	*fakevtctldclient.VtctldClient
+	calls atomic.Int64
+}
+
+func (c *progressingVtctldClient) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaMigrationsResponse, error) {
+	n := c.calls.Add(1)
+	running := &vtctldatapb.SchemaMigration{
+		Uuid:       "uuid1",
+		Keyspace:   req.Keyspace,
+		Shard:      "-80",
+		Status:     vtctldatapb.SchemaMigration_RUNNING,
+		RowsCopied: uint64(min(n, 3) * 100),
+		TableRows:  300,
+	}
+	if n >= 3 {
+		running.Status = vtctldatapb.SchemaMigration_COMPLETE
+	}
+	return &vtctldatapb.GetSchemaMigrationsResponse{
+		Migrations: []*vtctldatapb.SchemaMigration{
+			running,
+			{
+				Uuid:     "uuid2",
+				Keyspace: req.Keyspace,
+				Shard:    "80-",
+				Status:   vtctldatapb.SchemaMigration_QUEUED,
+			},
+		},
+	}, nil
+}
+
+func TestWatchSchemaMigrations(t *testing.T) {
+	c := vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+		Cluster: &vtadminpb.Cluster{
+			Id:   "c1",
+			Name: "cluster1",
+		},
+		VtctldClient: &progressingVtctldClient{
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+		},
+	})
+	api := NewAPI(vtenv.NewTestEnv(), []*cluster.Cluster{c}, Options{SchemaMigrationsStreamInterval: time.Millisecond})
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	req := &vtadminpb.GetSchemaMigrationsRequest{
+		ClusterRequests: []*vtadminpb.GetSchemaMigrationsRequest_ClusterRequest{
+			{
+				ClusterId: "c1",
+				Request:   &vtctldatapb.GetSchemaMigrationsRequest{Keyspace: "ks"},
+			},
+		},
+	}
+
+	errDone := errors.New("done")
+	var sent []*vtadminpb.GetSchemaMigrationsResponse
+	err := api.WatchSchemaMigrations(context.Background(), req, func(resp *vtadminpb.GetSchemaMigrationsResponse) error {
+		sent = append(sent, resp)
+		if len(sent) == 3 {
+			return errDone
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+	require.Len(t, sent, 3)
+
+	// The first response has all migrations, later ones only the migration
+	// that made progress.
+	assert.Len(t, sent[0].SchemaMigrations, 2)
+	for i, resp := range sent[1:] {
+		require.Len(t, resp.SchemaMigrations, 1, "response %d", i+1)
+		m := resp.SchemaMigrations[0]
+		assert.Equal(t, "c1", m.Cluster.Id)
+		assert.Equal(t, "uuid1", m.SchemaMigration.Uuid)
+		assert.EqualValues(t, (i+2)*100, m.SchemaMigration.RowsCopied)
+	}
+	assert.Equal(t, vtctldatapb.SchemaMigration_COMPLETE, sent[2].SchemaMigrations[0].SchemaMigration.Status)
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		var sends int
+		err := api.WatchSchemaMigrations(ctx, req, func(resp *vtadminpb.GetSchemaMigrationsResponse) error {
+			sends++
+			return nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		// The migration completed in the parent test, so only the initial
+		// response is sent.
+		assert.Equal(t, 1, sends)
+	})
+}
+
+// shrinkingVtctldClient returns two schema migrations on the first call, and
+// only one of them afterwards.
+type shrinkingVtctldClient struct {
+	*fakevtctldclient.VtctldClient
+	calls atomic.Int64
+}
+
+func (c *shrinkingVtctldClient) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaMigrationsResponse, error) {
+	migrations := []*vtctldatapb.SchemaMigration{
+		{Uuid: "uuid1", Keyspace: req.Keyspace, Shard: "-80", Status: vtctldatapb.SchemaMigration_QUEUED},
+	}
+	if c.calls.Add(1) == 1 {
+		migrations = append(migrations, &vtctldatapb.SchemaMigration{Uuid: "uuid2", Keyspace: req.Keyspace, Shard: "80-", Status: vtctldatapb.SchemaMigration_COMPLETE})
+	}
+	return &vtctldatapb.GetSchemaMigrationsResponse{Migrations: migrations}, nil
+}
+
+func TestWatchSchemaMigrationsRemoved(t *testing.T) {
+	c := vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+		Cluster: &vtadminpb.Cluster{
+			Id:   "c1",
+			Name: "cluster1",
+		},
+		VtctldClient: &shrinkingVtctldClient{
+			VtctldClient: &fakevtctldclient.VtctldClient{},
+		},
+	})
+	api := NewAPI(vtenv.NewTestEnv(), []*cluster.Cluster{c}, Options{SchemaMigrationsStreamInterval: time.Millisecond})
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	req := &vtadminpb.GetSchemaMigrationsRequest{
+		ClusterRequests: []*vtadminpb.GetSchemaMigrationsRequest_ClusterRequest{
+			{
+				ClusterId: "c1",
+				Request:   &vtctldatapb.GetSchemaMigrationsRequest{Keyspace: "ks"},
+			},
+		},
+	}
+
+	errDone := errors.New("done")
+	var sent []*vtadminpb.GetSchemaMigrationsResponse
+	err := api.WatchSchemaMigrations(context.Background(), req, func(resp *vtadminpb.GetSchemaMigrationsResponse) error {
+		sent = append(sent, resp)
+		if len(sent) == 2 {
+			return errDone
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errDone)
+	require.Len(t, sent, 2)
+	assert.Len(t, sent[0].SchemaMigrations, 2)
+
+	// The migration that went away is sent once more, with an UNKNOWN status.
+	require.Len(t, sent[1].SchemaMigrations, 1)
+	removed := sent[1].SchemaMigrations[0]
+	assert.Equal(t, "c1", removed.Cluster.Id)
+	assert.Equal(t, "uuid2", removed.SchemaMigration.Uuid)
+	assert.Equal(t, "80-", removed.SchemaMigration.Shard)
+	assert.Equal(t, vtctldatapb.SchemaMigration_UNKNOWN, removed.SchemaMigration.Status)
+}
+
+func TestStreamSchemaMigrationsPreflight(t *testing.T) {
+	api := NewAPI(vtenv.NewTestEnv(), nil, Options{})
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	// A preflight request is answered right away, without opening a stream.
+	w := httptest.NewRecorder()
+	api.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/api/migration/c1/ks/stream", nil))
+	assert.Less(t, w.Code, http.StatusMultipleChoices)
+	assert.NotEqual(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	// Only GET requests open a stream.
+	w = httptest.NewRecorder()
+	api.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/migration/c1/ks/stream", nil))
+	assert.NotEqual(t, "text/event-stream", w.Header().Get("Content-Type"))
+}
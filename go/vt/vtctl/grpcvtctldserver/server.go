@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -66,6 +67,7 @@ import (
 	vtorcdatapb "vitess.io/vitess/go/vt/proto/vtorcdata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/schemamanager"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
@@ -78,6 +80,7 @@ import (
 	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
@@ -226,9 +229,39 @@ func (s *VtctldServer) ApplyShardRoutingRules(ctx context.Context, req *vtctldat
 	return resp, nil
 }
 
+// ApplyQueryDenylist is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyQueryDenylist(ctx context.Context, req *vtctldatapb.ApplyQueryDenylistRequest) (*vtctldatapb.ApplyQueryDenylistResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyQueryDenylist")
+	defer span.Finish()
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if err := querydenylist.Validate(req.QueryDenylist); err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SaveQueryDenylist(ctx, req.QueryDenylist); err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyQueryDenylistResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
 // ApplySchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySchemaRequest) (resp *vtctldatapb.ApplySchemaResponse, err error) {
-	log.Infof("VtctldServer.ApplySchema: keyspace=%s, migrationContext=%v, ddlStrategy=%v, batchSize=%v", req.Keyspace, req.MigrationContext, req.DdlStrategy, req.BatchSize)
+	log.Infof("VtctldServer.ApplySchema: keyspace=%s, migrationContext=%v, ddlStrategy=%v, batchSize=%v, lint=%v", req.Keyspace, req.MigrationContext, req.DdlStrategy, req.BatchSize, req.Lint)
 
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplySchema")
 	defer span.Finish()
@@ -291,6 +324,18 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 		}
 	}
 
+	if req.Lint {
+		var lintConfig *schemadiff.LintConfig
+		if lintConfig, err = schemadiff.ParseLintConfig([]byte(req.LintConfig)); err != nil {
+			return resp, err
+		}
+		env := schemadiff.NewEnv(s.ws.Environment(), s.ws.Environment().CollationEnv().DefaultConnectionCharset())
+		if err = executor.SetLintConfig(env, lintConfig); err != nil {
+			err = vterrors.Wrapf(err, "invalid LintConfig")
+			return resp, err
+		}
+	}
+
 	execResult, err := schemamanager.Run(
 		ctx,
 		schemamanager.NewPlainController(req.Sql, req.Keyspace),
@@ -1757,6 +1802,21 @@ func (s *VtctldServer) GetShardRoutingRules(ctx context.Context, req *vtctldatap
 	}, nil
 }
 
+// GetQueryDenylist is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetQueryDenylist(ctx context.Context, req *vtctldatapb.GetQueryDenylistRequest) (*vtctldatapb.GetQueryDenylistResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetQueryDenylist")
+	defer span.Finish()
+
+	qd, err := s.ts.GetQueryDenylist(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetQueryDenylistResponse{
+		QueryDenylist: qd,
+	}, nil
+}
+
 // GetSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchemaRequest) (resp *vtctldatapb.GetSchemaResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSchema")
@@ -1808,6 +1868,21 @@ func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchema
 	}, nil
 }
 
+// GetSchemaDrift is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetSchemaDrift(ctx context.Context, req *vtctldatapb.GetSchemaDriftRequest) (resp *vtctldatapb.GetSchemaDriftResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSchemaDrift")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("reference_shard", req.ReferenceShard)
+	span.Annotate("include_views", req.IncludeViews)
+
+	env := schemadiff.NewEnv(s.ws.Environment(), s.ws.Environment().CollationEnv().DefaultConnectionCharset())
+	return schematools.GetSchemaDrift(ctx, s.ts, s.tmc, env, req)
+}
+
 func (s *VtctldServer) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest) (resp *vtctldatapb.GetSchemaMigrationsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetShard")
 	defer span.Finish()
@@ -2096,6 +2171,21 @@ func (s *VtctldServer) UpdateThrottlerConfig(ctx context.Context, req *vtctldata
 		}
 	}
 
+	if req.PrometheusMetric.GetUrl() == "" && req.PrometheusMetric.GetMetric() != "" {
+		return nil, errors.New("prometheus metric requires a URL")
+	}
+	if req.PrometheusMetric.GetUrl() != "" {
+		if req.PrometheusMetric.Metric == "" {
+			return nil, errors.New("prometheus metric requires a metric name")
+		}
+		if err := base.ValidatePrometheusURL(req.PrometheusMetric.Url); err != nil {
+			return nil, err
+		}
+		if _, err := base.ParsePrometheusAggregation(req.PrometheusMetric.Aggregation); err != nil {
+			return nil, err
+		}
+	}
+
 	update := func(throttlerConfig *topodatapb.ThrottlerConfig) *topodatapb.ThrottlerConfig {
 		if throttlerConfig == nil {
 			throttlerConfig = &topodatapb.ThrottlerConfig{}
@@ -2142,6 +2232,13 @@ func (s *VtctldServer) UpdateThrottlerConfig(ctx context.Context, req *vtctldata
 		if req.Disable {
 			throttlerConfig.Enabled = false
 		}
+		if req.PrometheusMetric != nil {
+			if req.PrometheusMetric.Url != "" {
+				throttlerConfig.PrometheusMetric = req.PrometheusMetric
+			} else {
+				throttlerConfig.PrometheusMetric = nil
+			}
+		}
 		if req.ThrottledApp != nil && req.ThrottledApp.Name != "" {
 			timeNow := time.Now()
 			if protoutil.TimeFromProto(req.ThrottledApp.ExpiresAt).After(timeNow) {
@@ -3106,6 +3203,21 @@ func (s *VtctldServer) LookupVindexInternalize(ctx context.Context, req *vtctlda
 	return resp, err
 }
 
+// LookupVindexVerify is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LookupVindexVerify(ctx context.Context, req *vtctldatapb.LookupVindexVerifyRequest) (resp *vtctldatapb.LookupVindexVerifyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexVerify")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("repair", req.Repair)
+
+	resp, err = s.ws.LookupVindexVerify(ctx, req)
+	return resp, err
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) MaterializeCreate(ctx context.Context, req *vtctldatapb.MaterializeCreateRequest) (resp *vtctldatapb.MaterializeCreateResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.MaterializeCreate")
@@ -3378,6 +3490,107 @@ func (s *VtctldServer) RebuildVSchemaGraph(ctx context.Context, req *vtctldatapb
 	return &vtctldatapb.RebuildVSchemaGraphResponse{}, nil
 }
 
+// ReconcileSchemaDrift is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ReconcileSchemaDrift(ctx context.Context, req *vtctldatapb.ReconcileSchemaDriftRequest) (resp *vtctldatapb.ReconcileSchemaDriftResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ReconcileSchemaDrift")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("reference_shard", req.ReferenceShard)
+	span.Annotate("ddl_strategy", req.DdlStrategy)
+	span.Annotate("dry_run", req.DryRun)
+
+	if req.Shard == "" {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "shard must be specified")
+		return nil, err
+	}
+	if req.Shard == req.ReferenceShard {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "shard %v cannot be reconciled against itself", req.Shard)
+		return nil, err
+	}
+
+	// Attach the callerID as the EffectiveCallerID.
+	if req.CallerId != nil {
+		span.Annotate("caller_id", req.CallerId.Principal)
+		ctx = callerid.NewContext(ctx, req.CallerId, &querypb.VTGateCallerID{Username: req.CallerId.Principal})
+	}
+
+	env := schemadiff.NewEnv(s.ws.Environment(), s.ws.Environment().CollationEnv().DefaultConnectionCharset())
+	drift, err := schematools.GetSchemaDrift(ctx, s.ts, s.tmc, env, &vtctldatapb.GetSchemaDriftRequest{
+		Keyspace:       req.Keyspace,
+		ReferenceShard: req.ReferenceShard,
+		IncludeViews:   req.IncludeViews,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if drift.ReferenceShard == req.Shard {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %v holds the canonical schema of keyspace %v", req.Shard, req.Keyspace)
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(drift.Shards, func(shard *vtctldatapb.ShardSchemaDrift) bool { return shard.Shard == req.Shard })
+	if idx < 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "shard %v not found in keyspace %v", req.Shard, req.Keyspace)
+		return nil, err
+	}
+	if drift.Shards[idx].Error != "" {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unable to read schema of shard %v/%v: %v", req.Keyspace, req.Shard, drift.Shards[idx].Error)
+		return nil, err
+	}
+
+	resp = &vtctldatapb.ReconcileSchemaDriftResponse{}
+	for _, diff := range drift.Shards[idx].Diffs {
+		resp.Statements = append(resp.Statements, diff.Statement)
+	}
+	if len(resp.Statements) == 0 || req.DryRun {
+		return resp, nil
+	}
+
+	ddlStrategy := req.DdlStrategy
+	if ddlStrategy == "" {
+		ddlStrategy = string(schema.DDLStrategyVitess)
+	}
+
+	setting, err := schema.ParseDDLStrategy(ddlStrategy)
+	if err != nil {
+		err = vterrors.Wrapf(err, "invalid DdlStrategy: %s", ddlStrategy)
+		return nil, err
+	}
+	if setting.Strategy == schema.DDLStrategyDirect {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "DdlStrategy must be an online DDL strategy, got %s", ddlStrategy)
+		return nil, err
+	}
+
+	executionUUID, err := schema.CreateUUID()
+	if err != nil {
+		err = vterrors.Wrapf(err, "unable to create execution UUID")
+		return nil, err
+	}
+
+	executor := schemamanager.NewTabletExecutor("vtctl:"+executionUUID, s.ts, s.tmc, logutil.NewConsoleLogger(), 30*time.Second, 0, s.ws.SQLParser())
+	if err = executor.SetDDLStrategy(ddlStrategy); err != nil {
+		err = vterrors.Wrapf(err, "invalid DdlStrategy: %s", ddlStrategy)
+		return nil, err
+	}
+	executor.SetShards([]string{req.Shard})
+
+	execResult, err := schemamanager.Run(
+		ctx,
+		schemamanager.NewPlainController(resp.Statements, req.Keyspace),
+		executor,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.UuidList = execResult.UUIDs
+	return resp, nil
+}
+
 // RefreshState is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) RefreshState(ctx context.Context, req *vtctldatapb.RefreshStateRequest) (resp *vtctldatapb.RefreshStateResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RefreshState")
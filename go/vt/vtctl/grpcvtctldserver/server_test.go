@@ -354,6 +354,57 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplyQueryDenylist(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	qd := &vschemapb.QueryDenylist{
+		Rules: []*vschemapb.QueryDenylistRule{
+			{
+				Fingerprint: "0123456789abcdef",
+				Mode:        vschemapb.QueryDenylistRule_FAIL,
+				Description: "full table scan",
+			},
+			{
+				Fingerprint:    "fedcba9876543210",
+				Mode:           vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY,
+				MaxConcurrency: 4,
+			},
+		},
+	}
+	_, err := vtctld.ApplyQueryDenylist(ctx, &vtctldatapb.ApplyQueryDenylistRequest{QueryDenylist: qd})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetQueryDenylist(ctx, &vtctldatapb.GetQueryDenylistRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, qd, resp.QueryDenylist)
+
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, qd, srvVSchema.QueryDenylist)
+
+	_, err = vtctld.ApplyQueryDenylist(ctx, &vtctldatapb.ApplyQueryDenylistRequest{
+		QueryDenylist: &vschemapb.QueryDenylist{
+			Rules: []*vschemapb.QueryDenylistRule{{Fingerprint: "0123456789abcdef", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY}},
+		},
+	})
+	assert.ErrorContains(t, err, "LIMIT_CONCURRENCY requires a positive max_concurrency")
+
+	// clearing the denylist removes it from the topo
+	_, err = vtctld.ApplyQueryDenylist(ctx, &vtctldatapb.ApplyQueryDenylistRequest{QueryDenylist: &vschemapb.QueryDenylist{}})
+	require.NoError(t, err)
+	resp, err = vtctld.GetQueryDenylist(ctx, &vtctldatapb.GetQueryDenylistRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.QueryDenylist.Rules)
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -686,6 +737,12 @@ func TestApplyVSchema(t *testing.T) {
 					ShardRoutingRules: &vschemapb.ShardRoutingRules{
 						Rules: []*vschemapb.ShardRoutingRule{},
 					},
+					WeightedRoutingRules: &vschemapb.WeightedRoutingRules{
+						Rules: []*vschemapb.WeightedRoutingRule{},
+					},
+					QueryDenylist: &vschemapb.QueryDenylist{
+						Rules: []*vschemapb.QueryDenylistRule{},
+					},
 				}
 				utils.MustMatch(t, changedSrvVSchema, finalSrvVSchema)
 			}
@@ -9238,6 +9295,124 @@ func TestRebuildVSchemaGraph(t *testing.T) {
 	}
 }
 
+func TestReconcileSchemaDrift(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	for i, shard := range []string{"-80", "80-"} {
+		testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uint32(100 + i)},
+			Keyspace: "testkeyspace",
+			Shard:    shard,
+			Type:     topodatapb.TabletType_PRIMARY,
+		}, &testutil.AddTabletOptions{AlsoSetShardPrimary: true})
+	}
+
+	tmc := &testutil.TabletManagerClient{
+		GetSchemaResults: map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{
+			"zone1-0000000100": {
+				Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+						{Schema: "create table t1 (id int primary key, name varchar(10))"},
+					},
+				},
+			},
+			"zone1-0000000101": {
+				Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+						{Schema: "create table t1 (id int primary key)"},
+					},
+				},
+			},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	tests := []struct {
+		name               string
+		req                *vtctldatapb.ReconcileSchemaDriftRequest
+		expectedStatements []string
+		shouldErr          bool
+	}{
+		{
+			name: "dry run",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace:       "testkeyspace",
+				Shard:          "80-",
+				ReferenceShard: "-80",
+				DryRun:         true,
+			},
+			expectedStatements: []string{"ALTER TABLE `t1` ADD COLUMN `name` varchar(10)"},
+		},
+		{
+			name: "other reference shard",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace:       "testkeyspace",
+				Shard:          "-80",
+				ReferenceShard: "80-",
+				DryRun:         true,
+			},
+			expectedStatements: []string{"ALTER TABLE `t1` DROP COLUMN `name`"},
+		},
+		{
+			name: "missing shard",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace: "testkeyspace",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "shard is reference",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace:       "testkeyspace",
+				Shard:          "-80",
+				ReferenceShard: "-80",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "shard holds canonical schema",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace: "testkeyspace",
+				Shard:    "-80",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "direct strategy",
+			req: &vtctldatapb.ReconcileSchemaDriftRequest{
+				Keyspace:       "testkeyspace",
+				Shard:          "80-",
+				ReferenceShard: "-80",
+				DdlStrategy:    "direct",
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := vtctld.ReconcileSchemaDrift(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatements, resp.Statements)
+			assert.Empty(t, resp.UuidList)
+		})
+	}
+}
+
 func TestRefreshState(t *testing.T) {
 	t.Parallel()
 
@@ -44,6 +44,11 @@ func (client *localVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context,
 	return client.s.ApplyKeyspaceRoutingRules(ctx, in)
 }
 
+// ApplyQueryDenylist is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyQueryDenylist(ctx context.Context, in *vtctldatapb.ApplyQueryDenylistRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyQueryDenylistResponse, error) {
+	return client.s.ApplyQueryDenylist(ctx, in)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	return client.s.ApplyRoutingRules(ctx, in)
@@ -331,6 +336,11 @@ func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctlda
 	return client.s.GetPermissions(ctx, in)
 }
 
+// GetQueryDenylist is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetQueryDenylist(ctx context.Context, in *vtctldatapb.GetQueryDenylistRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryDenylistResponse, error) {
+	return client.s.GetQueryDenylist(ctx, in)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	return client.s.GetRoutingRules(ctx, in)
@@ -341,6 +351,11 @@ func (client *localVtctldClient) GetSchema(ctx context.Context, in *vtctldatapb.
 	return client.s.GetSchema(ctx, in)
 }
 
+// GetSchemaDrift is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetSchemaDrift(ctx context.Context, in *vtctldatapb.GetSchemaDriftRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaDriftResponse, error) {
+	return client.s.GetSchemaDrift(ctx, in)
+}
+
 // GetSchemaMigrations is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetSchemaMigrations(ctx context.Context, in *vtctldatapb.GetSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaMigrationsResponse, error) {
 	return client.s.GetSchemaMigrations(ctx, in)
@@ -456,6 +471,11 @@ func (client *localVtctldClient) LookupVindexInternalize(ctx context.Context, in
 	return client.s.LookupVindexInternalize(ctx, in)
 }
 
+// LookupVindexVerify is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LookupVindexVerify(ctx context.Context, in *vtctldatapb.LookupVindexVerifyRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexVerifyResponse, error) {
+	return client.s.LookupVindexVerify(ctx, in)
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
 	return client.s.MaterializeCreate(ctx, in)
@@ -516,6 +536,11 @@ func (client *localVtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vt
 	return client.s.RebuildVSchemaGraph(ctx, in)
 }
 
+// ReconcileSchemaDrift is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ReconcileSchemaDrift(ctx context.Context, in *vtctldatapb.ReconcileSchemaDriftRequest, opts ...grpc.CallOption) (*vtctldatapb.ReconcileSchemaDriftResponse, error) {
+	return client.s.ReconcileSchemaDrift(ctx, in)
+}
+
 // RefreshState is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RefreshState(ctx context.Context, in *vtctldatapb.RefreshStateRequest, opts ...grpc.CallOption) (*vtctldatapb.RefreshStateResponse, error) {
 	return client.s.RefreshState(ctx, in)
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schematools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// shardSchema is the schema of a single shard, as read from its primary.
+type shardSchema struct {
+	drift  *vtctldatapb.ShardSchemaDrift
+	schema *schemadiff.Schema
+}
+
+// GetSchemaDrift compares the schema of each shard in a keyspace, as read from the shard's primary,
+// against the canonical schema of the keyspace, and returns the diffs that take each shard's schema
+// into the canonical one.
+//
+// The canonical schema is the schema of the requested reference shard. Without one, it is the schema
+// shared by most shards, with ties going to the shard that comes first in order. Shards whose schema
+// cannot be read report an error rather than fail the whole request.
+func GetSchemaDrift(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, env *schemadiff.Environment, req *vtctldatapb.GetSchemaDriftRequest) (*vtctldatapb.GetSchemaDriftResponse, error) {
+	shardNames, err := ts.GetShardNames(ctx, req.Keyspace)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "GetShardNames(%v) failed", req.Keyspace)
+	}
+	if len(shardNames) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v has no shards", req.Keyspace)
+	}
+	sort.Strings(shardNames)
+	if req.ReferenceShard != "" && !slices.Contains(shardNames, req.ReferenceShard) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "reference shard %v not found in keyspace %v", req.ReferenceShard, req.Keyspace)
+	}
+
+	shards := make([]*shardSchema, len(shardNames))
+	wg := sync.WaitGroup{}
+	for i, shardName := range shardNames {
+		shards[i] = &shardSchema{drift: &vtctldatapb.ShardSchemaDrift{Shard: shardName}}
+		wg.Add(1)
+		go func(shard *shardSchema) {
+			defer wg.Done()
+			if err := readShardSchema(ctx, ts, tmc, env, req, shard); err != nil {
+				shard.drift.Error = err.Error()
+			}
+		}(shards[i])
+	}
+	wg.Wait()
+
+	reference := canonicalShardSchema(ctx, shards, req.ReferenceShard)
+	if reference == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unable to read the schema of any shard in keyspace %v", req.Keyspace)
+	}
+	if reference.schema == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unable to read the schema of reference shard %v: %v", reference.drift.Shard, reference.drift.Error)
+	}
+
+	resp := &vtctldatapb.GetSchemaDriftResponse{
+		Keyspace:       req.Keyspace,
+		ReferenceShard: reference.drift.Shard,
+	}
+	for _, shard := range shards {
+		if shard.schema != nil && shard != reference {
+			diffs, err := schemaDriftDiffs(ctx, shard.schema, reference.schema)
+			if err != nil {
+				shard.drift.Error = err.Error()
+			}
+			shard.drift.Diffs = diffs
+		}
+		resp.Shards = append(resp.Shards, shard.drift)
+	}
+	return resp, nil
+}
+
+// readShardSchema reads the schema of the given shard from its primary.
+func readShardSchema(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, env *schemadiff.Environment, req *vtctldatapb.GetSchemaDriftRequest, shard *shardSchema) error {
+	si, err := ts.GetShard(ctx, req.Keyspace, shard.drift.Shard)
+	if err != nil {
+		return err
+	}
+	if !si.HasPrimary() {
+		return fmt.Errorf("shard %v/%v has no primary", req.Keyspace, shard.drift.Shard)
+	}
+	shard.drift.PrimaryAlias = si.PrimaryAlias
+	sd, err := GetSchema(ctx, ts, tmc, si.PrimaryAlias, &tabletmanagerdatapb.GetSchemaRequest{
+		IncludeViews:    req.IncludeViews,
+		TableSchemaOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	queries := make([]string, 0, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		if schema.IsInternalOperationTableName(td.Name) {
+			continue
+		}
+		queries = append(queries, td.Schema)
+	}
+	shard.schema, err = schemadiff.NewSchemaFromQueries(env, queries)
+	return err
+}
+
+// canonicalShardSchema returns the shard whose schema is canonical: either the named reference shard,
+// or else the first of the largest group of shards with identical schemas.
+func canonicalShardSchema(ctx context.Context, shards []*shardSchema, referenceShard string) *shardSchema {
+	if referenceShard != "" {
+		for _, shard := range shards {
+			if shard.drift.Shard == referenceShard {
+				return shard
+			}
+		}
+		return nil
+	}
+	var representatives []*shardSchema
+	counts := map[*shardSchema]int{}
+	for _, shard := range shards {
+		if shard.schema == nil {
+			continue
+		}
+		found := false
+		for _, representative := range representatives {
+			if diffs, err := schemaDriftDiffs(ctx, shard.schema, representative.schema); err == nil && len(diffs) == 0 {
+				counts[representative]++
+				found = true
+				break
+			}
+		}
+		if !found {
+			representatives = append(representatives, shard)
+			counts[shard] = 1
+		}
+	}
+	var canonical *shardSchema
+	for _, representative := range representatives {
+		if canonical == nil || counts[representative] > counts[canonical] {
+			canonical = representative
+		}
+	}
+	return canonical
+}
+
+// schemaDriftDiffs returns the diffs that take the from schema into the to schema, in a valid
+// order of execution. If there is no valid order, the diffs are returned unordered along with
+// the error.
+func schemaDriftDiffs(ctx context.Context, from *schemadiff.Schema, to *schemadiff.Schema) ([]*vtctldatapb.SchemaDriftDiff, error) {
+	schemaDiff, err := from.SchemaDiff(to, schemadiff.EmptyDiffHints())
+	if err != nil {
+		return nil, err
+	}
+	entityDiffs, err := schemaDiff.OrderedDiffs(ctx)
+	if err != nil {
+		// No valid order. Still report the diffs.
+		entityDiffs = schemaDiff.UnorderedDiffs()
+	}
+	var diffs []*vtctldatapb.SchemaDriftDiff
+	for _, diff := range entityDiffs {
+		for ; diff != nil && !diff.IsEmpty(); diff = diff.SubsequentDiff() {
+			diffs = append(diffs, &vtctldatapb.SchemaDriftDiff{
+				Entity:    diff.EntityName(),
+				Statement: diff.CanonicalStatementString(),
+			})
+		}
+	}
+	return diffs, err
+}
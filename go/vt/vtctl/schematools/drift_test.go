@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schematools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestGetSchemaDrift(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	shards := []string{"-40", "40-80", "80-c0", "c0-"}
+	for i, shard := range shards {
+		testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uint32(100 + i)},
+			Keyspace: "ks",
+			Shard:    shard,
+			Type:     topodatapb.TabletType_PRIMARY,
+		}, &testutil.AddTabletOptions{AlsoSetShardPrimary: true})
+	}
+
+	schemaDefinition := func(queries ...string) struct {
+		Schema *tabletmanagerdatapb.SchemaDefinition
+		Error  error
+	} {
+		sd := &tabletmanagerdatapb.SchemaDefinition{}
+		for _, query := range queries {
+			sd.TableDefinitions = append(sd.TableDefinitions, &tabletmanagerdatapb.TableDefinition{Schema: query})
+		}
+		return struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{Schema: sd}
+	}
+	canonical := schemaDefinition("create table t1 (id int primary key, name varchar(10))")
+	drifted := schemaDefinition("create table t1 (id int primary key)", "create table t2 (id int primary key)")
+	withInternalTable := schemaDefinition("create table t1 (id int primary key, name varchar(10))")
+	withInternalTable.Schema.TableDefinitions = append(withInternalTable.Schema.TableDefinitions, &tabletmanagerdatapb.TableDefinition{
+		Name:   "_vt_hld_6ace8bcef73211ea87e9f875a4d24e90_20200915120410_",
+		Schema: "create table _vt_hld_6ace8bcef73211ea87e9f875a4d24e90_20200915120410_ (id int primary key)",
+	})
+
+	tests := []struct {
+		name    string
+		schemas map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}
+		referenceShard string
+		expectedRef    string
+		expectedDiffs  map[string][]string
+		expectedErrors []string
+		wantErr        bool
+	}{
+		{
+			name: "majority is canonical",
+			schemas: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": canonical,
+				"zone1-0000000101": drifted,
+				"zone1-0000000102": canonical,
+				"zone1-0000000103": canonical,
+			},
+			expectedRef: "-40",
+			expectedDiffs: map[string][]string{
+				"40-80": {"DROP TABLE `t2`", "ALTER TABLE `t1` ADD COLUMN `name` varchar(10)"},
+			},
+		},
+		{
+			name: "reference shard",
+			schemas: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": canonical,
+				"zone1-0000000101": drifted,
+				"zone1-0000000102": canonical,
+				"zone1-0000000103": canonical,
+			},
+			referenceShard: "40-80",
+			expectedRef:    "40-80",
+			expectedDiffs: map[string][]string{
+				"-40":   {"ALTER TABLE `t1` DROP COLUMN `name`", "CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)"},
+				"80-c0": {"ALTER TABLE `t1` DROP COLUMN `name`", "CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)"},
+				"c0-":   {"ALTER TABLE `t1` DROP COLUMN `name`", "CREATE TABLE `t2` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)"},
+			},
+		},
+		{
+			name: "internal operation tables",
+			schemas: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": canonical,
+				"zone1-0000000101": withInternalTable,
+				"zone1-0000000102": canonical,
+				"zone1-0000000103": withInternalTable,
+			},
+			expectedRef: "-40",
+		},
+		{
+			name: "unreadable shard",
+			schemas: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": drifted,
+				"zone1-0000000101": canonical,
+				"zone1-0000000102": canonical,
+			},
+			expectedRef: "40-80",
+			expectedDiffs: map[string][]string{
+				"-40": {"DROP TABLE `t2`", "ALTER TABLE `t1` ADD COLUMN `name` varchar(10)"},
+			},
+			expectedErrors: []string{"c0-"},
+		},
+		{
+			name: "unknown reference shard",
+			schemas: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": canonical,
+			},
+			referenceShard: "80-",
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmc := &testutil.TabletManagerClient{GetSchemaResults: tt.schemas}
+			resp, err := GetSchemaDrift(ctx, ts, tmc, schemadiff.NewTestEnv(), &vtctldatapb.GetSchemaDriftRequest{
+				Keyspace:       "ks",
+				ReferenceShard: tt.referenceShard,
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRef, resp.ReferenceShard)
+			require.Len(t, resp.Shards, len(shards))
+
+			for i, drift := range resp.Shards {
+				assert.Equal(t, shards[i], drift.Shard)
+				if drift.Error != "" {
+					assert.Contains(t, tt.expectedErrors, drift.Shard, "unexpected error on shard %s: %s", drift.Shard, drift.Error)
+					continue
+				}
+				assert.NotContains(t, tt.expectedErrors, drift.Shard)
+				var statements []string
+				for _, diff := range drift.Diffs {
+					statements = append(statements, diff.Statement)
+				}
+				assert.Equal(t, tt.expectedDiffs[drift.Shard], statements, "shard %s", drift.Shard)
+			}
+		})
+	}
+}
@@ -0,0 +1,850 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// DefaultLookupVindexVerifyMaxSampleRows is the default maximum number of
+	// sample rows reported for each kind of difference.
+	DefaultLookupVindexVerifyMaxSampleRows = 10
+
+	// lookupVindexVerifyMaxRows is the maximum number of rows read from a
+	// primary tablet to check the rows of a single 'from' value again.
+	lookupVindexVerifyMaxRows = 10000
+)
+
+// lookupVindexVerifier compares the lookup table of an owned Lookup Vindex
+// with the projection of its owner table onto it, the way VDiff compares the
+// tables of a workflow: each shard of both tables is streamed from a
+// consistent snapshot on a tablet, the shards are merged with
+// engine.MergeSort and the two sorted streams are compared. As the owner and
+// lookup tables are not read at the same point in time, each difference is
+// checked again on the primary tablets, and only the differences that are
+// still present are reported and repaired.
+type lookupVindexVerifier struct {
+	s    *Server
+	req  *vtctldatapb.LookupVindexVerifyRequest
+	resp *vtctldatapb.LookupVindexVerifyResponse
+
+	cells       []string
+	tabletTypes string
+
+	vInfo        *vindexInfo
+	unique       bool
+	ownerColumns []string
+	// primaryVindex and primaryColumns are used to compute the keyspace
+	// ids of the owner table rows.
+	primaryVindex  vindexes.Vindex
+	primaryColumns []string
+	// lookupVindex and lookupColumns are used to find the shard of the
+	// lookup table that a 'from' value belongs to. lookupVindex is nil
+	// when the lookup table is not sharded.
+	lookupVindex  vindexes.Vindex
+	lookupColumns []int
+	// collations are the collations of the 'from' columns of the lookup
+	// table. Both tables are sorted and compared using them, so that for
+	// instance 'a' and 'A' are the same value in a case insensitive column.
+	collations []collations.ID
+	// ownerExprs select the 'from' columns of the owner table converted to
+	// the collations of the lookup table.
+	ownerExprs []string
+
+	ownerShards  []*topo.ShardInfo
+	lookupShards []*topo.ShardInfo
+	// primaries caches the primary tablets, keyed by tablet alias.
+	primaries map[string]*topo.TabletInfo
+}
+
+func newLookupVindexVerifier(s *Server, req *vtctldatapb.LookupVindexVerifyRequest) *lookupVindexVerifier {
+	return &lookupVindexVerifier{
+		s:         s,
+		req:       req,
+		primaries: make(map[string]*topo.TabletInfo),
+	}
+}
+
+// init loads the definitions of the Lookup Vindex, of its owner table and of
+// its lookup table.
+func (lvv *lookupVindexVerifier) init(ctx context.Context) error {
+	vs, err := lvv.s.ts.GetVSchema(ctx, lvv.req.Keyspace)
+	if err != nil {
+		return err
+	}
+	vindex, ok := vs.Vindexes[lvv.req.Name]
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "vindex %s not found in the %s keyspace", lvv.req.Name, lvv.req.Keyspace)
+	}
+	if vindex.Owner == "" {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vindex %s has no owner", lvv.req.Name)
+	}
+	switch vindex.Type {
+	case "lookup", "lookup_unique", "consistent_lookup", "consistent_lookup_unique":
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "vindex type %s is not supported, the lookup table must store keyspace ids", vindex.Type)
+	}
+
+	// validateAndGetVindexInfo marks the vindex as write only, so work
+	// on a copy.
+	lvv.vInfo, err = newLookupVindex(lvv.s).validateAndGetVindexInfo(lvv.req.Name, proto.Clone(vindex).(*vschemapb.Vindex), vs.Tables)
+	if err != nil {
+		return err
+	}
+	lvv.unique = strings.HasSuffix(vindex.Type, "_unique")
+	lvv.vInfo.sourceTableName = vindex.Owner
+	lvv.vInfo.sourceTable, ok = vs.Tables[vindex.Owner]
+	if !ok || len(lvv.vInfo.sourceTable.ColumnVindexes) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "owner table %s of vindex %s has no primary vindex", vindex.Owner, lvv.req.Name)
+	}
+	idx := slices.IndexFunc(lvv.vInfo.sourceTable.ColumnVindexes, func(cv *vschemapb.ColumnVindex) bool {
+		return cv.Name == lvv.req.Name
+	})
+	if idx < 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "column vindex %s not found in the owner table %s", lvv.req.Name, vindex.Owner)
+	}
+	if lvv.ownerColumns, err = getSourceVindexColumns(lvv.vInfo, lvv.vInfo.sourceTable.ColumnVindexes[idx]); err != nil {
+		return err
+	}
+	lvv.primaryVindex, lvv.primaryColumns, err = createColumnVindex(vs.Keyspace, lvv.vInfo.sourceTable.ColumnVindexes[0])
+	if err != nil {
+		return err
+	}
+
+	targetVSchema := vs
+	if lvv.vInfo.targetKeyspace != lvv.req.Keyspace {
+		if targetVSchema, err = lvv.s.ts.GetVSchema(ctx, lvv.vInfo.targetKeyspace); err != nil {
+			return err
+		}
+	}
+	if targetVSchema.Sharded {
+		targetTable, ok := targetVSchema.Tables[lvv.vInfo.targetTableName]
+		if !ok || len(targetTable.ColumnVindexes) == 0 {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "lookup table %s.%s has no primary vindex",
+				lvv.vInfo.targetKeyspace, lvv.vInfo.targetTableName)
+		}
+		lookupVindex, lookupColumns, err := createColumnVindex(targetVSchema.Keyspace, targetTable.ColumnVindexes[0])
+		if err != nil {
+			return err
+		}
+		for _, col := range lookupColumns {
+			i := slices.IndexFunc(lvv.vInfo.fromCols, func(from string) bool { return strings.EqualFold(from, col) })
+			if i < 0 {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "primary vindex column %s of lookup table %s is not a 'from' column of vindex %s",
+					col, lvv.vInfo.targetTableName, lvv.req.Name)
+			}
+			lvv.lookupColumns = append(lvv.lookupColumns, i)
+		}
+		lvv.lookupVindex = lookupVindex
+	}
+
+	if lvv.ownerShards, err = lvv.s.ts.GetServingShards(ctx, lvv.req.Keyspace); err != nil {
+		return err
+	}
+	if lvv.lookupShards, err = lvv.s.ts.GetServingShards(ctx, lvv.vInfo.targetKeyspace); err != nil {
+		return err
+	}
+	if err := lvv.initCollations(ctx); err != nil {
+		return err
+	}
+
+	lvv.cells = lvv.req.Cells
+	if len(lvv.cells) == 0 {
+		if lvv.cells, err = lvv.s.ts.GetCellInfoNames(ctx); err != nil {
+			return err
+		}
+	}
+	// Both tables are read in full, so prefer the replicas to the primaries.
+	lvv.tabletTypes = discovery.InOrderHint + "replica,rdonly,primary"
+	if len(lvv.req.TabletTypes) > 0 {
+		lvv.tabletTypes = discovery.BuildTabletTypesString(lvv.req.TabletTypes, lvv.req.TabletSelectionPreference)
+	}
+	lvv.resp = &vtctldatapb.LookupVindexVerifyResponse{
+		OwnerTable:  lvv.vInfo.sourceTableName,
+		LookupTable: fmt.Sprintf("%s.%s", lvv.vInfo.targetKeyspace, lvv.vInfo.targetTableName),
+	}
+	return nil
+}
+
+// createColumnVindex creates the functional vindex of a column vindex so that
+// keyspace ids can be computed without a vtgate.
+func createColumnVindex(vs *vschemapb.Keyspace, cv *vschemapb.ColumnVindex) (vindexes.Vindex, []string, error) {
+	vdef, ok := vs.GetVindexes()[cv.Name]
+	if !ok {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "vindex %s not found", cv.Name)
+	}
+	vindex, err := vindexes.CreateVindex(vdef.Type, cv.Name, vdef.Params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vindex.NeedsVCursor() || !vindex.IsUnique() {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "primary vindex %s must be a functional unique vindex", cv.Name)
+	}
+	columns := cv.Columns
+	if len(columns) == 0 {
+		columns = []string{cv.Column}
+	}
+	return vindex, columns, nil
+}
+
+// initCollations reads the 'from' columns of the owner and lookup tables from
+// their primary tablets, and sets the collations used to sort and compare
+// them.
+func (lvv *lookupVindexVerifier) initCollations(ctx context.Context) error {
+	ownerFields, err := lvv.tableFields(ctx, lvv.ownerShards[0], lvv.vInfo.sourceTableName, lvv.ownerColumns)
+	if err != nil {
+		return err
+	}
+	lookupFields, err := lvv.tableFields(ctx, lvv.lookupShards[0], lvv.vInfo.targetTableName, lvv.vInfo.fromCols)
+	if err != nil {
+		return err
+	}
+	collationEnv := lvv.s.env.CollationEnv()
+	for i, lookupField := range lookupFields {
+		ownerField := ownerFields[i]
+		if !(sqltypes.IsNumber(ownerField.Type) && sqltypes.IsNumber(lookupField.Type)) &&
+			!(sqltypes.IsTextOrBinary(ownerField.Type) && sqltypes.IsTextOrBinary(lookupField.Type)) &&
+			ownerField.Type != lookupField.Type {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "column %s of the owner table %s and column %s of the lookup table %s cannot be compared, their types are %s and %s",
+				ownerField.Name, lvv.vInfo.sourceTableName, lookupField.Name, lvv.vInfo.targetTableName, ownerField.Type, lookupField.Type)
+		}
+		coll := fieldCollation(lookupField)
+		lvv.collations = append(lvv.collations, coll)
+
+		expr := sqlescape.EscapeID(lvv.ownerColumns[i])
+		switch {
+		case fieldCollation(ownerField) == coll || !sqltypes.IsTextOrBinary(ownerField.Type):
+		case coll == collations.CollationBinaryID:
+			expr = fmt.Sprintf("convert(%s using binary)", expr)
+		default:
+			expr = fmt.Sprintf("convert(%s using %s) collate %s", expr, collationEnv.LookupCharsetName(coll), collationEnv.LookupName(coll))
+		}
+		lvv.ownerExprs = append(lvv.ownerExprs, expr)
+	}
+	return nil
+}
+
+// fieldCollation returns the collation of field, which is binary when the
+// tablet did not report one.
+func fieldCollation(field *querypb.Field) collations.ID {
+	if coll := collations.ID(field.Charset); coll != collations.Unknown {
+		return coll
+	}
+	return collations.CollationBinaryID
+}
+
+// tableFields returns the fields of the given columns of a table, as read
+// from the primary tablet of shard.
+func (lvv *lookupVindexVerifier) tableFields(ctx context.Context, shard *topo.ShardInfo, table string, columns []string) ([]*querypb.Field, error) {
+	tablet, err := lvv.primary(ctx, shard)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := lvv.s.tmc.GetSchema(ctx, tablet.Tablet, &tabletmanagerdatapb.GetSchemaRequest{Tables: []string{table}})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get the schema of table %s on tablet %s", table, tablet.AliasString())
+	}
+	if len(schema.GetTableDefinitions()) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "table %s not found on tablet %s", table, tablet.AliasString())
+	}
+	tableFields := schema.TableDefinitions[0].Fields
+	fields := make([]*querypb.Field, 0, len(columns))
+	for _, col := range columns {
+		i := slices.IndexFunc(tableFields, func(field *querypb.Field) bool { return strings.EqualFold(field.Name, col) })
+		if i < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "column %s not found in table %s on tablet %s", col, table, tablet.AliasString())
+		}
+		fields = append(fields, tableFields[i])
+	}
+	return fields, nil
+}
+
+// primary returns the primary tablet of shard.
+func (lvv *lookupVindexVerifier) primary(ctx context.Context, shard *topo.ShardInfo) (*topo.TabletInfo, error) {
+	if shard.PrimaryAlias == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", shard.Keyspace(), shard.ShardName())
+	}
+	alias := topoproto.TabletAliasString(shard.PrimaryAlias)
+	if tablet, ok := lvv.primaries[alias]; ok {
+		return tablet, nil
+	}
+	tablet, err := lvv.s.ts.GetTablet(ctx, shard.PrimaryAlias)
+	if err != nil {
+		return nil, err
+	}
+	lvv.primaries[alias] = tablet
+	return tablet, nil
+}
+
+// verify compares the lookup table with the projection of the owner table and
+// returns the differences that are still present on the primary tablets,
+// repairing them if requested.
+func (lvv *lookupVindexVerifier) verify(ctx context.Context) (*vtctldatapb.LookupVindexVerifyResponse, error) {
+	// Stop the streams if the comparison ends early.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var query strings.Builder
+	query.WriteString("select ")
+	query.WriteString(strings.Join(lvv.ownerExprs, ", "))
+	for _, col := range lvv.primaryColumns {
+		fmt.Fprintf(&query, ", %s", sqlescape.EscapeID(col))
+	}
+	fmt.Fprintf(&query, " from %s", sqlescape.EscapeID(lvv.vInfo.sourceTableName))
+	if lvv.vInfo.ignoreNulls {
+		for i, col := range lvv.ownerColumns {
+			if i == 0 {
+				query.WriteString(" where ")
+			} else {
+				query.WriteString(" and ")
+			}
+			fmt.Fprintf(&query, "%s is not null", sqlescape.EscapeID(col))
+		}
+	}
+	fmt.Fprintf(&query, " order by %s", strings.Join(lvv.ownerExprs, ", "))
+	owner, err := lvv.streamTable(ctx, lvv.req.Keyspace, lvv.ownerShards, query.String())
+	if err != nil {
+		return nil, err
+	}
+
+	fromCols := make([]string, 0, len(lvv.vInfo.fromCols))
+	for _, col := range lvv.vInfo.fromCols {
+		fromCols = append(fromCols, sqlescape.EscapeID(col))
+	}
+	lookup, err := lvv.streamTable(ctx, lvv.vInfo.targetKeyspace, lvv.lookupShards,
+		fmt.Sprintf("select %s, %s from %s order by %s", strings.Join(fromCols, ", "), sqlescape.EscapeID(lvv.vInfo.toCol),
+			sqlescape.EscapeID(lvv.vInfo.targetTableName), strings.Join(fromCols, ", ")))
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(lvv.vInfo.fromCols)
+	ownerKsid := func(row []sqltypes.Value) ([]byte, error) {
+		dests, err := vindexes.Map(ctx, lvv.primaryVindex, nil, [][]sqltypes.Value{row[n:]})
+		if err != nil {
+			return nil, err
+		}
+		ksid, ok := dests[0].(key.DestinationKeyspaceID)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "could not map row %v of the owner table %s to a keyspace id", row, lvv.vInfo.sourceTableName)
+		}
+		return ksid, nil
+	}
+	lookupKsid := func(row []sqltypes.Value) ([]byte, error) {
+		return row[n].ToBytes()
+	}
+
+	ownerGroup, err := lvv.nextGroup(owner, lvv.vInfo.sourceTableName, ownerKsid)
+	if err != nil {
+		return nil, err
+	}
+	lookupGroup, err := lvv.nextGroup(lookup, lvv.vInfo.targetTableName, lookupKsid)
+	if err != nil {
+		return nil, err
+	}
+	for ownerGroup != nil || lookupGroup != nil {
+		var c int
+		switch {
+		case ownerGroup == nil:
+			c = 1
+		case lookupGroup == nil:
+			c = -1
+		default:
+			if c, err = lvv.compareFrom(ownerGroup.from, lookupGroup.from); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case c < 0:
+			err = lvv.diff(ctx, ownerGroup.from, ownerGroup.ksids, nil)
+		case c > 0:
+			err = lvv.diff(ctx, lookupGroup.from, nil, lookupGroup.ksids)
+		default:
+			err = lvv.diff(ctx, lookupGroup.from, ownerGroup.ksids, lookupGroup.ksids)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c <= 0 {
+			if ownerGroup, err = lvv.nextGroup(owner, lvv.vInfo.sourceTableName, ownerKsid); err != nil {
+				return nil, err
+			}
+		}
+		if c >= 0 {
+			if lookupGroup, err = lvv.nextGroup(lookup, lvv.vInfo.targetTableName, lookupKsid); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return lvv.resp, nil
+}
+
+// lookupVindexShardStreamer streams the rows of a table from one shard. The
+// rows are read by a single select statement, which InnoDB serves from a
+// consistent snapshot. lookupVindexShardStreamer satisfies
+// engine.StreamExecutor, so that the shards can be merged by engine.MergeSort.
+type lookupVindexShardStreamer struct {
+	tablet *topodatapb.Tablet
+	query  string
+}
+
+var _ engine.StreamExecutor = (*lookupVindexShardStreamer)(nil)
+
+// StreamExecute implements the engine.StreamExecutor interface.
+func (ss *lookupVindexShardStreamer) StreamExecute(ctx context.Context, _ engine.VCursor, _ map[string]*querypb.BindVariable, _, _ bool, callback func(*sqltypes.Result) error) error {
+	conn, err := tabletconn.GetDialer()(ctx, ss.tablet, grpcclient.FailFast(false))
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	target := &querypb.Target{
+		Keyspace:   ss.tablet.Keyspace,
+		Shard:      ss.tablet.Shard,
+		TabletType: ss.tablet.Type,
+	}
+	err = conn.StreamExecute(ctx, target, ss.query, nil, 0, 0, nil, callback)
+	return vterrors.Wrapf(err, "failed to stream %s from tablet %s", ss.query, topoproto.TabletAliasString(ss.tablet.Alias))
+}
+
+// lookupVindexRowStream iterates over the rows of a table, merged from all of
+// its shards in the order of the 'from' columns.
+type lookupVindexRowStream struct {
+	rows     [][]sqltypes.Value
+	resultch chan *sqltypes.Result
+	err      error
+}
+
+// streamTable picks a tablet in each shard and starts streaming the rows of
+// query from them.
+func (lvv *lookupVindexVerifier) streamTable(ctx context.Context, keyspace string, shards []*topo.ShardInfo, query string) (*lookupVindexRowStream, error) {
+	streamers := make([]engine.StreamExecutor, 0, len(shards))
+	for _, shard := range shards {
+		tp, err := discovery.NewTabletPicker(ctx, lvv.s.ts, lvv.cells, "", keyspace, shard.ShardName(), lvv.tabletTypes,
+			discovery.TabletPickerOptions{CellPreference: "OnlySpecified"})
+		if err != nil {
+			return nil, err
+		}
+		pickCtx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+		tablet, err := tp.PickForStreaming(pickCtx)
+		cancel()
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to find a tablet to stream from in shard %s/%s", keyspace, shard.ShardName())
+		}
+		streamers = append(streamers, &lookupVindexShardStreamer{tablet: tablet, query: query})
+	}
+	orderBy := make([]evalengine.OrderByParams, 0, len(lvv.collations))
+	for i, coll := range lvv.collations {
+		orderBy = append(orderBy, evalengine.OrderByParams{
+			Col:             i,
+			WeightStringCol: -1,
+			Type:            evalengine.NewType(sqltypes.Unknown, coll),
+			CollationEnv:    lvv.s.env.CollationEnv(),
+		})
+	}
+	ms := &engine.MergeSort{
+		Primitives: streamers,
+		OrderBy:    orderBy,
+	}
+
+	rs := &lookupVindexRowStream{resultch: make(chan *sqltypes.Result, 1)}
+	go func() {
+		defer close(rs.resultch)
+		// The merge sorter only passes the vcursor on to the streamers,
+		// which do not use it.
+		rs.err = ms.TryStreamExecute(ctx, nil, nil, false, func(qr *sqltypes.Result) error {
+			select {
+			case rs.resultch <- qr:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return rs, nil
+}
+
+// peek returns the next row of the stream without consuming it, or nil at
+// the end of the stream.
+func (rs *lookupVindexRowStream) peek() ([]sqltypes.Value, error) {
+	for len(rs.rows) == 0 {
+		qr, ok := <-rs.resultch
+		if !ok {
+			return nil, rs.err
+		}
+		rs.rows = qr.Rows
+	}
+	return rs.rows[0], nil
+}
+
+// lookupVindexGroup holds the distinct keyspace ids of the rows of a table
+// that have the same 'from' values.
+type lookupVindexGroup struct {
+	from  []sqltypes.Value
+	ksids [][]byte
+}
+
+// nextGroup returns the next group of rows of a stream, or nil at the end of
+// the stream. ksid returns the keyspace id of a row.
+func (lvv *lookupVindexVerifier) nextGroup(rs *lookupVindexRowStream, table string, ksid func(row []sqltypes.Value) ([]byte, error)) (*lookupVindexGroup, error) {
+	n := len(lvv.vInfo.fromCols)
+	var group *lookupVindexGroup
+	for {
+		row, err := rs.peek()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			break
+		}
+		if group == nil {
+			group = &lookupVindexGroup{from: row[:n]}
+		} else {
+			c, err := lvv.compareFrom(group.from, row[:n])
+			if err != nil {
+				return nil, err
+			}
+			if c > 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "the rows of table %s are not sorted in the collations of the lookup table: %v after %v",
+					table, row[:n], group.from)
+			}
+			if c < 0 {
+				break
+			}
+		}
+		rs.rows = rs.rows[1:]
+		k, err := ksid(row)
+		if err != nil {
+			return nil, err
+		}
+		group.ksids = append(group.ksids, k)
+	}
+	if group != nil {
+		slices.SortFunc(group.ksids, bytes.Compare)
+		group.ksids = slices.CompactFunc(group.ksids, bytes.Equal)
+	}
+	return group, nil
+}
+
+// compareFrom compares two 'from' values in the collations of the lookup
+// table.
+func (lvv *lookupVindexVerifier) compareFrom(a, b []sqltypes.Value) (int, error) {
+	for i, coll := range lvv.collations {
+		c, err := evalengine.NullsafeCompare(a[i], b[i], lvv.s.env.CollationEnv(), coll, nil)
+		if err != nil || c != 0 {
+			return c, err
+		}
+	}
+	return 0, nil
+}
+
+// diff compares the keyspace ids of the owner and lookup rows that have the
+// same 'from' values. The rows missing from the lookup table and the orphaned
+// rows of the lookup table are checked again on the primary tablets, and only
+// those still present are reported and repaired.
+func (lvv *lookupVindexVerifier) diff(ctx context.Context, from []sqltypes.Value, ownerKsids, lookupKsids [][]byte) error {
+	lvv.resp.OwnerRows += int64(len(ownerKsids))
+	lvv.resp.LookupRows += int64(len(lookupKsids))
+	missing := subtractKeyspaceIDs(ownerKsids, lookupKsids)
+	orphaned := subtractKeyspaceIDs(lookupKsids, ownerKsids)
+	lvv.resp.MatchingRows += int64(len(ownerKsids) - len(missing))
+	if len(missing) == 0 && len(orphaned) == 0 {
+		return nil
+	}
+
+	primary, err := lvv.lookupPrimary(ctx, from)
+	if err != nil {
+		return err
+	}
+	current, err := lvv.lookupKeyspaceIDs(ctx, primary, from)
+	if err != nil {
+		return err
+	}
+	var stillMissing, stillOrphaned [][]byte
+	for _, ksid := range missing {
+		if current[string(ksid)] {
+			continue
+		}
+		exists, err := lvv.ownerRowExists(ctx, from, ksid)
+		if err != nil {
+			return err
+		}
+		if exists {
+			stillMissing = append(stillMissing, ksid)
+		}
+	}
+	for _, ksid := range orphaned {
+		if !current[string(ksid)] {
+			continue
+		}
+		exists, err := lvv.ownerRowExists(ctx, from, ksid)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			stillOrphaned = append(stillOrphaned, ksid)
+		}
+	}
+	// A unique lookup table row that points to the wrong keyspace id is
+	// both missing and orphaned.
+	var mismatched [][2][]byte
+	if lvv.unique {
+		for len(stillMissing) > 0 && len(stillOrphaned) > 0 {
+			mismatched = append(mismatched, [2][]byte{stillMissing[0], stillOrphaned[0]})
+			stillMissing, stillOrphaned = stillMissing[1:], stillOrphaned[1:]
+		}
+	}
+
+	maxSampleRows := int(max(lvv.req.MaxSampleRows, 0))
+	for _, ksid := range stillOrphaned {
+		if len(lvv.resp.OrphanedRowsSample) < maxSampleRows {
+			lvv.resp.OrphanedRowsSample = append(lvv.resp.OrphanedRowsSample, lvv.sampleRow(from, ksid))
+		}
+	}
+	for _, ksid := range stillMissing {
+		if len(lvv.resp.MissingRowsSample) < maxSampleRows {
+			lvv.resp.MissingRowsSample = append(lvv.resp.MissingRowsSample, lvv.sampleRow(from, ksid))
+		}
+	}
+	for _, pair := range mismatched {
+		if len(lvv.resp.MismatchedRowsSample) < maxSampleRows {
+			lvv.resp.MismatchedRowsSample = append(lvv.resp.MismatchedRowsSample, &vtctldatapb.LookupVindexVerifyResponse_MismatchedRow{
+				Owner:  lvv.sampleRow(from, pair[0]),
+				Lookup: lvv.sampleRow(from, pair[1]),
+			})
+		}
+	}
+	lvv.resp.OrphanedRows += int64(len(stillOrphaned))
+	lvv.resp.MissingRows += int64(len(stillMissing))
+	lvv.resp.MismatchedRows += int64(len(mismatched))
+
+	if !lvv.req.Repair {
+		return nil
+	}
+	return lvv.repair(ctx, primary, from, stillOrphaned, stillMissing, mismatched)
+}
+
+// subtractKeyspaceIDs returns the keyspace ids of a that are not in b. Both
+// must be sorted.
+func subtractKeyspaceIDs(a, b [][]byte) [][]byte {
+	var res [][]byte
+	for _, ksid := range a {
+		for len(b) > 0 && bytes.Compare(b[0], ksid) < 0 {
+			b = b[1:]
+		}
+		if len(b) == 0 || !bytes.Equal(b[0], ksid) {
+			res = append(res, ksid)
+		}
+	}
+	return res
+}
+
+// sampleRow returns the sample of a lookup table row.
+func (lvv *lookupVindexVerifier) sampleRow(from []sqltypes.Value, ksid []byte) *vtctldatapb.LookupVindexVerifyResponse_Row {
+	row := &vtctldatapb.LookupVindexVerifyResponse_Row{Values: make(map[string]string, len(from)+1)}
+	for i, col := range lvv.vInfo.fromCols {
+		row.Values[col] = from[i].ToString()
+	}
+	row.Values[lvv.vInfo.toCol] = hex.EncodeToString(ksid)
+	return row
+}
+
+// repair fixes the differences of a 'from' value on the primary tablet of the
+// lookup table.
+func (lvv *lookupVindexVerifier) repair(ctx context.Context, primary *topo.TabletInfo, from []sqltypes.Value, orphaned, missing [][]byte, mismatched [][2][]byte) error {
+	table := sqlescape.EscapeID(lvv.vInfo.targetTableName)
+	toCol := sqlescape.EscapeID(lvv.vInfo.toCol)
+	fromCols := make([]string, 0, len(lvv.vInfo.fromCols))
+	for _, col := range lvv.vInfo.fromCols {
+		fromCols = append(fromCols, sqlescape.EscapeID(col))
+	}
+	where := nullSafeWhere(fromCols, from)
+
+	var queries []string
+	for _, ksid := range orphaned {
+		queries = append(queries, fmt.Sprintf("delete from %s where %s and %s = %s", table, where, toCol, ksidSQL(ksid)))
+	}
+	for _, pair := range mismatched {
+		queries = append(queries, fmt.Sprintf("update %s set %s = %s where %s and %s = %s", table, toCol, ksidSQL(pair[0]), where, toCol, ksidSQL(pair[1])))
+	}
+	for _, ksid := range missing {
+		var values strings.Builder
+		for _, v := range from {
+			v.EncodeSQLStringBuilder(&values)
+			values.WriteString(", ")
+		}
+		values.WriteString(ksidSQL(ksid))
+		queries = append(queries, fmt.Sprintf("insert into %s (%s, %s) values (%s)", table, strings.Join(fromCols, ", "), toCol, values.String()))
+	}
+	for _, query := range queries {
+		qr, err := lvv.executeFetch(ctx, primary, query, 0)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to repair the lookup table %s", lvv.resp.LookupTable)
+		}
+		lvv.resp.RepairedRows += int64(qr.RowsAffected)
+	}
+	return nil
+}
+
+// lookupPrimary returns the primary tablet of the lookup table shard that a
+// 'from' value belongs to.
+func (lvv *lookupVindexVerifier) lookupPrimary(ctx context.Context, from []sqltypes.Value) (*topo.TabletInfo, error) {
+	if lvv.lookupVindex == nil {
+		return lvv.primary(ctx, lvv.lookupShards[0])
+	}
+	colValues := make([]sqltypes.Value, 0, len(lvv.lookupColumns))
+	for _, i := range lvv.lookupColumns {
+		colValues = append(colValues, from[i])
+	}
+	dests, err := vindexes.Map(ctx, lvv.lookupVindex, nil, [][]sqltypes.Value{colValues})
+	if err != nil {
+		return nil, err
+	}
+	ksid, ok := dests[0].(key.DestinationKeyspaceID)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "could not map %v to a shard of the lookup table %s", colValues, lvv.vInfo.targetTableName)
+	}
+	for _, shard := range lvv.lookupShards {
+		if key.KeyRangeContains(shard.KeyRange, ksid) {
+			return lvv.primary(ctx, shard)
+		}
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no shard of keyspace %s contains keyspace id %x", lvv.vInfo.targetKeyspace, []byte(ksid))
+}
+
+// lookupKeyspaceIDs returns the keyspace ids that the lookup table currently
+// holds for a 'from' value.
+func (lvv *lookupVindexVerifier) lookupKeyspaceIDs(ctx context.Context, primary *topo.TabletInfo, from []sqltypes.Value) (map[string]bool, error) {
+	fromCols := make([]string, 0, len(lvv.vInfo.fromCols))
+	for _, col := range lvv.vInfo.fromCols {
+		fromCols = append(fromCols, sqlescape.EscapeID(col))
+	}
+	query := fmt.Sprintf("select %s from %s where %s", sqlescape.EscapeID(lvv.vInfo.toCol), sqlescape.EscapeID(lvv.vInfo.targetTableName),
+		nullSafeWhere(fromCols, from))
+	qr, err := lvv.executeFetch(ctx, primary, query, lookupVindexVerifyMaxRows)
+	if err != nil {
+		return nil, err
+	}
+	ksids := make(map[string]bool, len(qr.Rows))
+	for _, row := range qr.Rows {
+		ksid, err := row[0].ToBytes()
+		if err != nil {
+			return nil, err
+		}
+		ksids[string(ksid)] = true
+	}
+	return ksids, nil
+}
+
+// ownerRowExists returns true if the owner table currently has a row with a
+// 'from' value and a keyspace id, reading it on the primary tablet of the
+// shard that the keyspace id belongs to.
+func (lvv *lookupVindexVerifier) ownerRowExists(ctx context.Context, from []sqltypes.Value, ksid []byte) (bool, error) {
+	i := slices.IndexFunc(lvv.ownerShards, func(shard *topo.ShardInfo) bool {
+		return key.KeyRangeContains(shard.KeyRange, ksid)
+	})
+	if i < 0 {
+		return false, nil
+	}
+	primary, err := lvv.primary(ctx, lvv.ownerShards[i])
+	if err != nil {
+		return false, err
+	}
+	cols := make([]string, 0, len(lvv.primaryColumns))
+	for _, col := range lvv.primaryColumns {
+		cols = append(cols, sqlescape.EscapeID(col))
+	}
+	query := fmt.Sprintf("select distinct %s from %s where %s", strings.Join(cols, ", "), sqlescape.EscapeID(lvv.vInfo.sourceTableName),
+		nullSafeWhere(lvv.ownerExprs, from))
+	qr, err := lvv.executeFetch(ctx, primary, query, lookupVindexVerifyMaxRows)
+	if err != nil {
+		return false, err
+	}
+	if len(qr.Rows) == 0 {
+		return false, nil
+	}
+	dests, err := vindexes.Map(ctx, lvv.primaryVindex, nil, qr.Rows)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(dests, func(dest key.ShardDestination) bool {
+		k, ok := dest.(key.DestinationKeyspaceID)
+		return ok && bytes.Equal(k, ksid)
+	}), nil
+}
+
+// executeFetch executes a query on a primary tablet.
+func (lvv *lookupVindexVerifier) executeFetch(ctx context.Context, primary *topo.TabletInfo, query string, maxRows uint64) (*sqltypes.Result, error) {
+	qr, err := lvv.s.tmc.ExecuteFetchAsDba(ctx, primary.Tablet, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		DbName:  primary.DbName(),
+		MaxRows: maxRows,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to execute %s on tablet %s", query, primary.AliasString())
+	}
+	return sqltypes.Proto3ToResult(qr), nil
+}
+
+// nullSafeWhere returns the condition matching the expressions to values,
+// NULL values included.
+func nullSafeWhere(exprs []string, values []sqltypes.Value) string {
+	var sb strings.Builder
+	for i, expr := range exprs {
+		if i > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(expr)
+		sb.WriteString(" <=> ")
+		values[i].EncodeSQLStringBuilder(&sb)
+	}
+	return sb.String()
+}
+
+// ksidSQL returns the hexadecimal SQL literal of a keyspace id.
+func ksidSQL(ksid []byte) string {
+	return fmt.Sprintf("X'%x'", ksid)
+}
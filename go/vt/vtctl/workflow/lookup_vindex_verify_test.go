@@ -0,0 +1,498 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/queryservice/fakes"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+	"vitess.io/vitess/go/vt/vttablet/tabletconntest"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	lookupVindexVerifyDialerOnce sync.Once
+	// lookupVindexVerifyTablets are the tablets of the current test, keyed
+	// by tablet uid.
+	lookupVindexVerifyTablets sync.Map
+)
+
+// fakeLookupVindexVerifyTablet streams the rows of the tables of one tablet.
+type fakeLookupVindexVerifyTablet struct {
+	queryservice.QueryService
+	tablet *topodatapb.Tablet
+	// results are the rows of the tables, keyed by table name.
+	results map[string]*sqltypes.Result
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func (tablet *fakeLookupVindexVerifyTablet) StreamHealth(ctx context.Context, callback func(*querypb.StreamHealthResponse) error) error {
+	return callback(&querypb.StreamHealthResponse{
+		Serving: true,
+		Target: &querypb.Target{
+			Keyspace:   tablet.tablet.Keyspace,
+			Shard:      tablet.tablet.Shard,
+			TabletType: tablet.tablet.Type,
+		},
+		RealtimeStats: &querypb.RealtimeStats{},
+	})
+}
+
+func (tablet *fakeLookupVindexVerifyTablet) StreamExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]*querypb.BindVariable, transactionID int64, reservedID int64, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) error {
+	tablet.mu.Lock()
+	tablet.queries = append(tablet.queries, sql)
+	tablet.mu.Unlock()
+	for table, result := range tablet.results {
+		if strings.Contains(sql, fmt.Sprintf(" from `%s` ", table)) {
+			if err := callback(&sqltypes.Result{Fields: result.Fields}); err != nil {
+				return err
+			}
+			return callback(&sqltypes.Result{Rows: result.Rows})
+		}
+	}
+	return fmt.Errorf("unexpected query on tablet %d: %s", tablet.tablet.Alias.Uid, sql)
+}
+
+func (tablet *fakeLookupVindexVerifyTablet) Close(ctx context.Context) error {
+	return nil
+}
+
+// fakeLookupVindexVerifyTMClient serves the schema of the tables and the
+// results of the queries executed on the primary tablets.
+type fakeLookupVindexVerifyTMClient struct {
+	tmclient.TabletManagerClient
+	fields map[string][]*querypb.Field
+	// results is keyed by query prefix. The longest matching prefix wins.
+	results map[string]*sqltypes.Result
+
+	mu sync.Mutex
+	// dmls are the non-select queries executed, prefixed by the tablet uid.
+	dmls []string
+}
+
+func (tmc *fakeLookupVindexVerifyTMClient) GetSchema(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetSchemaRequest) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	sd := &tabletmanagerdatapb.SchemaDefinition{}
+	for _, table := range req.Tables {
+		if fields, ok := tmc.fields[table]; ok {
+			sd.TableDefinitions = append(sd.TableDefinitions, &tabletmanagerdatapb.TableDefinition{Name: table, Fields: fields})
+		}
+	}
+	return sd, nil
+}
+
+func (tmc *fakeLookupVindexVerifyTMClient) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, req *tabletmanagerdatapb.ExecuteFetchAsDbaRequest) (*querypb.QueryResult, error) {
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+	query := string(req.Query)
+	if !strings.HasPrefix(query, "select ") {
+		tmc.dmls = append(tmc.dmls, fmt.Sprintf("%d: %s", tablet.Alias.Uid, query))
+		return &querypb.QueryResult{RowsAffected: 1}, nil
+	}
+	var match string
+	for prefix := range tmc.results {
+		if strings.HasPrefix(query, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return nil, fmt.Errorf("unexpected query on tablet %d: %s", tablet.Alias.Uid, query)
+	}
+	return sqltypes.ResultToProto3(tmc.results[match]), nil
+}
+
+// lookupVindexVerifyEnv serves the sharded keyspace ks, which holds the owner
+// table t1 and the lookup table t1_col_vdx. Each shard has a primary tablet
+// and a replica tablet.
+type lookupVindexVerifyEnv struct {
+	ws  *Server
+	ts  *topo.Server
+	tmc *fakeLookupVindexVerifyTMClient
+	// tablets are keyed by tablet uid: 100 and 101 serve shard -80, 200
+	// and 201 serve shard 80-.
+	tablets map[uint32]*fakeLookupVindexVerifyTablet
+}
+
+func newLookupVindexVerifyEnv(t *testing.T, ctx context.Context, vindexType string) *lookupVindexVerifyEnv {
+	lookupVindexVerifyDialerOnce.Do(func() {
+		tabletconn.RegisterDialer("LookupVindexVerifyTest", func(ctx context.Context, tablet *topodatapb.Tablet, failFast grpcclient.FailFast) (queryservice.QueryService, error) {
+			if qs, ok := lookupVindexVerifyTablets.Load(tablet.Alias.Uid); ok {
+				return qs.(queryservice.QueryService), nil
+			}
+			return nil, fmt.Errorf("tablet %d not found", tablet.Alias.Uid)
+		})
+		tabletconntest.SetProtocol("go.vt.vtctl.workflow.lookup_vindex_verify_test", "LookupVindexVerifyTest")
+	})
+
+	env := &lookupVindexVerifyEnv{
+		ts: memorytopo.NewServer(ctx, "zone1"),
+		tmc: &fakeLookupVindexVerifyTMClient{
+			fields: map[string][]*querypb.Field{
+				"t1":         sqltypes.MakeTestFields("c1|id", "varchar|int64"),
+				"t1_col_vdx": sqltypes.MakeTestFields("col|keyspace_id", "varchar|varbinary"),
+			},
+			results: map[string]*sqltypes.Result{},
+		},
+		tablets: make(map[uint32]*fakeLookupVindexVerifyTablet),
+	}
+	t.Cleanup(env.ts.Close)
+	env.ws = NewServer(vtenv.NewTestEnv(), env.ts, env.tmc)
+
+	for _, shard := range []struct {
+		name string
+		uid  uint32
+	}{{"-80", 100}, {"80-", 200}} {
+		for i, tabletType := range []topodatapb.TabletType{topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA} {
+			tablet := &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: shard.uid + uint32(i)},
+				Keyspace: "ks",
+				Shard:    shard.name,
+				Type:     tabletType,
+				PortMap:  map[string]int32{"test": int32(shard.uid) + int32(i)},
+			}
+			require.NoError(t, env.ts.InitTablet(ctx, tablet, false /* allowPrimaryOverride */, true /* createShardAndKeyspace */, false /* allowUpdate */))
+			env.tablets[tablet.Alias.Uid] = &fakeLookupVindexVerifyTablet{
+				QueryService: fakes.ErrorQueryService,
+				tablet:       tablet,
+			}
+			lookupVindexVerifyTablets.Store(tablet.Alias.Uid, env.tablets[tablet.Alias.Uid])
+		}
+		_, err := env.ts.UpdateShardFields(ctx, "ks", shard.name, func(si *topo.ShardInfo) error {
+			si.PrimaryAlias = &topodatapb.TabletAlias{Cell: "zone1", Uid: shard.uid}
+			si.IsPrimaryServing = true
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	err := env.ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: "ks",
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"hash":   {Type: "hash"},
+				"xxhash": {Type: "xxhash"},
+				"t1_col_vdx": {
+					Type: vindexType,
+					Params: map[string]string{
+						"table": "ks.t1_col_vdx",
+						"from":  "col",
+						"to":    "keyspace_id",
+					},
+					Owner: "t1",
+				},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"t1": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{
+						{Name: "hash", Column: "id"},
+						{Name: "t1_col_vdx", Column: "c1"},
+					},
+				},
+				"t1_col_vdx": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{
+						{Name: "xxhash", Column: "col"},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return env
+}
+
+// setRows sets the rows of table streamed from the replica tablet of a shard.
+func (env *lookupVindexVerifyEnv) setRows(uid uint32, table string, result *sqltypes.Result) {
+	tablet := env.tablets[uid]
+	if tablet.results == nil {
+		tablet.results = make(map[string]*sqltypes.Result)
+	}
+	tablet.results[table] = result
+}
+
+// testKsid returns the keyspace id of v using the vindexType vindex.
+func testKsid(t *testing.T, vindexType string, v sqltypes.Value) []byte {
+	vindex, err := vindexes.CreateVindex(vindexType, vindexType, nil)
+	require.NoError(t, err)
+	dests, err := vindexes.Map(context.Background(), vindex, nil, [][]sqltypes.Value{{v}})
+	require.NoError(t, err)
+	return dests[0].(key.DestinationKeyspaceID)
+}
+
+func TestLookupVindexVerify(t *testing.T) {
+	ctx := context.Background()
+	hashKsid := func(id int64) []byte { return testKsid(t, "hash", sqltypes.NewInt64(id)) }
+	ksid1, ksid2, ksid3, ksid4 := hashKsid(1), hashKsid(2), hashKsid(3), hashKsid(4)
+	// The lookup table rows are repaired on the primary tablet of the shard
+	// of their 'from' value.
+	lookupPrimary := func(col string) int {
+		if testKsid(t, "xxhash", sqltypes.NewVarChar(col))[0] >= 0x80 {
+			return 200
+		}
+		return 100
+	}
+
+	ownerFields := sqltypes.MakeTestFields("c1|id", "varchar|int64")
+	lookupFields := sqltypes.MakeTestFields("col|keyspace_id", "varchar|varbinary")
+	lookupRow := func(col string, ksid []byte) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.NewVarChar(col), sqltypes.MakeTrusted(sqltypes.VarBinary, ksid)}
+	}
+	ksidFields := sqltypes.MakeTestFields("keyspace_id", "varbinary")
+	idFields := sqltypes.MakeTestFields("id", "int64")
+
+	// The rows currently on the primary tablets, which the differences
+	// are checked against, by 'from' value.
+	currentOwnerRows := map[string][]string{"b": {"2"}, "c": {"4"}}
+	currentLookupRows := map[string][]byte{"b": ksid3, "x": ksid2}
+
+	testCases := []struct {
+		name       string
+		vindexType string
+		repair     bool
+		// ownerRows and lookupRows override currentOwnerRows and
+		// currentLookupRows.
+		ownerRows  map[string][]string
+		lookupRows map[string][]byte
+		want       *vtctldatapb.LookupVindexVerifyResponse
+		wantDMLs   []string
+	}{
+		{
+			name:       "unique",
+			vindexType: "consistent_lookup_unique",
+			want: &vtctldatapb.LookupVindexVerifyResponse{
+				OwnerRows:      4,
+				LookupRows:     4,
+				MatchingRows:   2,
+				OrphanedRows:   1,
+				MissingRows:    1,
+				MismatchedRows: 1,
+			},
+		},
+		{
+			name:       "non-unique",
+			vindexType: "consistent_lookup",
+			want: &vtctldatapb.LookupVindexVerifyResponse{
+				OwnerRows:    4,
+				LookupRows:   4,
+				MatchingRows: 2,
+				OrphanedRows: 2,
+				MissingRows:  2,
+			},
+		},
+		{
+			name:       "repair",
+			vindexType: "lookup_unique",
+			repair:     true,
+			want: &vtctldatapb.LookupVindexVerifyResponse{
+				OwnerRows:      4,
+				LookupRows:     4,
+				MatchingRows:   2,
+				OrphanedRows:   1,
+				MissingRows:    1,
+				MismatchedRows: 1,
+				RepairedRows:   3,
+			},
+			wantDMLs: []string{
+				fmt.Sprintf("%d: update `t1_col_vdx` set `keyspace_id` = X'%x' where `col` <=> 'b' and `keyspace_id` = X'%x'", lookupPrimary("b"), ksid2, ksid3),
+				fmt.Sprintf("%d: insert into `t1_col_vdx` (`col`, `keyspace_id`) values ('c', X'%x')", lookupPrimary("c"), ksid4),
+				fmt.Sprintf("%d: delete from `t1_col_vdx` where `col` <=> 'x' and `keyspace_id` = X'%x'", lookupPrimary("x"), ksid2),
+			},
+		},
+		{
+			name:       "differences that are gone are not reported or repaired",
+			vindexType: "lookup_unique",
+			repair:     true,
+			// After the tables were streamed, 'x' was inserted in the
+			// owner table, 'b' was updated again and the lookup row of
+			// 'c' was inserted.
+			ownerRows:  map[string][]string{"x": {"2"}, "b": {"3"}, "c": {"4"}},
+			lookupRows: map[string][]byte{"b": ksid3, "c": ksid4, "x": ksid2},
+			want: &vtctldatapb.LookupVindexVerifyResponse{
+				OwnerRows:    4,
+				LookupRows:   4,
+				MatchingRows: 2,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newLookupVindexVerifyEnv(t, ctx, tc.vindexType)
+			env.setRows(101, "t1", sqltypes.MakeTestResult(ownerFields, "a|1", "b|2", "b|2"))
+			env.setRows(201, "t1", sqltypes.MakeTestResult(ownerFields, "c|4", "d|4"))
+			env.setRows(101, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields, Rows: [][]sqltypes.Value{lookupRow("a", ksid1), lookupRow("x", ksid2)}})
+			env.setRows(201, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields, Rows: [][]sqltypes.Value{lookupRow("b", ksid3), lookupRow("d", ksid4)}})
+
+			ownerRows, lookupRows := currentOwnerRows, currentLookupRows
+			if tc.ownerRows != nil {
+				ownerRows, lookupRows = tc.ownerRows, tc.lookupRows
+			}
+			env.tmc.results["select distinct `id` from `t1` where"] = sqltypes.MakeTestResult(idFields)
+			for col, ids := range ownerRows {
+				env.tmc.results[fmt.Sprintf("select distinct `id` from `t1` where `c1` <=> '%s'", col)] = sqltypes.MakeTestResult(idFields, ids...)
+			}
+			env.tmc.results["select `keyspace_id` from `t1_col_vdx` where"] = sqltypes.MakeTestResult(ksidFields)
+			for col, ksid := range lookupRows {
+				env.tmc.results[fmt.Sprintf("select `keyspace_id` from `t1_col_vdx` where `col` <=> '%s'", col)] = &sqltypes.Result{
+					Fields: ksidFields,
+					Rows:   [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.VarBinary, ksid)}},
+				}
+			}
+
+			resp, err := env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{
+				Keyspace: "ks",
+				Name:     "t1_col_vdx",
+				Repair:   tc.repair,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "t1", resp.OwnerTable)
+			assert.Equal(t, "ks.t1_col_vdx", resp.LookupTable)
+			assert.Equal(t, tc.want.OwnerRows, resp.OwnerRows, "OwnerRows")
+			assert.Equal(t, tc.want.LookupRows, resp.LookupRows, "LookupRows")
+			assert.Equal(t, tc.want.MatchingRows, resp.MatchingRows, "MatchingRows")
+			assert.Equal(t, tc.want.OrphanedRows, resp.OrphanedRows, "OrphanedRows")
+			assert.Equal(t, tc.want.MissingRows, resp.MissingRows, "MissingRows")
+			assert.Equal(t, tc.want.MismatchedRows, resp.MismatchedRows, "MismatchedRows")
+			assert.Equal(t, tc.want.RepairedRows, resp.RepairedRows, "RepairedRows")
+			assert.Equal(t, tc.wantDMLs, env.tmc.dmls)
+
+			// The tables are streamed from the replicas, sorted by the
+			// 'from' columns.
+			for _, uid := range []uint32{101, 201} {
+				assert.ElementsMatch(t, []string{
+					"select `c1`, `id` from `t1` order by `c1`",
+					"select `col`, `keyspace_id` from `t1_col_vdx` order by `col`",
+				}, env.tablets[uid].queries)
+			}
+			for _, uid := range []uint32{100, 200} {
+				assert.Empty(t, env.tablets[uid].queries)
+			}
+		})
+	}
+
+	t.Run("samples", func(t *testing.T) {
+		env := newLookupVindexVerifyEnv(t, ctx, "consistent_lookup_unique")
+		env.setRows(101, "t1", sqltypes.MakeTestResult(ownerFields, "a|1"))
+		env.setRows(201, "t1", sqltypes.MakeTestResult(ownerFields))
+		env.setRows(101, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields, Rows: [][]sqltypes.Value{lookupRow("a", ksid2)}})
+		env.setRows(201, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields})
+		env.tmc.results["select distinct `id` from `t1` where `c1` <=> 'a'"] = sqltypes.MakeTestResult(idFields, "1")
+		env.tmc.results["select `keyspace_id` from `t1_col_vdx` where `col` <=> 'a'"] = &sqltypes.Result{
+			Fields: ksidFields,
+			Rows:   [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.VarBinary, ksid2)}},
+		}
+
+		resp, err := env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{
+			Keyspace:      "ks",
+			Name:          "t1_col_vdx",
+			MaxSampleRows: 1,
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, resp.MismatchedRows)
+		require.Len(t, resp.MismatchedRowsSample, 1)
+		assert.Equal(t, map[string]string{"col": "a", "keyspace_id": hex.EncodeToString(ksid1)}, resp.MismatchedRowsSample[0].Owner.Values)
+		assert.Equal(t, map[string]string{"col": "a", "keyspace_id": hex.EncodeToString(ksid2)}, resp.MismatchedRowsSample[0].Lookup.Values)
+		assert.Empty(t, env.tmc.dmls)
+	})
+}
+
+func TestLookupVindexVerifyCollation(t *testing.T) {
+	ctx := context.Background()
+	ksid1, ksid2 := testKsid(t, "hash", sqltypes.NewInt64(1)), testKsid(t, "hash", sqltypes.NewInt64(2))
+
+	env := newLookupVindexVerifyEnv(t, ctx, "consistent_lookup")
+	// The owner column is case sensitive, the lookup column is not.
+	env.tmc.fields["t1"][0].Charset = uint32(collations.MySQL8().LookupByName("utf8mb4_0900_as_cs"))
+	env.tmc.fields["t1_col_vdx"][0].Charset = uint32(collations.MySQL8().LookupByName("utf8mb4_0900_ai_ci"))
+
+	ownerFields := sqltypes.MakeTestFields("c1|id", "varchar|int64")
+	lookupFields := sqltypes.MakeTestFields("col|keyspace_id", "varchar|varbinary")
+	lookupRow := func(col string, ksid []byte) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.NewVarChar(col), sqltypes.MakeTrusted(sqltypes.VarBinary, ksid)}
+	}
+	env.setRows(101, "t1", sqltypes.MakeTestResult(ownerFields, "Abc|1", "abc|1", "b|2"))
+	env.setRows(201, "t1", sqltypes.MakeTestResult(ownerFields))
+	env.setRows(101, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields, Rows: [][]sqltypes.Value{lookupRow("ABC", ksid1), lookupRow("B", ksid2)}})
+	env.setRows(201, "t1_col_vdx", &sqltypes.Result{Fields: lookupFields})
+
+	resp, err := env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "t1_col_vdx"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.OwnerRows)
+	assert.EqualValues(t, 2, resp.MatchingRows)
+	assert.Zero(t, resp.OrphanedRows+resp.MissingRows+resp.MismatchedRows)
+	// The owner table is sorted in the collation of the lookup table.
+	assert.Contains(t, env.tablets[101].queries,
+		"select convert(`c1` using utf8mb4) collate utf8mb4_0900_ai_ci, `id` from `t1` order by convert(`c1` using utf8mb4) collate utf8mb4_0900_ai_ci")
+}
+
+func TestLookupVindexVerifyErrors(t *testing.T) {
+	ctx := context.Background()
+
+	env := newLookupVindexVerifyEnv(t, ctx, "lookup_hash")
+	_, err := env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "t1_col_vdx"})
+	assert.ErrorContains(t, err, "vindex type lookup_hash is not supported")
+
+	env = newLookupVindexVerifyEnv(t, ctx, "lookup")
+	_, err = env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "nonexistent"})
+	assert.ErrorContains(t, err, "vindex nonexistent not found in the ks keyspace")
+
+	env = newLookupVindexVerifyEnv(t, ctx, "lookup")
+	vs, err := env.ts.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	vs.Vindexes["t1_col_vdx"].Owner = ""
+	require.NoError(t, env.ts.SaveVSchema(ctx, vs))
+	_, err = env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "t1_col_vdx"})
+	assert.ErrorContains(t, err, "vindex t1_col_vdx has no owner")
+
+	env = newLookupVindexVerifyEnv(t, ctx, "lookup")
+	env.tmc.fields["t1"][0].Type = sqltypes.Int64
+	_, err = env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "t1_col_vdx"})
+	assert.ErrorContains(t, err, "column c1 of the owner table t1 and column col of the lookup table t1_col_vdx cannot be compared")
+
+	env = newLookupVindexVerifyEnv(t, ctx, "lookup")
+	_, err = env.ts.UpdateShardFields(ctx, "ks", "-80", func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = nil
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = env.ws.LookupVindexVerify(ctx, &vtctldatapb.LookupVindexVerifyRequest{Keyspace: "ks", Name: "t1_col_vdx"})
+	assert.ErrorContains(t, err, "shard ks/-80 has no primary")
+}
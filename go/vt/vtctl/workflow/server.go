@@ -162,6 +162,10 @@ func (s *Server) SQLParser() *sqlparser.Parser {
 	return s.env.Parser()
 }
 
+func (s *Server) Environment() *vtenv.Environment {
+	return s.env
+}
+
 // CheckReshardingJournalExistsOnTablet returns the journal (or an empty
 // journal) and a boolean to indicate if the resharding_journal table exists on
 // the given tablet.
@@ -505,6 +509,17 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 					break
 				}
 			}
+			weightedRules, err := topotools.GetWeightedRoutingRules(ctx, ts.TopoServer())
+			if err != nil {
+				return nil, nil, err
+			}
+			toTable := fmt.Sprintf("%s.%s", targetKeyspace, table)
+			if wr := weightedRules[fmt.Sprintf("%s.%s@replica", sourceKeyspace, table)]; wr.GetToTable() == toTable {
+				state.ReplicaReadsWeightedPercent = wr.GetPercent()
+			}
+			if wr := weightedRules[fmt.Sprintf("%s.%s@rdonly", sourceKeyspace, table)]; wr.GetToTable() == toTable {
+				state.RdonlyReadsWeightedPercent = wr.GetPercent()
+			}
 		}
 	} else {
 		state.WorkflowType = TypeReshard
@@ -530,6 +545,17 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 		if !shard.IsPrimaryServing {
 			state.WritesSwitched = true
 		}
+
+		weightedRules, err := topotools.GetWeightedShardRoutingRules(ctx, ts.TopoServer())
+		if err != nil {
+			return nil, nil, err
+		}
+		if wr := weightedRules[weightedFromKeyspace(targetKeyspace, topodatapb.TabletType_REPLICA)]; ts.isWeightedToShards(wr) {
+			state.ReplicaReadsWeightedPercent = wr.GetPercent()
+		}
+		if wr := weightedRules[weightedFromKeyspace(targetKeyspace, topodatapb.TabletType_RDONLY)]; ts.isWeightedToShards(wr) {
+			state.RdonlyReadsWeightedPercent = wr.GetPercent()
+		}
 	}
 	if ts.workflowType == binlogdatapb.VReplicationWorkflowType_Migrate {
 		state.WorkflowType = TypeMigrate
@@ -774,6 +800,23 @@ func (s *Server) LookupVindexInternalize(ctx context.Context, req *vtctldatapb.L
 	return resp, s.ts.RebuildSrvVSchema(ctx, nil)
 }
 
+// LookupVindexVerify compares the lookup table of an owned lookup vindex
+// with its owner table and, if requested, repairs the differences.
+func (s *Server) LookupVindexVerify(ctx context.Context, req *vtctldatapb.LookupVindexVerifyRequest) (*vtctldatapb.LookupVindexVerifyResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexVerify")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("name", req.Name)
+	span.Annotate("repair", req.Repair)
+
+	lvv := newLookupVindexVerifier(s, req)
+	if err := lvv.init(ctx); err != nil {
+		return nil, err
+	}
+	return lvv.verify(ctx)
+}
+
 // Materialize performs the steps needed to materialize a list of
 // tables based on the materialization specs.
 func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSettings) error {
@@ -2752,6 +2795,9 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	if startState.WorkflowType == TypeMigrate {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for Migrate workflow: SwitchTraffic")
 	}
+	if err := validateWeightedSwitchTraffic(req, ts, startState, direction, switchPrimary); err != nil {
+		return nil, err
+	}
 
 	if ts.IsMultiTenantMigration() {
 		// Multi-tenant migrations use keyspace routing rules, so we need to update the state
@@ -2878,6 +2924,37 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	return resp, nil
 }
 
+// validateWeightedSwitchTraffic validates the percent of a SwitchTraffic
+// request. The reads of MoveTables workflows are split by weighted routing
+// rules for their tables, and those of Reshard workflows by weighted shard
+// routing rules for their keyspace.
+func validateWeightedSwitchTraffic(req *vtctldatapb.WorkflowSwitchTrafficRequest, ts *trafficSwitcher, state *State, direction TrafficSwitchDirection, switchPrimary bool) error {
+	if req.GetPercent() < 0 || req.GetPercent() > 100 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent must be between 0 and 100: %v", req.GetPercent())
+	}
+	if req.GetMaxErrorPercent() < 0 || req.GetMaxErrorPercent() > 100 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "max error percent must be between 0 and 100: %v", req.GetMaxErrorPercent())
+	}
+	if req.GetPercent() == 0 || req.GetPercent() == 100 {
+		return nil
+	}
+	switch {
+	case direction != DirectionForward:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent can only be used when switching traffic forward")
+	case switchPrimary:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent can only be used when switching REPLICA and RDONLY traffic")
+	case state.WorkflowType != TypeMoveTables && state.WorkflowType != TypeReshard:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent is not supported for %s workflows", string(state.WorkflowType))
+	case ts.IsPartialMigration():
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent is not supported for partial migrations")
+	case ts.IsMultiTenantMigration():
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent is not supported for multi-tenant migrations")
+	case len(state.ReplicaCellsSwitched) > 0 || len(state.RdonlyCellsSwitched) > 0:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot route a percent of the reads for workflow %s: reads are already switched", state.Workflow)
+	}
+	return nil
+}
+
 // switchReads is a generic way of switching read traffic for a workflow.
 func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, ts *trafficSwitcher, state *State, rebuildSrvVSchema bool, direction TrafficSwitchDirection) (*[]string, error) {
 	var roTabletTypes []topodatapb.TabletType
@@ -2936,11 +3013,11 @@ func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitc
 	}
 
 	if !trafficSwitchingIsAllOrNothing {
-		if direction == DirectionBackward && switchReplica && len(state.ReplicaCellsSwitched) == 0 {
+		if direction == DirectionBackward && switchReplica && len(state.ReplicaCellsSwitched) == 0 && state.ReplicaReadsWeightedPercent == 0 {
 			return defaultErrorHandler(ts.Logger(), "invalid request", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
 				"requesting reversal of read traffic for REPLICAs but REPLICA reads have not been switched"))
 		}
-		if direction == DirectionBackward && switchRdonly && len(state.RdonlyCellsSwitched) == 0 {
+		if direction == DirectionBackward && switchRdonly && len(state.RdonlyCellsSwitched) == 0 && state.RdonlyReadsWeightedPercent == 0 {
 			return defaultErrorHandler(ts.Logger(), "invalid request", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
 				"requesting reversal of read traffic for RDONLYs but RDONLY reads have not been switched"))
 		}
@@ -3012,6 +3089,23 @@ func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitc
 			ts.SourceKeyspaceName(), ts.TargetKeyspaceName(), ts.WorkflowName()), err)
 	}
 
+	if ts.MigrationType() == binlogdatapb.MigrationType_TABLES && !ts.IsMultiTenantMigration() && !ts.isPartialMigration {
+		// Route a percentage of the reads to the target with weighted routing
+		// rules, or remove them when the reads are switched all at once.
+		var percent, maxErrorPercent float32
+		weighted := direction == DirectionForward && req.GetPercent() > 0 && req.GetPercent() < 100
+		if weighted {
+			percent, maxErrorPercent = req.GetPercent(), req.GetMaxErrorPercent()
+		}
+		if err := sw.weightTableTraffic(ctx, roTabletTypes, percent, maxErrorPercent); err != nil {
+			return defaultErrorHandler(ts.Logger(), fmt.Sprintf("failed to update weighted routing rules from source keyspace %s to target keyspace %s, workflow %s",
+				ts.SourceKeyspaceName(), ts.TargetKeyspaceName(), ts.WorkflowName()), err)
+		}
+		if weighted {
+			return sw.logs(), nil
+		}
+	}
+
 	if ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		switch {
 		case ts.IsMultiTenantMigration():
@@ -3031,6 +3125,27 @@ func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitc
 		return sw.logs(), nil
 	}
 
+	// Route a percentage of the reads to the target shards with weighted
+	// shard routing rules, or remove them when the reads are switched all at
+	// once.
+	var percent, maxErrorPercent float32
+	weighted := direction == DirectionForward && req.GetPercent() > 0 && req.GetPercent() < 100
+	if weighted {
+		percent, maxErrorPercent = req.GetPercent(), req.GetMaxErrorPercent()
+	}
+	if err := sw.weightShardTraffic(ctx, roTabletTypes, percent, maxErrorPercent); err != nil {
+		return defaultErrorHandler(ts.Logger(), fmt.Sprintf("failed to update weighted shard routing rules for keyspace %s, workflow %s",
+			ts.TargetKeyspaceName(), ts.WorkflowName()), err)
+	}
+	if weighted {
+		return sw.logs(), nil
+	}
+	if direction == DirectionBackward && !(switchReplica && len(state.ReplicaCellsSwitched) > 0) && !(switchRdonly && len(state.RdonlyCellsSwitched) > 0) {
+		// Only the weighted shard routing rules had routed reads to the
+		// target shards, so there is nothing left to switch back.
+		return sw.logs(), nil
+	}
+
 	if err := confirmKeyspaceLocksHeld(); err != nil {
 		return defaultErrorHandler(ts.Logger(), "locks were lost", err)
 	}
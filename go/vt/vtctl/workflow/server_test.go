@@ -1813,6 +1813,250 @@ func TestMoveTablesTrafficSwitchingDryRun(t *testing.T) {
 	}
 }
 
+func TestMoveTablesWeightedTrafficSwitching(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	tableName := "t1"
+	sourceKeyspaceName := "sourceks"
+	targetKeyspaceName := "targetks"
+
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		tableName: {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   tableName,
+					Schema: fmt.Sprintf("CREATE TABLE %s (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))", tableName),
+				},
+			},
+		},
+	}
+
+	copyTableQR := &queryResult{
+		query:  "/select vrepl_id, table_name, lastpk from _vt.copy_state.*",
+		result: &querypb.QueryResult{},
+	}
+	journalQR := &queryResult{
+		query:  "/select val from _vt.resharding_journal.*",
+		result: &querypb.QueryResult{},
+	}
+
+	testcases := []struct {
+		name    string
+		req     *vtctldatapb.WorkflowSwitchTrafficRequest
+		want    map[string]*vschemapb.WeightedRoutingRule
+		wantErr string
+	}{
+		{
+			name: "replica and rdonly",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:        targetKeyspaceName,
+				Workflow:        workflowName,
+				Direction:       int32(DirectionForward),
+				TabletTypes:     roTabletTypes,
+				Percent:         25,
+				MaxErrorPercent: 5,
+			},
+			want: map[string]*vschemapb.WeightedRoutingRule{
+				fmt.Sprintf("%s.%s@replica", sourceKeyspaceName, tableName): {
+					FromTable:       fmt.Sprintf("%s.%s@replica", sourceKeyspaceName, tableName),
+					ToTable:         fmt.Sprintf("%s.%s", targetKeyspaceName, tableName),
+					Percent:         25,
+					MaxErrorPercent: 5,
+				},
+				fmt.Sprintf("%s.%s@rdonly", sourceKeyspaceName, tableName): {
+					FromTable:       fmt.Sprintf("%s.%s@rdonly", sourceKeyspaceName, tableName),
+					ToTable:         fmt.Sprintf("%s.%s", targetKeyspaceName, tableName),
+					Percent:         25,
+					MaxErrorPercent: 5,
+				},
+			},
+		},
+		{
+			name: "primary",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:    targetKeyspaceName,
+				Workflow:    workflowName,
+				Direction:   int32(DirectionForward),
+				TabletTypes: allTabletTypes,
+				Percent:     25,
+			},
+			wantErr: "percent can only be used when switching REPLICA and RDONLY traffic",
+		},
+		{
+			name: "percent out of range",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:    targetKeyspaceName,
+				Workflow:    workflowName,
+				Direction:   int32(DirectionForward),
+				TabletTypes: roTabletTypes,
+				Percent:     101,
+			},
+			wantErr: "percent must be between 0 and 100",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, ctx, defaultCellName, &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"0"},
+			}, &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			})
+			defer env.close()
+			env.tmc.schema = schema
+			env.tmc.expectVRQueryResultOnKeyspaceTablets(targetKeyspaceName, copyTableQR)
+			for i := 0; i < 2; i++ { // Per stream
+				env.tmc.expectVRQueryResultOnKeyspaceTablets(sourceKeyspaceName, journalQR)
+			}
+
+			got, err := env.ws.WorkflowSwitchTraffic(ctx, tc.req)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Reads Not Switched. Writes Not Switched", got.StartState)
+
+			rules, err := topotools.GetWeightedRoutingRules(ctx, env.ts)
+			require.NoError(t, err)
+			utils.MustMatch(t, tc.want, rules)
+
+			// Reads of the source tables are still routed to the source keyspace.
+			rr, err := env.ts.GetRoutingRules(ctx)
+			require.NoError(t, err)
+			for _, rule := range rr.Rules {
+				for _, to := range rule.ToTables {
+					require.Equal(t, fmt.Sprintf("%s.%s", sourceKeyspaceName, tableName), to, "rule for %s", rule.FromTable)
+				}
+			}
+
+			// Cancelling the workflow removes the weighted routing rules.
+			tc.req.Percent, tc.req.MaxErrorPercent = 0, 0
+			tc.req.Direction = int32(DirectionBackward)
+			for i := 0; i < 2; i++ { // Per stream
+				env.tmc.expectVRQueryResultOnKeyspaceTablets(sourceKeyspaceName, journalQR)
+			}
+			_, err = env.ws.WorkflowSwitchTraffic(ctx, tc.req)
+			require.NoError(t, err)
+			rules, err = topotools.GetWeightedRoutingRules(ctx, env.ts)
+			require.NoError(t, err)
+			require.Empty(t, rules)
+		})
+	}
+}
+
+func TestReshardWeightedTrafficSwitching(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	tableName := "t1"
+	keyspaceName := "ks"
+
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		tableName: {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   tableName,
+					Schema: fmt.Sprintf("CREATE TABLE %s (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))", tableName),
+				},
+			},
+		},
+	}
+
+	copyTableQR := &queryResult{
+		query:  "/select vrepl_id, table_name, lastpk from _vt.copy_state.*",
+		result: &querypb.QueryResult{},
+	}
+	journalQR := &queryResult{
+		query:  "/select val from _vt.resharding_journal.*",
+		result: &querypb.QueryResult{},
+	}
+
+	env := newTestEnv(t, ctx, defaultCellName, &testKeyspace{
+		KeyspaceName: keyspaceName,
+		ShardNames:   []string{"0"},
+	}, &testKeyspace{
+		KeyspaceName: keyspaceName,
+		ShardNames:   []string{"-80", "80-"},
+	})
+	defer env.close()
+	env.tmc.schema = schema
+	// The source shard has no streams of the workflow.
+	env.tmc.expectReadVReplicationWorkflowRequest(startingSourceTabletUID, &readVReplicationWorkflowRequestResponse{
+		req: &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{Workflow: workflowName},
+		res: &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{Workflow: workflowName},
+	})
+	env.tmc.expectVRQueryResultOnKeyspaceTablets(keyspaceName, copyTableQR)
+	env.tmc.expectVRQueryResultOnKeyspaceTablets(keyspaceName, journalQR)
+
+	// The source shard serves the reads.
+	srvKeyspace, err := env.ts.GetSrvKeyspace(ctx, defaultCellName, keyspaceName)
+	require.NoError(t, err)
+	for _, tabletType := range roTabletTypes {
+		srvKeyspace.Partitions = append(srvKeyspace.Partitions, &topodatapb.SrvKeyspace_KeyspacePartition{
+			ServedType:      tabletType,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0", KeyRange: &topodatapb.KeyRange{}}},
+		})
+	}
+	require.NoError(t, env.ts.UpdateSrvKeyspace(ctx, defaultCellName, keyspaceName, srvKeyspace))
+
+	req := &vtctldatapb.WorkflowSwitchTrafficRequest{
+		Keyspace:        keyspaceName,
+		Workflow:        workflowName,
+		Direction:       int32(DirectionForward),
+		TabletTypes:     roTabletTypes,
+		Percent:         25,
+		MaxErrorPercent: 5,
+	}
+	got, err := env.ws.WorkflowSwitchTraffic(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "Reads Not Switched. Writes Not Switched", got.StartState)
+
+	toShards := []*topodatapb.ShardReference{
+		{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+		{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+	}
+	rules, err := topotools.GetWeightedShardRoutingRules(ctx, env.ts)
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*vschemapb.WeightedShardRoutingRule{
+		keyspaceName + "@replica": {
+			FromKeyspace:    keyspaceName + "@replica",
+			ToShards:        toShards,
+			Percent:         25,
+			MaxErrorPercent: 5,
+		},
+		keyspaceName + "@rdonly": {
+			FromKeyspace:    keyspaceName + "@rdonly",
+			ToShards:        toShards,
+			Percent:         25,
+			MaxErrorPercent: 5,
+		},
+	}, rules)
+
+	// The reads are still served by the source shard.
+	ts, state, err := env.ws.getWorkflowState(ctx, keyspaceName, workflowName)
+	require.NoError(t, err)
+	require.Empty(t, state.ReplicaCellsSwitched)
+	require.EqualValues(t, 25, state.ReplicaReadsWeightedPercent)
+	require.EqualValues(t, 25, state.RdonlyReadsWeightedPercent)
+	require.Equal(t, binlogdatapb.MigrationType_SHARDS, ts.MigrationType())
+
+	// Cancelling the workflow removes the weighted shard routing rules.
+	req.Percent, req.MaxErrorPercent = 0, 0
+	req.Direction = int32(DirectionBackward)
+	env.tmc.expectVRQueryResultOnKeyspaceTablets(keyspaceName, journalQR)
+	_, err = env.ws.WorkflowSwitchTraffic(ctx, req)
+	require.NoError(t, err)
+	rules, err = topotools.GetWeightedShardRoutingRules(ctx, env.ts)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+}
+
 func TestMirrorTraffic(t *testing.T) {
 	ctx := context.Background()
 	sourceKs := "source"
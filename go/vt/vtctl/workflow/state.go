@@ -17,6 +17,7 @@ limitations under the License.
 package workflow
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -70,6 +71,11 @@ type State struct {
 
 	WritesSwitched bool
 
+	// Percentage of the reads routed to the target keyspace by weighted
+	// routing rules, before the reads are switched.
+	ReplicaReadsWeightedPercent float32
+	RdonlyReadsWeightedPercent  float32
+
 	// Partial MoveTables info
 	IsPartialMigration    bool
 	ShardsAlreadySwitched []string
@@ -83,6 +89,12 @@ func (s *State) String() string {
 			stateInfo = append(stateInfo, "All Reads Switched")
 		} else if len(s.RdonlyCellsSwitched) == 0 && len(s.ReplicaCellsSwitched) == 0 {
 			stateInfo = append(stateInfo, "Reads Not Switched")
+			if s.ReplicaReadsWeightedPercent > 0 {
+				stateInfo = append(stateInfo, fmt.Sprintf("%.2f%% of Replica Reads Routed to Target", s.ReplicaReadsWeightedPercent))
+			}
+			if s.RdonlyReadsWeightedPercent > 0 {
+				stateInfo = append(stateInfo, fmt.Sprintf("%.2f%% of Rdonly Reads Routed to Target", s.RdonlyReadsWeightedPercent))
+			}
 		} else {
 			stateInfo = append(stateInfo, "Reads partially switched")
 			if len(s.ReplicaCellsNotSwitched) == 0 {
@@ -167,3 +167,11 @@ func (r *switcher) initializeTargetSequences(ctx context.Context, sequencesByBac
 func (r *switcher) mirrorTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
 	return r.ts.mirrorTableTraffic(ctx, types, percent)
 }
+
+func (r *switcher) weightTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	return r.ts.weightTableTraffic(ctx, types, percent, maxErrorPercent)
+}
+
+func (r *switcher) weightShardTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	return r.ts.weightShardTraffic(ctx, types, percent, maxErrorPercent)
+}
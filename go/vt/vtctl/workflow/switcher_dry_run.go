@@ -29,6 +29,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topotools"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -76,6 +77,65 @@ func (dr *switcherDryRun) mirrorTableTraffic(ctx context.Context, types []topoda
 	return nil
 }
 
+func (dr *switcherDryRun) weightTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	var tabletTypes []string
+	for _, servedType := range types {
+		tabletTypes = append(tabletTypes, servedType.String())
+	}
+	if percent == 0 {
+		wrs, err := topotools.GetWeightedRoutingRules(ctx, dr.ts.TopoServer())
+		if err != nil {
+			return err
+		}
+		for _, table := range dr.ts.tables {
+			for _, tabletType := range types {
+				if _, ok := wrs[weightedFromTable(dr.ts.SourceKeyspaceName(), table, tabletType)]; ok {
+					dr.drLog.Logf("Weighted routing rules from keyspace %s to keyspace %s for tablet types [%s] will be removed",
+						dr.ts.SourceKeyspaceName(), dr.ts.TargetKeyspaceName(), strings.Join(tabletTypes, ","))
+					return nil
+				}
+			}
+		}
+		return nil
+	}
+	dr.drLog.Logf("Routing %.2f percent of read traffic from keyspace %s to keyspace %s for tablet types [%s]",
+		percent, dr.ts.SourceKeyspaceName(), dr.ts.TargetKeyspaceName(), strings.Join(tabletTypes, ","))
+
+	return nil
+}
+
+func (dr *switcherDryRun) weightShardTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	if dr.ts.MigrationType() != binlogdatapb.MigrationType_SHARDS {
+		return nil
+	}
+	var tabletTypes []string
+	for _, servedType := range types {
+		tabletTypes = append(tabletTypes, servedType.String())
+	}
+	var toShards []string
+	for _, shard := range dr.ts.weightedToShards() {
+		toShards = append(toShards, shard.Name)
+	}
+	if percent == 0 {
+		wrs, err := topotools.GetWeightedShardRoutingRules(ctx, dr.ts.TopoServer())
+		if err != nil {
+			return err
+		}
+		for _, tabletType := range types {
+			if _, ok := wrs[weightedFromKeyspace(dr.ts.TargetKeyspaceName(), tabletType)]; ok {
+				dr.drLog.Logf("Weighted shard routing rules from keyspace %s to shards [%s] for tablet types [%s] will be removed",
+					dr.ts.TargetKeyspaceName(), strings.Join(toShards, ","), strings.Join(tabletTypes, ","))
+				return nil
+			}
+		}
+		return nil
+	}
+	dr.drLog.Logf("Routing %.2f percent of read traffic from keyspace %s to shards [%s] for tablet types [%s]",
+		percent, dr.ts.TargetKeyspaceName(), strings.Join(toShards, ","), strings.Join(tabletTypes, ","))
+
+	return nil
+}
+
 func (dr *switcherDryRun) switchKeyspaceReads(ctx context.Context, types []topodatapb.TabletType) error {
 	var tabletTypes []string
 	for _, servedType := range types {
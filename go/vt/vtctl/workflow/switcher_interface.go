@@ -37,6 +37,8 @@ type iswitcher interface {
 	allowTargetWrites(ctx context.Context) error
 	changeRouting(ctx context.Context) error
 	mirrorTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error
+	weightTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error
+	weightShardTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error
 	streamMigraterfinalize(ctx context.Context, ts *trafficSwitcher, workflows []string) error
 	startReverseVReplication(ctx context.Context) error
 	switchKeyspaceReads(ctx context.Context, types []topodatapb.TabletType) error
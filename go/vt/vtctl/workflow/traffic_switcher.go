@@ -427,7 +427,10 @@ func (ts *trafficSwitcher) deleteRoutingRules(ctx context.Context) error {
 	if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
 		return err
 	}
-	return nil
+	if err := ts.weightTableTraffic(ctx, []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY}, 0, 0); err != nil {
+		return err
+	}
+	return ts.weightShardTraffic(ctx, []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY}, 0, 0)
 }
 
 func (ts *trafficSwitcher) deleteShardRoutingRules(ctx context.Context) error {
@@ -1585,3 +1588,139 @@ func (ts *trafficSwitcher) mirrorTableTraffic(ctx context.Context, types []topod
 
 	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
 }
+
+// weightedFromTable returns the from_table of the weighted routing rule of a
+// table for a tablet type.
+func weightedFromTable(keyspace, table string, tabletType topodatapb.TabletType) string {
+	return fmt.Sprintf("%s.%s@%s", keyspace, table, topoproto.TabletTypeLString(tabletType))
+}
+
+// weightTableTraffic routes percent of the reads of the tablet types for the
+// tables of the workflow to the target keyspace, using weighted routing
+// rules. A percent of 0 removes the rules.
+func (ts *trafficSwitcher) weightTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	wrs, err := topotools.GetWeightedRoutingRules(ctx, ts.TopoServer())
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	for _, table := range ts.tables {
+		for _, tabletType := range types {
+			if tabletType == topodatapb.TabletType_PRIMARY {
+				continue
+			}
+			fromTable := weightedFromTable(ts.SourceKeyspaceName(), table, tabletType)
+			if percent == 0 {
+				if _, ok := wrs[fromTable]; ok {
+					delete(wrs, fromTable)
+					changed = true
+				}
+				continue
+			}
+			wrs[fromTable] = &vschemapb.WeightedRoutingRule{
+				FromTable:       fromTable,
+				ToTable:         fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), table),
+				Percent:         percent,
+				MaxErrorPercent: maxErrorPercent,
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := topotools.SaveWeightedRoutingRules(ctx, ts.TopoServer(), wrs); err != nil {
+		return err
+	}
+
+	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
+}
+
+// weightedFromKeyspace returns the from_keyspace of the weighted shard
+// routing rule of a keyspace for a tablet type.
+func weightedFromKeyspace(keyspace string, tabletType topodatapb.TabletType) string {
+	return fmt.Sprintf("%s@%s", keyspace, topoproto.TabletTypeLString(tabletType))
+}
+
+// weightedToShards returns the target shards of the workflow as the to_shards
+// of a weighted shard routing rule, in key range order.
+func (ts *trafficSwitcher) weightedToShards() []*topodatapb.ShardReference {
+	shards := make([]*topodatapb.ShardReference, 0, len(ts.Targets()))
+	for _, si := range ts.TargetShards() {
+		shards = append(shards, &topodatapb.ShardReference{
+			Name:     si.ShardName(),
+			KeyRange: si.GetKeyRange(),
+		})
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return key.KeyRangeLess(shards[i].KeyRange, shards[j].KeyRange)
+	})
+	return shards
+}
+
+// weightShardTraffic routes percent of the reads of the tablet types for the
+// keyspace of a Reshard workflow to its target shards, using weighted shard
+// routing rules. A percent of 0 removes the rules.
+func (ts *trafficSwitcher) weightShardTraffic(ctx context.Context, types []topodatapb.TabletType, percent, maxErrorPercent float32) error {
+	if ts.MigrationType() != binlogdatapb.MigrationType_SHARDS {
+		return nil
+	}
+	wrs, err := topotools.GetWeightedShardRoutingRules(ctx, ts.TopoServer())
+	if err != nil {
+		return err
+	}
+	if wrs == nil {
+		wrs = make(map[string]*vschemapb.WeightedShardRoutingRule)
+	}
+
+	var changed bool
+	for _, tabletType := range types {
+		if tabletType == topodatapb.TabletType_PRIMARY {
+			continue
+		}
+		fromKeyspace := weightedFromKeyspace(ts.TargetKeyspaceName(), tabletType)
+		if percent == 0 {
+			if _, ok := wrs[fromKeyspace]; ok {
+				delete(wrs, fromKeyspace)
+				changed = true
+			}
+			continue
+		}
+		wrs[fromKeyspace] = &vschemapb.WeightedShardRoutingRule{
+			FromKeyspace:    fromKeyspace,
+			ToShards:        ts.weightedToShards(),
+			Percent:         percent,
+			MaxErrorPercent: maxErrorPercent,
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := topotools.SaveWeightedShardRoutingRules(ctx, ts.TopoServer(), wrs); err != nil {
+		return err
+	}
+
+	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
+}
+
+// isWeightedToShards returns whether a weighted shard routing rule routes
+// reads to the target shards of the workflow.
+func (ts *trafficSwitcher) isWeightedToShards(wr *vschemapb.WeightedShardRoutingRule) bool {
+	if wr == nil {
+		return false
+	}
+	toShards := ts.weightedToShards()
+	if len(wr.GetToShards()) != len(toShards) {
+		return false
+	}
+	for i, shard := range toShards {
+		if wr.GetToShards()[i].GetName() != shard.Name {
+			return false
+		}
+	}
+	return true
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -179,6 +180,39 @@ func unmarshalRequest(r *http.Request, v any) error {
 	return json.Unmarshal(data, v)
 }
 
+// parseAuditFilter reads a topo audit log filter from the cell, path,
+// since, until and limit parameters of a request. The times use the
+// RFC 3339 format.
+func parseAuditFilter(r *http.Request) (*topo.AuditFilter, error) {
+	filter := &topo.AuditFilter{
+		Cell:       r.FormValue("cell"),
+		PathPrefix: r.FormValue("path"),
+	}
+	for _, t := range []struct {
+		name  string
+		value *time.Time
+	}{
+		{"since", &filter.Since},
+		{"until", &filter.Until},
+	} {
+		if v := r.FormValue(t.name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v: %v", t.name, err)
+			}
+			*t.value = parsed
+		}
+	}
+	if v := r.FormValue("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %v", err)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
 func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository) {
 	tabletHealthCache := newTabletHealthCache(ts)
 	tmClient := tmclient.NewTabletManagerClient()
@@ -470,6 +504,34 @@ func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository) {
 		return nil
 	})
 
+	// Audit log of the topology writes.
+	handleAPI("topo_audit", func(w http.ResponseWriter, r *http.Request) error {
+		if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+			return nil
+		}
+
+		filter, err := parseAuditFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return nil
+		}
+		entries, err := ts.GetAuditLog(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("can't read topo audit log: %v", err)
+		}
+		if entries == nil {
+			entries = []*topo.AuditEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json error: %v", err)
+		}
+		w.Header().Set("Content-Type", jsonContentType)
+		w.Write(data)
+		return nil
+	})
+
 	// Vtctl Command
 	handleAPI("vtctl/", func(w http.ResponseWriter, r *http.Request) error {
 		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
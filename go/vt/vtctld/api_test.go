@@ -490,6 +490,22 @@ func TestAPI(t *testing.T) {
 			useStringMatch: true,
 		},
 
+		// Topo audit log
+		{
+			method:         "GET",
+			path:           "topo_audit?path=/keyspaces/ks1/",
+			expectedString: `[]`,
+			statusCode:     http.StatusOK,
+			useStringMatch: true,
+		},
+		{
+			method:         "GET",
+			path:           "topo_audit?since=yesterday",
+			expectedString: `invalid since`,
+			statusCode:     http.StatusBadRequest,
+			useStringMatch: true,
+		},
+
 		// vtctl RunCommand
 		{
 			method:              "POST",
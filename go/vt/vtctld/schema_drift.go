@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	schemaDriftCheckInterval     time.Duration
+	schemaDriftCheckIncludeViews = false
+
+	schemaDriftDiffs = stats.NewGaugesWithMultiLabels(
+		"SchemaDriftDiffs",
+		"Number of schema diffs between a shard and the canonical schema of its keyspace",
+		[]string{"Keyspace", "Shard"},
+	)
+	schemaDriftCheckErrors = stats.NewCountersWithMultiLabels(
+		"SchemaDriftCheckErrors",
+		"Number of times the schema of a shard could not be checked for drift",
+		[]string{"Keyspace", "Shard"},
+	)
+)
+
+// schemaDriftChecker periodically compares the schema of every shard against the canonical schema
+// of its keyspace, and exports the number of diffs per shard.
+type schemaDriftChecker struct {
+	ts  *topo.Server
+	tmc tmclient.TabletManagerClient
+	env *schemadiff.Environment
+}
+
+// startSchemaDriftChecker runs the schema drift check every --schema-drift-check-interval, until
+// ctx is done. It does nothing when the interval is zero.
+func startSchemaDriftChecker(ctx context.Context, env *vtenv.Environment, ts *topo.Server) {
+	if schemaDriftCheckInterval <= 0 {
+		return
+	}
+	checker := &schemaDriftChecker{
+		ts:  ts,
+		tmc: tmclient.NewTabletManagerClient(),
+		env: schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset()),
+	}
+	go func() {
+		defer checker.tmc.Close()
+		ticker := time.NewTicker(schemaDriftCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				checkCtx, cancel := context.WithTimeout(ctx, schemaDriftCheckInterval)
+				checker.check(checkCtx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// check runs a single pass of the schema drift check over all keyspaces.
+func (c *schemaDriftChecker) check(ctx context.Context) {
+	keyspaces, err := c.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Warningf("schema drift check: unable to get keyspaces: %v", err)
+		return
+	}
+	diffs := make(map[[2]string]int64)
+	for _, keyspace := range keyspaces {
+		resp, err := schematools.GetSchemaDrift(ctx, c.ts, c.tmc, c.env, &vtctldatapb.GetSchemaDriftRequest{
+			Keyspace:     keyspace,
+			IncludeViews: schemaDriftCheckIncludeViews,
+		})
+		if err != nil {
+			log.Warningf("schema drift check: unable to check keyspace %v: %v", keyspace, err)
+			schemaDriftCheckErrors.Add([]string{keyspace, ""}, 1)
+			continue
+		}
+		for _, shard := range resp.Shards {
+			if shard.Error != "" {
+				log.Warningf("schema drift check: unable to check shard %v/%v: %v", keyspace, shard.Shard, shard.Error)
+				schemaDriftCheckErrors.Add([]string{keyspace, shard.Shard}, 1)
+				continue
+			}
+			if len(shard.Diffs) > 0 {
+				log.Warningf("schema drift check: shard %v/%v drifted from reference shard %v by %d diff(s)", keyspace, shard.Shard, resp.ReferenceShard, len(shard.Diffs))
+			}
+			diffs[[2]string{keyspace, shard.Shard}] = int64(len(shard.Diffs))
+		}
+	}
+
+	// Shards that were deleted or could not be checked in this pass drop out of the gauge.
+	schemaDriftDiffs.ResetAll()
+	for labels, count := range diffs {
+		schemaDriftDiffs.Set(labels[:], count)
+	}
+}
@@ -48,6 +48,8 @@ func init() {
 
 func registerVtctldFlags(fs *pflag.FlagSet) {
 	utils.SetFlagBoolVar(fs, &sanitizeLogMessages, "vtctld-sanitize-log-messages", sanitizeLogMessages, "When true, vtctld sanitizes logging.")
+	utils.SetFlagDurationVar(fs, &schemaDriftCheckInterval, "schema-drift-check-interval", schemaDriftCheckInterval, "How often vtctld compares the schema of every shard against the canonical schema of its keyspace and exports the SchemaDriftDiffs metric. Zero disables the check.")
+	utils.SetFlagBoolVar(fs, &schemaDriftCheckIncludeViews, "schema-drift-check-include-views", schemaDriftCheckIncludeViews, "When true, the periodic schema drift check also compares views.")
 }
 
 // InitVtctld initializes all the vtctld functionality.
@@ -128,6 +130,11 @@ func InitVtctld(env *vtenv.Environment, ts *topo.Server) error {
 			return "", err
 		})
 
+	// Periodically check shards for schema drift, until the process shuts down
+	schemaDriftCtx, cancelSchemaDrift := context.WithCancel(context.Background())
+	servenv.OnClose(cancelSchemaDrift)
+	startSchemaDriftChecker(schemaDriftCtx, env, ts)
+
 	// Serve the REST API
 	initAPI(context.Background(), ts, actionRepo)
 
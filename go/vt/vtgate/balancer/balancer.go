@@ -96,6 +96,7 @@ const (
 	ModeCell
 	ModePreferCell
 	ModeRandom
+	ModePeakEWMA
 )
 
 func ParseMode(ms string) Mode {
@@ -106,6 +107,8 @@ func ParseMode(ms string) Mode {
 		return ModePreferCell
 	case "random":
 		return ModeRandom
+	case "peak-ewma":
+		return ModePeakEWMA
 	default:
 		return ModeInvalid
 	}
@@ -119,13 +122,15 @@ func (m Mode) String() string {
 		return "prefer-cell"
 	case ModeRandom:
 		return "random"
+	case ModePeakEWMA:
+		return "peak-ewma"
 	default:
 		return "invalid"
 	}
 }
 
 func GetAvailableModeNames() []string {
-	return []string{ModeCell.String(), ModePreferCell.String(), ModeRandom.String()}
+	return []string{ModeCell.String(), ModePreferCell.String(), ModeRandom.String(), ModePeakEWMA.String()}
 }
 
 type TabletBalancer interface {
@@ -137,11 +142,22 @@ type TabletBalancer interface {
 	DebugHandler(w http.ResponseWriter, r *http.Request)
 }
 
+// QueryObserver is implemented by balancers that take the observed performance
+// of tablets into account when picking one.
+type QueryObserver interface {
+	// QueryStarted is called when a query is sent to the tablet picked by the
+	// balancer. The returned function must be called with the result of the
+	// query once it completes. Streaming queries are not reported.
+	QueryStarted(th *discovery.TabletHealth) (done func(err error))
+}
+
 // NewTabletBalancer creates a new tablet balancer based on the specified mode.
 // Supported modes:
 //   - "prefer-cell": Flow-based balancer that maintains cell affinity while balancing load
 //   - See the RFC here: https://github.com/vitessio/vitess/issues/12241
 //   - "random": Random balancer that uniformly distributes load without cell affinity
+//   - "peak-ewma": Latency-aware balancer that prefers the tablets with the lowest
+//     peak-EWMA latency and the fewest in-flight queries
 //
 // Note: "cell" mode is handled by the gateway and does not create a balancer instance.
 // operates as a round robin inside of the vtgate's cell
@@ -152,6 +168,8 @@ func NewTabletBalancer(mode Mode, localCell string, vtGateCells []string) (Table
 		return newFlowBalancer(localCell, vtGateCells), nil
 	case ModeRandom:
 		return newRandomBalancer(localCell, vtGateCells), nil
+	case ModePeakEWMA:
+		return newPeakEWMABalancer(localCell, vtGateCells), nil
 	case ModeCell:
 		return nil, errors.New("cell mode should be handled by the gateway, not the balancer factory")
 	default:
@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancer
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+
+The peakEWMABalancer routes queries away from tablets that are performing
+worse than their peers, e.g. a replica suffering from a noisy neighbor.
+
+For every tablet it keeps track of:
+
+* The peak-EWMA of the latency of the queries sent to it: an exponentially
+  weighted moving average that immediately jumps up to any latency higher
+  than the current average, and decays back down as faster queries complete
+  or as time passes without any queries.
+* The number of queries currently in flight.
+
+The cost of a tablet is its peak-EWMA latency multiplied by the number of
+in-flight queries plus one, i.e. an estimate of how long a new query would take.
+Tablets that have not served any query yet, e.g. right after startup, are
+assumed to be as fast as the average of their peers, so that they do not take
+the whole burst of queries. Streaming queries are not observed, as a long
+running stream says nothing about the latency of the tablet.
+Queries that fail because of the tablet, rather than because of the query
+itself, are recorded with a latency of at least peakEWMAPenalty, so that a
+tablet that fails fast does not look like a fast tablet.
+
+Tablets that have not served a query for peakEWMAPruneAge, e.g. because they
+were removed, are forgotten.
+
+To pick a tablet, the balancer uses the "power of two choices": it samples two
+tablets at random and picks the one with the lower cost. This sends most of the
+traffic to the fastest tablets while avoiding herding all vtgates onto the
+single best tablet. When the costs of both tablets are within
+peakEWMATieTolerance or peakEWMATieThreshold of each other, the tablet in the
+local cell is preferred.
+
+Like the random balancer, tablets can optionally be restricted to the cells in
+vtGateCells.
+
+*/
+
+const (
+	// peakEWMADecay is the time constant of the moving average: the weight of
+	// a latency sample halves after about 0.7 * peakEWMADecay.
+	peakEWMADecay = 10 * time.Second
+
+	// peakEWMAPenalty is the cost of a tablet that has queries in flight, but
+	// none has completed yet, so that unknown tablets are not flooded. It is
+	// also the minimum latency recorded for a query the tablet failed.
+	peakEWMAPenalty = float64(time.Second)
+
+	// peakEWMAPruneAge is how long a tablet has to go without queries before
+	// it is forgotten. By then its moving average has decayed to nothing.
+	peakEWMAPruneAge = 10 * peakEWMADecay
+
+	// peakEWMATieTolerance is the relative difference under which the costs of
+	// two tablets are considered equal, and the local cell is preferred.
+	peakEWMATieTolerance = 0.1
+
+	// peakEWMATieThreshold is the absolute difference under which the costs of
+	// two tablets are considered equal, since sub-millisecond differences are
+	// not worth leaving the local cell for.
+	peakEWMATieThreshold = float64(time.Millisecond)
+)
+
+func newPeakEWMABalancer(localCell string, vtGateCells []string) *peakEWMABalancer {
+	cellsMap := make(map[string]struct{}, len(vtGateCells))
+	for _, cell := range vtGateCells {
+		cellsMap[cell] = struct{}{}
+	}
+
+	return &peakEWMABalancer{
+		localCell:      localCell,
+		vtGateCells:    vtGateCells,
+		vtGateCellsMap: cellsMap,
+		decay:          peakEWMADecay,
+		now:            time.Now,
+		tablets:        map[tabletKey]*tabletLatency{},
+	}
+}
+
+type peakEWMABalancer struct {
+	// The local cell for the vtgate, preferred when tablet costs are equal
+	localCell string
+
+	// Optional list of cells to filter tablets to. If empty, all tablets are considered.
+	vtGateCells []string
+
+	// Map of vtGateCells for O(1) lookup performance. Initialized from vtGateCells.
+	vtGateCellsMap map[string]struct{}
+
+	// decay is the time constant of the moving average
+	decay time.Duration
+
+	// now returns the current time, overridden in tests
+	now func() time.Time
+
+	// mu protects the tablets map, but not its values
+	mu      sync.RWMutex
+	tablets map[tabletKey]*tabletLatency
+
+	// nextPrune is when idle tablets are next pruned, in Unix nanoseconds
+	nextPrune atomic.Int64
+}
+
+type tabletKey struct {
+	cell string
+	uid  uint32
+}
+
+func keyOf(th *discovery.TabletHealth) tabletKey {
+	return tabletKey{cell: th.Tablet.Alias.Cell, uid: th.Tablet.Alias.Uid}
+}
+
+// tabletLatency tracks the performance of a single tablet.
+type tabletLatency struct {
+	inflight atomic.Int64
+
+	mu         sync.Mutex
+	ewma       float64 // nanoseconds
+	lastUpdate time.Time
+}
+
+// observe adds a latency sample to the moving average.
+func (t *tabletLatency) observe(sample float64, now time.Time, decay time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sample > t.ewma {
+		// Peak sensitivity: jump up to latency spikes right away.
+		t.ewma = sample
+	} else {
+		w := math.Exp(-float64(now.Sub(t.lastUpdate)) / float64(decay))
+		t.ewma = t.ewma*w + sample*(1-w)
+	}
+	t.lastUpdate = now
+}
+
+// latency returns the moving average at the given time, decayed by the time
+// elapsed since the last sample.
+func (t *tabletLatency) latency(now time.Time, decay time.Duration) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.ewma == 0 {
+		return 0
+	}
+	return t.ewma * math.Exp(-float64(now.Sub(t.lastUpdate))/float64(decay))
+}
+
+// idle returns whether no query was sent to the tablet for the given time.
+func (t *tabletLatency) idle(now time.Time, age time.Duration) bool {
+	if t.inflight.Load() > 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return now.Sub(t.lastUpdate) > age
+}
+
+// cost estimates how long a new query sent to the tablet would take. If the
+// tablet has not served any query yet, its latency is assumed to be seed.
+func (t *tabletLatency) cost(now time.Time, decay time.Duration, seed float64) float64 {
+	inflight := t.inflight.Load()
+	latency := t.latency(now, decay)
+	if latency == 0 {
+		latency = seed
+	}
+	if latency == 0 && inflight > 0 {
+		return peakEWMAPenalty + float64(inflight)
+	}
+	return latency * float64(inflight+1)
+}
+
+// acquire returns the latency tracker of the tablet, with a query added to
+// its in-flight count. The count is incremented under mu, so that prune does
+// not remove a tablet that a query was just sent to.
+func (b *peakEWMABalancer) acquire(th *discovery.TabletHealth) *tabletLatency {
+	key := keyOf(th)
+
+	b.mu.RLock()
+	t, ok := b.tablets[key]
+	if ok {
+		t.inflight.Add(1)
+	}
+	b.mu.RUnlock()
+	if ok {
+		return t
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok = b.tablets[key]
+	if !ok {
+		t = &tabletLatency{}
+		b.tablets[key] = t
+	}
+	t.inflight.Add(1)
+	return t
+}
+
+// prune forgets the tablets that have been idle for peakEWMAPruneAge. It only
+// scans the tablets once per decay period.
+func (b *peakEWMABalancer) prune(now time.Time) {
+	next := b.nextPrune.Load()
+	if now.UnixNano() < next || !b.nextPrune.CompareAndSwap(next, now.Add(b.decay).UnixNano()) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, t := range b.tablets {
+		if t.idle(now, peakEWMAPruneAge) {
+			delete(b.tablets, key)
+		}
+	}
+}
+
+// cost estimates how long a new query sent to the tablet would take. If the
+// tablet has not served any query yet, its latency is assumed to be seed.
+func (b *peakEWMABalancer) cost(th *discovery.TabletHealth, now time.Time, seed float64) float64 {
+	b.mu.RLock()
+	t, ok := b.tablets[keyOf(th)]
+	b.mu.RUnlock()
+	if !ok {
+		return seed
+	}
+	return t.cost(now, b.decay, seed)
+}
+
+// averageLatency returns the average latency of the given tablets that have
+// served queries, or zero if none has.
+func (b *peakEWMABalancer) averageLatency(tablets []*discovery.TabletHealth, now time.Time) float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var sum float64
+	var count int
+	for _, th := range tablets {
+		t, ok := b.tablets[keyOf(th)]
+		if !ok {
+			continue
+		}
+		if latency := t.latency(now, b.decay); latency > 0 {
+			sum += latency
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// Pick returns the cheaper of two randomly sampled tablets, preferring the
+// local cell when their costs are about the same. If vtGateCells is
+// configured, only tablets in those cells are considered.
+func (b *peakEWMABalancer) Pick(target *querypb.Target, tablets []*discovery.TabletHealth) *discovery.TabletHealth {
+	if len(b.vtGateCells) > 0 {
+		filtered := make([]*discovery.TabletHealth, 0, len(tablets))
+		for _, tablet := range tablets {
+			if _, ok := b.vtGateCellsMap[tablet.Tablet.Alias.Cell]; ok {
+				filtered = append(filtered, tablet)
+			}
+		}
+		tablets = filtered
+	}
+
+	switch len(tablets) {
+	case 0:
+		return nil
+	case 1:
+		return tablets[0]
+	}
+
+	i := rand.IntN(len(tablets))
+	j := rand.IntN(len(tablets) - 1)
+	if j >= i {
+		j++
+	}
+	a, c := tablets[i], tablets[j]
+
+	now := b.now()
+	seed := b.averageLatency(tablets, now)
+	costA, costC := b.cost(a, now, seed), b.cost(c, now, seed)
+
+	if diff := math.Abs(costA - costC); diff <= peakEWMATieThreshold || diff <= peakEWMATieTolerance*max(costA, costC) {
+		aLocal := a.Tablet.Alias.Cell == b.localCell
+		cLocal := c.Tablet.Alias.Cell == b.localCell
+		if aLocal != cLocal {
+			if aLocal {
+				return a
+			}
+			return c
+		}
+	}
+
+	if costC < costA {
+		return c
+	}
+	return a
+}
+
+// QueryStarted is part of the QueryObserver interface.
+func (b *peakEWMABalancer) QueryStarted(th *discovery.TabletHealth) func(err error) {
+	start := b.now()
+	b.prune(start)
+	t := b.acquire(th)
+
+	return func(err error) {
+		now := b.now()
+		sample := float64(now.Sub(start))
+		if tabletFailed(err) {
+			sample = max(sample, peakEWMAPenalty)
+		}
+		t.observe(sample, now, b.decay)
+		t.inflight.Add(-1)
+	}
+}
+
+// tabletFailed returns whether err means that the tablet failed to serve a
+// query, as opposed to the query itself being invalid or canceled.
+func tabletFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_INVALID_ARGUMENT,
+		vtrpcpb.Code_NOT_FOUND,
+		vtrpcpb.Code_ALREADY_EXISTS,
+		vtrpcpb.Code_PERMISSION_DENIED,
+		vtrpcpb.Code_UNAUTHENTICATED,
+		vtrpcpb.Code_FAILED_PRECONDITION,
+		vtrpcpb.Code_OUT_OF_RANGE,
+		vtrpcpb.Code_CANCELED:
+		return false
+	default:
+		return true
+	}
+}
+
+type peakEWMAScore struct {
+	key      tabletKey
+	latency  time.Duration
+	inflight int64
+	cost     time.Duration
+}
+
+func (b *peakEWMABalancer) scores() []peakEWMAScore {
+	now := b.now()
+
+	b.mu.RLock()
+	scores := make([]peakEWMAScore, 0, len(b.tablets))
+	for key, t := range b.tablets {
+		scores = append(scores, peakEWMAScore{
+			key:      key,
+			latency:  time.Duration(t.latency(now, b.decay)),
+			inflight: t.inflight.Load(),
+			cost:     time.Duration(t.cost(now, b.decay, 0)),
+		})
+	}
+	b.mu.RUnlock()
+
+	slices.SortFunc(scores, func(a, b peakEWMAScore) int {
+		return cmp.Or(
+			cmp.Compare(a.cost, b.cost),
+			cmp.Compare(a.key.cell, b.key.cell),
+			cmp.Compare(a.key.uid, b.key.uid),
+		)
+	})
+	return scores
+}
+
+func (b *peakEWMABalancer) DebugHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "Balancer Mode: peak-ewma\r\n")
+	fmt.Fprintf(w, "Local Cell: %v\r\n", b.localCell)
+	if len(b.vtGateCells) > 0 {
+		fmt.Fprintf(w, "Filtered to Cells: %v\r\n", b.vtGateCells)
+	} else {
+		fmt.Fprintf(w, "Cells: all (no filter)\r\n")
+	}
+	fmt.Fprintf(w, "Decay: %v\r\n", b.decay)
+	fmt.Fprintf(w, "Strategy: Power of two choices by peak-EWMA latency * (in-flight queries + 1), local cell breaks ties\r\n")
+	fmt.Fprintf(w, "\r\n%-24s %14s %10s %14s\r\n", "Tablet", "Latency", "In-flight", "Score")
+	for _, s := range b.scores() {
+		alias := fmt.Sprintf("%s-%010d", s.key.cell, s.key.uid)
+		fmt.Fprintf(w, "%-24s %14v %10d %14v\r\n", alias, s.latency.Round(time.Microsecond), s.inflight, s.cost.Round(time.Microsecond))
+	}
+}
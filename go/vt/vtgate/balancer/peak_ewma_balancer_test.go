@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancer
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// fakeClock is a manually advanced time source.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestPeakEWMABalancer(localCell string, vtGateCells []string) (*peakEWMABalancer, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	b := newPeakEWMABalancer(localCell, vtGateCells)
+	b.now = clock.now
+	return b, clock
+}
+
+// runQuery records a query that took d on th.
+func runQuery(b *peakEWMABalancer, clock *fakeClock, th *discovery.TabletHealth, d time.Duration) {
+	done := b.QueryStarted(th)
+	clock.t = clock.t.Add(d)
+	done(nil)
+}
+
+func TestPeakEWMABalancerObserve(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	th := createTestTablet("cell1")
+
+	// Peaks are picked up immediately.
+	runQuery(b, clock, th, 10*time.Millisecond)
+	assert.InDelta(t, float64(10*time.Millisecond), b.cost(th, clock.t, 0), 1)
+	runQuery(b, clock, th, 100*time.Millisecond)
+	assert.InDelta(t, float64(100*time.Millisecond), b.cost(th, clock.t, 0), 1)
+
+	// Faster queries bring the average down gradually.
+	runQuery(b, clock, th, time.Millisecond)
+	cost := b.cost(th, clock.t, 0)
+	assert.Less(t, cost, float64(100*time.Millisecond))
+	assert.Greater(t, cost, float64(50*time.Millisecond))
+
+	// The average decays while the tablet is idle.
+	clock.t = clock.t.Add(10 * b.decay)
+	assert.Less(t, b.cost(th, clock.t, 0), float64(time.Millisecond))
+}
+
+func TestPeakEWMABalancerInflight(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	th := createTestTablet("cell1")
+
+	// A tablet with queries in flight but no completed ones gets the penalty.
+	done := b.QueryStarted(th)
+	assert.Greater(t, b.cost(th, clock.t, 0), peakEWMAPenalty)
+	clock.t = clock.t.Add(10 * time.Millisecond)
+	done(nil)
+
+	// Each in-flight query adds the expected latency to the cost.
+	done1 := b.QueryStarted(th)
+	done2 := b.QueryStarted(th)
+	assert.InDelta(t, float64(30*time.Millisecond), b.cost(th, clock.t, 0), 1)
+	done1(nil)
+	done2(nil)
+	assert.EqualValues(t, 0, b.tablets[keyOf(th)].inflight.Load())
+}
+
+func TestPeakEWMABalancerErrors(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	failing := createTestTablet("cell1")
+	badQuery := createTestTablet("cell1")
+
+	// A tablet that fails fast is not mistaken for a fast tablet.
+	done := b.QueryStarted(failing)
+	clock.t = clock.t.Add(time.Millisecond)
+	done(vterrors.New(vtrpcpb.Code_UNAVAILABLE, "connection refused"))
+	assert.InDelta(t, peakEWMAPenalty, b.cost(failing, clock.t, 0), 1)
+
+	// Errors caused by the query itself are observed like any other query.
+	done = b.QueryStarted(badQuery)
+	clock.t = clock.t.Add(time.Millisecond)
+	done(vterrors.New(vtrpcpb.Code_ALREADY_EXISTS, "duplicate entry"))
+	assert.InDelta(t, float64(time.Millisecond), b.cost(badQuery, clock.t, 0), 1)
+}
+
+func TestPeakEWMABalancerPrune(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	removed := createTestTablet("cell1")
+	busy := createTestTablet("cell1")
+
+	runQuery(b, clock, removed, 10*time.Millisecond)
+	done := b.QueryStarted(busy)
+	require.Len(t, b.tablets, 2)
+
+	// Tablets are kept until they have been idle for the prune age, and
+	// tablets with queries in flight are never pruned.
+	clock.t = clock.t.Add(peakEWMAPruneAge / 2)
+	runQuery(b, clock, createTestTablet("cell1"), time.Millisecond)
+	assert.Contains(t, b.tablets, keyOf(removed))
+
+	clock.t = clock.t.Add(peakEWMAPruneAge)
+	runQuery(b, clock, createTestTablet("cell1"), time.Millisecond)
+	assert.NotContains(t, b.tablets, keyOf(removed))
+	assert.Contains(t, b.tablets, keyOf(busy))
+	done(nil)
+}
+
+func TestPeakEWMABalancerAvoidsSlowTablet(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	slow := createTestTablet("cell1")
+	tablets := []*discovery.TabletHealth{
+		slow,
+		createTestTablet("cell1"),
+		createTestTablet("cell2"),
+	}
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+
+	runQuery(b, clock, slow, 500*time.Millisecond)
+	for _, th := range tablets[1:] {
+		runQuery(b, clock, th, 5*time.Millisecond)
+	}
+
+	for range 1000 {
+		th := b.Pick(target, tablets)
+		require.NotNil(t, th)
+		assert.NotEqual(t, slow.Tablet.Alias.Uid, th.Tablet.Alias.Uid, "slow tablet should not be picked")
+	}
+}
+
+func TestPeakEWMABalancerSeedsNewTablets(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	tablets := []*discovery.TabletHealth{
+		createTestTablet("cell1"),
+		createTestTablet("cell1"),
+		createTestTablet("cell1"),
+	}
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+
+	// A new tablet is assumed to be as fast as its peers.
+	newTablet := tablets[2]
+	for _, th := range tablets[:2] {
+		runQuery(b, clock, th, 10*time.Millisecond)
+	}
+	assert.InEpsilon(t, float64(10*time.Millisecond), b.cost(newTablet, clock.t, b.averageLatency(tablets, clock.t)), 0.01)
+
+	// A burst of queries is spread across all tablets, instead of going to
+	// the new tablet until it has completed a query.
+	picks := map[uint32]int{}
+	var done []func(error)
+	for range 30 {
+		th := b.Pick(target, tablets)
+		require.NotNil(t, th)
+		picks[th.Tablet.Alias.Uid]++
+		done = append(done, b.QueryStarted(th))
+	}
+	for _, th := range tablets {
+		assert.InDelta(t, 10, picks[th.Tablet.Alias.Uid], 5)
+	}
+	for _, d := range done {
+		d(nil)
+	}
+}
+
+func TestPeakEWMABalancerPrefersLocalCell(t *testing.T) {
+	b, _ := newTestPeakEWMABalancer("cell1", nil)
+	local := createTestTablet("cell1")
+	tablets := []*discovery.TabletHealth{
+		local,
+		createTestTablet("cell2"),
+	}
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+
+	// No latency is known for either tablet, so the local one always wins.
+	for range 100 {
+		th := b.Pick(target, tablets)
+		require.NotNil(t, th)
+		assert.Equal(t, local.Tablet.Alias.Uid, th.Tablet.Alias.Uid)
+	}
+}
+
+func TestPeakEWMABalancerCellFilter(t *testing.T) {
+	b, _ := newTestPeakEWMABalancer("cell1", []string{"cell2"})
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+
+	assert.Nil(t, b.Pick(target, nil))
+	assert.Nil(t, b.Pick(target, []*discovery.TabletHealth{createTestTablet("cell1")}))
+
+	remote := createTestTablet("cell2")
+	th := b.Pick(target, []*discovery.TabletHealth{createTestTablet("cell1"), remote})
+	require.NotNil(t, th)
+	assert.Equal(t, remote.Tablet.Alias.Uid, th.Tablet.Alias.Uid)
+}
+
+func TestPeakEWMABalancerDebugHandler(t *testing.T) {
+	b, clock := newTestPeakEWMABalancer("cell1", nil)
+	th := createTestTablet("cell1")
+	runQuery(b, clock, th, 10*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	b.DebugHandler(w, httptest.NewRequest("GET", "/debug/balancer", nil))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "Balancer Mode: peak-ewma")
+	assert.Contains(t, body, "Local Cell: cell1")
+	assert.Regexp(t, `cell1-\d{10}\s+10ms\s+0\s+10ms`, body)
+}
+
+func TestNewTabletBalancerPeakEWMA(t *testing.T) {
+	assert.Equal(t, ModePeakEWMA, ParseMode("peak-ewma"))
+	assert.Contains(t, GetAvailableModeNames(), "peak-ewma")
+
+	b, err := NewTabletBalancer(ModePeakEWMA, "cell1", nil)
+	require.NoError(t, err)
+	assert.Implements(t, (*QueryObserver)(nil), b)
+}
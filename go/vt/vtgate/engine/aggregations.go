@@ -308,6 +308,10 @@ type aggregatorGroupConcat struct {
 	type_     sqltypes.Type
 	separator []byte
 
+	// qm accounts for the bytes of concat, which grows with the group.
+	qm   *QueryMemory
+	size int64
+
 	concat []byte
 	n      int
 }
@@ -316,11 +320,18 @@ func (a *aggregatorGroupConcat) add(row []sqltypes.Value) error {
 	if row[a.from].IsNull() {
 		return nil
 	}
+	before := len(a.concat)
 	if a.n > 0 {
 		a.concat = append(a.concat, a.separator...)
 	}
 	a.concat = append(a.concat, row[a.from].Raw()...)
 	a.n++
+
+	size := int64(len(a.concat) - before)
+	a.size += size
+	if !a.qm.Grow(size) {
+		return a.qm.exceededError()
+	}
 	return nil
 }
 
@@ -334,6 +345,8 @@ func (a *aggregatorGroupConcat) finish(*evalengine.ExpressionEnv, collations.ID)
 func (a *aggregatorGroupConcat) reset() {
 	a.n = 0
 	a.concat = nil // not safe to reuse this byte slice as it's returned as MakeTrusted
+	a.qm.Release(a.size)
+	a.size = 0
 }
 
 type aggregatorGtid struct {
@@ -411,7 +424,7 @@ func isComparable(typ sqltypes.Type) bool {
 	return false
 }
 
-func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env *evalengine.ExpressionEnv, collation collations.ID) (*aggregationState, []*querypb.Field, error) {
+func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env *evalengine.ExpressionEnv, collation collations.ID, qm *QueryMemory) (*aggregationState, []*querypb.Field, error) {
 	fields = slice.Map(fields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
 
 	aggregators := make([]aggregator, len(fields))
@@ -502,6 +515,7 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env
 				from:      aggr.Col,
 				type_:     targetType,
 				separator: separator,
+				qm:        qm,
 			}
 
 		case opcode.AggregateConstant:
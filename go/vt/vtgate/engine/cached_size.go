@@ -1617,6 +1617,57 @@ func (cached *VitessMetadata) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Value)))
 	return size
 }
+func (cached *WeightedRoute) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Rules string
+	size += hack.RuntimeAllocSize(int64(len(cached.Rules)))
+	// field Source vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Source.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Target vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Target.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
+//go:nocheckptr
+func (cached *WeightedShardRoute) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Rules string
+	size += hack.RuntimeAllocSize(int64(len(cached.Rules)))
+	// field Shards map[string][]*vitess.io/vitess/go/vt/proto/topodata.ShardReference
+	if cached.Shards != nil {
+		size += hack.RuntimeMapSize(cached.Shards)
+		for k, v := range cached.Shards {
+			size += hack.RuntimeAllocSize(int64(len(k)))
+			{
+				size += hack.RuntimeAllocSize(int64(cap(v)) * int64(8))
+				for _, elem := range v {
+					size += elem.CachedSize(true)
+				}
+			}
+		}
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *percentBasedMirror) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
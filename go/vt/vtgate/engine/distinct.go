@@ -33,6 +33,9 @@ import (
 // Distinct Primitive is used to uniqueify results
 var _ Primitive = (*Distinct)(nil)
 
+// probeTableEntrySize is the estimated memory used by a row seen by the probe table.
+const probeTableEntrySize = 48
+
 type (
 	// Distinct Primitive is used to uniqueify results
 	Distinct struct {
@@ -115,6 +118,15 @@ func (d *Distinct) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 
 	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
 
+	qm := vcursor.QueryMemory()
+	size, err := qm.growRows(input.Rows)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		qm.Release(size)
+	}()
+
 	for _, row := range input.Rows {
 		appendRow, err := pt.exists(row)
 		if err != nil {
@@ -122,6 +134,10 @@ func (d *Distinct) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 		}
 		if appendRow != nil {
 			result.Rows = append(result.Rows, appendRow)
+			size += probeTableEntrySize
+			if !qm.Grow(probeTableEntrySize) {
+				return nil, qm.exceededError()
+			}
 		}
 	}
 	if d.Truncate > 0 {
@@ -135,6 +151,22 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 	var mu sync.Mutex
 
 	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
+
+	// When the probe table exceeds the memory limit, it stops growing: the rows it has
+	// not seen are partitioned to disk by hash instead, and deduplicated one partition
+	// at a time once all the input is read.
+	qm := vcursor.QueryMemory()
+	var (
+		spill  *spillPartitionFiles
+		ptSize int64
+	)
+	defer func() {
+		qm.Release(ptSize)
+		if spill != nil {
+			spill.close()
+		}
+	}()
+
 	err := vcursor.StreamExecutePrimitive(ctx, d.Source, bindVars, wantfields, func(input *sqltypes.Result) error {
 		result := &sqltypes.Result{
 			Fields:   input.Fields,
@@ -143,18 +175,78 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 		mu.Lock()
 		defer mu.Unlock()
 		for _, row := range input.Rows {
-			appendRow, err := pt.exists(row)
+			code, err := pt.hashCodeForRow(row)
 			if err != nil {
 				return err
 			}
-			if appendRow != nil {
-				result.Rows = append(result.Rows, appendRow)
+			if _, found := pt.seenRows[code]; found {
+				continue
+			}
+			if spill != nil {
+				if err := spill.write(code, row); err != nil {
+					return err
+				}
+				continue
 			}
+			pt.seenRows[code] = struct{}{}
+			result.Rows = append(result.Rows, row)
+			ptSize += probeTableEntrySize
+			if qm.Grow(probeTableEntrySize) {
+				continue
+			}
+			if !qm.CanSpill() {
+				return qm.exceededError()
+			}
+			querySpills.Add("Distinct", 1)
+			spill = newSpillPartitionFiles(qm, "Distinct")
 		}
 		return callback(result.Truncate(len(d.CheckCols)))
 	})
+	if err != nil || spill == nil {
+		return err
+	}
 
-	return err
+	for i := range spillPartitions {
+		if err := d.sendPartition(qm, pt, spill.partition(i), callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendPartition sends the distinct rows of a partition which spilled to disk.
+func (d *Distinct) sendPartition(qm *QueryMemory, pt *probeTable, partition *spillFile, callback func(*sqltypes.Result) error) error {
+	// all the rows of the partition are new, and only need to be deduplicated among themselves
+	seen := make(map[vthash.Hash]struct{})
+	defer func() {
+		qm.Release(int64(len(seen)) * probeTableEntrySize)
+	}()
+	var rows []sqltypes.Row
+	err := forEachRow(partition, func(row sqltypes.Row) error {
+		code, err := pt.hashCodeForRow(row)
+		if err != nil {
+			return err
+		}
+		if _, found := seen[code]; found {
+			return nil
+		}
+		seen[code] = struct{}{}
+		// the partition is expected to fit in memory, so its size is only accounted for
+		qm.Grow(probeTableEntrySize)
+		rows = append(rows, row)
+		if len(rows) >= spillBatchSize {
+			err = callback((&sqltypes.Result{Rows: rows}).Truncate(len(d.CheckCols)))
+			rows = nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		return callback((&sqltypes.Result{Rows: rows}).Truncate(len(d.CheckCols)))
+	}
+	return nil
 }
 
 // GetFields implements the Primitive interface
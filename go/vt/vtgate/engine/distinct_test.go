@@ -27,6 +27,7 @@ import (
 
 	"vitess.io/vitess/go/test/utils"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -217,3 +218,62 @@ func TestWeightStringFallBack(t *testing.T) {
 		Type:  evalengine.NewType(sqltypes.VarBinary, collations.CollationBinaryID),
 	}}, distinct.CheckCols, "checkCols should not be updated")
 }
+
+func TestDistinctSpill(t *testing.T) {
+	distinct := &Distinct{
+		Source: &fakePrimitive{
+			results: sqltypes.MakeTestStreamingResults(sqltypes.MakeTestFields("myid|id", "varchar|int64"),
+				"a|1",
+				"b|1",
+				"a|1",
+				"---",
+				"c|1",
+				"b|1",
+				"d|2",
+				"c|1",
+				"---",
+				"e|1",
+				"d|2",
+				"a|1",
+			),
+			allResultsInOneCall: true,
+		},
+		CheckCols: []CheckCol{
+			{Col: 0, Type: evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID)},
+			{Col: 1, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)},
+		},
+	}
+
+	// two distinct rows fit in memory, the others are spilled
+	spillDir := t.TempDir()
+	qm := NewQueryMemory(2*probeTableEntrySize, true, spillDir)
+	qr, err := wrapStreamExecute(distinct, &noopVCursor{queryMemory: qm}, nil, true)
+	require.NoError(t, err)
+	expectResultAnyOrder(t, qr, sqltypes.MakeTestResult(sqltypes.MakeTestFields("myid|id", "varchar|int64"),
+		"a|1",
+		"b|1",
+		"c|1",
+		"d|2",
+		"e|1",
+	))
+	assert.Zero(t, qm.Used())
+	assertEmptyDir(t, spillDir)
+
+	distinct.Source.(*fakePrimitive).rewind()
+	qm = NewQueryMemory(2*probeTableEntrySize, false, "")
+	_, err = wrapStreamExecute(distinct, &noopVCursor{queryMemory: qm}, nil, true)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 96")
+
+	// non-streaming queries hold all their input in memory, and cannot spill
+	distinct.Source = &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("myid|id", "varchar|int64"),
+			"a|1",
+			"b|1",
+			"a|1",
+		)},
+	}
+	qm = NewQueryMemory(2*probeTableEntrySize, true, t.TempDir())
+	_, err = distinct.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, nil, true)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 96")
+	assert.Zero(t, qm.Used())
+}
@@ -58,7 +58,8 @@ var (
 
 // noopVCursor is used to build other vcursors.
 type noopVCursor struct {
-	inTx bool
+	inTx        bool
+	queryMemory *QueryMemory
 }
 
 func (t *noopVCursor) GetExecutionMetrics() *Metrics {
@@ -175,6 +176,10 @@ func (t *noopVCursor) SQLMode() string {
 	return config.DefaultSQLMode
 }
 
+func (t *noopVCursor) BlockEncryptionMode() string {
+	return config.DefaultBlockEncryptionMode
+}
+
 func (t *noopVCursor) ExecutePrimitive(ctx context.Context, primitive Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
 	return primitive.TryExecute(ctx, t, bindVars, wantfields)
 }
@@ -271,6 +276,9 @@ func (t *noopVCursor) SetInDMLExecution(inDMLExec bool) {
 	panic("implement me")
 }
 
+func (t *noopVCursor) InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value) {
+}
+
 func (t *noopVCursor) InTransactionAndIsDML() bool {
 	panic("implement me")
 }
@@ -367,6 +375,10 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) QueryMemory() *QueryMemory {
+	return t.queryMemory
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return "test_ks"
 }
@@ -682,7 +694,7 @@ func (f *loggingVCursor) ResolveDestinations(ctx context.Context, keyspace strin
 				}
 			}
 			shards = f.shards
-		case key.DestinationKeyRange:
+		case key.DestinationKeyRange, *key.DestinationKeyRange:
 			shards = f.shardForKsid
 		case key.DestinationKeyspaceID:
 			if f.shardForKsid == nil || f.curShardForKsid >= len(f.shardForKsid) {
@@ -98,6 +98,13 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 		return nil, err
 	}
 
+	qm := vcursor.QueryMemory()
+	size, err := qm.growRows(lresult.Rows)
+	if err != nil {
+		return nil, err
+	}
+	defer qm.Release(size)
+
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
 	// build the probe table from the LHS result
 	for _, row := range lresult.Rows {
@@ -135,6 +142,21 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	// build the probe table from the LHS result
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+
+	// When the probe table exceeds the memory limit, both sides are partitioned
+	// to disk by the hash of their join column, and joined one partition at a time.
+	qm := vcursor.QueryMemory()
+	var (
+		spill  *hashJoinSpill
+		ptSize int64
+	)
+	defer func() {
+		qm.Release(ptSize)
+		if spill != nil {
+			spill.close()
+		}
+	}()
+
 	var lfields []*querypb.Field
 	var mu sync.Mutex
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
@@ -144,10 +166,31 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			lfields = result.Fields
 		}
 		for _, current := range result.Rows {
+			if spill != nil {
+				if err := spill.addLeftRow(pt, current); err != nil {
+					return err
+				}
+				continue
+			}
 			err := pt.addLeftRow(current)
 			if err != nil {
 				return err
 			}
+			size := rowMemorySize(current)
+			ptSize += size
+			if qm.Grow(size) {
+				continue
+			}
+			if !qm.CanSpill() {
+				return qm.exceededError()
+			}
+			spill = newHashJoinSpill(qm)
+			if err := spill.addProbeTable(pt); err != nil {
+				return err
+			}
+			pt = newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+			qm.Release(ptSize)
+			ptSize = 0
 		}
 		return nil
 	})
@@ -167,6 +210,12 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			res.Fields = joinFields(lfields, result.Fields, hj.Cols)
 		}
 		for _, currentRHSRow := range result.Rows {
+			if spill != nil {
+				if err := spill.addRightRow(pt, currentRHSRow); err != nil {
+					return err
+				}
+				continue
+			}
 			results, err := pt.get(currentRHSRow)
 			if err != nil {
 				return err
@@ -182,23 +231,32 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 		return err
 	}
 
-	if hj.Opcode == LeftJoin {
-		res := &sqltypes.Result{}
-		if sendFields.CompareAndSwap(true, false) {
-			// If we still have not sent the fields, we need to fetch
-			// the fields from the RHS to be able to build the result fields
-			rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
-			if err != nil {
+	if hj.Opcode != LeftJoin && spill == nil {
+		return nil
+	}
+
+	res := &sqltypes.Result{}
+	if hj.Opcode == LeftJoin && sendFields.CompareAndSwap(true, false) {
+		// If we still have not sent the fields, we need to fetch
+		// the fields from the RHS to be able to build the result fields
+		rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
+		if err != nil {
+			return err
+		}
+		res.Fields = joinFields(lfields, rres.Fields, hj.Cols)
+	}
+	if spill != nil {
+		if len(res.Fields) != 0 {
+			if err := callback(res); err != nil {
 				return err
 			}
-			res.Fields = joinFields(lfields, rres.Fields, hj.Cols)
 		}
-		// this will only be called when all the concurrent access to the pt has
-		// ceased, so we don't need to lock it here
-		res.Rows = pt.notFetched()
-		return callback(res)
+		return spill.join(hj, callback)
 	}
-	return nil
+	// this will only be called when all the concurrent access to the pt has
+	// ceased, so we don't need to lock it here
+	res.Rows = pt.notFetched()
+	return callback(res)
 }
 
 // GetFields implements the Primitive interface
@@ -311,3 +369,116 @@ func (pt *hashJoinProbeTable) notFetched() (rows []sqltypes.Row) {
 	}
 	return
 }
+
+// hashJoinSpill holds the rows of both sides of a hash join which spilled to
+// disk, partitioned by the hash of their join column.
+type hashJoinSpill struct {
+	qm          *QueryMemory
+	left, right *spillPartitionFiles
+}
+
+func newHashJoinSpill(qm *QueryMemory) *hashJoinSpill {
+	querySpills.Add("HashJoin", 1)
+	return &hashJoinSpill{
+		qm:    qm,
+		left:  newSpillPartitionFiles(qm, "HashJoin"),
+		right: newSpillPartitionFiles(qm, "HashJoin"),
+	}
+}
+
+// addProbeTable moves the rows of the probe table to disk.
+func (s *hashJoinSpill) addProbeTable(pt *hashJoinProbeTable) error {
+	for _, e := range pt.innerMap {
+		for ; e != nil; e = e.next {
+			if err := s.addLeftRow(pt, e.row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *hashJoinSpill) addLeftRow(pt *hashJoinProbeTable, row sqltypes.Row) error {
+	hash, err := pt.hash(row[pt.lhsKey])
+	if err != nil {
+		return err
+	}
+	return s.left.write(hash, row)
+}
+
+func (s *hashJoinSpill) addRightRow(pt *hashJoinProbeTable, row sqltypes.Row) error {
+	val := row[pt.rhsKey]
+	// NULL never matches, so the row can be dropped
+	if val.IsNull() {
+		return nil
+	}
+	hash, err := pt.hash(val)
+	if err != nil {
+		return err
+	}
+	return s.right.write(hash, row)
+}
+
+// join joins the partitions one at a time, building the probe table from the
+// LHS rows of the partition and probing it with its RHS rows.
+func (s *hashJoinSpill) join(hj *HashJoin, callback func(*sqltypes.Result) error) error {
+	for i := range spillPartitions {
+		if err := s.joinPartition(hj, i, callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *hashJoinSpill) joinPartition(hj *HashJoin, i int, callback func(*sqltypes.Result) error) error {
+	left := s.left.partition(i)
+	if left == nil {
+		// no RHS row of the partition can match
+		return nil
+	}
+
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	var ptSize int64
+	defer func() {
+		s.qm.Release(ptSize)
+	}()
+	err := forEachRow(left, func(row sqltypes.Row) error {
+		// the partition is expected to fit in memory, so its size is only accounted for
+		size := rowMemorySize(row)
+		ptSize += size
+		s.qm.Grow(size)
+		return pt.addLeftRow(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows []sqltypes.Row
+	err = forEachRow(s.right.partition(i), func(row sqltypes.Row) error {
+		matches, err := pt.get(row)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, matches...)
+		if len(rows) >= spillBatchSize {
+			err = callback(&sqltypes.Result{Rows: rows})
+			rows = nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if hj.Opcode == LeftJoin {
+		rows = append(rows, pt.notFetched()...)
+	}
+	if len(rows) > 0 {
+		return callback(&sqltypes.Result{Rows: rows})
+	}
+	return nil
+}
+
+func (s *hashJoinSpill) close() {
+	s.left.close()
+	s.right.close()
+}
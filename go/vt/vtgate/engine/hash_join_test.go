@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -158,9 +159,56 @@ func TestHashJoinVariations(t *testing.T) {
 			require.NoError(t, err)
 			expectResultAnyOrder(t, r, expected)
 		})
+		t.Run("Spilling "+tc.name, func(t *testing.T) {
+			jn.Left = first()
+			jn.Right = last()
+			spillDir := t.TempDir()
+			qm := NewQueryMemory(1, true, spillDir)
+			r, err := wrapStreamExecute(jn, &noopVCursor{queryMemory: qm}, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResultAnyOrder(t, r, expected)
+			assert.Zero(t, qm.Used())
+			assertEmptyDir(t, spillDir)
+		})
 	}
 }
 
+func TestHashJoinMaxMemoryBytes(t *testing.T) {
+	jn := &HashJoin{
+		Opcode: InnerJoin,
+		Left: &fakePrimitive{
+			results: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("col1", "int64"), "1", "2")},
+		},
+		Right: &fakePrimitive{
+			results: []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("col2", "int64"), "1")},
+		},
+		Cols:           []int{-1, 1},
+		ComparisonType: sqltypes.Int64,
+		Collation:      collations.CollationBinaryID,
+		CollationEnv:   collations.MySQL8(),
+	}
+
+	qm := NewQueryMemory(1, false, "")
+	_, err := wrapStreamExecute(jn, &noopVCursor{queryMemory: qm}, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 1")
+	assert.Zero(t, qm.Used())
+
+	// non-streaming queries cannot spill, and fail as well
+	jn.Left.(*fakePrimitive).rewind()
+	jn.Right.(*fakePrimitive).rewind()
+	qm = NewQueryMemory(1, true, t.TempDir())
+	_, err = jn.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 1")
+	assert.Zero(t, qm.Used())
+
+	jn.Left.(*fakePrimitive).rewind()
+	jn.Right.(*fakePrimitive).rewind()
+	qm = NewQueryMemory(0, false, "")
+	_, err = jn.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	assert.Zero(t, qm.Used())
+}
+
 func typeForOffset(i int) evalengine.Type {
 	switch i {
 	case 0:
@@ -56,6 +56,13 @@ func (ms *MemorySort) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 		return nil, err
 	}
 
+	qm := vcursor.QueryMemory()
+	size, err := qm.growRows(result.Rows)
+	if err != nil {
+		return nil, err
+	}
+	defer qm.Release(size)
+
 	if err = ms.OrderBy.SortResult(result); err != nil {
 		return nil, err
 	}
@@ -83,6 +90,20 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		Limit:   count,
 	}
 
+	// When the sorter exceeds the memory limits, its rows are sorted and spilled
+	// to disk as a run. All the runs are merged once all the input is read.
+	qm := vcursor.QueryMemory()
+	var (
+		runs       []*spillFile
+		sorterSize int64
+	)
+	defer func() {
+		qm.Release(sorterSize)
+		for _, run := range runs {
+			run.close()
+		}
+	}()
+
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		mu.Lock()
@@ -92,18 +113,66 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 				return err
 			}
 		}
+		withinMemory := true
 		for _, row := range qr.Rows {
+			rows := sorter.Len()
 			sorter.Push(row)
+			// a row which does not grow the sorter replaced one, or was dropped because of the limit
+			if sorter.Len() > rows {
+				size := rowMemorySize(row)
+				sorterSize += size
+				withinMemory = qm.Grow(size) && withinMemory
+			}
+		}
+		exceedsMaxMemoryRows := vcursor.ExceedsMaxMemoryRows(sorter.Len())
+		if withinMemory && !exceedsMaxMemoryRows {
+			return nil
+		}
+		if !qm.CanSpill() {
+			if exceedsMaxMemoryRows {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+			return qm.exceededError()
 		}
-		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
-			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		if len(runs) == 0 {
+			querySpills.Add("Sort", 1)
+		}
+		run, err := qm.newSpillFile("Sort")
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+		for _, row := range sorter.Sorted() {
+			if err := run.write(row); err != nil {
+				return err
+			}
+		}
+		qm.Release(sorterSize)
+		sorterSize = 0
+		sorter = &evalengine.Sorter{
+			Compare: ms.OrderBy,
+			Limit:   count,
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	if len(runs) == 0 {
+		return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	}
+
+	merged := []*sortedRun{{rows: sorter.Sorted()}}
+	for _, run := range runs {
+		reader, err := run.reader()
+		if err != nil {
+			return err
+		}
+		merged = append(merged, &sortedRun{reader: reader})
+	}
+	return mergeSortedRuns(merged, ms.OrderBy.Compare, count, func(rows []sqltypes.Row) error {
+		return cb(&sqltypes.Result{Rows: rows})
+	})
 }
 
 // GetFields satisfies the Primitive interface.
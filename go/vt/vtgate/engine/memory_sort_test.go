@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -610,6 +611,124 @@ func TestMemorySortMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestMemorySortSpill(t *testing.T) {
+	saveMax := testMaxMemoryRows
+	testMaxMemoryRows = 3
+	defer func() { testMaxMemoryRows = saveMax }()
+
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	testCases := []struct {
+		name  string
+		qm    func(spillDir string) *QueryMemory
+		limit int64
+		want  []string
+	}{{
+		name: "max memory rows",
+		qm: func(spillDir string) *QueryMemory {
+			return NewQueryMemory(0, true, spillDir)
+		},
+		want: []string{"a|1", "a|1", "g|2", "c|3", "c|4", "e|5", "b|6", "f|7"},
+	}, {
+		name: "max memory bytes",
+		qm: func(spillDir string) *QueryMemory {
+			return NewQueryMemory(1, true, spillDir)
+		},
+		want: []string{"a|1", "a|1", "g|2", "c|3", "c|4", "e|5", "b|6", "f|7"},
+	}, {
+		name: "upper limit",
+		qm: func(spillDir string) *QueryMemory {
+			return NewQueryMemory(1, true, spillDir)
+		},
+		limit: 4,
+		want:  []string{"a|1", "a|1", "g|2", "c|3"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := &fakePrimitive{
+				results: sqltypes.MakeTestStreamingResults(
+					fields,
+					"c|4",
+					"a|1",
+					"---",
+					"f|7",
+					"g|2",
+					"---",
+					"b|6",
+					"c|3",
+					"e|5",
+					"a|1",
+				),
+				allResultsInOneCall: true,
+			}
+			ms := &MemorySort{
+				OrderBy: []evalengine.OrderByParams{{
+					WeightStringCol: -1,
+					Col:             1,
+				}},
+				Input: fp,
+			}
+			var bv map[string]*querypb.BindVariable
+			if tc.limit > 0 {
+				ms.UpperLimit = evalengine.NewBindVar("__upper_limit", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID))
+				bv = map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(tc.limit)}
+			}
+
+			spillDir := t.TempDir()
+			qm := tc.qm(spillDir)
+			spills := querySpills.Counts()["Sort"]
+			result, err := wrapStreamExecute(ms, &noopVCursor{queryMemory: qm}, bv, true)
+			require.NoError(t, err)
+			utils.MustMatch(t, sqltypes.MakeTestResult(fields, tc.want...), result)
+			assert.EqualValues(t, 1, querySpills.Counts()["Sort"]-spills)
+			assert.Zero(t, qm.Used())
+			assertEmptyDir(t, spillDir)
+		})
+	}
+
+	t.Run("max memory bytes without spilling", func(t *testing.T) {
+		ms := &MemorySort{
+			OrderBy: []evalengine.OrderByParams{{
+				WeightStringCol: -1,
+				Col:             1,
+			}},
+			Input: &fakePrimitive{
+				results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "a|1")},
+			},
+		}
+		qm := NewQueryMemory(1, false, "")
+		_, err := wrapStreamExecute(ms, &noopVCursor{queryMemory: qm}, nil, true)
+		require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 1")
+		assert.Zero(t, qm.Used())
+	})
+
+	t.Run("max memory bytes of non-streaming queries", func(t *testing.T) {
+		input := &fakePrimitive{
+			results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "b|2", "a|1")},
+		}
+		ms := &MemorySort{
+			OrderBy: []evalengine.OrderByParams{{
+				WeightStringCol: -1,
+				Col:             1,
+			}},
+			Input: input,
+		}
+		qm := NewQueryMemory(1, true, t.TempDir())
+		_, err := ms.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, nil, true)
+		require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 1")
+		assert.Zero(t, qm.Used())
+
+		input.rewind()
+		qm = NewQueryMemory(0, false, "")
+		result, err := ms.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, nil, true)
+		require.NoError(t, err)
+		utils.MustMatch(t, sqltypes.MakeTestResult(fields, "a|1", "b|2"), result)
+		assert.Zero(t, qm.Used())
+	})
+}
+
 func TestMemorySortExecuteNoVarChar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2",
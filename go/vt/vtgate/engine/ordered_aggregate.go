@@ -133,10 +133,11 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 		return oa.executeGroupBy(result)
 	}
 
-	agg, fields, err := newAggregation(result.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+	agg, fields, err := newAggregation(result.Fields, oa.Aggregates, env, vcursor.ConnCollation(), vcursor.QueryMemory())
 	if err != nil {
 		return nil, err
 	}
+	defer agg.reset()
 
 	out := &sqltypes.Result{
 		Fields: fields,
@@ -242,12 +243,17 @@ func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCurso
 	var agg *aggregationState
 	var fields []*querypb.Field
 	var currentKey []sqltypes.Value
+	defer func() {
+		if agg != nil {
+			agg.reset()
+		}
+	}()
 
 	visitor := func(qr *sqltypes.Result) error {
 		var err error
 
 		if agg == nil && len(qr.Fields) != 0 {
-			agg, fields, err = newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+			agg, fields, err = newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation(), vcursor.QueryMemory())
 			if err != nil {
 				return err
 			}
@@ -310,7 +316,7 @@ func (oa *OrderedAggregate) GetFields(ctx context.Context, vcursor VCursor, bind
 		return nil, err
 	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	_, fields, err := newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+	_, fields, err := newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation(), nil)
 	if err != nil {
 		return nil, err
 	}
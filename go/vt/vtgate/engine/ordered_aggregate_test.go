@@ -1194,3 +1194,37 @@ func TestGroupConcat(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupConcatMaxMemoryBytes(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|group_concat(c2)",
+		"int64|text",
+	)
+	input := sqltypes.MakeTestResult(fields, "10|aaaa", "10|bbbb", "20|cc")
+	agp := NewAggregateParam(AggregateGroupConcat, 1, nil, "", collations.MySQL8())
+	agp.Func = &sqlparser.GroupConcatExpr{Separator: ","}
+	fp := &fakePrimitive{results: []*sqltypes.Result{input}}
+	oa := &OrderedAggregate{
+		Aggregates:  []*AggregateParams{agp},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0}},
+		Input:       fp,
+	}
+
+	// the largest group concatenates 9 bytes
+	qm := NewQueryMemory(9, false, "")
+	_, err := oa.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, nil, false)
+	require.NoError(t, err)
+	assert.Zero(t, qm.Used())
+
+	fp.rewind()
+	qm = NewQueryMemory(8, false, "")
+	_, err = oa.TryExecute(context.Background(), &noopVCursor{queryMemory: qm}, nil, false)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 8")
+	assert.Zero(t, qm.Used())
+
+	fp.rewind()
+	qm = NewQueryMemory(8, false, "")
+	_, err = wrapStreamExecute(oa, &noopVCursor{queryMemory: qm}, nil, true)
+	require.EqualError(t, err, "in-memory byte size exceeded allowed limit of 8")
+	assert.Zero(t, qm.Used())
+}
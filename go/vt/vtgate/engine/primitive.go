@@ -56,6 +56,10 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// QueryMemory returns the memory accounting of the query, which tells
+		// primitives whether they can spill to disk when it is exceeded.
+		QueryMemory() *QueryMemory
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -91,10 +95,13 @@ type (
 		Environment() *vtenv.Environment
 		TimeZone() *time.Location
 		SQLMode() string
+		BlockEncryptionMode() string
 
 		ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error)
 
+		InTransaction() bool
 		InTransactionAndIsDML() bool
+		InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value)
 
 		LookupRowLockShardSession() vtgatepb.CommitOrder
 
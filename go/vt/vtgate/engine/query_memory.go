@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+)
+
+var (
+	querySpills       = stats.NewCountersWithSingleLabel("QuerySpills", "Number of times a primitive spilled its intermediate results to disk because its query exceeded its memory limit", "Operator")
+	querySpilledBytes = stats.NewCountersWithSingleLabel("QuerySpilledBytes", "Number of bytes of intermediate results written to disk by spilling primitives", "Operator")
+)
+
+// QueryMemory accounts for the memory used by the intermediate results held by the
+// primitives of a single query, and lets them spill to disk when it exceeds its limit.
+// It is safe for concurrent use. A nil QueryMemory has no limit and never spills.
+type QueryMemory struct {
+	limit    int64
+	spill    bool
+	spillDir string
+
+	used atomic.Int64
+}
+
+// NewQueryMemory returns the memory accounting of a query. A limit of 0 means
+// no limit. When spill is set, the primitives which support it write their
+// intermediate results to temporary files in spillDir instead of failing when
+// the limit is exceeded. An empty spillDir is the default temporary directory.
+func NewQueryMemory(limit int64, spill bool, spillDir string) *QueryMemory {
+	return &QueryMemory{
+		limit:    limit,
+		spill:    spill,
+		spillDir: spillDir,
+	}
+}
+
+// Grow accounts for size more bytes, and returns false if the query now uses more than its limit.
+func (qm *QueryMemory) Grow(size int64) bool {
+	if qm == nil {
+		return true
+	}
+	used := qm.used.Add(size)
+	return qm.limit <= 0 || used <= qm.limit
+}
+
+// Release accounts for size bytes that are no longer used.
+func (qm *QueryMemory) Release(size int64) {
+	if qm == nil {
+		return
+	}
+	qm.used.Add(-size)
+}
+
+// Used returns the number of bytes currently used by the query.
+func (qm *QueryMemory) Used() int64 {
+	if qm == nil {
+		return 0
+	}
+	return qm.used.Load()
+}
+
+// CanSpill returns true if primitives may spill to disk instead of failing when over the limit.
+func (qm *QueryMemory) CanSpill() bool {
+	return qm != nil && qm.spill
+}
+
+// exceededError is returned by primitives that exceed the memory limit and cannot spill.
+func (qm *QueryMemory) exceededError() error {
+	return fmt.Errorf("in-memory byte size exceeded allowed limit of %d", qm.limit)
+}
+
+// growRows accounts for the rows a primitive of a non-streaming query holds in
+// memory. Their input is already fully in memory, so they cannot spill: the
+// rows are released again and an error is returned when the query exceeds its
+// limit. Otherwise, the returned size must be released once they are not held
+// anymore.
+func (qm *QueryMemory) growRows(rows []sqltypes.Row) (int64, error) {
+	var size int64
+	for _, row := range rows {
+		size += rowMemorySize(row)
+	}
+	if !qm.Grow(size) {
+		qm.Release(size)
+		return 0, qm.exceededError()
+	}
+	return size, nil
+}
+
+// rowMemorySize estimates the memory used by a row held in memory.
+func rowMemorySize(row sqltypes.Row) int64 {
+	size := int64(24 + 32*len(row))
+	for i := range row {
+		size += row[i].CachedSize(false)
+	}
+	return size
+}
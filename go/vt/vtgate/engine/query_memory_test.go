@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestQueryMemory(t *testing.T) {
+	qm := NewQueryMemory(10, true, "")
+	assert.True(t, qm.Grow(6))
+	assert.True(t, qm.Grow(4))
+	assert.False(t, qm.Grow(1))
+	assert.EqualValues(t, 11, qm.Used())
+	qm.Release(5)
+	assert.True(t, qm.Grow(1))
+	assert.EqualValues(t, 7, qm.Used())
+	assert.True(t, qm.CanSpill())
+
+	unlimited := NewQueryMemory(0, false, "")
+	assert.True(t, unlimited.Grow(1<<40))
+	assert.False(t, unlimited.CanSpill())
+
+	// a nil QueryMemory has no limit and never spills
+	var none *QueryMemory
+	assert.True(t, none.Grow(1<<40))
+	none.Release(1 << 40)
+	assert.Zero(t, none.Used())
+	assert.False(t, none.CanSpill())
+}
+
+func TestSpillFile(t *testing.T) {
+	spillDir := t.TempDir()
+	qm := NewQueryMemory(0, true, spillDir)
+	file, err := qm.newSpillFile("Test")
+	require.NoError(t, err)
+
+	rows := []sqltypes.Row{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NULL},
+		{sqltypes.NewInt64(-2), sqltypes.NewVarChar(""), sqltypes.NewFloat64(1.5)},
+		{},
+		{sqltypes.NewVarBinary("\x00\xff"), sqltypes.NewDecimal("3.14")},
+	}
+	for _, row := range rows {
+		require.NoError(t, file.write(row))
+	}
+
+	r, err := file.reader()
+	require.NoError(t, err)
+	for _, want := range rows {
+		row, err := r.next()
+		require.NoError(t, err)
+		assert.Equal(t, want, row)
+	}
+	_, err = r.next()
+	assert.Equal(t, io.EOF, err)
+
+	file.close()
+	assertEmptyDir(t, spillDir)
+}
+
+// assertEmptyDir checks that no spill file was left behind in dir.
+func assertEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -558,8 +558,14 @@ func resolveShardsBetween(ctx context.Context, vcursor VCursor, vindex vindexes.
 		ids[i] = sqltypes.ValueToProto(vik)
 	}
 
-	// RangeMap using the Vindex
-	destinations, err := vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	// RangeMap using the Vindex, or MapRange if it supports open and inclusive ranges
+	var destinations []key.ShardDestination
+	var err error
+	if rm, ok := vindex.(vindexes.RangeMapper); ok {
+		destinations, err = rm.MapRange(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	} else {
+		destinations, err = vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	}
 	if err != nil {
 		return nil, nil, err
 	}
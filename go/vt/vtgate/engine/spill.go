@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vthash"
+)
+
+const (
+	// spillPartitions is the number of files the rows of hash based primitives are partitioned into when they spill.
+	spillPartitions = 32
+	// spillBatchSize is the number of rows read back from disk that are sent to the callback at once.
+	spillBatchSize = 1000
+)
+
+// spillFile is a temporary file holding rows that did not fit in memory.
+// The file is removed when it is closed.
+type spillFile struct {
+	operator string
+	file     *os.File
+	w        *bufio.Writer
+	buf      []byte
+	size     int64
+}
+
+// newSpillFile creates a spill file for the operator in the spill directory of the query.
+func (qm *QueryMemory) newSpillFile(operator string) (*spillFile, error) {
+	file, err := os.CreateTemp(qm.spillDir, "vtgate-spill-")
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to create spill file")
+	}
+	return &spillFile{
+		operator: operator,
+		file:     file,
+		w:        bufio.NewWriter(file),
+	}, nil
+}
+
+// write appends a row to the file. Every value is written as its type, followed
+// by its length and bytes unless it is NULL.
+func (f *spillFile) write(row sqltypes.Row) error {
+	f.buf = binary.AppendUvarint(f.buf[:0], uint64(len(row)))
+	for _, v := range row {
+		f.buf = binary.AppendUvarint(f.buf, uint64(v.Type()))
+		if v.IsNull() {
+			continue
+		}
+		f.buf = binary.AppendUvarint(f.buf, uint64(v.Len()))
+		f.buf = append(f.buf, v.Raw()...)
+	}
+	n, err := f.w.Write(f.buf)
+	f.size += int64(n)
+	return err
+}
+
+// reader flushes the rows written so far, and returns a reader for all of them.
+func (f *spillFile) reader() (*spillReader, error) {
+	if err := f.w.Flush(); err != nil {
+		return nil, err
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &spillReader{r: bufio.NewReader(f.file)}, nil
+}
+
+// close removes the file, and accounts for the bytes written to it.
+func (f *spillFile) close() {
+	querySpilledBytes.Add(f.operator, f.size)
+	_ = f.file.Close()
+	_ = os.Remove(f.file.Name())
+}
+
+// spillReader reads the rows of a spillFile back.
+type spillReader struct {
+	r *bufio.Reader
+}
+
+// next returns the next row of the file, or io.EOF when all of them have been read.
+func (r *spillReader) next() (sqltypes.Row, error) {
+	cols, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	row := make(sqltypes.Row, cols)
+	for i := range row {
+		typ, err := binary.ReadUvarint(r.r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if querypb.Type(typ) == sqltypes.Null {
+			row[i] = sqltypes.NULL
+			continue
+		}
+		length, err := binary.ReadUvarint(r.r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		val := make([]byte, length)
+		if _, err := io.ReadFull(r.r, val); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), val)
+	}
+	return row, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// spillPartitionFiles partitions rows by hash into spill files, so that rows
+// with the same hash can be processed together, one partition at a time.
+type spillPartitionFiles struct {
+	qm       *QueryMemory
+	operator string
+	files    [spillPartitions]*spillFile
+}
+
+func newSpillPartitionFiles(qm *QueryMemory, operator string) *spillPartitionFiles {
+	return &spillPartitionFiles{qm: qm, operator: operator}
+}
+
+// write appends the row to the partition of its hash.
+func (p *spillPartitionFiles) write(hash vthash.Hash, row sqltypes.Row) error {
+	i := binary.LittleEndian.Uint64(hash[:8]) % spillPartitions
+	if p.files[i] == nil {
+		file, err := p.qm.newSpillFile(p.operator)
+		if err != nil {
+			return err
+		}
+		p.files[i] = file
+	}
+	return p.files[i].write(row)
+}
+
+// partition returns the file of the i-th partition, nil if no rows were written to it.
+func (p *spillPartitionFiles) partition(i int) *spillFile {
+	return p.files[i]
+}
+
+func (p *spillPartitionFiles) close() {
+	for _, file := range p.files {
+		if file != nil {
+			file.close()
+		}
+	}
+}
+
+// forEachRow calls fn with all the rows of a spill file. A nil file has no rows.
+func forEachRow(file *spillFile, fn func(sqltypes.Row) error) error {
+	if file == nil {
+		return nil
+	}
+	r, err := file.reader()
+	if err != nil {
+		return err
+	}
+	for {
+		row, err := r.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+// sortedRun is a sequence of sorted rows being merged, either spilled to disk or held in memory.
+type sortedRun struct {
+	reader *spillReader
+	rows   []sqltypes.Row
+	row    sqltypes.Row
+}
+
+// advance moves to the next row of the run, returning false when there are no more rows.
+func (run *sortedRun) advance() (bool, error) {
+	if run.reader == nil {
+		if len(run.rows) == 0 {
+			return false, nil
+		}
+		run.row, run.rows = run.rows[0], run.rows[1:]
+		return true, nil
+	}
+	row, err := run.reader.next()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	run.row = row
+	return true, nil
+}
+
+// sortedRunHeap orders the runs by their current row.
+type sortedRunHeap struct {
+	runs    []*sortedRun
+	compare func(a, b sqltypes.Row) int
+}
+
+func (h *sortedRunHeap) Len() int           { return len(h.runs) }
+func (h *sortedRunHeap) Less(i, j int) bool { return h.compare(h.runs[i].row, h.runs[j].row) < 0 }
+func (h *sortedRunHeap) Swap(i, j int)      { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+func (h *sortedRunHeap) Push(x any)         { h.runs = append(h.runs, x.(*sortedRun)) }
+func (h *sortedRunHeap) Pop() any {
+	run := h.runs[len(h.runs)-1]
+	h.runs = h.runs[:len(h.runs)-1]
+	return run
+}
+
+// mergeSortedRuns merges the sorted runs, sending up to limit rows in order to the callback.
+func mergeSortedRuns(runs []*sortedRun, compare func(a, b sqltypes.Row) int, limit int, callback func([]sqltypes.Row) error) error {
+	h := &sortedRunHeap{compare: compare}
+	for _, run := range runs {
+		ok, err := run.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.runs = append(h.runs, run)
+		}
+	}
+	heap.Init(h)
+
+	var batch []sqltypes.Row
+	for sent := 0; h.Len() > 0 && sent < limit; sent++ {
+		run := h.runs[0]
+		batch = append(batch, run.row)
+		if len(batch) == spillBatchSize {
+			if err := callback(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		ok, err := run.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	if len(batch) > 0 {
+		return callback(batch)
+	}
+	return nil
+}
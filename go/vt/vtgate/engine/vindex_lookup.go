@@ -156,10 +156,16 @@ func (vr *VindexLookup) lookup(ctx context.Context, vcursor VCursor, ids []sqlty
 		vcursor.Session().SetCommitOrder(co)
 		defer vcursor.Session().SetCommitOrder(vtgatepb.CommitOrder_NORMAL)
 	}
-	if ids[0].IsIntegral() || vr.Vindex.AllowBatch() {
-		return vr.executeBatch(ctx, vcursor, ids)
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		if ids[0].IsIntegral() || vr.Vindex.AllowBatch() {
+			return vr.executeBatch(ctx, vcursor, ids)
+		}
+		return vr.executeNonBatch(ctx, vcursor, ids)
+	}
+	if lc, ok := vr.Vindex.(vindexes.LookupCaching); ok && !vcursor.InTransaction() {
+		return lc.LookupCache().Lookup(ids, lookup)
 	}
-	return vr.executeNonBatch(ctx, vcursor, ids)
+	return lookup(ids)
 }
 
 func (vr *VindexLookup) executeNonBatch(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]*sqltypes.Result, error) {
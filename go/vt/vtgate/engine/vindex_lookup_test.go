@@ -134,3 +134,47 @@ func TestVindexLookupTruncate(t *testing.T) {
 	})
 	expectResult(t, result, wantRes)
 }
+
+func TestVindexLookupCache(t *testing.T) {
+	cachedVindex, err := vindexes.CreateVindex("lookup_unique", "", map[string]string{
+		"table":     "lkp",
+		"from":      "from",
+		"to":        "toc",
+		"cache_ttl": "1m",
+	})
+	require.NoError(t, err)
+	planableVindex := cachedVindex.(vindexes.LookupPlanable)
+	_, args := planableVindex.Query()
+
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields("id|keyspace_id", "int64|varbinary"),
+				"1|\x10"),
+		},
+	}
+	vdxLookup := &VindexLookup{
+		Opcode:    EqualUnique,
+		Keyspace:  ks,
+		Vindex:    planableVindex,
+		Arguments: args,
+		Values:    []evalengine.Expr{evalengine.NewLiteralInt(1)},
+		Lookup:    fp,
+		SendTo:    NewRoute(ByDestination, ks, "dummy_select", "dummy_select_field"),
+	}
+
+	vc := &loggingVCursor{results: []*sqltypes.Result{defaultSelectResult, defaultSelectResult}}
+	for range 2 {
+		_, err = vdxLookup.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+	}
+
+	// The second execution is routed with the cached lookup result.
+	fp.ExpectLog(t, []string{fmt.Sprintf(`Execute from: %v false`, &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: querypb.Type_INT64, Value: []byte("1")}}})})
+	vc.ExpectLog(t, []string{
+		fmt.Sprintf(`ResolveDestinations ks [%v] Destinations:DestinationKeyspaceID(10)`, sqltypes.Int64BindVariable(1)),
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+		fmt.Sprintf(`ResolveDestinations ks [%v] Destinations:DestinationKeyspaceID(10)`, sqltypes.Int64BindVariable(1)),
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+	})
+}
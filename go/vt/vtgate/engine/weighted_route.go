@@ -0,0 +1,389 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var (
+	_ Primitive = (*WeightedRoute)(nil)
+	_ Primitive = (*WeightedShardRoute)(nil)
+)
+
+var (
+	// WeightedRoutingRollbackDuration is how long the reads of weighted
+	// routing rules whose target exceeded its error rate are all routed to the
+	// source, before the target is tried again.
+	WeightedRoutingRollbackDuration = 10 * time.Minute
+
+	weightedRoutingQueries = stats.NewCountersWithMultiLabels(
+		"WeightedRoutingQueries",
+		"Number of reads of tables with weighted routing rules, by destination",
+		[]string{"Rules", "Destination"})
+	weightedRoutingTargetErrors = stats.NewCountersWithSingleLabel(
+		"WeightedRoutingTargetErrors",
+		"Number of reads routed to the target of weighted routing rules that failed",
+		"Rules")
+	weightedRoutingRollbacks = stats.NewCountersWithSingleLabel(
+		"WeightedRoutingRollbacks",
+		"Number of times the reads of weighted routing rules were routed back to the source because of target errors",
+		"Rules")
+
+	// weightedRouteBreakers has the *weightedRouteBreaker of the weighted
+	// routing rules, by their WeightedRoute.Rules.
+	weightedRouteBreakers sync.Map
+)
+
+// weightedRouteMinQueries is the number of reads routed to the target of
+// weighted routing rules over which their error rate is computed.
+const weightedRouteMinQueries = 100
+
+// WeightedRoute routes a percentage of reads to the Target primitive, planned
+// on the tables the weighted routing rules route to, and the others to the
+// Source primitive. When the error rate of the reads routed to the target
+// exceeds MaxErrorPercent, all the reads are routed to the source for
+// WeightedRoutingRollbackDuration.
+type WeightedRoute struct {
+	Percent         float32
+	MaxErrorPercent float32
+	// Rules describes the weighted routing rules this primitive was planned
+	// from. It labels the stats, and keys the error rate tracking, which is
+	// shared by the plans of all the queries using the same rules.
+	Rules string
+
+	Source Primitive
+	Target Primitive
+}
+
+// WeightedShardRoute resolves a percentage of the reads of Input against the
+// shards the weighted shard routing rules of their keyspaces route to, and the
+// others against the shards serving them. Like WeightedRoute, the reads are
+// all resolved against the serving shards for WeightedRoutingRollbackDuration
+// when the error rate of the others exceeds MaxErrorPercent.
+type WeightedShardRoute struct {
+	Percent         float32
+	MaxErrorPercent float32
+	// Rules describes the weighted shard routing rules this primitive was
+	// planned from, like WeightedRoute.Rules.
+	Rules string
+	// Shards has the shards the reads are routed to, by keyspace.
+	Shards map[string][]*topodatapb.ShardReference
+
+	Input Primitive
+}
+
+// weightedRouteBreaker tracks the error rate of the reads routed to the
+// target of weighted routing rules, for the percentages it was created with.
+type weightedRouteBreaker struct {
+	percent         float32
+	maxErrorPercent float32
+
+	mu        sync.Mutex
+	queries   int
+	errors    int
+	trippedAt time.Time
+}
+
+func (w *WeightedRoute) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return w.Source.GetFields(ctx, vcursor, bindVars)
+}
+
+func (w *WeightedRoute) NeedsTransaction() bool {
+	return w.Source.NeedsTransaction()
+}
+
+func (w *WeightedRoute) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if !w.routeToTarget() {
+		return vcursor.ExecutePrimitive(ctx, w.Source, bindVars, wantfields)
+	}
+	r, err := vcursor.ExecutePrimitive(ctx, w.Target, bindVars, wantfields)
+	w.recordTargetResult(ctx, err)
+	if isWeightedTargetError(ctx, err) {
+		// Reads are safe to retry: serve the query from the source.
+		return vcursor.ExecutePrimitive(ctx, w.Source, bindVars, wantfields)
+	}
+	return r, err
+}
+
+func (w *WeightedRoute) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	if !w.routeToTarget() {
+		return vcursor.StreamExecutePrimitive(ctx, w.Source, bindVars, wantfields, callback)
+	}
+	var sent bool
+	err := vcursor.StreamExecutePrimitive(ctx, w.Target, bindVars, wantfields, func(r *sqltypes.Result) error {
+		sent = true
+		return callback(r)
+	})
+	w.recordTargetResult(ctx, err)
+	if !sent && isWeightedTargetError(ctx, err) {
+		// Nothing was sent to the client yet, so the query can still be
+		// served from the source.
+		return vcursor.StreamExecutePrimitive(ctx, w.Source, bindVars, wantfields, callback)
+	}
+	return err
+}
+
+// Inputs is a slice containing the inputs to this Primitive.
+// The returned map has additional information about the inputs, that is used in the description.
+func (w *WeightedRoute) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Source, w.Target}, []map[string]any{{
+		inputName: "Source",
+	}, {
+		inputName: "Target",
+	}}
+}
+
+// description is the description, sans the inputs, of this Primitive.
+// to get the plan description with all children, use PrimitiveToPlanDescription()
+func (w *WeightedRoute) description() PrimitiveDescription {
+	other := map[string]any{
+		"Percent": w.Percent,
+		"Rules":   w.Rules,
+	}
+	if w.MaxErrorPercent > 0 {
+		other["MaxErrorPercent"] = w.MaxErrorPercent
+	}
+	return PrimitiveDescription{
+		OperatorType: "WeightedRoute",
+		Other:        other,
+	}
+}
+
+func (w *WeightedRoute) routeToTarget() bool {
+	return weightedRouteToTarget(w.Rules, w.Percent, w.MaxErrorPercent)
+}
+
+func (w *WeightedRoute) recordTargetResult(ctx context.Context, err error) {
+	weightedRecordTargetResult(ctx, w.Rules, w.Percent, w.MaxErrorPercent, err)
+}
+
+func (w *WeightedShardRoute) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return w.Input.GetFields(ctx, vcursor, bindVars)
+}
+
+func (w *WeightedShardRoute) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func (w *WeightedShardRoute) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if !weightedRouteToTarget(w.Rules, w.Percent, w.MaxErrorPercent) {
+		return vcursor.ExecutePrimitive(ctx, w.Input, bindVars, wantfields)
+	}
+	r, err := vcursor.ExecutePrimitive(srvtopo.WithShardReferences(ctx, w.Shards), w.Input, bindVars, wantfields)
+	weightedRecordTargetResult(ctx, w.Rules, w.Percent, w.MaxErrorPercent, err)
+	if isWeightedTargetError(ctx, err) {
+		// Reads are safe to retry: serve the query from the serving shards.
+		return vcursor.ExecutePrimitive(ctx, w.Input, bindVars, wantfields)
+	}
+	return r, err
+}
+
+func (w *WeightedShardRoute) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	if !weightedRouteToTarget(w.Rules, w.Percent, w.MaxErrorPercent) {
+		return vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, wantfields, callback)
+	}
+	var sent bool
+	err := vcursor.StreamExecutePrimitive(srvtopo.WithShardReferences(ctx, w.Shards), w.Input, bindVars, wantfields, func(r *sqltypes.Result) error {
+		sent = true
+		return callback(r)
+	})
+	weightedRecordTargetResult(ctx, w.Rules, w.Percent, w.MaxErrorPercent, err)
+	if !sent && isWeightedTargetError(ctx, err) {
+		// Nothing was sent to the client yet, so the query can still be
+		// served from the serving shards.
+		return vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, wantfields, callback)
+	}
+	return err
+}
+
+// Inputs is a slice containing the inputs to this Primitive.
+func (w *WeightedShardRoute) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// description is the description, sans the inputs, of this Primitive.
+// to get the plan description with all children, use PrimitiveToPlanDescription()
+func (w *WeightedShardRoute) description() PrimitiveDescription {
+	other := map[string]any{
+		"Percent": w.Percent,
+		"Rules":   w.Rules,
+	}
+	if w.MaxErrorPercent > 0 {
+		other["MaxErrorPercent"] = w.MaxErrorPercent
+	}
+	return PrimitiveDescription{
+		OperatorType: "WeightedShardRoute",
+		Other:        other,
+	}
+}
+
+// NewWeightedShardRoute returns a WeightedShardRoute resolving the reads of
+// input against the shards of the weighted shard routing rules of the
+// keyspaces it reads from, or input itself if none of them has a rule. Like
+// mirroring, the lowest percentage found across all rules is used.
+func NewWeightedShardRoute(input Primitive, findRule func(keyspace string) *vindexes.WeightedShardRoutingRule) Primitive {
+	w := &WeightedShardRoute{Input: input}
+	var rules []string
+	for _, keyspace := range primitiveKeyspaces(input) {
+		wr := findRule(keyspace)
+		if wr == nil {
+			continue
+		}
+		if w.Shards == nil {
+			w.Shards = make(map[string][]*topodatapb.ShardReference)
+		}
+		w.Shards[keyspace] = wr.Shards
+		if w.Percent == 0 || wr.Percent < w.Percent {
+			w.Percent = wr.Percent
+		}
+		if wr.MaxErrorPercent > 0 && (w.MaxErrorPercent == 0 || wr.MaxErrorPercent < w.MaxErrorPercent) {
+			w.MaxErrorPercent = wr.MaxErrorPercent
+		}
+		rules = append(rules, wr.FromKeyspace)
+	}
+	if len(rules) == 0 {
+		return input
+	}
+	w.Rules = strings.Join(rules, ",")
+	return w
+}
+
+// primitiveKeyspaces returns the sorted names of the keyspaces the primitives
+// of a plan send queries to.
+func primitiveKeyspaces(p Primitive) []string {
+	var keyspaces []string
+	var visit func(p Primitive)
+	visit = func(p Primitive) {
+		if ks := p.description().Keyspace; ks != nil {
+			keyspaces = append(keyspaces, ks.Name)
+		}
+		inputs, _ := p.Inputs()
+		for _, input := range inputs {
+			visit(input)
+		}
+	}
+	visit(p)
+	slices.Sort(keyspaces)
+	return slices.Compact(keyspaces)
+}
+
+// weightedRouteToTarget rolls the dice for a read of weighted routing rules,
+// and returns whether it should be routed to the target.
+func weightedRouteToTarget(rules string, percent, maxErrorPercent float32) bool {
+	toTarget := percent >= (rand.Float32()*100.0) &&
+		(maxErrorPercent <= 0 || !weightedBreaker(rules, percent, maxErrorPercent).tripped())
+	destination := "Source"
+	if toTarget {
+		destination = "Target"
+	}
+	weightedRoutingQueries.Add([]string{rules, destination}, 1)
+	return toTarget
+}
+
+// isWeightedTargetError returns true if a read routed to the target of
+// weighted routing rules failed because of the target, rather than because of
+// the query itself or of the client going away. Only those reads count against
+// the error rate of the target, and are served again from the source.
+func isWeightedTargetError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_CLUSTER_EVENT, vtrpcpb.Code_FAILED_PRECONDITION:
+		return true
+	}
+	return false
+}
+
+// weightedRecordTargetResult records the outcome of a read routed to the
+// target of weighted routing rules.
+func weightedRecordTargetResult(ctx context.Context, rules string, percent, maxErrorPercent float32, err error) {
+	failed := isWeightedTargetError(ctx, err)
+	if failed {
+		weightedRoutingTargetErrors.Add(rules, 1)
+	}
+	if maxErrorPercent <= 0 {
+		return
+	}
+	if weightedBreaker(rules, percent, maxErrorPercent).record(failed, maxErrorPercent) {
+		weightedRoutingRollbacks.Add(rules, 1)
+		log.Warningf("Weighted routing rules %s exceeded their %.2f%% maximum error rate, routing all their reads to the source for %v",
+			rules, maxErrorPercent, WeightedRoutingRollbackDuration)
+	}
+}
+
+// weightedBreaker returns the breaker of weighted routing rules. When their
+// percentages change, the breaker of their previous version is replaced by a
+// new one, so the error rate of the updated rules starts over.
+func weightedBreaker(rules string, percent, maxErrorPercent float32) *weightedRouteBreaker {
+	for {
+		v, ok := weightedRouteBreakers.Load(rules)
+		if !ok {
+			v, _ = weightedRouteBreakers.LoadOrStore(rules, &weightedRouteBreaker{percent: percent, maxErrorPercent: maxErrorPercent})
+		}
+		b := v.(*weightedRouteBreaker)
+		if b.percent == percent && b.maxErrorPercent == maxErrorPercent {
+			return b
+		}
+		nb := &weightedRouteBreaker{percent: percent, maxErrorPercent: maxErrorPercent}
+		if weightedRouteBreakers.CompareAndSwap(rules, b, nb) {
+			return nb
+		}
+	}
+}
+
+func (b *weightedRouteBreaker) tripped() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.trippedAt.IsZero() && time.Since(b.trippedAt) < WeightedRoutingRollbackDuration
+}
+
+// record records the outcome of a read routed to the target, and returns
+// true if it tripped the breaker.
+func (b *weightedRouteBreaker) record(failed bool, maxErrorPercent float32) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queries++
+	if failed {
+		b.errors++
+	}
+	if b.queries < weightedRouteMinQueries {
+		return false
+	}
+	tripped := float32(b.errors)*100 > maxErrorPercent*float32(b.queries)
+	if tripped {
+		b.trippedAt = time.Now()
+	}
+	b.queries, b.errors = 0, 0
+	return tripped
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+func TestWeightedRoute(t *testing.T) {
+	sourceResult := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	targetResult := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "2")
+
+	t.Run("routes to the target", func(t *testing.T) {
+		source := &fakePrimitive{results: []*sqltypes.Result{sourceResult}}
+		target := &fakePrimitive{results: []*sqltypes.Result{targetResult}}
+		wr := &WeightedRoute{Percent: 100, Rules: t.Name(), Source: source, Target: target}
+
+		res, err := wr.TryExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, targetResult, res)
+		assert.Empty(t, source.log)
+	})
+
+	t.Run("falls back to the source on target errors", func(t *testing.T) {
+		source := &fakePrimitive{results: []*sqltypes.Result{sourceResult}}
+		target := &fakePrimitive{sendErr: vterrors.New(vtrpcpb.Code_UNAVAILABLE, "target error")}
+		wr := &WeightedRoute{Percent: 100, Rules: t.Name(), Source: source, Target: target}
+
+		res, err := wr.TryExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, sourceResult, res)
+
+		var results []*sqltypes.Result
+		source.rewind()
+		err = wr.TryStreamExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, false, func(r *sqltypes.Result) error {
+			results = append(results, r)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, results)
+		assert.Len(t, source.log, 1)
+	})
+
+	t.Run("does not fall back to the source on query errors", func(t *testing.T) {
+		source := &fakePrimitive{results: []*sqltypes.Result{sourceResult}}
+		target := &fakePrimitive{sendErr: vterrors.New(vtrpcpb.Code_ALREADY_EXISTS, "duplicate entry")}
+		wr := &WeightedRoute{Percent: 100, MaxErrorPercent: 10, Rules: t.Name(), Source: source, Target: target}
+
+		_, err := wr.TryExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.ErrorContains(t, err, "duplicate entry")
+		assert.Empty(t, source.log)
+		assert.Zero(t, weightedRoutingTargetErrors.Counts()[t.Name()])
+	})
+
+	t.Run("rolls back when the target error rate is exceeded", func(t *testing.T) {
+		target := &fakePrimitive{sendErr: vterrors.New(vtrpcpb.Code_UNAVAILABLE, "target error")}
+		wr := &WeightedRoute{Percent: 100, MaxErrorPercent: 10, Rules: t.Name(), Target: target}
+		vc := &noopVCursor{}
+		for range weightedRouteMinQueries {
+			wr.Source = &fakePrimitive{results: []*sqltypes.Result{sourceResult}}
+			_, err := wr.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+			require.NoError(t, err)
+		}
+		assert.Len(t, target.log, weightedRouteMinQueries)
+		assert.EqualValues(t, 1, weightedRoutingRollbacks.Counts()[t.Name()])
+
+		// The reads are not routed to the target anymore.
+		wr.Source = &fakePrimitive{results: []*sqltypes.Result{sourceResult}}
+		_, err := wr.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		assert.Len(t, target.log, weightedRouteMinQueries)
+	})
+}
+
+func TestWeightedBreaker(t *testing.T) {
+	b := weightedBreaker(t.Name(), 50, 10)
+	assert.Same(t, b, weightedBreaker(t.Name(), 50, 10))
+
+	// Updated rules replace the breaker of their previous version.
+	nb := weightedBreaker(t.Name(), 60, 10)
+	assert.NotSame(t, b, nb)
+	v, ok := weightedRouteBreakers.Load(t.Name())
+	require.True(t, ok)
+	assert.Same(t, nb, v)
+}
+
+// ctxPrimitive records the contexts it is executed with.
+type ctxPrimitive struct {
+	*fakePrimitive
+	ctxs []context.Context
+}
+
+func (c *ctxPrimitive) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	c.ctxs = append(c.ctxs, ctx)
+	return c.fakePrimitive.TryExecute(ctx, vcursor, bindVars, wantfields)
+}
+
+func TestNewWeightedShardRoute(t *testing.T) {
+	ks1 := &vindexes.Keyspace{Name: "ks1", Sharded: true}
+	ks2 := &vindexes.Keyspace{Name: "ks2", Sharded: true}
+	input := &Join{
+		Left:  NewRoute(Scatter, ks1, "select 1 from t1", "select 1 from t1 where 1 != 1"),
+		Right: NewRoute(Scatter, ks2, "select 1 from t2", "select 1 from t2 where 1 != 1"),
+	}
+	shards := []*topodatapb.ShardReference{{Name: "-"}}
+	rules := map[string]*vindexes.WeightedShardRoutingRule{
+		"ks1": {FromKeyspace: "ks1@replica", Percent: 50, MaxErrorPercent: 5, Shards: shards},
+		"ks2": {FromKeyspace: "ks2@replica", Percent: 20, Shards: shards},
+	}
+
+	p := NewWeightedShardRoute(input, func(keyspace string) *vindexes.WeightedShardRoutingRule {
+		return rules[keyspace]
+	})
+	require.IsType(t, &WeightedShardRoute{}, p)
+	wr := p.(*WeightedShardRoute)
+	assert.EqualValues(t, 20, wr.Percent)
+	assert.EqualValues(t, 5, wr.MaxErrorPercent)
+	assert.Equal(t, "ks1@replica,ks2@replica", wr.Rules)
+	assert.Equal(t, map[string][]*topodatapb.ShardReference{"ks1": shards, "ks2": shards}, wr.Shards)
+
+	// Without any rule the plan is left alone.
+	p = NewWeightedShardRoute(input, func(string) *vindexes.WeightedShardRoutingRule { return nil })
+	assert.Equal(t, input, p)
+}
+
+func TestWeightedShardRoute(t *testing.T) {
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	shards := map[string][]*topodatapb.ShardReference{"ks": {{Name: "-"}}}
+
+	t.Run("routes to the target shards", func(t *testing.T) {
+		ctx := context.Background()
+		input := &ctxPrimitive{fakePrimitive: &fakePrimitive{results: []*sqltypes.Result{result}}}
+		wr := &WeightedShardRoute{Percent: 100, Rules: t.Name(), Shards: shards, Input: input}
+
+		res, err := wr.TryExecute(ctx, &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, result, res)
+		require.Len(t, input.ctxs, 1)
+		assert.NotEqual(t, ctx, input.ctxs[0])
+	})
+
+	t.Run("routes to the serving shards", func(t *testing.T) {
+		ctx := context.Background()
+		input := &ctxPrimitive{fakePrimitive: &fakePrimitive{results: []*sqltypes.Result{result}}}
+		wr := &WeightedShardRoute{Percent: 0, Rules: t.Name(), Shards: shards, Input: input}
+
+		_, err := wr.TryExecute(ctx, &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		require.Len(t, input.ctxs, 1)
+		assert.Equal(t, ctx, input.ctxs[0])
+	})
+
+	t.Run("falls back to the serving shards on target errors", func(t *testing.T) {
+		ctx := context.Background()
+		input := &ctxPrimitive{fakePrimitive: &fakePrimitive{
+			results: []*sqltypes.Result{nil, result},
+			sendErr: vterrors.New(vtrpcpb.Code_UNAVAILABLE, "target error"),
+		}}
+		wr := &WeightedShardRoute{Percent: 100, Rules: t.Name(), Shards: shards, Input: input}
+
+		res, err := wr.TryExecute(ctx, &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, result, res)
+		require.Len(t, input.ctxs, 2)
+		assert.Equal(t, ctx, input.ctxs[1])
+		assert.EqualValues(t, 1, weightedRoutingTargetErrors.Counts()[t.Name()])
+	})
+}
@@ -591,6 +591,18 @@ func (c *compiler) compileParseJSON(fn string, doct ctype, offset int) (ctype, e
 	return ctype{Type: sqltypes.TypeJSON, Flag: doct.Flag, Col: collationJSON}, nil
 }
 
+// compileParseJSONNullable is like compileParseJSON, but it leaves NULL values
+// on the stack untouched.
+func (c *compiler) compileParseJSONNullable(fn string, doct ctype, offset int) (ctype, error) {
+	if doct.Type == sqltypes.Null {
+		return ctype{Type: sqltypes.TypeJSON, Flag: doct.Flag, Col: collationJSON}, nil
+	}
+	skip := c.compileNullCheckOffset(doct, offset)
+	jt, err := c.compileParseJSON(fn, doct, offset)
+	c.asm.jumpDestination(skip)
+	return jt, err
+}
+
 func (c *compiler) compileToJSON(doct ctype, offset int) (ctype, error) {
 	switch doct.Type {
 	case sqltypes.TypeJSON:
@@ -643,6 +655,18 @@ func (c *compiler) compileArgToJSON(doct ctype, offset int) (ctype, error) {
 	return ctype{Type: sqltypes.TypeJSON, Col: collationJSON}, nil
 }
 
+// compileArgToJSONNullable is like compileArgToJSON, but it leaves NULL values
+// on the stack untouched.
+func (c *compiler) compileArgToJSONNullable(doct ctype, offset int) (ctype, error) {
+	if doct.Type == sqltypes.Null || doct.Type == sqltypes.TypeJSON {
+		return c.compileArgToJSON(doct, offset)
+	}
+	skip := c.compileNullCheckOffset(doct, offset)
+	jt, err := c.compileArgToJSON(doct, offset)
+	c.asm.jumpDestination(skip)
+	return jt, err
+}
+
 func (c *compiler) compileToJSONKey(key ctype) error {
 	if key.Type == sqltypes.Null {
 		return errJSONKeyIsNil
@@ -670,6 +694,34 @@ func (c *compiler) jsonExtractPath(expr IR) (*json.Path, error) {
 	return parser.ParseBytes(pathBytes.bytes)
 }
 
+// jsonExtractPaths parses the static paths for a JSON function. It reports whether
+// any of the paths is NULL, which makes the result of the whole function NULL.
+func (c *compiler) jsonExtractPaths(call IR, args []IR, transform bool) ([]*json.Path, bool, error) {
+	paths := make([]*json.Path, 0, len(args))
+	for _, arg := range args {
+		if !arg.constant() {
+			return nil, false, c.unsupported(call)
+		}
+		if isNullLiteral(arg) {
+			return nil, true, nil
+		}
+		jp, err := c.jsonExtractPath(arg)
+		if err != nil {
+			return nil, false, err
+		}
+		if transform && jp.ContainsWildcards() {
+			return nil, false, errInvalidPathForTransform
+		}
+		paths = append(paths, jp)
+	}
+	return paths, false, nil
+}
+
+func isNullLiteral(expr IR) bool {
+	lit, ok := expr.(*Literal)
+	return ok && lit.inner == nil
+}
+
 func (c *compiler) jsonExtractOneOrAll(fname string, expr IR) (jsonMatch, error) {
 	lit, ok := expr.(*Literal)
 	if !ok {
@@ -31,6 +31,7 @@ import (
 	"math/bits"
 	"net/netip"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -2294,6 +2295,95 @@ func (asm *assembler) Fn_JSON_KEYS(jp *json.Path) {
 	}
 }
 
+func (asm *assembler) Fn_JSON_MERGE_PATCH(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		docs := make([]*json.Value, 0, args)
+		for sp := env.vm.sp - args; sp < env.vm.sp; sp++ {
+			doc, _ := env.vm.stack[sp].(*evalJSON)
+			docs = append(docs, doc)
+		}
+		env.vm.stack[env.vm.sp-args] = builtin_JSON_MERGE_PATCH(docs)
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_MERGE_PATCH (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PRESERVE(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		docs := make([]*json.Value, 0, args)
+		for sp := env.vm.sp - args; sp < env.vm.sp; sp++ {
+			doc, _ := env.vm.stack[sp].(*evalJSON)
+			docs = append(docs, doc)
+		}
+		env.vm.stack[env.vm.sp-args] = builtin_JSON_MERGE_PRESERVE(docs)
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_MERGE_PRESERVE (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_MODIFY(method string, t json.Transformation, paths []*json.Path) {
+	values := len(paths)
+	asm.adjustStack(-values)
+	asm.emit(func(env *ExpressionEnv) int {
+		if doc := env.vm.stack[env.vm.sp-values-1]; doc != nil {
+			vals := make([]*json.Value, 0, values)
+			for sp := env.vm.sp - values; sp < env.vm.sp; sp++ {
+				val, ok := env.vm.stack[sp].(*evalJSON)
+				if !ok {
+					val = json.ValueNull
+				}
+				vals = append(vals, val)
+			}
+			env.vm.stack[env.vm.sp-values-1], env.vm.err = builtin_JSON_TRANSFORM(t, doc.(*evalJSON), paths, vals)
+		}
+		env.vm.sp -= values
+		return 1
+	}, "FN %s (SP-%d)...(SP-1), [static]", method, values+1)
+}
+
+func (asm *assembler) Fn_JSON_OVERLAPS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		l, lok := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		r, rok := env.vm.stack[env.vm.sp-1].(*evalJSON)
+		if lok && rok {
+			var overlaps bool
+			overlaps, env.vm.err = builtin_JSON_OVERLAPS(l, r)
+			env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalBool(overlaps)
+		} else {
+			env.vm.stack[env.vm.sp-2] = nil
+		}
+		env.vm.sp--
+		return 1
+	}, "FN JSON_OVERLAPS (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_JSON_REMOVE(paths []*json.Path) {
+	asm.emit(func(env *ExpressionEnv) int {
+		if doc := env.vm.stack[env.vm.sp-1]; doc != nil {
+			env.vm.stack[env.vm.sp-1], env.vm.err = builtin_JSON_TRANSFORM(json.Remove, doc.(*evalJSON), paths, nil)
+		}
+		return 1
+	}, "FN JSON_REMOVE (SP-1), [static]")
+}
+
+func (asm *assembler) Fn_JSON_SEARCH(match jsonMatch, escape rune, paths []*json.Path) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		doc, ok := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		search := env.vm.stack[env.vm.sp-1]
+		if ok && search != nil {
+			env.vm.stack[env.vm.sp-2], env.vm.err = builtin_JSON_SEARCH(doc, match, search, escape, paths)
+		} else {
+			env.vm.stack[env.vm.sp-2] = nil
+		}
+		env.vm.sp--
+		return 1
+	}, "FN JSON_SEARCH (SP-2), (SP-1), '%s', [static]", match)
+}
+
 func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	asm.adjustStack(-(args - 1))
 	asm.emit(func(env *ExpressionEnv) int {
@@ -3778,6 +3868,58 @@ func (asm *assembler) Fn_RandomBytes() {
 	}, "FN RANDOM_BYTES INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_AES(method string, hasIV, decrypt bool) {
+	if hasIV {
+		asm.adjustStack(-2)
+		asm.emit(func(env *ExpressionEnv) int {
+			str := env.vm.stack[env.vm.sp-3].(*evalBytes)
+			key := env.vm.stack[env.vm.sp-2].(*evalBytes)
+			iv := env.vm.stack[env.vm.sp-1]
+			env.vm.stack[env.vm.sp-3], env.vm.err = aesEvaluate(env, method, str.bytes, key.bytes, true, iv, decrypt)
+			env.vm.sp -= 2
+			return 1
+		}, "FN %s VARBINARY(SP-3), VARBINARY(SP-2), VARBINARY(SP-1)", strings.ToUpper(method))
+	} else {
+		asm.adjustStack(-1)
+		asm.emit(func(env *ExpressionEnv) int {
+			str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+			key := env.vm.stack[env.vm.sp-1].(*evalBytes)
+			env.vm.stack[env.vm.sp-2], env.vm.err = aesEvaluate(env, method, str.bytes, key.bytes, false, nil, decrypt)
+			env.vm.sp--
+			return 1
+		}, "FN %s VARBINARY(SP-2), VARBINARY(SP-1)", strings.ToUpper(method))
+	}
+}
+
+func (asm *assembler) Fn_COMPRESS() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBinary(mysqlCompress(arg.bytes))
+		return 1
+	}, "FN COMPRESS VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_UNCOMPRESS() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		out := mysqlUncompress(arg.bytes)
+		if out == nil {
+			env.vm.stack[env.vm.sp-1] = nil
+		} else {
+			env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBinary(out)
+		}
+		return 1
+	}, "FN UNCOMPRESS VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_UNCOMPRESSED_LENGTH() {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalInt64(mysqlUncompressedLength(arg.bytes))
+		return 1
+	}, "FN UNCOMPRESSED_LENGTH VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	asm.adjustStack(-1)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -31,6 +31,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -807,6 +808,104 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `GREATEST(JSON_OBJECT(), JSON_ARRAY())`,
 			result:     `VARCHAR("{}")`,
 		},
+		{
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey'))`,
+			result:     `VARCHAR("C5DB682EA9EEF230C81A60AD2FAD0123131B6C88A725E613E7EE335A1011AD5C")`,
+		},
+		{
+			expression: `AES_DECRYPT(UNHEX('C5DB682EA9EEF230C81A60AD2FAD0123131B6C88A725E613E7EE335A1011AD5C'), 'secretkey')`,
+			result:     `VARBINARY("hello world, this is a test!")`,
+		},
+		{
+			expression: `AES_DECRYPT(UNHEX('C5DB682EA9EEF230C81A60AD2FAD0123131B6C88A725E613E7EE335A1011AD5C'), 'not the right key')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `AES_ENCRYPT(NULL, 'secretkey')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `UNCOMPRESS(COMPRESS('hello hello hello '))`,
+			result:     `VARBINARY("hello hello hello ")`,
+		},
+		{
+			expression: `UNCOMPRESSED_LENGTH(COMPRESS(REPEAT('a', 1000)))`,
+			result:     `INT64(1000)`,
+		},
+		{
+			expression: `UNCOMPRESS(COMPRESS(''))`,
+			result:     `VARBINARY("")`,
+		},
+		{
+			expression: `UNCOMPRESS('not compressed')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `JSON_SET('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_INSERT('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 1, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_REPLACE('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3]}")`,
+		},
+		{
+			expression: `JSON_SET(column0, '$.a', column1)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"a": 1}`)), sqltypes.NULL},
+			result:     `JSON("{\"a\": null}")`,
+		},
+		{
+			expression: `JSON_SET(column0, '$.a', 1)`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `JSON_REMOVE('["a", ["b", "c"], "d"]', '$[1]')`,
+			result:     `JSON("[\"a\", \"d\"]")`,
+		},
+		{
+			expression: `JSON_ARRAY_APPEND('["a", ["b", "c"], "d"]', '$[1][0]', 3)`,
+			result:     `JSON("[\"a\", [[\"b\", 3], \"c\"], \"d\"]")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`,
+			result:     `JSON("{\"a\": 5, \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"b": null}')`,
+			result:     `JSON("{\"a\": 1}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(NULL, '{"a": 1}', '[2]')`,
+			result:     `JSON("[2]")`,
+		},
+		{
+			expression: `JSON_MERGE_PRESERVE('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`,
+			result:     `JSON("{\"a\": [1, 3, 5], \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', 'abc')`,
+			result:     `JSON("[\"$[0]\", \"$[2].x\"]")`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', '%b%', NULL, '$[3]')`,
+			result:     `JSON("\"$[3].y\"")`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[1, 3, 5, 7]', '[2, 5, 7]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('{"a": 1, "b": 10, "d": 10}', '{"c": 1, "e": 10, "f": 1, "d": 20}')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[4, 5, 6, 7]', '6')`,
+			result:     `INT64(1)`,
+		},
 	}
 
 	tz, _ := time.LoadLocation("Europe/Madrid")
@@ -951,8 +1050,9 @@ func TestBindVarLiteral(t *testing.T) {
 }
 
 type testVcursor struct {
-	lastInsertID *uint64
-	env          *vtenv.Environment
+	lastInsertID        *uint64
+	env                 *vtenv.Environment
+	blockEncryptionMode string
 }
 
 func (t *testVcursor) TimeZone() *time.Location {
@@ -967,6 +1067,13 @@ func (t *testVcursor) SQLMode() string {
 	return "oltp"
 }
 
+func (t *testVcursor) BlockEncryptionMode() string {
+	if t.blockEncryptionMode == "" {
+		return config.DefaultBlockEncryptionMode
+	}
+	return t.blockEncryptionMode
+}
+
 func (t *testVcursor) Environment() *vtenv.Environment {
 	return t.env
 }
@@ -1043,6 +1150,96 @@ func runTest(t *testing.T, expr sqlparser.Expr, cfg *evalengine.Config, tc struc
 	}
 }
 
+func TestBlockEncryptionMode(t *testing.T) {
+	var testCases = []struct {
+		mode       string
+		expression string
+		result     string
+		err        string
+	}{
+		{
+			mode:       "aes-256-cbc",
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey', '0123456789abcdef'))`,
+			result:     `VARCHAR("14BA8EB85F58AD031055B8A0482A8FB3E298207735E60DCC651BBA4398B960D4")`,
+		},
+		{
+			mode:       "aes-192-cfb1",
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey', '0123456789abcdef'))`,
+			result:     `VARCHAR("7B60A72B1D93507D941AA253369258A096307229CC889BC6F1862C08")`,
+		},
+		{
+			mode:       "aes-128-cfb8",
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey', '0123456789abcdef'))`,
+			result:     `VARCHAR("5156F3AF4B0692043F0C7EEF26CA2A349512B54716F4EEDA402FBF5B")`,
+		},
+		{
+			mode:       "aes-256-cfb128",
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey', '0123456789abcdef'))`,
+			result:     `VARCHAR("8BE355E060F5E164D23A446E898DF8B580396BB8801E0222A52891CF")`,
+		},
+		{
+			mode:       "aes-128-ofb",
+			expression: `HEX(AES_ENCRYPT('hello world, this is a test!', 'secretkey', '0123456789abcdef'))`,
+			result:     `VARCHAR("51C8FC50A6DB425808DF2030B95162C7587955C71E4692112D6B3608")`,
+		},
+		{
+			mode:       "aes-192-cfb1",
+			expression: `AES_DECRYPT(UNHEX('7B60A72B1D93507D941AA253369258A096307229CC889BC6F1862C08'), 'secretkey', '0123456789abcdef')`,
+			result:     `VARBINARY("hello world, this is a test!")`,
+		},
+		{
+			mode:       "aes-256-cbc",
+			expression: `AES_ENCRYPT('hello', 'secretkey')`,
+			err:        "Incorrect parameter count in the call to native function 'aes_encrypt'",
+		},
+		{
+			mode:       "aes-256-cbc",
+			expression: `AES_DECRYPT('hello', 'secretkey', 'short')`,
+			err:        "The initialization vector supplied to aes_decrypt is too short. Must be at least 16 bytes long",
+		},
+		{
+			mode:       "aes-256-cbc",
+			expression: `AES_ENCRYPT('hello', 'secretkey', NULL)`,
+			err:        "The initialization vector supplied to aes_encrypt is too short. Must be at least 16 bytes long",
+		},
+	}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		t.Run(tc.mode+"/"+tc.expression, func(t *testing.T) {
+			expr, err := venv.Parser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			cfg := &evalengine.Config{
+				Collation:   collations.CollationUtf8mb4ID,
+				Environment: venv,
+			}
+
+			converted, err := evalengine.Translate(expr, cfg)
+			require.NoError(t, err)
+
+			vc := &testVcursor{env: venv, blockEncryptionMode: tc.mode}
+			env := evalengine.NewExpressionEnv(context.Background(), nil, vc)
+
+			expected, err := env.EvaluateAST(converted)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.result, expected.String())
+			}
+
+			res, err := env.Evaluate(converted)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.result, res.String())
+			}
+		})
+	}
+}
+
 func TestCompilerNonConstant(t *testing.T) {
 	var testCases = []struct {
 		expression string
@@ -17,15 +17,25 @@ limitations under the License.
 package evalengine
 
 import (
+	"bytes"
+	"compress/zlib"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/binary"
 	"encoding/hex"
+	"io"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
 )
 
 type builtinMD5 struct {
@@ -246,3 +256,543 @@ func (call *builtinRandomBytes) compile(c *compiler) (ctype, error) {
 	c.asm.jumpDestination(skip)
 	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: nullableFlags(arg.Flag) | flagNullable}, nil
 }
+
+// aesOpMode is the block cipher mode of operation used by AES_ENCRYPT
+// and AES_DECRYPT, as configured through block_encryption_mode.
+type aesOpMode int8
+
+const (
+	aesModeECB aesOpMode = iota
+	aesModeCBC
+	aesModeCFB1
+	aesModeCFB8
+	aesModeCFB128
+	aesModeOFB
+)
+
+type aesMode struct {
+	keySize int
+	opmode  aesOpMode
+}
+
+// needsIV returns whether this mode requires an initialization vector.
+func (m aesMode) needsIV() bool {
+	return m.opmode != aesModeECB
+}
+
+// padded returns whether this mode works on whole blocks and therefore
+// pads its input using PKCS7, like OpenSSL does.
+func (m aesMode) padded() bool {
+	return m.opmode == aesModeECB || m.opmode == aesModeCBC
+}
+
+// parseAESMode parses a block_encryption_mode value such as 'aes-256-cbc'.
+func parseAESMode(mode string) (aesMode, bool) {
+	if mode == "" {
+		mode = config.DefaultBlockEncryptionMode
+	}
+	parts := strings.Split(strings.ToLower(mode), "-")
+	if len(parts) != 3 || parts[0] != "aes" {
+		return aesMode{}, false
+	}
+
+	var m aesMode
+	switch parts[1] {
+	case "128":
+		m.keySize = 16
+	case "192":
+		m.keySize = 24
+	case "256":
+		m.keySize = 32
+	default:
+		return aesMode{}, false
+	}
+
+	switch parts[2] {
+	case "ecb":
+		m.opmode = aesModeECB
+	case "cbc":
+		m.opmode = aesModeCBC
+	case "cfb1":
+		m.opmode = aesModeCFB1
+	case "cfb8":
+		m.opmode = aesModeCFB8
+	case "cfb128":
+		m.opmode = aesModeCFB128
+	case "ofb":
+		m.opmode = aesModeOFB
+	default:
+		return aesMode{}, false
+	}
+	return m, true
+}
+
+// aesCreateKey derives the actual AES key from a user supplied key the
+// same way MySQL does when no KDF is given: the key bytes are XOR'ed
+// cyclically into a zeroed buffer of the key size.
+func aesCreateKey(key []byte, size int) []byte {
+	rkey := make([]byte, size)
+	for i, b := range key {
+		rkey[i%size] ^= b
+	}
+	return rkey
+}
+
+// aesCrypt encrypts or decrypts src with the given mode, key and initialization vector.
+// It returns nil when decryption fails because of invalid input or padding, in which case
+// MySQL returns NULL.
+func aesCrypt(mode aesMode, src, key, iv []byte, decrypt bool) []byte {
+	block, err := aes.NewCipher(aesCreateKey(key, mode.keySize))
+	if err != nil {
+		return nil
+	}
+
+	if mode.padded() {
+		if decrypt {
+			if len(src) == 0 || len(src)%aes.BlockSize != 0 {
+				return nil
+			}
+		} else {
+			pad := aes.BlockSize - len(src)%aes.BlockSize
+			padded := make([]byte, len(src)+pad)
+			copy(padded, src)
+			for i := len(src); i < len(padded); i++ {
+				padded[i] = byte(pad)
+			}
+			src = padded
+		}
+	}
+
+	dst := make([]byte, len(src))
+	switch mode.opmode {
+	case aesModeECB:
+		for i := 0; i < len(src); i += aes.BlockSize {
+			if decrypt {
+				block.Decrypt(dst[i:], src[i:])
+			} else {
+				block.Encrypt(dst[i:], src[i:])
+			}
+		}
+	case aesModeCBC:
+		if decrypt {
+			cipher.NewCBCDecrypter(block, iv[:aes.BlockSize]).CryptBlocks(dst, src)
+		} else {
+			cipher.NewCBCEncrypter(block, iv[:aes.BlockSize]).CryptBlocks(dst, src)
+		}
+	case aesModeCFB1:
+		aesCFB1(block, iv, dst, src, decrypt)
+	case aesModeCFB8:
+		aesCFB8(block, iv, dst, src, decrypt)
+	case aesModeCFB128:
+		aesCFB128(block, iv, dst, src, decrypt)
+	case aesModeOFB:
+		aesOFB(block, iv, dst, src)
+	}
+
+	if mode.padded() && decrypt {
+		pad := int(dst[len(dst)-1])
+		if pad == 0 || pad > aes.BlockSize {
+			return nil
+		}
+		for _, b := range dst[len(dst)-pad:] {
+			if int(b) != pad {
+				return nil
+			}
+		}
+		dst = dst[:len(dst)-pad]
+	}
+	return dst
+}
+
+// aesCFB1 implements the CFB mode with a 1-bit feedback segment, processing
+// the bits of every byte starting with the most significant one.
+func aesCFB1(block cipher.Block, iv, dst, src []byte, decrypt bool) {
+	var reg, out [aes.BlockSize]byte
+	copy(reg[:], iv)
+
+	for n := 0; n < len(src)*8; n++ {
+		block.Encrypt(out[:], reg[:])
+
+		mask := byte(0x80) >> (n % 8)
+		in := src[n/8]&mask != 0
+		res := in != (out[0]&0x80 != 0)
+		if res {
+			dst[n/8] |= mask
+		}
+
+		feedback := res
+		if decrypt {
+			feedback = in
+		}
+		for i := 0; i < aes.BlockSize-1; i++ {
+			reg[i] = reg[i]<<1 | reg[i+1]>>7
+		}
+		reg[aes.BlockSize-1] <<= 1
+		if feedback {
+			reg[aes.BlockSize-1] |= 1
+		}
+	}
+}
+
+// aesCFB8 implements the CFB mode with an 8-bit feedback segment.
+func aesCFB8(block cipher.Block, iv, dst, src []byte, decrypt bool) {
+	var reg, out [aes.BlockSize]byte
+	copy(reg[:], iv)
+
+	for i := range src {
+		block.Encrypt(out[:], reg[:])
+		dst[i] = src[i] ^ out[0]
+
+		copy(reg[:], reg[1:])
+		if decrypt {
+			reg[aes.BlockSize-1] = src[i]
+		} else {
+			reg[aes.BlockSize-1] = dst[i]
+		}
+	}
+}
+
+// aesCFB128 implements the CFB mode with a full block feedback segment.
+func aesCFB128(block cipher.Block, iv, dst, src []byte, decrypt bool) {
+	var reg, out [aes.BlockSize]byte
+	copy(reg[:], iv)
+
+	for i := 0; i < len(src); i += aes.BlockSize {
+		block.Encrypt(out[:], reg[:])
+		end := min(i+aes.BlockSize, len(src))
+		for j := i; j < end; j++ {
+			dst[j] = src[j] ^ out[j-i]
+		}
+		if decrypt {
+			copy(reg[:], src[i:end])
+		} else {
+			copy(reg[:], dst[i:end])
+		}
+	}
+}
+
+// aesOFB implements the OFB mode, which is symmetric for encryption and decryption.
+func aesOFB(block cipher.Block, iv, dst, src []byte) {
+	var reg [aes.BlockSize]byte
+	copy(reg[:], iv)
+
+	for i := 0; i < len(src); i += aes.BlockSize {
+		block.Encrypt(reg[:], reg[:])
+		end := min(i+aes.BlockSize, len(src))
+		for j := i; j < end; j++ {
+			dst[j] = src[j] ^ reg[j-i]
+		}
+	}
+}
+
+func errAESInvalidIV(method string) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "The initialization vector supplied to %s is too short. Must be at least %d bytes long", method, aes.BlockSize)
+}
+
+// aesEvaluate runs AES_ENCRYPT or AES_DECRYPT using the block_encryption_mode
+// of the current session. The IV argument is optional and only used by the
+// modes that require one.
+func aesEvaluate(env *ExpressionEnv, method string, str, key []byte, hasIV bool, iv eval, decrypt bool) (eval, error) {
+	mode, ok := parseAESMode(env.currentBlockEncryptionMode())
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Variable 'block_encryption_mode' can't be set to the value of '%s'", env.currentBlockEncryptionMode())
+	}
+
+	var ivbytes []byte
+	if mode.needsIV() {
+		if !hasIV {
+			return nil, argError(method)
+		}
+		if iv != nil {
+			ivbytes = iv.ToRawBytes()
+		}
+		if len(ivbytes) < aes.BlockSize {
+			return nil, errAESInvalidIV(method)
+		}
+	}
+
+	res := aesCrypt(mode, str, key, ivbytes, decrypt)
+	if res == nil {
+		return nil, nil
+	}
+	return newEvalBinary(res), nil
+}
+
+type builtinAESEncrypt struct {
+	CallExpr
+}
+
+var _ IR = (*builtinAESEncrypt)(nil)
+
+func (call *builtinAESEncrypt) eval(env *ExpressionEnv) (eval, error) {
+	return call.evalAES(env, false)
+}
+
+// constant returns false because the result depends on the
+// block_encryption_mode of the session evaluating the expression.
+func (call *builtinAESEncrypt) constant() bool {
+	return false
+}
+
+func (call *builtinAESEncrypt) compile(c *compiler) (ctype, error) {
+	return call.compileAES(c, false)
+}
+
+type builtinAESDecrypt struct {
+	CallExpr
+}
+
+var _ IR = (*builtinAESDecrypt)(nil)
+
+func (call *builtinAESDecrypt) eval(env *ExpressionEnv) (eval, error) {
+	return call.evalAES(env, true)
+}
+
+func (call *builtinAESDecrypt) constant() bool {
+	return false
+}
+
+func (call *builtinAESDecrypt) compile(c *compiler) (ctype, error) {
+	return call.compileAES(c, true)
+}
+
+func (call *CallExpr) evalAES(env *ExpressionEnv, decrypt bool) (eval, error) {
+	str, key, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var iv eval
+	hasIV := len(call.Arguments) > 2
+	if hasIV {
+		iv, err = call.Arguments[2].eval(env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if str == nil || key == nil {
+		return nil, nil
+	}
+	return aesEvaluate(env, call.Method, evalToBinary(str).bytes, evalToBinary(key).bytes, hasIV, iv, decrypt)
+}
+
+func (call *CallExpr) compileAES(c *compiler, decrypt bool) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	key, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(str, key)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(2, sqltypes.Binary, nil)
+	}
+
+	switch {
+	case key.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	hasIV := len(call.Arguments) > 2
+	if hasIV {
+		// The IV is not null-checked: a NULL IV is only an error for the
+		// modes that need one, which is decided when evaluating.
+		if _, err := call.Arguments[2].compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+
+	c.asm.Fn_AES(call.Method, hasIV, decrypt)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: flagNullable}, nil
+}
+
+// maxUncompressedLength is the upper bound for the size of the data that UNCOMPRESS
+// will inflate, matching the default max_allowed_packet in MySQL.
+const maxUncompressedLength = 64 * 1024 * 1024
+
+// mysqlCompress compresses the input the same way as MySQL's COMPRESS:
+// a 4-byte little-endian length header followed by the zlib stream.
+func mysqlCompress(src []byte) []byte {
+	if len(src) == 0 {
+		return []byte{}
+	}
+
+	var buf bytes.Buffer
+	var hdr [4]byte
+	binary.LittleEndian.PutUint32(hdr[:], uint32(len(src))&0x3FFFFFFF)
+	buf.Write(hdr[:])
+
+	w := zlib.NewWriter(&buf)
+	_, _ = w.Write(src)
+	_ = w.Close()
+
+	out := buf.Bytes()
+	// MySQL appends a '.' when the compressed data ends with a space,
+	// so that the value survives trailing space removal in CHAR columns.
+	if out[len(out)-1] == ' ' {
+		out = append(out, '.')
+	}
+	return out
+}
+
+// mysqlUncompress reverses mysqlCompress. It returns nil when the input is
+// not valid compressed data, in which case MySQL returns NULL.
+func mysqlUncompress(src []byte) []byte {
+	if len(src) == 0 {
+		return []byte{}
+	}
+	if len(src) <= 4 {
+		return nil
+	}
+
+	size := mysqlUncompressedLength(src)
+	if size > maxUncompressedLength {
+		return nil
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(src[4:]))
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+
+	out := make([]byte, 0, size)
+	buf := bytes.NewBuffer(out)
+	n, err := io.Copy(buf, io.LimitReader(r, size+1))
+	if err != nil || n > size {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// mysqlUncompressedLength returns the length stored in the header of data
+// produced by COMPRESS.
+func mysqlUncompressedLength(src []byte) int64 {
+	if len(src) <= 4 {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint32(src) & 0x3FFFFFFF)
+}
+
+type builtinCompress struct {
+	CallExpr
+}
+
+var _ IR = (*builtinCompress)(nil)
+
+func (call *builtinCompress) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	return newEvalBinary(mysqlCompress(evalToBinary(arg).bytes)), nil
+}
+
+func (call *builtinCompress) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_COMPRESS()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: nullableFlags(str.Flag)}, nil
+}
+
+type builtinUncompress struct {
+	CallExpr
+}
+
+var _ IR = (*builtinUncompress)(nil)
+
+func (call *builtinUncompress) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	out := mysqlUncompress(evalToBinary(arg).bytes)
+	if out == nil {
+		return nil, nil
+	}
+	return newEvalBinary(out), nil
+}
+
+func (call *builtinUncompress) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_UNCOMPRESS()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: flagNullable}, nil
+}
+
+type builtinUncompressedLength struct {
+	CallExpr
+}
+
+var _ IR = (*builtinUncompressedLength)(nil)
+
+func (call *builtinUncompressedLength) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	return newEvalInt64(mysqlUncompressedLength(evalToBinary(arg).bytes)), nil
+}
+
+func (call *builtinUncompressedLength) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	switch {
+	case str.isTextual():
+	default:
+		c.asm.Convert_xb(1, sqltypes.Binary, nil)
+	}
+
+	c.asm.Fn_UNCOMPRESSED_LENGTH()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: nullableFlags(str.Flag)}, nil
+}
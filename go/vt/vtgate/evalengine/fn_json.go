@@ -17,7 +17,11 @@ limitations under the License.
 package evalengine
 
 import (
+	"unicode/utf8"
+
+	"vitess.io/vitess/go/hack"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
@@ -57,6 +61,31 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONModify struct {
+		CallExpr
+		Transformation json.Transformation
+	}
+
+	builtinJSONRemove struct {
+		CallExpr
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
+	builtinJSONMergePreserve struct {
+		CallExpr
+	}
+
+	builtinJSONSearch struct {
+		CallExpr
+	}
+
+	builtinJSONOverlaps struct {
+		CallExpr
+	}
 )
 
 var _ IR = (*builtinJSONExtract)(nil)
@@ -67,6 +96,12 @@ var _ IR = (*builtinJSONDepth)(nil)
 var _ IR = (*builtinJSONLength)(nil)
 var _ IR = (*builtinJSONContainsPath)(nil)
 var _ IR = (*builtinJSONKeys)(nil)
+var _ IR = (*builtinJSONModify)(nil)
+var _ IR = (*builtinJSONRemove)(nil)
+var _ IR = (*builtinJSONMergePatch)(nil)
+var _ IR = (*builtinJSONMergePreserve)(nil)
+var _ IR = (*builtinJSONSearch)(nil)
+var _ IR = (*builtinJSONOverlaps)(nil)
 
 var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
 
@@ -511,3 +546,466 @@ func (call *builtinJSONKeys) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_JSON_KEYS(jp)
 	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
 }
+
+func intoJSONTransformPath(e eval) (*json.Path, error) {
+	jp, err := intoJSONPath(e)
+	if err != nil {
+		return nil, err
+	}
+	if jp.ContainsWildcards() {
+		return nil, errInvalidPathForTransform
+	}
+	return jp, nil
+}
+
+// builtin_JSON_TRANSFORM applies the transformation to copies of doc and values,
+// since both of them can be shared with other expressions or rows.
+func builtin_JSON_TRANSFORM(t json.Transformation, doc *json.Value, paths []*json.Path, values []*json.Value) (eval, error) {
+	doc = doc.Clone()
+	for i, v := range values {
+		values[i] = v.Clone()
+	}
+	res, err := json.ApplyTransform(t, doc, paths, values)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]*json.Path, 0, len(args)/2)
+	values := make([]*json.Value, 0, len(args)/2)
+	for i := 1; i < len(args); i += 2 {
+		if args[i] == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONTransformPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+		val, err := argToJSON(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, jp)
+		values = append(values, val)
+	}
+	return builtin_JSON_TRANSFORM(call.Transformation, doc, paths, values)
+}
+
+func (call *builtinJSONModify) compile(c *compiler) (ctype, error) {
+	pathArgs := make([]IR, 0, len(call.Arguments)/2)
+	for i := 1; i < len(call.Arguments); i += 2 {
+		pathArgs = append(pathArgs, call.Arguments[i])
+	}
+	paths, null, err := c.jsonExtractPaths(call, pathArgs, true)
+	if err != nil {
+		return ctype{}, err
+	}
+	if null {
+		c.asm.PushNull()
+		return ctype{Type: sqltypes.TypeJSON, Flag: flagNull | flagNullable, Col: collationJSON}, nil
+	}
+
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	if _, err := c.compileParseJSONNullable(call.Method, doct, 1); err != nil {
+		return ctype{}, err
+	}
+
+	for i := 2; i < len(call.Arguments); i += 2 {
+		vt, err := call.Arguments[i].compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		if _, err := c.compileArgToJSONNullable(vt, 1); err != nil {
+			return ctype{}, err
+		}
+	}
+
+	c.asm.Fn_JSON_MODIFY(call.Method, call.Transformation, paths)
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *builtinJSONRemove) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]*json.Path, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		if arg == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONTransformPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, jp)
+	}
+	return builtin_JSON_TRANSFORM(json.Remove, doc, paths, nil)
+}
+
+func (call *builtinJSONRemove) compile(c *compiler) (ctype, error) {
+	paths, null, err := c.jsonExtractPaths(call, call.Arguments[1:], true)
+	if err != nil {
+		return ctype{}, err
+	}
+	if null {
+		c.asm.PushNull()
+		return ctype{Type: sqltypes.TypeJSON, Flag: flagNull | flagNullable, Col: collationJSON}, nil
+	}
+
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	if _, err := c.compileParseJSONNullable(call.Method, doct, 1); err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_JSON_REMOVE(paths)
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *CallExpr) jsonDocs(env *ExpressionEnv) ([]*json.Value, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*json.Value, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			continue
+		}
+		docs[i], err = intoJSON(call.Method, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+func (call *CallExpr) compileJSONDocs(c *compiler) error {
+	for _, arg := range call.Arguments {
+		doct, err := arg.compile(c)
+		if err != nil {
+			return err
+		}
+		if _, err := c.compileParseJSONNullable(call.Method, doct, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func builtin_JSON_MERGE_PATCH(docs []*json.Value) eval {
+	var merged *json.Value
+	for i, doc := range docs {
+		switch {
+		case doc == nil:
+			// a NULL document makes the result NULL, unless a later
+			// document that is not an object replaces it entirely
+			merged = nil
+		case i == 0 || doc.Type() != json.TypeObject:
+			merged = doc.Clone()
+		case merged != nil:
+			merged = json.MergePatch(merged, doc.Clone())
+		}
+	}
+	if merged == nil {
+		return nil
+	}
+	return merged
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	docs, err := call.jsonDocs(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PATCH(docs), nil
+}
+
+func (call *builtinJSONMergePatch) compile(c *compiler) (ctype, error) {
+	if err := call.compileJSONDocs(c); err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_JSON_MERGE_PATCH(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func builtin_JSON_MERGE_PRESERVE(docs []*json.Value) eval {
+	var merged *json.Value
+	for i, doc := range docs {
+		if doc == nil {
+			return nil
+		}
+		if i == 0 {
+			merged = doc.Clone()
+		} else {
+			merged = json.MergePreserve(merged, doc.Clone())
+		}
+	}
+	return merged
+}
+
+func (call *builtinJSONMergePreserve) eval(env *ExpressionEnv) (eval, error) {
+	docs, err := call.jsonDocs(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PRESERVE(docs), nil
+}
+
+func (call *builtinJSONMergePreserve) compile(c *compiler) (ctype, error) {
+	if err := call.compileJSONDocs(c); err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_JSON_MERGE_PRESERVE(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func errIncorrectEscape() error {
+	return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongArguments, "Incorrect arguments to ESCAPE")
+}
+
+// intoJSONSearchEscape returns the escape character for JSON_SEARCH. A NULL or
+// empty escape argument selects the default escape character.
+func intoJSONSearchEscape(e eval) (rune, error) {
+	if e == nil {
+		return 0, nil
+	}
+	esc, err := evalToVarchar(e, collationJSON.Collation, true)
+	if err != nil {
+		return 0, err
+	}
+	if len(esc.bytes) == 0 {
+		return 0, nil
+	}
+	r, size := utf8.DecodeRune(esc.bytes)
+	if r == utf8.RuneError || size != len(esc.bytes) {
+		return 0, errIncorrectEscape()
+	}
+	return r, nil
+}
+
+func builtin_JSON_SEARCH(doc *json.Value, match jsonMatch, search eval, escape rune, paths []*json.Path) (eval, error) {
+	str, err := evalToVarchar(search, collationJSON.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := colldata.Lookup(collationJSON.Collation).Wildcard(str.bytes, 0, 0, escape)
+	results := json.Search(doc, paths, match == jsonMatchOne, func(s string) bool {
+		return wc.Match(hack.StringBytes(s))
+	})
+
+	switch len(results) {
+	case 0:
+		return nil, nil
+	case 1:
+		return json.NewString(results[0]), nil
+	default:
+		ary := make([]*json.Value, 0, len(results))
+		for _, r := range results {
+			ary = append(ary, json.NewString(r))
+		}
+		return json.NewArray(ary), nil
+	}
+}
+
+func (call *builtinJSONSearch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil || args[2] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := intoOneOrAll(call.Method, evalToBinary(args[1]).string())
+	if err != nil {
+		return nil, err
+	}
+
+	var escape rune
+	if len(args) > 3 {
+		escape, err = intoJSONSearchEscape(args[3])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []*json.Path
+	if len(args) > 4 {
+		for _, arg := range args[4:] {
+			if arg == nil {
+				return nil, nil
+			}
+			jp, err := intoJSONPath(arg)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, jp)
+		}
+	}
+
+	return builtin_JSON_SEARCH(doc, match, args[2], escape, paths)
+}
+
+func (call *builtinJSONSearch) compile(c *compiler) (ctype, error) {
+	if !call.Arguments[1].constant() {
+		return ctype{}, c.unsupported(call)
+	}
+
+	var escape rune
+	if len(call.Arguments) > 3 {
+		lit, ok := call.Arguments[3].(*Literal)
+		if !ok {
+			return ctype{}, c.unsupported(call)
+		}
+		var err error
+		escape, err = intoJSONSearchEscape(lit.inner)
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+
+	var paths []*json.Path
+	var null bool
+	if len(call.Arguments) > 4 {
+		var err error
+		paths, null, err = c.jsonExtractPaths(call, call.Arguments[4:], false)
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+	if null || isNullLiteral(call.Arguments[1]) {
+		c.asm.PushNull()
+		return ctype{Type: sqltypes.TypeJSON, Flag: flagNull | flagNullable, Col: collationJSON}, nil
+	}
+
+	match, err := c.jsonExtractOneOrAll(call.Method, call.Arguments[1])
+	if err != nil {
+		return ctype{}, err
+	}
+
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	if _, err := c.compileParseJSONNullable(call.Method, doct, 1); err != nil {
+		return ctype{}, err
+	}
+
+	if _, err := call.Arguments[2].compile(c); err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_JSON_SEARCH(match, escape, paths)
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func builtin_JSON_OVERLAPS(a, b *json.Value) (bool, error) {
+	if a.Type() != json.TypeArray && b.Type() == json.TypeArray {
+		a, b = b, a
+	}
+
+	switch {
+	case a.Type() == json.TypeArray:
+		// when only one of the documents is an array, the other one
+		// is compared as if it was wrapped in a single-element array
+		left, _ := a.Array()
+		right := []*json.Value{b}
+		if b.Type() == json.TypeArray {
+			right, _ = b.Array()
+		}
+		for _, l := range left {
+			for _, r := range right {
+				cmp, err := compareJSONValue(l, r)
+				if err != nil {
+					return false, err
+				}
+				if cmp == 0 {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+
+	case a.Type() == json.TypeObject && b.Type() == json.TypeObject:
+		left, _ := a.Object()
+		right, _ := b.Object()
+
+		var overlaps bool
+		var err error
+		left.Visit(func(key string, l *json.Value) {
+			if overlaps || err != nil {
+				return
+			}
+			if r := right.Get(key); r != nil {
+				var cmp int
+				cmp, err = compareJSONValue(l, r)
+				overlaps = cmp == 0
+			}
+		})
+		return overlaps && err == nil, err
+
+	default:
+		cmp, err := compareJSONValue(a, b)
+		return cmp == 0, err
+	}
+}
+
+func (call *builtinJSONOverlaps) eval(env *ExpressionEnv) (eval, error) {
+	docs, err := call.jsonDocs(env)
+	if err != nil {
+		return nil, err
+	}
+	if docs[0] == nil || docs[1] == nil {
+		return nil, nil
+	}
+	overlaps, err := builtin_JSON_OVERLAPS(docs[0], docs[1])
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(overlaps), nil
+}
+
+func (call *builtinJSONOverlaps) compile(c *compiler) (ctype, error) {
+	if err := call.compileJSONDocs(c); err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_JSON_OVERLAPS()
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flagNullable}, nil
+}
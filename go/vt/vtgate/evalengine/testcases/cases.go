@@ -33,6 +33,9 @@ var Cases = []TestCase{
 	{Run: JSONPathOperations},
 	{Run: JSONArray},
 	{Run: JSONObject},
+	{Run: JSONModification},
+	{Run: JSONMerge},
+	{Run: JSONSearch},
 	{Run: CharsetConversionOperators},
 	{Run: CaseExprWithPredicate},
 	{Run: CaseExprWithValue},
@@ -126,6 +129,10 @@ var Cases = []TestCase{
 	{Run: FnSHA1},
 	{Run: FnSHA2},
 	{Run: FnRandomBytes},
+	{Run: FnAESEncrypt},
+	{Run: FnAESDecrypt},
+	{Run: FnCompress},
+	{Run: FnUncompress},
 	{Run: FnDateFormat},
 	{Run: FnConvertTz},
 	{Run: FnDate},
@@ -195,6 +202,61 @@ func JSONPathOperations(yield Query) {
 	}
 }
 
+func JSONModification(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, path := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s')", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s', '$[0]')", obj, path), nil, false)
+
+			for _, val := range inputJSONPrimitives {
+				yield(fmt.Sprintf("JSON_SET('%s', '%s', %s)", obj, path, val), nil, false)
+				yield(fmt.Sprintf("JSON_INSERT('%s', '%s', %s)", obj, path, val), nil, false)
+				yield(fmt.Sprintf("JSON_REPLACE('%s', '%s', %s)", obj, path, val), nil, false)
+				yield(fmt.Sprintf("JSON_ARRAY_APPEND('%s', '%s', %s)", obj, path, val), nil, false)
+			}
+			yield(fmt.Sprintf("JSON_SET('%s', '%s', 1, '$.z', 2)", obj, path), nil, false)
+		}
+		yield(fmt.Sprintf("JSON_SET('%s', NULL, 1)", obj), nil, false)
+		yield(fmt.Sprintf("JSON_REMOVE('%s', NULL)", obj), nil, false)
+	}
+}
+
+func JSONMerge(yield Query) {
+	docs := append([]string{"NULL", "'1'", `'"foo"'`, `'{"a": null}'`}, inputJSONObjects...)
+	for _, a := range docs {
+		if a != "NULL" && a[0] != '\'' {
+			a = "'" + a + "'"
+		}
+		for _, b := range docs {
+			if b != "NULL" && b[0] != '\'' {
+				b = "'" + b + "'"
+			}
+			yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_MERGE_PRESERVE(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_MERGE(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_OVERLAPS(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s, '{\"a\": 1}')", a, b), nil, false)
+		}
+	}
+}
+
+func JSONSearch(yield Query) {
+	search := []string{"'foo'", "'f%'", "'%o%'", "'12_'", "'123'", "'a'", "'A'", "NULL"}
+	paths := []string{"'$'", "'$.b'", "'$**.c'", "'$[*]'", "'$.a'", "NULL"}
+
+	for _, obj := range inputJSONObjects {
+		for _, s := range search {
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s)", obj, s), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s)", obj, s), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s, NULL)", obj, s), nil, false)
+			for _, p := range paths {
+				yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s, NULL, %s)", obj, s, p), nil, false)
+				yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s, '', %s, '$')", obj, s, p), nil, false)
+			}
+		}
+	}
+}
+
 func JSONArray(yield Query) {
 	for _, a := range inputJSONPrimitives {
 		yield(fmt.Sprintf("JSON_ARRAY(%s)", a), nil, false)
@@ -752,6 +814,62 @@ func FnRandomBytes(yield Query) {
 	}
 }
 
+func FnAESEncrypt(yield Query) {
+	keys := []string{"'key'", "'a much longer key that wraps around'", "''", "1234", "NULL"}
+	for _, key := range keys {
+		for _, str := range inputStrings {
+			yield(fmt.Sprintf("AES_ENCRYPT(%s, %s)", str, key), nil, false)
+			yield(fmt.Sprintf("AES_ENCRYPT(%s, %s, 'ignored because of the mode')", str, key), nil, false)
+		}
+
+		for _, num := range inputConversions {
+			yield(fmt.Sprintf("AES_ENCRYPT(%s, %s)", num, key), nil, false)
+		}
+	}
+}
+
+func FnAESDecrypt(yield Query) {
+	keys := []string{"'key'", "'a much longer key that wraps around'", "''", "1234"}
+	for _, key := range keys {
+		for _, str := range inputStrings {
+			yield(fmt.Sprintf("AES_DECRYPT(AES_ENCRYPT(%s, %s), %s)", str, key, key), nil, false)
+			yield(fmt.Sprintf("AES_DECRYPT(AES_ENCRYPT(%s, %s), 'wrong key')", str, key), nil, false)
+			yield(fmt.Sprintf("AES_DECRYPT(%s, %s)", str, key), nil, false)
+		}
+
+		for _, num := range inputConversions {
+			yield(fmt.Sprintf("AES_DECRYPT(AES_ENCRYPT(%s, %s), %s)", num, key, key), nil, false)
+		}
+	}
+}
+
+func FnCompress(yield Query) {
+	// The zlib stream produced by COMPRESS is not guaranteed to be identical
+	// to MySQL's, so only check the properties that must be preserved.
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("UNCOMPRESS(COMPRESS(%s))", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(COMPRESS(%s))", str), nil, false)
+		yield(fmt.Sprintf("COMPRESS(%s) IS NULL", str), nil, false)
+	}
+
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("UNCOMPRESS(COMPRESS(%s))", num), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(COMPRESS(%s))", num), nil, false)
+	}
+}
+
+func FnUncompress(yield Query) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("UNCOMPRESS(%s)", str), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(%s)", str), nil, false)
+	}
+
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("UNCOMPRESS(%s)", num), nil, false)
+		yield(fmt.Sprintf("UNCOMPRESSED_LENGTH(%s)", num), nil, false)
+	}
+}
+
 func CaseExprWithValue(yield Query) {
 	var elements []string
 	elements = append(elements, inputBitwise...)
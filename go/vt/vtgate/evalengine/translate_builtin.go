@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -641,6 +642,35 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinSHA2{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "aes_encrypt":
+		switch len(args) {
+		case 2, 3:
+			return &builtinAESEncrypt{CallExpr: call}, nil
+		default:
+			return nil, argError(method)
+		}
+	case "aes_decrypt":
+		switch len(args) {
+		case 2, 3:
+			return &builtinAESDecrypt{CallExpr: call}, nil
+		default:
+			return nil, argError(method)
+		}
+	case "compress":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCompress{CallExpr: call}, nil
+	case "uncompress":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinUncompress{CallExpr: call}, nil
+	case "uncompressed_length":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinUncompressedLength{CallExpr: call}, nil
 	case "convert_tz":
 		if len(args) != 3 {
 			return nil, argError(method)
@@ -787,6 +817,106 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			Method:    "JSON_KEYS",
 		}}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		var t json.Transformation
+		var method string
+		switch call.Type {
+		case sqlparser.JSONSetType:
+			t, method = json.Set, "JSON_SET"
+		case sqlparser.JSONInsertType:
+			t, method = json.Insert, "JSON_INSERT"
+		case sqlparser.JSONReplaceType:
+			t, method = json.Replace, "JSON_REPLACE"
+		case sqlparser.JSONArrayAppendType:
+			t, method = json.ArrayAppend, "JSON_ARRAY_APPEND"
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+		doc, err := ast.translateExpr(call.JSONDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		args := []IR{doc}
+
+		for _, param := range call.Params {
+			path, err := ast.translateExpr(param.Key)
+			if err != nil {
+				return nil, err
+			}
+			val, err := ast.translateExpr(param.Value)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, path, val)
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    method,
+			},
+			Transformation: t,
+		}, nil
+
+	case *sqlparser.JSONRemoveExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONRemove{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_REMOVE",
+		}}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		switch call.Type {
+		case sqlparser.JSONMergePatchType:
+			return &builtinJSONMergePatch{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE_PATCH",
+			}}, nil
+		case sqlparser.JSONMergePreserveType:
+			return &builtinJSONMergePreserve{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE_PRESERVE",
+			}}, nil
+		default:
+			return &builtinJSONMergePreserve{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE",
+			}}, nil
+		}
+
+	case *sqlparser.JSONSearchExpr:
+		exprs := []sqlparser.Expr{call.JSONDoc, call.OneOrAll, call.SearchStr}
+		if call.EscapeChar != nil {
+			exprs = append(exprs, call.EscapeChar)
+		}
+		exprs = append(exprs, call.PathList...)
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONSearch{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_SEARCH",
+		}}, nil
+
+	case *sqlparser.JSONOverlapsExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc1, call.JSONDoc2})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONOverlaps{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_OVERLAPS",
+		}}, nil
+
 	case *sqlparser.CurTimeFuncExpr:
 		if call.Fsp > 6 {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Too-big precision %d specified for '%s'. Maximum is 6.", call.Fsp, call.Name.String())
@@ -40,6 +40,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/key"
@@ -66,6 +67,10 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
+	"vitess.io/vitess/go/vt/vtgate/querydigest"
+	"vitess.io/vitess/go/vt/vtgate/ratelimiter"
+	"vitess.io/vitess/go/vt/vtgate/resultcache"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
@@ -115,6 +120,16 @@ type (
 		AllowScatter        bool
 		WarmingReadsPercent int
 		QueryLogToFile      string
+		// RateLimiter limits the query rate per tenant. Nil when rate limiting is disabled.
+		RateLimiter *ratelimiter.RateLimiter
+		// QueryDigests aggregates the statistics of executed queries by fingerprint. Nil when disabled.
+		QueryDigests *querydigest.Store
+		// Consolidator merges identical in-flight read-only queries. Nil when consolidation is disabled.
+		Consolidator sync2.Consolidator
+		// ConsolidatorQueryWaiterCap is the maximum number of queries waiting for an identical in-flight query, 0 means unlimited.
+		ConsolidatorQueryWaiterCap int64
+		// ResultCache caches the results of read-only queries. Nil when disabled.
+		ResultCache *resultcache.Cache
 	}
 
 	Executor struct {
@@ -146,6 +161,9 @@ type (
 
 		vConfig   econtext.VCursorConfig
 		ddlConfig dynamicconfig.DDL
+
+		// queryDenylist holds the query denylist of the current vschema.
+		queryDenylist *querydenylist.Denylist
 	}
 
 	Metrics struct {
@@ -158,6 +176,8 @@ var executorOnce sync.Once
 const pathQueryPlans = "/debug/query_plans"
 const pathScatterStats = "/debug/scatter_stats"
 const pathVSchema = "/debug/vschema"
+const pathQueryDigests = "/debug/query_digests"
+const pathQueryConsolidations = "/debug/query_consolidations"
 
 type PlanCacheKey = theine.HashKey256
 type PlanCache = theine.Store[PlanCacheKey, *engine.Plan]
@@ -196,7 +216,9 @@ func NewExecutor(
 		plans:               plans,
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
 		ddlConfig:           ddlConfig,
+		queryDenylist:       querydenylist.New(),
 	}
+	e.txConn.onCommit = e.invalidateCommittedWrites
 	// setting the vcursor config.
 	e.initVConfig(warnOnShardedOnly, pv)
 	e.metrics = &Metrics{
@@ -235,6 +257,8 @@ func NewExecutor(
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
+		servenv.HTTPHandle(pathQueryDigests, e)
+		servenv.HTTPHandle(pathQueryConsolidations, e)
 	})
 	return e
 }
@@ -278,6 +302,7 @@ func (e *Executor) Execute(
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	e.recordQueryDigest(logStats)
 
 	err = errorTransform.TransformError(err)
 	err = vterrors.TruncateError(err, truncateErrorLen)
@@ -365,6 +390,8 @@ func (e *Executor) StreamExecute(
 		err := vc.StreamExecutePrimitive(ctx, plan.Instructions, bindVars, true, func(qr *sqltypes.Result) error {
 			return srr.storeResultStats(plan.QueryType, qr)
 		})
+		// Invalidate even if the query failed, as a write may have been partially applied.
+		e.invalidateResultCache(safeSession, plan)
 
 		// Check if there was partial DML execution. If so, rollback the effect of the partially executed query.
 		if err != nil {
@@ -419,6 +446,7 @@ func (e *Executor) StreamExecute(
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	e.recordQueryDigest(logStats)
 
 	err = errorTransform.TransformError(err)
 	err = vterrors.TruncateError(err, truncateErrorLen)
@@ -640,6 +668,40 @@ func (e *Executor) Commit(ctx context.Context, safeSession *econtext.SafeSession
 	return e.txConn.Commit(ctx, safeSession)
 }
 
+// invalidateCommittedWrites invalidates the cached results and lookup vindex rows written by a
+// transaction once it is committed, as other sessions may have cached them again in the meantime.
+func (e *Executor) invalidateCommittedWrites(safeSession *econtext.SafeSession) {
+	if tables := safeSession.GetWrittenTables(); len(tables) > 0 && e.config.ResultCache != nil {
+		e.config.ResultCache.Invalidate(tables...)
+		resultCacheInvalidations.Add("Write", 1)
+	}
+	lookupIds := safeSession.GetWrittenLookupIds()
+	if len(lookupIds) == 0 {
+		return
+	}
+	vschema := e.VSchema()
+	if vschema == nil {
+		return
+	}
+	for _, ks := range vschema.Keyspaces {
+		for _, vindex := range ks.Vindexes {
+			lc, ok := vindex.(vindexes.LookupCaching)
+			if !ok || lc.LookupCache() == nil {
+				continue
+			}
+			written, ok := lookupIds[lc.LookupCache().Table()]
+			if !ok {
+				continue
+			}
+			ids := make([]sqltypes.Value, 0, len(written.Ids))
+			for _, id := range written.Ids {
+				ids = append(ids, sqltypes.ProtoToValue(id))
+			}
+			lc.LookupCache().Invalidate(ids)
+		}
+	}
+}
+
 func (e *Executor) handleRollback(ctx context.Context, vcursor *econtext.VCursorImpl, safeSession *econtext.SafeSession, logStats *logstats.LogStats) (*sqltypes.Result, error) {
 	execStart := time.Now()
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
@@ -1087,6 +1149,7 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	defer e.mu.Unlock()
 	if vschema != nil {
 		e.vschema = vschema
+		e.queryDenylist.Update(vschema.QueryDenylist)
 	}
 	e.vschemaStats = stats
 	e.ClearPlans()
@@ -1136,10 +1199,15 @@ func (e *Executor) fetchOrCreatePlan(
 	if preparedPlan {
 		planKey = buildPlanKey(ctx, vcursor, query, setVarComment)
 		plan, logStats.CachedPlan = e.plans.Get(planKey.Hash(), e.epoch.Load())
+		if plan != nil {
+			if err := e.checkQueryDenylist(plan.Original, logStats); err != nil {
+				return nil, nil, nil, err
+			}
+		}
 	}
 
 	if plan == nil {
-		plan, logStats.CachedPlan, stmt, err = e.getCachedOrBuildPlan(ctx, vcursor, query, bindVars, setVarComment, parameterize, planKey, false)
+		plan, logStats.CachedPlan, stmt, err = e.getCachedOrBuildPlan(ctx, vcursor, query, bindVars, setVarComment, parameterize, planKey, logStats, false)
 		if err != nil && preparedPlan && isExecutePath {
 			// The baseline plan failed to build, try to build an optimized plan
 			plan, err = e.tryOptimizedPlan(ctx, vcursor, bindVars, query, setVarComment, parameterize, planKey, plan, err)
@@ -1151,7 +1219,7 @@ func (e *Executor) fetchOrCreatePlan(
 
 	if shouldOptimizePlan(preparedPlan, isExecutePath, plan) {
 		vcursor.SetBindVars(bindVars)
-		optimizedPlan, _, _, err := e.getCachedOrBuildPlan(ctx, vcursor, query, bindVars, setVarComment, parameterize, planKey, true)
+		optimizedPlan, _, _, err := e.getCachedOrBuildPlan(ctx, vcursor, query, bindVars, setVarComment, parameterize, planKey, nil, true)
 		if err == nil {
 			if sp, ok := optimizedPlan.Instructions.(*engine.PlanSwitcher); ok {
 				sp.Baseline = plan.Instructions
@@ -1187,7 +1255,7 @@ func (e *Executor) tryOptimizedPlan(
 	prevErr error,
 ) (*engine.Plan, error) {
 	vcursor.SetBindVars(bindVars)
-	sPlan, _, _, err := e.getCachedOrBuildPlan(ctx, vcursor, baseQuery, bindVars, setVarComment, parameterize, planKey, true)
+	sPlan, _, _, err := e.getCachedOrBuildPlan(ctx, vcursor, baseQuery, bindVars, setVarComment, parameterize, planKey, nil, true)
 	if err == nil {
 		if sp, ok := sPlan.Instructions.(*engine.PlanSwitcher); ok {
 			sp.BaselineErr = prevErr
@@ -1230,6 +1298,7 @@ func (e *Executor) getCachedOrBuildPlan(
 	setVarComment string,
 	parameterize bool,
 	planKey engine.PlanKey,
+	logStats *logstats.LogStats, // nil when re-planning a query that was already checked against the query denylist
 	ignoreCache bool,
 ) (plan *engine.Plan, cached bool, stmt sqlparser.Statement, err error) {
 	stmt, reservedVars, err := parseAndValidateQuery(query, e.env.Parser())
@@ -1285,6 +1354,12 @@ func (e *Executor) getCachedOrBuildPlan(
 		query = sqlparser.String(stmt)
 	}
 
+	if logStats != nil {
+		if err := e.checkQueryDenylist(query, logStats); err != nil {
+			return nil, false, stmt, err
+		}
+	}
+
 	planCachable := sqlparser.CachePlan(stmt) && vcursor.CachePlan()
 	if planCachable && !ignoreCache {
 		if !preparedPlan {
@@ -1386,6 +1461,10 @@ func (e *Executor) ServeHTTP(response http.ResponseWriter, request *http.Request
 		returnAsJSON(response, e.VSchema())
 	case pathScatterStats:
 		e.WriteScatterStats(response)
+	case pathQueryDigests:
+		e.serveQueryDigests(response, request)
+	case pathQueryConsolidations:
+		e.serveQueryConsolidations(response)
 	default:
 		response.WriteHeader(http.StatusNotFound)
 	}
@@ -1540,8 +1619,11 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 		DefaultTabletType: defaultTabletType,
 		PlannerVersion:    pv,
 
-		QueryTimeout:  queryTimeout,
-		MaxMemoryRows: maxMemoryRows,
+		QueryTimeout:   queryTimeout,
+		MaxMemoryRows:  maxMemoryRows,
+		MaxMemoryBytes: maxMemoryBytes,
+		SpillToDisk:    spillToDisk,
+		SpillDir:       spillDir,
 
 		SetVarEnabled:      sysVarSetEnabled,
 		EnableViews:        enableViews,
@@ -316,6 +316,10 @@ func TestExecutorSetOp(t *testing.T) {
 		result:          returnResult("sql_mode", "varchar", "STRICT_ALL_TABLES,NO_AUTO_UPDATES"),
 		sysVars:         nil,
 		disallowResConn: true,
+	}, {
+		in:      "set block_encryption_mode = 'aes-256-cbc'",
+		sysVars: map[string]string{"block_encryption_mode": "'aes-256-cbc'"},
+		result:  returnResult("block_encryption_mode", "varchar", "aes-256-cbc"),
 	}, {
 		in:      "set sql_safe_updates = 1",
 		sysVars: map[string]string{"sql_safe_updates": "1"},
@@ -650,3 +654,21 @@ func TestExecutorTimeZone(t *testing.T) {
 
 	assert.False(t, qr.Rows[0][0].Equal(qrWith.Rows[0][0]), "%v vs %v", qr.Rows[0][0].ToString(), qrWith.Rows[0][0].ToString())
 }
+
+// TestExecutorBlockEncryptionMode verifies that AES_ENCRYPT is evaluated
+// with the block_encryption_mode set in the session.
+func TestExecutorBlockEncryptionMode(t *testing.T) {
+	e, _, _, _, ctx := createExecutorEnv(t)
+
+	session := econtext.NewAutocommitSession(&vtgatepb.Session{TargetString: KsTestUnsharded, EnableSystemSettings: true})
+	const query = "select hex(aes_encrypt('hello world, this is a test!', 'secretkey', '0123456789abcdef'))"
+
+	qr, err := executorExecSession(ctx, e, session, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARCHAR("C5DB682EA9EEF230C81A60AD2FAD0123131B6C88A725E613E7EE335A1011AD5C")]]`, fmt.Sprintf("%v", qr.Rows))
+
+	session.SetSystemVariable("block_encryption_mode", "'aes-256-cbc'")
+	qr, err = executorExecSession(ctx, e, session, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARCHAR("14BA8EB85F58AD031055B8A0482A8FB3E298207735E60DCC651BBA4398B960D4")]]`, fmt.Sprintf("%v", qr.Rows))
+}
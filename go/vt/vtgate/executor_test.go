@@ -3373,3 +3373,31 @@ func TestExecutorShowShards(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutorLookupCacheInvalidatedOnCommit(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	vindex, err := vindexes.CreateVindex("lookup", "cached_lookup", map[string]string{
+		"table":     "cached_lookup",
+		"from":      "fromc",
+		"to":        "toc",
+		"cache_ttl": "1h",
+	})
+	require.NoError(t, err)
+	executor.VSchema().Keyspaces[KsTestSharded].Vindexes["cached_lookup"] = vindex
+	lc := vindex.(vindexes.LookupCaching).LookupCache()
+	_, err = lc.Lookup([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		fields := sqltypes.MakeTestFields("fromc|toc", "int64|varbinary")
+		return []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|ksid1"),
+			sqltypes.MakeTestResult(fields, "2|ksid2"),
+		}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, lc.Len())
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", InTransaction: true})
+	session.RecordWrittenLookupIds("cached_lookup", []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, executor.Commit(ctx, session))
+	assert.Equal(t, 1, lc.Len())
+	assert.Empty(t, session.WrittenLookupIds)
+}
@@ -18,6 +18,7 @@ package executorcontext
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -25,6 +26,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/datetime"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -145,6 +147,8 @@ func NewAutocommitSession(sessn *vtgatepb.Session) *SafeSession {
 	newSession.LockSession = nil
 	newSession.Autocommit = true
 	newSession.Warnings = nil
+	newSession.WrittenTables = nil
+	newSession.WrittenLookupIds = nil
 	return NewSafeSession(newSession)
 }
 
@@ -199,6 +203,8 @@ func (session *SafeSession) resetCommonLocked() {
 	session.Session.InTransaction = false
 	session.commitOrder = vtgatepb.CommitOrder_NORMAL
 	session.Savepoints = nil
+	session.WrittenTables = nil
+	session.WrittenLookupIds = nil
 	if session.Options != nil {
 		session.Options.TransactionAccessMode = nil
 	}
@@ -678,6 +684,23 @@ func (session *SafeSession) TimeZone() *time.Location {
 	return loc
 }
 
+// BlockEncryptionMode returns the block_encryption_mode stored in system_variables map in the session.
+func (session *SafeSession) BlockEncryptionMode() string {
+	session.mu.Lock()
+	modeSQL, ok := session.SystemVariables[sysvars.BlockEncryptionMode.Name]
+	session.mu.Unlock()
+
+	if !ok {
+		return config.DefaultBlockEncryptionMode
+	}
+
+	mode, err := sqltypes.DecodeStringSQL(modeSQL)
+	if err != nil {
+		return config.DefaultBlockEncryptionMode
+	}
+	return mode
+}
+
 // ForeignKeyChecks returns the foreign_key_checks stored in system_variables map in the session.
 func (session *SafeSession) ForeignKeyChecks() *bool {
 	session.mu.Lock()
@@ -712,6 +735,48 @@ func (session *SafeSession) StoreSavepoint(sql string) {
 	session.Savepoints = append(session.Savepoints, sql)
 }
 
+// RecordWrittenTables records tables as written in the current transaction.
+func (session *SafeSession) RecordWrittenTables(tables []string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for _, table := range tables {
+		if !slices.Contains(session.WrittenTables, table) {
+			session.WrittenTables = append(session.WrittenTables, table)
+		}
+	}
+}
+
+// GetWrittenTables returns the tables written in the current transaction.
+func (session *SafeSession) GetWrittenTables() []string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.WrittenTables
+}
+
+// RecordWrittenLookupIds records ids as written to the lookup table in the current transaction.
+func (session *SafeSession) RecordWrittenLookupIds(table string, ids []sqltypes.Value) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.WrittenLookupIds == nil {
+		session.WrittenLookupIds = make(map[string]*vtgatepb.Session_LookupIds)
+	}
+	written := session.WrittenLookupIds[table]
+	if written == nil {
+		written = &vtgatepb.Session_LookupIds{}
+		session.WrittenLookupIds[table] = written
+	}
+	for _, id := range ids {
+		written.Ids = append(written.Ids, sqltypes.ValueToProto(id))
+	}
+}
+
+// GetWrittenLookupIds returns the ids written to lookup tables in the current transaction, by lookup table.
+func (session *SafeSession) GetWrittenLookupIds() map[string]*vtgatepb.Session_LookupIds {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.WrittenLookupIds
+}
+
 // InReservedConn returns true if the session needs to execute on a dedicated connection
 func (session *SafeSession) InReservedConn() bool {
 	session.mu.Lock()
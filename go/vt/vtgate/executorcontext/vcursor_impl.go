@@ -78,6 +78,9 @@ type (
 		Collation collations.ID
 
 		MaxMemoryRows      int
+		MaxMemoryBytes     int64
+		SpillToDisk        bool
+		SpillDir           string
 		EnableShardRouting bool
 		DefaultTabletType  topodatapb.TabletType
 		QueryTimeout       int
@@ -108,6 +111,7 @@ type (
 		ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error)
 		ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		SetVitessMetadata(ctx context.Context, name, value string) error
 
 		// TODO: remove when resolver is gone
@@ -169,6 +173,7 @@ type (
 		// A nil value represents that no foreign_key_checks value was provided.
 		fkChecksState       *bool
 		ignoreMaxMemoryRows bool
+		queryMemory         *engine.QueryMemory
 		vschema             *vindexes.VSchema
 		vm                  VSchemaOperator
 		semTable            *semantics.SemTable
@@ -191,6 +196,11 @@ type (
 	}
 )
 
+// newQueryMemory returns the memory accounting of a new query.
+func (cfg VCursorConfig) newQueryMemory() *engine.QueryMemory {
+	return engine.NewQueryMemory(cfg.MaxMemoryBytes, cfg.SpillToDisk, cfg.SpillDir)
+}
+
 // NewVCursorImpl creates a VCursorImpl. Before creating this object, you have to separate out any marginComments that came with
 // the query and supply it here. Trailing comments are typically sent by the application for various reasons,
 // including as identifying markers. So, they have to be added back to all queries that are executed
@@ -239,6 +249,8 @@ func NewVCursorImpl(
 		vm:         vm,
 		topoServer: ts,
 		observer:   observer,
+
+		queryMemory: cfg.newQueryMemory(),
 	}, nil
 }
 
@@ -282,6 +294,7 @@ func (vc *VCursorImpl) CloneForMirroring(ctx context.Context) engine.VCursor {
 		metrics:        vc.metrics,
 
 		ignoreMaxMemoryRows: vc.ignoreMaxMemoryRows,
+		queryMemory:         vc.config.newQueryMemory(),
 		vschema:             vc.vschema,
 		vm:                  vc.vm,
 		semTable:            vc.semTable,
@@ -315,6 +328,7 @@ func (vc *VCursorImpl) CloneForReplicaWarming(ctx context.Context) engine.VCurso
 		metrics:        vc.metrics,
 
 		ignoreMaxMemoryRows: vc.ignoreMaxMemoryRows,
+		queryMemory:         vc.config.newQueryMemory(),
 		vschema:             vc.vschema,
 		vm:                  vc.vm,
 		semTable:            vc.semTable,
@@ -390,6 +404,12 @@ func (vc *VCursorImpl) SQLMode() string {
 	return config.DefaultSQLMode
 }
 
+// BlockEncryptionMode returns the block_encryption_mode of the session,
+// used by AES_ENCRYPT and AES_DECRYPT.
+func (vc *VCursorImpl) BlockEncryptionMode() string {
+	return vc.SafeSession.BlockEncryptionMode()
+}
+
 // MaxMemoryRows returns the maxMemoryRows flag value.
 func (vc *VCursorImpl) MaxMemoryRows() int {
 	return vc.config.MaxMemoryRows
@@ -401,6 +421,11 @@ func (vc *VCursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
 	return !vc.ignoreMaxMemoryRows && numRows > vc.config.MaxMemoryRows
 }
 
+// QueryMemory returns the memory accounting of the query.
+func (vc *VCursorImpl) QueryMemory() *engine.QueryMemory {
+	return vc.queryMemory
+}
+
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
 func (vc *VCursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
@@ -1355,6 +1380,11 @@ func (vc *VCursorImpl) InTransaction() bool {
 	return vc.SafeSession.InTransaction()
 }
 
+// InvalidateLookupCacheOnCommit is part of the engine.VCursor interface.
+func (vc *VCursorImpl) InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value) {
+	vc.SafeSession.RecordWrittenLookupIds(table, ids)
+}
+
 func (vc *VCursorImpl) Commit(ctx context.Context) error {
 	return vc.executor.Commit(ctx, vc.SafeSession)
 }
@@ -1449,6 +1479,25 @@ func (vc *VCursorImpl) FindMirrorRule(name sqlparser.TableName) (*vindexes.Mirro
 	return mirrorRule, err
 }
 
+// FindWeightedRoutingRule finds the weighted routing rule for the requested
+// table name and VSchema tablet type.
+func (vc *VCursorImpl) FindWeightedRoutingRule(name sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	destKeyspace, destTabletType, _, err := vc.parseDestinationTarget(name.Qualifier.String())
+	if err != nil {
+		return nil, err
+	}
+	if destKeyspace == "" {
+		destKeyspace = vc.keyspace
+	}
+	return vc.vschema.FindWeightedRoutingRule(destKeyspace, name.Name.String(), destTabletType)
+}
+
+// FindWeightedShardRoutingRule finds the weighted shard routing rule for the
+// requested keyspace and VSchema tablet type.
+func (vc *VCursorImpl) FindWeightedShardRoutingRule(keyspace string) (*vindexes.WeightedShardRoutingRule, error) {
+	return vc.vschema.FindWeightedShardRoutingRule(keyspace, vc.tabletType)
+}
+
 func (vc *VCursorImpl) GetKeyspace() string {
 	return vc.keyspace
 }
@@ -1501,6 +1550,8 @@ func (vc *VCursorImpl) VStream(ctx context.Context, rss []*srvtopo.ResolvedShard
 
 func (vc *VCursorImpl) ShowExec(ctx context.Context, command sqlparser.ShowCommandType, filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
 	switch command {
+	case sqlparser.VitessQueryDigests:
+		return vc.executor.ShowQueryDigests(filter)
 	case sqlparser.VitessReplicationStatus:
 		return vc.executor.ShowVitessReplicationStatus(ctx, filter)
 	case sqlparser.VitessShards:
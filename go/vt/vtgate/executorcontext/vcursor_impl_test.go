@@ -383,6 +383,11 @@ func (f fakeExecutor) ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Resul
 	panic("implement me")
 }
 
+func (f fakeExecutor) ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
 	// TODO implement me
 	panic("implement me")
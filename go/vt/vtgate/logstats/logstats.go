@@ -40,6 +40,8 @@ type LogStats struct {
 	TabletType              string
 	StmtType                string
 	SQL                     string
+	NormalizedSQL           string // NormalizedSQL is the query after normalization, as planned
+	Fingerprint             string // Fingerprint is the fingerprint of the normalized query
 	BindVariables           map[string]*querypb.BindVariable
 	StartTime               time.Time
 	EndTime                 time.Time
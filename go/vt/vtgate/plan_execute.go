@@ -88,6 +88,7 @@ func (e *Executor) newExecute(
 		vcursor            *econtext.VCursorImpl
 		stmt               sqlparser.Statement
 		cancel             context.CancelFunc
+		rateLimitChecked   bool
 	)
 
 	for try := 0; try < MaxBufferingRetries; try++ {
@@ -156,6 +157,16 @@ func (e *Executor) newExecute(
 			return recResult(plan.QueryType, result)
 		}
 
+		// A query takes a single rate limit token, however many times it is retried. The token is
+		// taken once the query is planned, as the vindex dimension keys the query by its plan.
+		if !rateLimitChecked {
+			rateLimitChecked = true
+			if err := e.checkRateLimit(ctx, safeSession, plan, vcursor, bindVars); err != nil {
+				logStats.Error = err
+				return err
+			}
+		}
+
 		// Prepare for execution.
 		err = e.addNeededBindVars(vcursor, plan.BindVarNeeds, bindVars, safeSession)
 		if err != nil {
@@ -166,6 +177,13 @@ func (e *Executor) newExecute(
 		// Set the session variable to indicate if the query is a read query or not.
 		safeSession.SetExecReadQuery(plan.QueryType.IsReadStatement())
 
+		var release func()
+		release, err = e.acquireQueryDenylist(ctx, logStats.Fingerprint)
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
+
 		// Execute the plan.
 		if plan.Instructions.NeedsTransaction() {
 			err = e.insideTransaction(ctx, safeSession, logStats,
@@ -175,6 +193,7 @@ func (e *Executor) newExecute(
 		} else {
 			err = execPlan(ctx, plan, vcursor, bindVars, execStart)
 		}
+		release()
 
 		if err == nil || safeSession.InTransaction() {
 			return err
@@ -328,7 +347,9 @@ func (e *Executor) executePlan(
 	execStart time.Time,
 ) (*sqltypes.Result, error) {
 	// 4: Execute!
-	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	qr, err := e.executePrimitive(ctx, safeSession, plan, vcursor, bindVars)
+	// Invalidate even if the query failed, as a write may have been partially applied.
+	e.invalidateResultCache(safeSession, plan)
 
 	// 5: Log and add statistics
 	e.setLogStats(logStats, plan, vcursor, execStart, err, qr)
@@ -123,9 +123,15 @@ func buildDDLPlans(ctx context.Context, sql string, ddlStatement sqlparser.DDLSt
 	case *sqlparser.RenameTable:
 		destination, keyspace, err = buildRenameTable(vschema, ddl)
 	case *sqlparser.CreateProcedure:
-		destination, keyspace, err = buildCreateProcedurePlan(vschema, ddl)
-	case *sqlparser.DropProcedure:
-		destination, keyspace, err = buildDropProcedurePlan(vschema, ddl)
+		destination, keyspace, err = buildCreateRoutinePlan(vschema, ddl, "CREATE PROCEDURE")
+	case *sqlparser.CreateFunction:
+		destination, keyspace, err = buildCreateRoutinePlan(vschema, ddl, "CREATE FUNCTION")
+	case *sqlparser.CreateTrigger:
+		destination, keyspace, err = buildCreateRoutinePlan(vschema, ddl, "CREATE TRIGGER")
+	case *sqlparser.CreateEvent:
+		destination, keyspace, err = buildCreateRoutinePlan(vschema, ddl, "CREATE EVENT")
+	case *sqlparser.DropProcedure, *sqlparser.DropFunction, *sqlparser.DropTrigger, *sqlparser.DropEvent:
+		destination, keyspace, err = buildDropRoutinePlan(vschema, ddlStatement)
 	default:
 		return nil, nil, vterrors.VT13001(fmt.Sprintf("unexpected DDL statement type: %T", ddlStatement))
 	}
@@ -156,27 +162,32 @@ func buildDDLPlans(ctx context.Context, sql string, ddlStatement sqlparser.DDLSt
 		}, nil
 }
 
-func buildDropProcedurePlan(vschema plancontext.VSchema, dp *sqlparser.DropProcedure) (key.ShardDestination, *vindexes.Keyspace, error) {
-	destination, keyspace, _, err := vschema.TargetDestination(dp.Name.Qualifier.String())
+// buildDropRoutinePlan builds the plan for dropping a stored procedure, function, trigger or event.
+func buildDropRoutinePlan(vschema plancontext.VSchema, ddl sqlparser.DDLStatement) (key.ShardDestination, *vindexes.Keyspace, error) {
+	name := ddl.GetTable()
+	destination, keyspace, _, err := vschema.TargetDestination(name.Qualifier.String())
 	if err != nil {
 		return nil, nil, err
 	}
-	// Clear out the qualifier from the table name.
-	dp.SetTable("", dp.Name.Name.String())
+	// Clear out the qualifier from the routine name.
+	ddl.SetTable("", name.Name.String())
 	return destination, keyspace, nil
 }
 
-func buildCreateProcedurePlan(vschema plancontext.VSchema, cp *sqlparser.CreateProcedure) (key.ShardDestination, *vindexes.Keyspace, error) {
-	destination, keyspace, _, err := vschema.TargetDestination(cp.Name.Qualifier.String())
+// buildCreateRoutinePlan builds the plan for creating a stored procedure, function, trigger or event.
+// These are only supported on unsharded keyspaces, as their bodies can reference any table in the keyspace.
+func buildCreateRoutinePlan(vschema plancontext.VSchema, ddl sqlparser.DDLStatement, stmtType string) (key.ShardDestination, *vindexes.Keyspace, error) {
+	name := ddl.GetTable()
+	destination, keyspace, _, err := vschema.TargetDestination(name.Qualifier.String())
 	if err != nil {
 		return nil, nil, err
 	}
 	if keyspace.Sharded {
-		return nil, nil, vterrors.VT12001("CREATE PROCEDURE is not supported on sharded keyspaces")
+		return nil, nil, vterrors.VT12001(stmtType + " is not supported on sharded keyspaces")
 	}
-	// Clear out the qualifier from the table name.
-	cp.SetTable("", cp.Name.Name.String())
-	sqlparser.RemoveSpecificKeyspace(cp, keyspace.Name)
+	// Clear out the qualifier from the routine name.
+	ddl.SetTable("", name.Name.String())
+	sqlparser.RemoveSpecificKeyspace(ddl, keyspace.Name)
 	return destination, keyspace, nil
 }
 
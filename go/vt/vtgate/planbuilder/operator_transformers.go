@@ -95,6 +95,8 @@ func recursiveTransform(ctx *plancontext.PlanningContext, op operators.Operator)
 		return transformWindow(ctx, op)
 	case *operators.PercentBasedMirror:
 		return transformPercentBasedMirror(ctx, op)
+	case *operators.WeightedRoute:
+		return transformWeightedRoute(ctx, op)
 	}
 
 	return nil, vterrors.VT13001(fmt.Sprintf("unknown type encountered: %T (transformToPrimitive)", op))
@@ -116,6 +118,29 @@ func transformPercentBasedMirror(ctx *plancontext.PlanningContext, op *operators
 	return engine.NewPercentBasedMirror(op.Percent, primitive, target), nil
 }
 
+func transformWeightedRoute(ctx *plancontext.PlanningContext, op *operators.WeightedRoute) (engine.Primitive, error) {
+	source, err := transformToPrimitive(ctx, op.Source())
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := transformToPrimitive(ctx.UseWeightedTarget(), op.Target())
+	// Like mirroring, weighted routing is best-effort. If we encounter an
+	// error while building the target primitive, route all reads to the
+	// source.
+	if err != nil {
+		return source, nil
+	}
+
+	return &engine.WeightedRoute{
+		Percent:         op.Percent,
+		MaxErrorPercent: op.MaxErrorPercent,
+		Rules:           op.Rules,
+		Source:          source,
+		Target:          target,
+	}, nil
+}
+
 func transformDMLWithInput(ctx *plancontext.PlanningContext, op *operators.DMLWithInput) (engine.Primitive, error) {
 	input, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
@@ -53,6 +53,12 @@ func translateQueryToOpWithMirroring(ctx *plancontext.PlanningContext, stmt sqlp
 	op := translateQueryToOp(ctx, stmt)
 
 	if selStmt, ok := stmt.(sqlparser.SelectStatement); ok {
+		if wi := ctx.SemTable.GetWeightedRoutingInfo(); wi.Percent > 0 {
+			// Weighted routing takes over mirroring: the reads it routes to
+			// the target are no longer mirrored.
+			targetOp := translateQueryToOp(ctx.UseWeightedTarget(), selStmt)
+			return NewWeightedRoute(wi, op, targetOp)
+		}
 		if mi := ctx.SemTable.GetMirrorInfo(); mi.Percent > 0 {
 			mirrorOp := translateQueryToOp(ctx.UseMirror(), selStmt)
 			op = NewPercentBasedMirror(mi.Percent, op, mirrorOp)
@@ -314,6 +320,24 @@ func getOperatorFromAliasedTableExpr(ctx *plancontext.PlanningContext, tableExpr
 					panic(vterrors.VT13001(fmt.Sprintf("unable to find mirror rule for table: %T", tbl)))
 				}
 			}
+			if ctx.IsWeightedTarget() {
+				wr := tableInfo.WeightedRoutingRule
+				vtbl := tableInfo.GetVindexTable()
+				switch {
+				case wr != nil:
+					newTbl := sqlparser.Clone(tbl)
+					newTbl.Qualifier = sqlparser.NewIdentifierCS(wr.Table.Keyspace.Name)
+					newTbl.Name = wr.Table.Name
+					if newTbl.Name.String() != tbl.Name.String() {
+						tableExpr = sqlparser.Clone(tableExpr)
+						tableExpr.As = tbl.Name
+					}
+					tbl = newTbl
+				case vtbl.Type == vindexes.TypeReference && vtbl.Name.String() == "dual":
+				default:
+					panic(vterrors.VT13001(fmt.Sprintf("unable to find weighted routing rule for table: %T", tbl)))
+				}
+			}
 			qt := &QueryTable{Alias: tableExpr, Table: tbl, ID: tableID, IsInfSchema: isInfSchema}
 			qg.Tables = append(qg.Tables, qt)
 			return qg
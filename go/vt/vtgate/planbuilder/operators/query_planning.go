@@ -72,6 +72,14 @@ func runPhases(ctx *plancontext.PlanningContext, root Operator) Operator {
 		})
 		return pbm
 	}
+	if wr, ok := root.(*WeightedRoute); ok {
+		targetCtx := ctx.UseWeightedTarget()
+		wr.SetInputs([]Operator{
+			runPhases(ctx, wr.Source()),
+			runPhases(targetCtx, wr.Target()),
+		})
+		return wr
+	}
 
 	p := phaser{}
 	for phase := p.next(ctx); phase != DONE; phase = p.next(ctx) {
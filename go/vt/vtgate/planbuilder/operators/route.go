@@ -374,12 +374,13 @@ func findVSchemaTableAndCreateRoute(
 
 	vschemaTable, _, _, tabletType, target, err = ctx.VSchema.FindTableOrVindex(tableName)
 
-	// If we're processing the target-side of a mirror operator, look up the
-	// mirror target table by using FindTable, which bypasses routing rules.
+	// If we're processing the target-side of a mirror or weighted route
+	// operator, look up the target table by using FindTable, which bypasses
+	// routing rules.
 	//
 	// Exclude dual tables, which do not get a mirror rule, and are not known to
 	// the VSchema.
-	if ctx.IsMirrored() && !(vschemaTable.Type == vindexes.TypeReference && vschemaTable.Name.String() == "dual") {
+	if (ctx.IsMirrored() || ctx.IsWeightedTarget()) && !(vschemaTable.Type == vindexes.TypeReference && vschemaTable.Name.String() == "dual") {
 		vschemaTable, _, tabletType, target, err = ctx.VSchema.FindTable(tableName)
 	}
 
@@ -25,6 +25,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -283,10 +284,147 @@ func (tr *ShardedRouting) planComparison(ctx *plancontext.PlanningContext, cmp *
 	case sqlparser.LikeOp:
 		found := tr.planLikeOp(ctx, cmp)
 		return nil, found
+	case sqlparser.LessThanOp, sqlparser.LessEqualOp, sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+		found := tr.planRangeOp(ctx, cmp)
+		return nil, found
 	}
 	return nil, false
 }
 
+// planRangeOp plans a range comparison against a RangeMapper vindex column as
+// a half-open BETWEEN, with a NULL for the unbounded side. Strict comparisons
+// are planned as inclusive ones, so we may route to a shard too many but never
+// miss one. A lower and an upper bound on the same vindex are combined into a
+// single option.
+func (tr *ShardedRouting) planRangeOp(ctx *plancontext.PlanningContext, cmp *sqlparser.ComparisonExpr) bool {
+	op := cmp.Operator
+	column, ok := cmp.Left.(*sqlparser.ColName)
+	vdValue := cmp.Right
+	if !ok {
+		column, ok = cmp.Right.(*sqlparser.ColName)
+		if !ok {
+			return false
+		}
+		vdValue = cmp.Left
+		op, _ = op.SwitchSides()
+	}
+
+	lowerBound := op == sqlparser.GreaterThanOp || op == sqlparser.GreaterEqualOp
+	var rangeValue sqlparser.ValTuple
+	if lowerBound {
+		rangeValue = sqlparser.ValTuple{vdValue, &sqlparser.NullVal{}}
+	} else {
+		rangeValue = sqlparser.ValTuple{&sqlparser.NullVal{}, vdValue}
+	}
+
+	val := makeEvalEngineExpr(ctx, rangeValue)
+	if val == nil {
+		return false
+	}
+
+	opcode := func(vindex *vindexes.ColumnVindex) engine.Opcode {
+		if _, ok := vindex.Vindex.(vindexes.RangeMapper); ok && rangeMapsColumn(ctx, vindex.Vindex, column) {
+			return engine.Between
+		}
+		return engine.Scatter
+	}
+	rangeVdx := func(vindex *vindexes.ColumnVindex) vindexes.Vindex {
+		if _, ok := vindex.Vindex.(vindexes.RangeMapper); ok && rangeMapsColumn(ctx, vindex.Vindex, column) {
+			return vindex.Vindex
+		}
+		return nil
+	}
+
+	if !tr.haveMatchingVindex(ctx, cmp, rangeValue, column, val, opcode, rangeVdx) {
+		return false
+	}
+	tr.combineRangeOptions(ctx, lowerBound)
+	return true
+}
+
+// rangeMapsColumn returns true if the order of the keyspace ids of the vindex
+// matches the order of the values of the column. The binary vindex orders its
+// ids byte by byte, which only matches the order MySQL uses for binary strings:
+// with any other collation, 'a' and 'A' may compare equal but map to different
+// shards. If the type of the column is unknown, we don't route on the range.
+func rangeMapsColumn(ctx *plancontext.PlanningContext, vindex vindexes.Vindex, column *sqlparser.ColName) bool {
+	if _, ok := vindex.(*vindexes.Binary); !ok {
+		return true
+	}
+	typ, found := ctx.TypeForExpr(column)
+	if !found {
+		return false
+	}
+	return sqltypes.IsBinary(typ.Type()) || typ.Collation() == collations.CollationBinaryID
+}
+
+// combineRangeOptions looks for vindexes with a half-open range option that
+// was just added, and an earlier one bounding the other side of the range. If
+// it finds them, it adds an option for the closed range. Since options of
+// equal cost are picked in order, the new option is preferred.
+func (tr *ShardedRouting) combineRangeOptions(ctx *plancontext.PlanningContext, lowerBound bool) {
+	for _, vpp := range tr.VindexPreds {
+		if len(vpp.Options) < 2 {
+			continue
+		}
+		last := vpp.Options[len(vpp.Options)-1]
+		newBound, ok := halfOpenRangeBound(last, lowerBound)
+		if !ok {
+			continue
+		}
+		for _, other := range vpp.Options[:len(vpp.Options)-1] {
+			otherBound, ok := halfOpenRangeBound(other, !lowerBound)
+			if !ok {
+				continue
+			}
+			rangeValue := sqlparser.ValTuple{otherBound, newBound}
+			if lowerBound {
+				rangeValue = sqlparser.ValTuple{newBound, otherBound}
+			}
+			val := makeEvalEngineExpr(ctx, rangeValue)
+			if val == nil {
+				continue
+			}
+			vpp.Options = append(vpp.Options, &VindexOption{
+				Values:      []evalengine.Expr{val},
+				ValueExprs:  []sqlparser.Expr{rangeValue},
+				Predicates:  []sqlparser.Expr{other.Predicates[0], last.Predicates[0]},
+				OpCode:      engine.Between,
+				FoundVindex: last.FoundVindex,
+				Cost:        last.Cost,
+				Ready:       true,
+			})
+			break
+		}
+	}
+}
+
+// halfOpenRangeBound returns the bound of an option planned by planRangeOp,
+// if it bounds the range on the given side only.
+func halfOpenRangeBound(option *VindexOption, lowerBound bool) (sqlparser.Expr, bool) {
+	if option.OpCode != engine.Between || len(option.ValueExprs) != 1 || len(option.Predicates) != 1 {
+		return nil, false
+	}
+	if _, ok := option.Predicates[0].(*sqlparser.ComparisonExpr); !ok {
+		return nil, false
+	}
+	tuple, ok := option.ValueExprs[0].(sqlparser.ValTuple)
+	if !ok || len(tuple) != 2 {
+		return nil, false
+	}
+	bound, open := tuple[0], tuple[1]
+	if !lowerBound {
+		open, bound = tuple[0], tuple[1]
+	}
+	if _, ok := open.(*sqlparser.NullVal); !ok {
+		return nil, false
+	}
+	if _, ok := bound.(*sqlparser.NullVal); ok {
+		return nil, false
+	}
+	return bound, true
+}
+
 func (tr *ShardedRouting) planIsExpr(ctx *plancontext.PlanningContext, node *sqlparser.IsExpr) bool {
 	// we only handle IS NULL correct. IsExpr can contain other expressions as well
 	if node.Right != sqlparser.IsNullOp {
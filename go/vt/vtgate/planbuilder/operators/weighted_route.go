@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+type (
+	// WeightedRoute sends a percentage of the reads planned on the LHS to
+	// the tables the weighted routing rules route to, planned on the RHS.
+	WeightedRoute struct {
+		binaryOperator
+		semantics.WeightedRoutingInfo
+	}
+)
+
+var _ Operator = (*WeightedRoute)(nil)
+
+func (w *WeightedRoute) Source() Operator {
+	return w.LHS
+}
+
+func (w *WeightedRoute) Target() Operator {
+	return w.RHS
+}
+
+func NewWeightedRoute(info semantics.WeightedRoutingInfo, source, target Operator) *WeightedRoute {
+	return &WeightedRoute{
+		binaryOperator:      newBinaryOp(source, target),
+		WeightedRoutingInfo: info,
+	}
+}
+
+// Clone will return a copy of this operator, protected so changed to the original will not impact the clone
+func (w *WeightedRoute) Clone(inputs []Operator) Operator {
+	cloneWeightedRoute := *w
+	cloneWeightedRoute.SetInputs(inputs)
+	return &cloneWeightedRoute
+}
+
+func (w *WeightedRoute) AddPredicate(*plancontext.PlanningContext, sqlparser.Expr) Operator {
+	panic(vterrors.VT13001("not supported"))
+}
+
+func (w *WeightedRoute) AddColumn(*plancontext.PlanningContext, bool, bool, *sqlparser.AliasedExpr) int {
+	panic(vterrors.VT13001("not supported"))
+}
+
+func (w *WeightedRoute) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
+	return w.Source().FindCol(ctx, expr, underRoute)
+}
+
+func (w *WeightedRoute) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return w.Source().GetColumns(ctx)
+}
+
+func (w *WeightedRoute) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return w.Source().GetSelectExprs(ctx)
+}
+
+func (w *WeightedRoute) ShortDescription() string {
+	return fmt.Sprintf("WeightedRoute (%.02f%%)", w.Percent)
+}
+
+func (w *WeightedRoute) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	return w.Source().GetOrdering(ctx)
+}
+
+// AddWSColumn implements Operator.
+func (w *WeightedRoute) AddWSColumn(*plancontext.PlanningContext, int, bool) int {
+	panic(vterrors.VT13001("not supported"))
+}
@@ -606,6 +606,16 @@ func (s *planTestSuite) TestMirrorPlanning() {
 	s.testFile("mirror_cases.json", vw, false)
 }
 
+func (s *planTestSuite) TestWeightedRoutingPlanning() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/weighted_routing_schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+	vw.TabletType_ = topodatapb.TabletType_REPLICA
+
+	s.testFile("weighted_routing_cases.json", vw, false)
+}
+
 func (s *planTestSuite) TestOneMirror() {
 	reset := operators.EnableDebugPrinting()
 	defer reset()
@@ -69,6 +69,14 @@ type PlanningContext struct {
 	// isMirrored indicates that mirrored tables should be used.
 	isMirrored bool
 
+	// weightedTarget contains a clone of this planning context using the
+	// targets of the weighted routing rules.
+	weightedTarget *PlanningContext
+
+	// isWeightedTarget indicates that the tables the weighted routing rules
+	// route to should be used.
+	isWeightedTarget bool
+
 	emptyEnv    *evalengine.ExpressionEnv
 	constantCfg *evalengine.Config
 
@@ -326,6 +334,10 @@ func (ctx *PlanningContext) IsMirrored() bool {
 	return ctx.isMirrored
 }
 
+func (ctx *PlanningContext) IsWeightedTarget() bool {
+	return ctx.isWeightedTarget
+}
+
 type ContextCTE struct {
 	*semantics.CTE
 	Id         semantics.TableSet
@@ -393,6 +405,34 @@ func (ctx *PlanningContext) UseMirror() *PlanningContext {
 	return ctx.mirror
 }
 
+// UseWeightedTarget returns a clone of this planning context that plans
+// against the tables the weighted routing rules route to.
+func (ctx *PlanningContext) UseWeightedTarget() *PlanningContext {
+	if ctx.isWeightedTarget || ctx.isMirrored {
+		panic(vterrors.VT13001("cannot use weighted routing target in a weighted or mirrored planning context"))
+	}
+	if ctx.weightedTarget != nil {
+		return ctx.weightedTarget
+	}
+	ctx.weightedTarget = &PlanningContext{
+		ReservedVars:      ctx.ReservedVars,
+		SemTable:          ctx.SemTable,
+		VSchema:           ctx.VSchema,
+		PlannerVersion:    ctx.PlannerVersion,
+		ReservedArguments: map[sqlparser.Expr]string{},
+		VerifyAllFKs:      ctx.VerifyAllFKs,
+		MergedSubqueries:  ctx.MergedSubqueries,
+		CurrentPhase:      ctx.CurrentPhase,
+		Statement:         ctx.Statement,
+		OuterTables:       ctx.OuterTables,
+		CurrentCTE:        ctx.CurrentCTE,
+		emptyEnv:          ctx.emptyEnv,
+		PredTracker:       ctx.PredTracker,
+		isWeightedTarget:  true,
+	}
+	return ctx.weightedTarget
+}
+
 // IsConstantBool checks whether this predicate can be evaluated at plan-time.
 // If it can, it returns the constant value.
 func (ctx *PlanningContext) IsConstantBool(expr sqlparser.Expr) *bool {
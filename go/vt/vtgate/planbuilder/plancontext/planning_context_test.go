@@ -376,6 +376,16 @@ func (v *vschema) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.Mirro
 	panic("unimplemented")
 }
 
+// FindWeightedRoutingRule implements VSchema.
+func (v *vschema) FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	panic("unimplemented")
+}
+
+// FindWeightedShardRoutingRule implements VSchema.
+func (v *vschema) FindWeightedShardRoutingRule(keyspace string) (*vindexes.WeightedShardRoutingRule, error) {
+	panic("unimplemented")
+}
+
 func (v *vschema) GetBindVars() map[string]*querypb.BindVariable {
 	// TODO implement me
 	panic("implement me")
@@ -105,6 +105,14 @@ type VSchema interface {
 	// name, and the tablet type in the VSchema.
 	FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error)
 
+	// FindWeightedRoutingRule finds the weighted routing rule for the
+	// requested keyspace, table name, and the tablet type in the VSchema.
+	FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error)
+
+	// FindWeightedShardRoutingRule finds the weighted shard routing rule for
+	// the requested keyspace and the tablet type in the VSchema.
+	FindWeightedShardRoutingRule(keyspace string) (*vindexes.WeightedShardRoutingRule, error)
+
 	// GetBindVars returns the bindvars. If we are executing a prepared statement for the first time,
 	// we re-plan with the bindvar values to see if we find any better plans now that we can see parameter values.
 	// If we find a better plan, we store it, and use it when the bindvars line up
@@ -25,13 +25,27 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func gen4Planner(query string, plannerVersion querypb.ExecuteOptions_PlannerVersion) stmtPlanner {
 	return func(stmt sqlparser.Statement, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema) (*planResult, error) {
 		switch stmt := stmt.(type) {
 		case sqlparser.SelectStatement:
-			return gen4SelectStmtPlanner(query, plannerVersion, stmt, reservedVars, vschema)
+			pr, err := gen4SelectStmtPlanner(query, plannerVersion, stmt, reservedVars, vschema)
+			if err != nil {
+				return nil, err
+			}
+			pr.primitive = engine.NewWeightedShardRoute(pr.primitive, func(keyspace string) *vindexes.WeightedShardRoutingRule {
+				wr, err := vschema.FindWeightedShardRoutingRule(keyspace)
+				if err != nil {
+					// Like weighted routing of tables, this is best effort: an
+					// invalid rule routes all the reads to the serving shards.
+					return nil
+				}
+				return wr
+			})
+			return pr, nil
 		case *sqlparser.Update:
 			return gen4UpdateStmtPlanner(plannerVersion, stmt, reservedVars, vschema)
 		case *sqlparser.Delete:
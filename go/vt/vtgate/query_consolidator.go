@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+)
+
+var (
+	queryConsolidations                = stats.NewCounter("QueryConsolidations", "Number of queries answered with the result of an identical in-flight query")
+	queryConsolidatorWaiterCapExceeded = stats.NewCounter("QueryConsolidatorWaiterCapExceeded", "Number of queries executed on their own because too many queries were already waiting for an identical in-flight query")
+)
+
+// executePrimitive executes the plan. Read-only queries can be answered from the result
+// cache, and identical ones that are in flight at the same time share a single execution
+// when the consolidator is enabled.
+func (e *Executor) executePrimitive(
+	ctx context.Context,
+	safeSession *econtext.SafeSession,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+) (*sqltypes.Result, error) {
+	if !canShareResult(safeSession, plan) {
+		return vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	}
+	key := sharedResultKey(ctx, safeSession, plan, vcursor, bindVars)
+	if ttl := e.resultCacheTTL(plan); ttl > 0 {
+		return e.executeCached(ctx, plan, vcursor, bindVars, key, ttl)
+	}
+	qr, _, err := e.executeConsolidated(ctx, plan, vcursor, bindVars, key)
+	return qr, err
+}
+
+// executeConsolidated executes a read-only query, sharing the execution of an identical
+// in-flight query when the consolidator is enabled. shared is true if the result is the
+// one of an identical query executed by another caller.
+func (e *Executor) executeConsolidated(
+	ctx context.Context,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+	key string,
+) (qr *sqltypes.Result, shared bool, err error) {
+	if e.config.Consolidator == nil || plan.QueryHints.SkipVTGateConsolidator {
+		qr, err = vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+		return qr, false, err
+	}
+
+	q, original := e.config.Consolidator.Create(key)
+	if original {
+		defer q.Broadcast()
+		qr, err = vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+		if qr != nil {
+			// The waiters get their own copy, so that they never see changes the caller makes to the result.
+			q.SetResult(qr.ShallowCopy())
+		}
+		q.SetErr(err)
+		return qr, false, err
+	}
+
+	waiterCap := e.config.ConsolidatorQueryWaiterCap
+	if waiterCap > 0 && *q.AddWaiterCounter(0) > waiterCap {
+		q.AddWaiterCounter(-1)
+		queryConsolidatorWaiterCapExceeded.Add(1)
+		qr, err = vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+		return qr, false, err
+	}
+	// Wait for the in-flight query, unless the caller goes away first.
+	done := make(chan struct{})
+	go func() {
+		q.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		q.AddWaiterCounter(-1)
+		return nil, false, vterrors.Wrapf(ctx.Err(), "context ended while waiting for an identical in-flight query")
+	}
+	q.AddWaiterCounter(-1)
+	queryConsolidations.Add(1)
+	if err := q.Err(); err != nil {
+		return nil, true, err
+	}
+	return q.Result().ShallowCopy(), true, nil
+}
+
+// serveQueryConsolidations writes how often recent queries were consolidated as JSON.
+func (e *Executor) serveQueryConsolidations(response http.ResponseWriter) {
+	if e.config.Consolidator == nil {
+		http.Error(response, "query consolidator is disabled", http.StatusNotFound)
+		return
+	}
+	returnAsJSON(response, e.config.Consolidator.Items())
+}
+
+// canShareResult returns true if the plan may share its result with identical queries of other sessions:
+// it must be a read-only query which does not depend on session state that is not part of its bind variables.
+func canShareResult(safeSession *econtext.SafeSession, plan *engine.Plan) bool {
+	if plan.QueryType != sqlparser.StmtSelect {
+		return false
+	}
+	if safeSession.InTransaction() || safeSession.InReservedConn() || safeSession.HasSystemVariables() {
+		return false
+	}
+	// Lock functions act on the connection of the session, and every
+	// sequence NEXTVAL must return new values.
+	return !engine.Exists(func(p engine.Primitive) bool {
+		switch p := p.(type) {
+		case *engine.Lock:
+			return true
+		case *engine.Route:
+			return p.Opcode == engine.Next
+		}
+		return false
+	}, plan.Instructions)
+}
+
+// sharedResultKey identifies identical queries: the same normalized query with the same
+// bind variables, for the same target, callers and connection collation.
+func sharedResultKey(
+	ctx context.Context,
+	safeSession *econtext.SafeSession,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+) string {
+	var key strings.Builder
+	// Every part is prefixed with its length, so that different parts never produce the same key.
+	writePart := func(part string) {
+		key.WriteString(strconv.Itoa(len(part)))
+		key.WriteByte(':')
+		key.WriteString(part)
+	}
+	writePart(callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)))
+	writePart(callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)))
+	writePart(safeSession.TargetString)
+	writePart(vcursor.TabletType().String())
+	writePart(strconv.Itoa(int(vcursor.ConnCollation())))
+	writePart(plan.Original)
+	for _, name := range slices.Sorted(maps.Keys(bindVars)) {
+		writePart(name)
+		bv, _ := bindVars[name].MarshalVT()
+		writePart(string(bv))
+	}
+	return key.String()
+}
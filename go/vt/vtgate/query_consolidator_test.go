@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sync2"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// recordingConsolidator records the keys of the queries it consolidates.
+type recordingConsolidator struct {
+	sync2.Consolidator
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (c *recordingConsolidator) Create(key string) (sync2.PendingResult, bool) {
+	c.mu.Lock()
+	c.keys = append(c.keys, key)
+	c.mu.Unlock()
+	return c.Consolidator.Create(key)
+}
+
+func (c *recordingConsolidator) takeKeys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := c.keys
+	c.keys = nil
+	return keys
+}
+
+func TestExecutorConsolidator(t *testing.T) {
+	consolidator := &recordingConsolidator{Consolidator: sync2.NewConsolidator()}
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.Consolidator = consolidator
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+
+	const query = "select id from user where id = 1"
+	_, err := executorExec(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	keys := consolidator.takeKeys()
+	require.Len(t, keys, 1)
+
+	// A different literal is a different bind variable, and thus a different query.
+	_, err = executorExec(ctx, executor, session, "select id from user where id = 2", nil)
+	require.NoError(t, err)
+	otherKeys := consolidator.takeKeys()
+	require.Len(t, otherKeys, 1)
+	assert.NotEqual(t, keys[0], otherKeys[0])
+
+	t.Run("waits for the in-flight query", func(t *testing.T) {
+		// act as the in-flight query
+		q, original := consolidator.Create(keys[0])
+		require.True(t, original)
+		consolidator.takeKeys()
+
+		execCount := sbc1.ExecCount.Load()
+		var (
+			wg  sync.WaitGroup
+			qr  *sqltypes.Result
+			err error
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			qr, err = executorExec(ctx, executor, session, query, nil)
+		}()
+		require.Eventually(t, func() bool {
+			return *q.AddWaiterCounter(0) == 1
+		}, 5*time.Second, time.Millisecond)
+
+		before := queryConsolidations.Get()
+		q.SetResult(sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "42"))
+		q.Broadcast()
+		wg.Wait()
+
+		require.NoError(t, err)
+		assert.Equal(t, "[[INT64(42)]]", fmt.Sprintf("%v", qr.Rows))
+		assert.Equal(t, execCount, sbc1.ExecCount.Load())
+		assert.EqualValues(t, 1, queryConsolidations.Get()-before)
+		assert.Equal(t, keys, consolidator.takeKeys())
+	})
+
+	t.Run("stops waiting when the context ends", func(t *testing.T) {
+		q, original := consolidator.Create(keys[0])
+		require.True(t, original)
+		defer q.Broadcast()
+		consolidator.takeKeys()
+
+		waitCtx, cancel := context.WithCancel(ctx)
+		var (
+			wg  sync.WaitGroup
+			err error
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err = executorExec(waitCtx, executor, session, query, nil)
+		}()
+		require.Eventually(t, func() bool {
+			return *q.AddWaiterCounter(0) == 1
+		}, 5*time.Second, time.Millisecond)
+
+		cancel()
+		wg.Wait()
+		require.ErrorContains(t, err, "context ended while waiting for an identical in-flight query")
+		assert.EqualValues(t, 0, *q.AddWaiterCounter(0))
+		assert.Equal(t, keys, consolidator.takeKeys())
+	})
+
+	t.Run("different collations are different queries", func(t *testing.T) {
+		vConfig := executor.vConfig
+		defer func() { executor.vConfig = vConfig }()
+		executor.vConfig.Collation = collations.CollationBinaryID
+
+		_, err := executorExec(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+		binaryKeys := consolidator.takeKeys()
+		require.Len(t, binaryKeys, 1)
+		assert.NotEqual(t, keys[0], binaryKeys[0])
+	})
+
+	t.Run("not consolidated", func(t *testing.T) {
+		for _, query := range []string{
+			"select /*vt+ SKIP_VTGATE_CONSOLIDATOR */ id from user where id = 1",
+			"update main1 set id = 2 where id = 1",
+		} {
+			_, err := executorExec(ctx, executor, session, query, nil)
+			require.NoError(t, err, query)
+			assert.Empty(t, consolidator.takeKeys(), query)
+		}
+
+		// the lock session depends on the sandbox state, only the consolidation matters here
+		_, _ = executorExec(ctx, executor, session, "select get_lock('lock', 10) from dual", nil)
+		assert.Empty(t, consolidator.takeKeys())
+
+		_, err := executorExec(ctx, executor, session, "select next 2 values from user_seq", nil)
+		require.NoError(t, err)
+		assert.Empty(t, consolidator.takeKeys())
+
+		txSession := &vtgatepb.Session{TargetString: "@primary", InTransaction: true}
+		_, err = executorExec(ctx, executor, txSession, query, nil)
+		require.NoError(t, err)
+		assert.Empty(t, consolidator.takeKeys())
+	})
+}
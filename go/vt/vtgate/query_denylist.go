@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
+)
+
+var (
+	queryDenylistMatches = stats.NewCountersWithMultiLabels("QueryDenylistMatches", "Counts queries matching the query denylist by fingerprint and mode.", []string{"Fingerprint", "Mode"})
+
+	queryDenylistDryRunLogger = logutil.NewThrottledLogger("QueryDenylistDryRun", 1*time.Minute)
+)
+
+// checkQueryDenylist records a normalized query and its fingerprint in logStats, and returns an
+// error if the query is denylisted in FAIL mode. It is called before the query is planned.
+func (e *Executor) checkQueryDenylist(query string, logStats *logstats.LogStats) error {
+	fingerprint := querydenylist.Fingerprint(query)
+	logStats.NormalizedSQL = query
+	logStats.Fingerprint = fingerprint
+
+	rule := e.queryDenylist.Match(fingerprint)
+	if rule == nil {
+		return nil
+	}
+	queryDenylistMatches.Add([]string{fingerprint, rule.Mode.String()}, 1)
+	switch rule.Mode {
+	case vschemapb.QueryDenylistRule_FAIL:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query is denylisted (fingerprint %s): %s", fingerprint, rule.Description)
+	case vschemapb.QueryDenylistRule_DRY_RUN:
+		queryDenylistDryRunLogger.Infof("query matches the query denylist in DRY_RUN mode (fingerprint %s): %s", fingerprint, e.env.Parser().TruncateForLog(query))
+	}
+	return nil
+}
+
+// acquireQueryDenylist waits for an execution slot if the query is denylisted in
+// LIMIT_CONCURRENCY mode. The returned function must be called once the query is executed.
+func (e *Executor) acquireQueryDenylist(ctx context.Context, fingerprint string) (release func(), err error) {
+	rule := e.queryDenylist.Match(fingerprint)
+	if rule == nil || rule.Mode != vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY {
+		return func() {}, nil
+	}
+	if err := rule.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return rule.Release, nil
+}
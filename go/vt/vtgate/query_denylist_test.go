@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
+)
+
+func TestExecutorQueryDenylist(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	_, err := executorExec(ctx, executor, session, "select id from main1 where id = 1", nil)
+	require.NoError(t, err)
+	var fingerprint string
+	executor.ForEachPlan(func(plan *engine.Plan) bool {
+		fingerprint = querydenylist.Fingerprint(plan.Original)
+		return true
+	})
+	require.NotEmpty(t, fingerprint)
+
+	applyDenylist := func(mode vschemapb.QueryDenylistRule_Mode, maxConcurrency int32) {
+		vschema := *executor.VSchema()
+		vschema.QueryDenylist = &vschemapb.QueryDenylist{Rules: []*vschemapb.QueryDenylistRule{{
+			Fingerprint:    fingerprint,
+			Mode:           mode,
+			MaxConcurrency: maxConcurrency,
+			Description:    "expensive query",
+		}}}
+		executor.SaveVSchema(&vschema, executor.vschemaStats)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		applyDenylist(vschemapb.QueryDenylistRule_DRY_RUN, 0)
+		before := queryDenylistMatches.Counts()[fingerprint+".DRY_RUN"]
+		_, err := executorExec(ctx, executor, session, "select id from main1 where id = 2", nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, queryDenylistMatches.Counts()[fingerprint+".DRY_RUN"]-before)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		applyDenylist(vschemapb.QueryDenylistRule_FAIL, 0)
+		// queries which only differ in their literals share a fingerprint
+		_, err := executorExec(ctx, executor, session, "select id from main1 where id = 3", nil)
+		require.ErrorContains(t, err, "query is denylisted (fingerprint "+fingerprint+"): expensive query")
+		assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+
+		_, err = executorExec(ctx, executor, session, "select id from main1", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("limit concurrency", func(t *testing.T) {
+		applyDenylist(vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY, 1)
+		_, err := executorExec(ctx, executor, session, "select id from main1 where id = 4", nil)
+		require.NoError(t, err)
+
+		// hold the only execution slot
+		rule := executor.queryDenylist.Match(fingerprint)
+		require.NoError(t, rule.Acquire(ctx))
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err = executorExec(timeoutCtx, executor, session, "select id from main1 where id = 5", nil)
+		assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+		rule.Release()
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		vschema := *executor.VSchema()
+		vschema.QueryDenylist = nil
+		executor.SaveVSchema(&vschema, executor.vschemaStats)
+		_, err := executorExec(ctx, executor, session, "select id from main1 where id = 6", nil)
+		require.NoError(t, err)
+	})
+}
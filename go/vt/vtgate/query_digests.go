@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/querydigest"
+)
+
+// initQueryDigests creates the query digests store if enabled, loading the
+// snapshot file and saving it periodically and on shutdown.
+func initQueryDigests() *querydigest.Store {
+	if queryDigestsMax <= 0 {
+		return nil
+	}
+	store := querydigest.NewStore(queryDigestsMax)
+	if queryDigestsSnapshotFile == "" {
+		return store
+	}
+	if err := store.LoadFile(queryDigestsSnapshotFile); err != nil {
+		log.Warningf("Unable to load query digests snapshot %s: %v", queryDigestsSnapshotFile, err)
+	}
+
+	saveSnapshot := func() {
+		if err := store.SaveFile(queryDigestsSnapshotFile); err != nil {
+			log.Warningf("Unable to save query digests snapshot %s: %v", queryDigestsSnapshotFile, err)
+		}
+	}
+	if queryDigestsSnapshotInterval <= 0 {
+		servenv.OnTerm(saveSnapshot)
+		return store
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(queryDigestsSnapshotInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				saveSnapshot()
+			case <-done:
+				return
+			}
+		}
+	}()
+	servenv.OnTerm(func() {
+		close(done)
+		saveSnapshot()
+	})
+	return store
+}
+
+// recordQueryDigest adds the statistics of an executed query to the query digests, if enabled.
+func (e *Executor) recordQueryDigest(logStats *logstats.LogStats) {
+	if e.config.QueryDigests == nil {
+		return
+	}
+	e.config.QueryDigests.Record(logStats)
+}
+
+// serveQueryDigests writes the top query digests by total latency as JSON. The number of
+// digests can be limited with the limit parameter.
+func (e *Executor) serveQueryDigests(response http.ResponseWriter, request *http.Request) {
+	if e.config.QueryDigests == nil {
+		http.Error(response, "query digests are disabled", http.StatusNotFound)
+		return
+	}
+	limit := 0
+	if param := request.FormValue("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil {
+			http.Error(response, fmt.Sprintf("invalid limit %q: %v", param, err), http.StatusBadRequest)
+			return
+		}
+	}
+	returnAsJSON(response, e.config.QueryDigests.Snapshot(limit))
+}
+
+// ShowQueryDigests returns the query digests by descending total latency for
+// SHOW VITESS_QUERY_DIGESTS. A LIKE filter matches the normalized query. The
+// row counts are totals over all the shards a query ran on; rows examined and
+// per shard statistics are not reported, see the querydigest package.
+func (e *Executor) ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	if e.config.QueryDigests == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "query digests are disabled, use --query-digests-max to enable them")
+	}
+
+	var matches func(query string) bool
+	if filter != nil {
+		if filter.Like != "" {
+			queryRegexp := sqlparser.LikeToRegexp(filter.Like)
+			matches = queryRegexp.MatchString
+		} else if filter.Filter != nil {
+			log.Infof("SHOW VITESS_QUERY_DIGESTS where clause: %+v. Ignoring this (for now).", filter.Filter)
+		}
+	}
+
+	rows := [][]sqltypes.Value{}
+	for _, d := range e.config.QueryDigests.Digests() {
+		if matches != nil && !matches(d.Query) {
+			continue
+		}
+		rows = append(rows, buildVarCharRow(
+			d.Fingerprint,
+			d.Query,
+			strconv.FormatUint(d.Count, 10),
+			strconv.FormatUint(d.Errors, 10),
+			formatErrorsByCode(d.ErrorsByCode),
+			formatDigestLatency(d.TotalLatency),
+			formatDigestLatency(d.AvgLatency()),
+			formatDigestLatency(d.MinLatency),
+			formatDigestLatency(d.MaxLatency),
+			formatDigestLatency(d.LatencyPercentile(95)),
+			formatDigestLatency(d.LatencyPercentile(99)),
+			strconv.FormatUint(d.ShardQueries, 10),
+			strconv.FormatUint(d.RowsReturned, 10),
+			strconv.FormatUint(d.RowsAffected, 10),
+			d.FirstSeen.UTC().Format(time.RFC3339),
+			d.LastSeen.UTC().Format(time.RFC3339),
+		))
+	}
+	return &sqltypes.Result{
+		Fields: buildVarCharFields("Fingerprint", "Query", "Count", "Errors", "ErrorsByCode", "TotalTime", "AvgTime", "MinTime", "MaxTime", "P95Time", "P99Time", "ShardQueries", "RowsReturned", "RowsAffected", "FirstSeen", "LastSeen"),
+		Rows:   rows,
+	}, nil
+}
+
+// formatDigestLatency formats a latency in seconds, like the times of /debug/queryz.
+func formatDigestLatency(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 6, 64)
+}
+
+// formatErrorsByCode formats error counts as a comma separated list of code:count, sorted by code.
+func formatErrorsByCode(errorsByCode map[string]uint64) string {
+	codes := make([]string, 0, len(errorsByCode))
+	for code := range errorsByCode {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for i, code := range codes {
+		codes[i] = fmt.Sprintf("%s:%d", code, errorsByCode[code])
+	}
+	return strings.Join(codes, ",")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/streamlog"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/querydigest"
+)
+
+func TestExecutorQueryDigests(t *testing.T) {
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.QueryDigests = querydigest.NewStore(100)
+	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	for _, query := range []string{
+		"select id from main1 where id = 1",
+		"select id from main1 where id = 2",
+		"select id from main1",
+		"select id from main1 where id = 3 and",
+	} {
+		_, _ = executorExec(ctx, executor, session, query, nil)
+	}
+
+	result, err := executorExec(ctx, executor, session, "show vitess_query_digests like '%main1%'", nil)
+	require.NoError(t, err)
+	// the query that failed to parse has no digest
+	require.Len(t, result.Rows, 2)
+	byQuery := make(map[string][]string)
+	for _, row := range result.Named().Rows {
+		byQuery[row.AsString("Query", "")] = []string{row.AsString("Fingerprint", ""), row.AsString("Count", ""), row.AsString("Errors", ""), row.AsString("ShardQueries", "")}
+	}
+	assert.Equal(t, []string{querydenylist.Fingerprint("select id from main1 where id = :id /* INT64 */"), "2", "0", "2"}, byQuery["select id from main1 where id = :id"])
+	assert.Equal(t, []string{querydenylist.Fingerprint("select id from main1"), "1", "0", "1"}, byQuery["select id from main1"])
+
+	// the latencies are known for queries recorded directly
+	for i := range 20 {
+		latency := 2 * time.Millisecond
+		if i == 0 {
+			latency = 3 * time.Second
+		}
+		ls := logstats.NewLogStats(ctx, "Execute", "select slow from main1", "", nil, streamlog.NewQueryLogConfigForTest())
+		ls.NormalizedSQL = "select slow from main1"
+		ls.Fingerprint = "slow"
+		ls.EndTime = ls.StartTime.Add(latency)
+		eConfig.QueryDigests.Record(ls)
+	}
+	result, err = executorExec(ctx, executor, session, "show vitess_query_digests like '%slow%'", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 1)
+	row := result.Named().Row()
+	assert.Equal(t, "20", row.AsString("Count", ""))
+	assert.Equal(t, "0.002000", row.AsString("MinTime", ""))
+	assert.Equal(t, "3.000000", row.AsString("MaxTime", ""))
+	assert.Equal(t, "0.005000", row.AsString("P95Time", ""))
+	assert.Equal(t, "3.000000", row.AsString("P99Time", ""))
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/debug/query_digests?limit=1", nil)
+	executor.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var snapshot querydigest.Snapshot
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &snapshot))
+	assert.Equal(t, querydigest.LatencyBuckets, snapshot.LatencyBuckets)
+	assert.Len(t, snapshot.Digests, 1)
+
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/debug/query_digests?limit=x", nil)
+	executor.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestExecutorQueryDigestsDisabled(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	_, err := executorExec(ctx, executor, session, "show vitess_query_digests", nil)
+	require.ErrorContains(t, err, "query digests are disabled")
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/debug/query_digests", nil)
+	executor.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
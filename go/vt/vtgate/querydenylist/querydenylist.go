@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package querydenylist blocks or limits the queries vtgate executes by the
+// fingerprint of their normalized text.
+package querydenylist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/sync/semaphore"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// Fingerprint returns the fingerprint of a normalized query. Queries which only
+// differ in the values of their literals share a fingerprint once normalized.
+// Like STATEMENT_DIGEST, the comments of the query are dropped before hashing,
+// so that neither the comments of the application nor the types of the bind
+// variables that the normalizer adds as comments change the fingerprint.
+func Fingerprint(query string) string {
+	sum := sha256.Sum256([]byte(stripComments(query)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// stripComments returns the query without its comments, and with every run of
+// whitespace outside of quotes collapsed into a single space.
+func stripComments(query string) string {
+	var buf strings.Builder
+	buf.Grow(len(query))
+	space := false
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			space = true
+			i++
+			continue
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return strings.TrimSpace(buf.String())
+			}
+			i += end + 4
+			space = true
+			continue
+		case ch == '#' || (ch == '-' && strings.HasPrefix(query[i:], "-- ")):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return strings.TrimSpace(buf.String())
+			}
+			i += end
+			continue
+		}
+		if space && buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+		space = false
+		if ch == '\'' || ch == '"' || ch == '`' {
+			end := quotedEnd(query, i)
+			buf.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		buf.WriteByte(ch)
+		i++
+	}
+	return buf.String()
+}
+
+// quotedEnd returns the position right after the string or identifier quoted
+// at start.
+func quotedEnd(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// Validate checks a denylist for errors.
+func Validate(denylist *vschemapb.QueryDenylist) error {
+	seen := make(map[string]bool, len(denylist.GetRules()))
+	for _, rule := range denylist.GetRules() {
+		if rule.Fingerprint == "" {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query denylist rule is missing a fingerprint")
+		}
+		if seen[rule.Fingerprint] {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "duplicate query denylist rule for fingerprint %s", rule.Fingerprint)
+		}
+		seen[rule.Fingerprint] = true
+		switch rule.Mode {
+		case vschemapb.QueryDenylistRule_FAIL, vschemapb.QueryDenylistRule_DRY_RUN:
+		case vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY:
+			if rule.MaxConcurrency <= 0 {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query denylist rule for fingerprint %s: LIMIT_CONCURRENCY requires a positive max_concurrency", rule.Fingerprint)
+			}
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query denylist rule for fingerprint %s: unknown mode %v", rule.Fingerprint, rule.Mode)
+		}
+	}
+	return nil
+}
+
+// Rule is a denylist rule as enforced by vtgate.
+type Rule struct {
+	Fingerprint    string
+	Mode           vschemapb.QueryDenylistRule_Mode
+	MaxConcurrency int64
+	Description    string
+
+	// sem limits the concurrency of LIMIT_CONCURRENCY rules.
+	sem *semaphore.Weighted
+}
+
+// Acquire waits for an execution slot of a LIMIT_CONCURRENCY rule, and returns
+// a RESOURCE_EXHAUSTED error if ctx is done first. Each successful call must be
+// paired with a call to Release. It is a no-op for rules in other modes.
+func (r *Rule) Acquire(ctx context.Context) error {
+	if r.sem == nil {
+		return nil
+	}
+	if err := r.sem.Acquire(ctx, 1); err != nil {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "timed out waiting under query denylist concurrency limit of %d for fingerprint %s: %v", r.MaxConcurrency, r.Fingerprint, err)
+	}
+	return nil
+}
+
+// Release frees the execution slot taken by Acquire.
+func (r *Rule) Release() {
+	if r.sem != nil {
+		r.sem.Release(1)
+	}
+}
+
+// Denylist holds the denylist rules in effect, by fingerprint. It is safe for
+// concurrent use.
+type Denylist struct {
+	rules atomic.Pointer[map[string]*Rule]
+}
+
+// New returns an empty Denylist.
+func New() *Denylist {
+	return &Denylist{}
+}
+
+// Update replaces the rules in effect. Rules which are unchanged keep their
+// state, so that queries executing under a concurrency limit keep counting
+// towards it. Invalid rules are skipped.
+func (d *Denylist) Update(denylist *vschemapb.QueryDenylist) {
+	var previous map[string]*Rule
+	if p := d.rules.Load(); p != nil {
+		previous = *p
+	}
+
+	rules := make(map[string]*Rule, len(denylist.GetRules()))
+	for _, pb := range denylist.GetRules() {
+		if pb.Fingerprint == "" {
+			continue
+		}
+		rule := &Rule{
+			Fingerprint: pb.Fingerprint,
+			Mode:        pb.Mode,
+			Description: pb.Description,
+		}
+		if pb.Mode == vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY {
+			if pb.MaxConcurrency <= 0 {
+				continue
+			}
+			rule.MaxConcurrency = int64(pb.MaxConcurrency)
+			if prev, ok := previous[rule.Fingerprint]; ok && prev.sem != nil && prev.MaxConcurrency == rule.MaxConcurrency {
+				rule.sem = prev.sem
+			} else {
+				rule.sem = semaphore.NewWeighted(rule.MaxConcurrency)
+			}
+		}
+		rules[rule.Fingerprint] = rule
+	}
+	d.rules.Store(&rules)
+}
+
+// Match returns the rule for a fingerprint, or nil if the fingerprint is not
+// denylisted. A nil Denylist matches nothing.
+func (d *Denylist) Match(fingerprint string) *Rule {
+	if d == nil {
+		return nil
+	}
+	p := d.rules.Load()
+	if p == nil {
+		return nil
+	}
+	return (*p)[fingerprint]
+}
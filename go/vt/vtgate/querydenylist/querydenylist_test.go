@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querydenylist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func TestFingerprint(t *testing.T) {
+	fp := Fingerprint("select * from t where id = :id")
+	assert.Len(t, fp, 16)
+	assert.Equal(t, fp, Fingerprint("select * from t where id = :id"))
+	assert.NotEqual(t, fp, Fingerprint("select * from t where id = :id1"))
+
+	// Comments do not change the fingerprint
+	assert.Equal(t, fp, Fingerprint("select /* app:web */ * from t where id = :id /* INT64 */"))
+	assert.Equal(t, fp, Fingerprint("/* leading */ select * from t where id = :id -- trailing"))
+	assert.Equal(t, fp, Fingerprint("select * from t # trailing\nwhere id = :id"))
+	// but comment markers in quotes do
+	assert.NotEqual(t, Fingerprint("select '/* a */'"), Fingerprint("select ''"))
+	assert.NotEqual(t, Fingerprint("select 'it''s /* a */'"), Fingerprint("select 'it''s '"))
+	assert.NotEqual(t, Fingerprint(`select "\" /* a */"`), Fingerprint(`select "\" "`))
+}
+
+func TestStripComments(t *testing.T) {
+	testcases := []struct {
+		query string
+		want  string
+	}{
+		{"select 1", "select 1"},
+		{"select /*+ SET_VAR(sort_buffer_size = 16M) */ 1", "select 1"},
+		{"select a  from\n\tt /* x */", "select a from t"},
+		{"select '/* a */', `b -- c`", "select '/* a */', `b -- c`"},
+		{"select 1 /* unterminated", "select 1"},
+		{"select 1 -- c\n, 2", "select 1 , 2"},
+		{"select 1 --2", "select 1 --2"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.want, stripComments(tc.query))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		rules   []*vschemapb.QueryDenylistRule
+		wantErr string
+	}{{
+		name: "valid",
+		rules: []*vschemapb.QueryDenylistRule{
+			{Fingerprint: "a", Mode: vschemapb.QueryDenylistRule_FAIL},
+			{Fingerprint: "b", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY, MaxConcurrency: 2},
+			{Fingerprint: "c", Mode: vschemapb.QueryDenylistRule_DRY_RUN},
+		},
+	}, {
+		name:    "missing fingerprint",
+		rules:   []*vschemapb.QueryDenylistRule{{Mode: vschemapb.QueryDenylistRule_FAIL}},
+		wantErr: "query denylist rule is missing a fingerprint",
+	}, {
+		name: "duplicate fingerprint",
+		rules: []*vschemapb.QueryDenylistRule{
+			{Fingerprint: "a", Mode: vschemapb.QueryDenylistRule_FAIL},
+			{Fingerprint: "a", Mode: vschemapb.QueryDenylistRule_DRY_RUN},
+		},
+		wantErr: "duplicate query denylist rule for fingerprint a",
+	}, {
+		name:    "missing max concurrency",
+		rules:   []*vschemapb.QueryDenylistRule{{Fingerprint: "a", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY}},
+		wantErr: "LIMIT_CONCURRENCY requires a positive max_concurrency",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&vschemapb.QueryDenylist{Rules: tc.rules})
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDenylist(t *testing.T) {
+	var nilDenylist *Denylist
+	assert.Nil(t, nilDenylist.Match("a"))
+
+	d := New()
+	assert.Nil(t, d.Match("a"))
+
+	d.Update(&vschemapb.QueryDenylist{Rules: []*vschemapb.QueryDenylistRule{
+		{Fingerprint: "a", Mode: vschemapb.QueryDenylistRule_FAIL, Description: "bad query"},
+		{Fingerprint: "b", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY, MaxConcurrency: 1},
+		{Fingerprint: "c", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY},
+	}})
+	rule := d.Match("a")
+	require.NotNil(t, rule)
+	assert.Equal(t, vschemapb.QueryDenylistRule_FAIL, rule.Mode)
+	assert.Equal(t, "bad query", rule.Description)
+	// rules without a valid concurrency limit are skipped
+	assert.Nil(t, d.Match("c"))
+
+	limited := d.Match("b")
+	require.NotNil(t, limited)
+	require.NoError(t, limited.Acquire(context.Background()))
+
+	// an unchanged rule keeps counting the queries executing under it
+	d.Update(&vschemapb.QueryDenylist{Rules: []*vschemapb.QueryDenylistRule{
+		{Fingerprint: "b", Mode: vschemapb.QueryDenylistRule_LIMIT_CONCURRENCY, MaxConcurrency: 1},
+	}})
+	assert.Nil(t, d.Match("a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := d.Match("b").Acquire(ctx)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	limited.Release()
+	require.NoError(t, d.Match("b").Acquire(context.Background()))
+	d.Match("b").Release()
+
+	d.Update(nil)
+	assert.Nil(t, d.Match("b"))
+}
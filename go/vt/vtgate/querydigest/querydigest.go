@@ -0,0 +1,399 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package querydigest aggregates the statistics of the queries vtgate executes
+// by normalized query, in the style of MySQL's
+// performance_schema.events_statements_summary_by_digest table.
+//
+// Two statistics of the MySQL table are not available:
+//
+//   - Rows examined: vttablet does not report how many rows MySQL examined to
+//     run a query, only the rows it returned or affected.
+//   - Per shard statistics: vtgate merges the results of the shards before a
+//     query is recorded, so RowsReturned and RowsAffected are totals over all
+//     the shards the query ran on, and ShardQueries is only their number.
+//
+// Once the store is full, the digest with the lowest total latency is evicted
+// to make room for a new fingerprint, see Store.
+package querydigest
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+// LatencyBuckets are the upper bounds of the buckets of the latency histogram
+// of a digest. The histogram has one more bucket, counting the queries slower
+// than the last bound.
+var LatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Digest holds the statistics of the queries sharing a fingerprint, since the
+// fingerprint entered the store.
+type Digest struct {
+	Fingerprint      string            `json:"fingerprint"`
+	Query            string            `json:"query"`
+	Count            uint64            `json:"count"`
+	Errors           uint64            `json:"errors"`
+	ErrorsByCode     map[string]uint64 `json:"errors_by_code,omitempty"`
+	TotalLatency     time.Duration     `json:"total_latency"`
+	MinLatency       time.Duration     `json:"min_latency"`
+	MaxLatency       time.Duration     `json:"max_latency"`
+	LatencyHistogram []uint64          `json:"latency_histogram"`
+	ShardQueries     uint64            `json:"shard_queries"`
+	RowsReturned     uint64            `json:"rows_returned"`
+	RowsAffected     uint64            `json:"rows_affected"`
+	FirstSeen        time.Time         `json:"first_seen"`
+	LastSeen         time.Time         `json:"last_seen"`
+}
+
+// AvgLatency returns the average latency of the queries.
+func (d *Digest) AvgLatency() time.Duration {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.TotalLatency / time.Duration(d.Count)
+}
+
+// LatencyPercentile estimates a percentile of the latency of the queries, as
+// the upper bound of the histogram bucket it falls in.
+func (d *Digest) LatencyPercentile(percentile float64) time.Duration {
+	if d.Count == 0 {
+		return 0
+	}
+	rank := uint64(float64(d.Count)*percentile/100 + 0.5)
+	var seen uint64
+	for i, count := range d.LatencyHistogram {
+		seen += count
+		if seen >= rank && i < len(LatencyBuckets) {
+			return min(LatencyBuckets[i], d.MaxLatency)
+		}
+	}
+	return d.MaxLatency
+}
+
+// merge adds the statistics of another digest with the same fingerprint.
+func (d *Digest) merge(other *Digest) {
+	if d.Count == 0 || other.MinLatency < d.MinLatency {
+		d.MinLatency = other.MinLatency
+	}
+	d.Count += other.Count
+	d.Errors += other.Errors
+	for code, count := range other.ErrorsByCode {
+		if d.ErrorsByCode == nil {
+			d.ErrorsByCode = make(map[string]uint64)
+		}
+		d.ErrorsByCode[code] += count
+	}
+	d.TotalLatency += other.TotalLatency
+	d.MaxLatency = max(d.MaxLatency, other.MaxLatency)
+	for i := range min(len(d.LatencyHistogram), len(other.LatencyHistogram)) {
+		d.LatencyHistogram[i] += other.LatencyHistogram[i]
+	}
+	d.ShardQueries += other.ShardQueries
+	d.RowsReturned += other.RowsReturned
+	d.RowsAffected += other.RowsAffected
+	if d.FirstSeen.IsZero() || (!other.FirstSeen.IsZero() && other.FirstSeen.Before(d.FirstSeen)) {
+		d.FirstSeen = other.FirstSeen
+	}
+	if other.LastSeen.After(d.LastSeen) {
+		d.LastSeen = other.LastSeen
+	}
+}
+
+func (d *Digest) clone() *Digest {
+	clone := *d
+	clone.ErrorsByCode = make(map[string]uint64, len(d.ErrorsByCode))
+	for code, count := range d.ErrorsByCode {
+		clone.ErrorsByCode[code] = count
+	}
+	clone.LatencyHistogram = slices.Clone(d.LatencyHistogram)
+	return &clone
+}
+
+type entry struct {
+	// baseScore is the score of the digest this entry evicted, see Store.
+	baseScore time.Duration
+
+	mu     sync.Mutex
+	digest Digest
+}
+
+func newEntry(fingerprint, query string, baseScore time.Duration) *entry {
+	return &entry{
+		baseScore: baseScore,
+		digest: Digest{
+			Fingerprint:      fingerprint,
+			Query:            query,
+			LatencyHistogram: make([]uint64, len(LatencyBuckets)+1),
+		},
+	}
+}
+
+// score ranks the entry for eviction.
+func (e *entry) score() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.baseScore + e.digest.TotalLatency
+}
+
+// scoredEntry is an entry in the eviction heap, with its score when it was
+// last pushed.
+type scoredEntry struct {
+	entry *entry
+	score time.Duration
+}
+
+// scoreHeap is a min-heap of entries by score.
+type scoreHeap []scoredEntry
+
+func (h scoreHeap) Len() int           { return len(h) }
+func (h scoreHeap) Less(i, j int) bool { return h[i].score < h[j].score }
+func (h scoreHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *scoreHeap) Push(x any)        { *h = append(*h, x.(scoredEntry)) }
+func (h *scoreHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// Store holds the digests of up to a maximum number of fingerprints. It is safe
+// for concurrent use.
+//
+// When the store is full, a new fingerprint evicts the digest with the lowest
+// score, using the space-saving algorithm: the score of a digest is its total
+// latency, plus the score of the digest it evicted. A new fingerprint thus
+// competes with the digests already in the store instead of being evicted by
+// the next one, and the fingerprints with the highest total latency are kept.
+//
+// Scores only grow, so the eviction heap is not updated when queries are
+// recorded: each digest keeps the score it had when it was last pushed, which
+// is a lower bound of its score, and is pushed again with its current score
+// when it reaches the top of the heap.
+type Store struct {
+	maxDigests int
+
+	mu      sync.RWMutex
+	entries map[string]*entry
+	scores  scoreHeap
+}
+
+// NewStore returns a Store holding the digests of up to maxDigests fingerprints.
+func NewStore(maxDigests int) *Store {
+	return &Store{
+		maxDigests: maxDigests,
+		entries:    make(map[string]*entry),
+	}
+}
+
+// getEntry returns the entry of a fingerprint, creating it if needed. The query
+// is only evaluated for new entries.
+func (s *Store) getEntry(fingerprint string, query func() string) *entry {
+	s.mu.RLock()
+	e, ok := s.entries[fingerprint]
+	s.mu.RUnlock()
+	if ok {
+		return e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[fingerprint]; ok {
+		return e
+	}
+	var baseScore time.Duration
+	if len(s.entries) >= s.maxDigests {
+		baseScore = s.evict()
+	}
+	e = newEntry(fingerprint, query(), baseScore)
+	s.entries[fingerprint] = e
+	heap.Push(&s.scores, scoredEntry{entry: e, score: baseScore})
+	return e
+}
+
+// evict removes the entry with the lowest score, and returns its score. The
+// queries being recorded in the evicted entry are lost. It must be called with
+// the write lock held.
+func (s *Store) evict() time.Duration {
+	for {
+		top := heap.Pop(&s.scores).(scoredEntry)
+		if score := top.entry.score(); score > top.score {
+			top.score = score
+			heap.Push(&s.scores, top)
+			continue
+		}
+		delete(s.entries, top.entry.digest.Fingerprint)
+		return top.score
+	}
+}
+
+// Record adds the statistics of an executed query. Queries that were not
+// planned, and thus have no fingerprint, are skipped.
+func (s *Store) Record(ls *logstats.LogStats) {
+	if ls.Fingerprint == "" {
+		return
+	}
+	e := s.getEntry(ls.Fingerprint, func() string {
+		query, _ := sqlparser.SplitMarginComments(ls.NormalizedSQL)
+		return query
+	})
+
+	latency := ls.TotalTime()
+	bucket, _ := slices.BinarySearch(LatencyBuckets, latency)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d := &e.digest
+	if d.Count == 0 || latency < d.MinLatency {
+		d.MinLatency = latency
+	}
+	d.Count++
+	d.TotalLatency += latency
+	d.MaxLatency = max(d.MaxLatency, latency)
+	d.LatencyHistogram[bucket]++
+	d.ShardQueries += ls.ShardQueries
+	d.RowsReturned += ls.RowsReturned
+	d.RowsAffected += ls.RowsAffected
+	if ls.Error != nil {
+		d.Errors++
+		if d.ErrorsByCode == nil {
+			d.ErrorsByCode = make(map[string]uint64)
+		}
+		d.ErrorsByCode[vterrors.Code(ls.Error).String()]++
+	}
+	if d.FirstSeen.IsZero() {
+		d.FirstSeen = ls.StartTime
+	}
+	d.LastSeen = ls.EndTime
+}
+
+// Digests returns a copy of the digests, by descending total latency.
+func (s *Store) Digests() []*Digest {
+	s.mu.RLock()
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	s.mu.RUnlock()
+
+	digests := make([]*Digest, 0, len(entries))
+	for _, e := range entries {
+		e.mu.Lock()
+		digests = append(digests, e.digest.clone())
+		e.mu.Unlock()
+	}
+	slices.SortFunc(digests, func(a, b *Digest) int {
+		if c := cmp.Compare(b.TotalLatency, a.TotalLatency); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	return digests
+}
+
+// Snapshot is the JSON representation of the digests, as served over HTTP and
+// saved to snapshot files.
+type Snapshot struct {
+	LatencyBuckets []time.Duration `json:"latency_buckets"`
+	Digests        []*Digest       `json:"digests"`
+}
+
+// Snapshot returns the top limit digests by total latency, or all of them if
+// limit is not positive.
+func (s *Store) Snapshot(limit int) *Snapshot {
+	digests := s.Digests()
+	if limit > 0 && len(digests) > limit {
+		digests = digests[:limit]
+	}
+	return &Snapshot{
+		LatencyBuckets: LatencyBuckets,
+		Digests:        digests,
+	}
+}
+
+// SaveFile writes a snapshot of all the digests to a file.
+func (s *Store) SaveFile(path string) error {
+	data, err := json.Marshal(s.Snapshot(0))
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a crash never leaves a truncated snapshot behind.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFile merges the digests of a snapshot file into the store. A missing file
+// is not an error. Snapshots taken with different latency buckets are rejected.
+func (s *Store) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	if !slices.Equal(snapshot.LatencyBuckets, LatencyBuckets) {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query digest snapshot %s has different latency buckets", path)
+	}
+	for _, d := range snapshot.Digests {
+		if len(d.LatencyHistogram) != len(LatencyBuckets)+1 {
+			continue
+		}
+		e := s.getEntry(d.Fingerprint, func() string { return d.Query })
+		e.mu.Lock()
+		e.digest.merge(d)
+		e.mu.Unlock()
+	}
+	return nil
+}
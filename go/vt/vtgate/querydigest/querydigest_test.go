@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querydigest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/streamlog"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+var testStart = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newLogStats(fingerprint, sql string, offset, latency time.Duration, err error) *logstats.LogStats {
+	ls := logstats.NewLogStats(context.Background(), "Execute", sql, "", nil, streamlog.NewQueryLogConfigForTest())
+	ls.NormalizedSQL = sql
+	ls.Fingerprint = fingerprint
+	ls.StartTime = testStart.Add(offset)
+	ls.EndTime = ls.StartTime.Add(latency)
+	ls.ShardQueries = 2
+	ls.RowsReturned = 10
+	ls.Error = err
+	return ls
+}
+
+func TestStoreRecord(t *testing.T) {
+	s := NewStore(2)
+	s.Record(newLogStats("a", "/* trace */ select * from t where id = :id", 0, 2*time.Millisecond, nil))
+	s.Record(newLogStats("a", "select * from t where id = :id", time.Minute, 20*time.Millisecond, nil))
+	s.Record(newLogStats("a", "select * from t where id = :id", 2*time.Minute, 3*time.Second, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no tablet")))
+	s.Record(newLogStats("b", "select 1 from dual", 0, time.Millisecond, nil))
+	// queries that failed before planning have no fingerprint
+	s.Record(newLogStats("", "selec", 0, time.Millisecond, nil))
+	// the store is full, so c evicts b, the digest with the lowest total latency,
+	// and d evicts c, whose score includes the total latency of b
+	s.Record(newLogStats("c", "select 2 from dual", 0, time.Millisecond, nil))
+	s.Record(newLogStats("d", "select 3 from dual", 0, time.Millisecond, nil))
+
+	digests := s.Digests()
+	require.Len(t, digests, 2)
+
+	a := digests[0]
+	assert.Equal(t, "a", a.Fingerprint)
+	assert.Equal(t, "select * from t where id = :id", a.Query)
+	assert.EqualValues(t, 3, a.Count)
+	assert.EqualValues(t, 1, a.Errors)
+	assert.Equal(t, map[string]uint64{"UNAVAILABLE": 1}, a.ErrorsByCode)
+	assert.Equal(t, 3022*time.Millisecond, a.TotalLatency)
+	assert.Equal(t, 2*time.Millisecond, a.MinLatency)
+	assert.Equal(t, 3*time.Second, a.MaxLatency)
+	assert.Equal(t, 3022*time.Millisecond/3, a.AvgLatency())
+	assert.Equal(t, []uint64{0, 1, 0, 1, 0, 0, 0, 1, 0, 0}, a.LatencyHistogram)
+	assert.Equal(t, 5*time.Millisecond, a.LatencyPercentile(30))
+	assert.Equal(t, 3*time.Second, a.LatencyPercentile(99))
+	assert.EqualValues(t, 6, a.ShardQueries)
+	assert.EqualValues(t, 30, a.RowsReturned)
+	assert.Equal(t, testStart, a.FirstSeen)
+	assert.Equal(t, testStart.Add(2*time.Minute+3*time.Second), a.LastSeen)
+
+	d := digests[1]
+	assert.Equal(t, "d", d.Fingerprint)
+	assert.Equal(t, "select 3 from dual", d.Query)
+	// the statistics of a digest only cover the queries since it entered the store
+	assert.EqualValues(t, 1, d.Count)
+	assert.Equal(t, time.Millisecond, d.TotalLatency)
+
+	snapshot := s.Snapshot(1)
+	assert.Equal(t, LatencyBuckets, snapshot.LatencyBuckets)
+	require.Len(t, snapshot.Digests, 1)
+	assert.Equal(t, "a", snapshot.Digests[0].Fingerprint)
+}
+
+func TestStoreEvictsLowestScore(t *testing.T) {
+	s := NewStore(2)
+	s.Record(newLogStats("a", "select 1 from dual", 0, time.Millisecond, nil))
+	s.Record(newLogStats("b", "select 2 from dual", 0, 2*time.Millisecond, nil))
+	// a is still in the eviction heap with the score it entered with, and is only
+	// pushed again with its current score when it is the eviction candidate
+	s.Record(newLogStats("a", "select 1 from dual", 0, 100*time.Millisecond, nil))
+	s.Record(newLogStats("c", "select 3 from dual", 0, time.Millisecond, nil))
+
+	digests := s.Digests()
+	require.Len(t, digests, 2)
+	assert.Equal(t, "a", digests[0].Fingerprint)
+	assert.Equal(t, "c", digests[1].Fingerprint)
+
+	// c entered with the 2ms score of b, so its 3ms score is still the lowest and d
+	// evicts it, then e evicts d
+	s.Record(newLogStats("d", "select 4 from dual", 0, time.Millisecond, nil))
+	s.Record(newLogStats("d", "select 4 from dual", 0, 5*time.Millisecond, nil))
+	s.Record(newLogStats("e", "select 5 from dual", 0, time.Millisecond, nil))
+	digests = s.Digests()
+	require.Len(t, digests, 2)
+	assert.Equal(t, "a", digests[0].Fingerprint)
+	assert.Equal(t, "e", digests[1].Fingerprint)
+}
+
+func TestStoreSaveLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digests.json")
+
+	s := NewStore(10)
+	// loading a missing snapshot is a no-op
+	require.NoError(t, s.LoadFile(path))
+	s.Record(newLogStats("a", "select * from t where id = :id", 0, 2*time.Millisecond, nil))
+	s.Record(newLogStats("b", "select 1 from dual", 0, time.Millisecond, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "bad query")))
+	require.NoError(t, s.SaveFile(path))
+
+	restored := NewStore(10)
+	require.NoError(t, restored.LoadFile(path))
+	assert.Equal(t, s.Digests(), restored.Digests())
+
+	// statistics of the restored digests keep accumulating
+	restored.Record(newLogStats("a", "select * from t where id = :id", time.Hour, 4*time.Millisecond, nil))
+	a := restored.Digests()[0]
+	assert.EqualValues(t, 2, a.Count)
+	assert.Equal(t, testStart, a.FirstSeen)
+	assert.Equal(t, testStart.Add(time.Hour+4*time.Millisecond), a.LastSeen)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"latency_buckets": [1000], "digests": []}`), 0o644))
+	assert.ErrorContains(t, restored.LoadFile(path), "has different latency buckets")
+}
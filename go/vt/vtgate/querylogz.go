@@ -48,6 +48,7 @@ var (
 				<th>Commit Time</th>
 				<th>Stmt Type</th>
 				<th>SQL</th>
+				<th>Fingerprint</th>
 				<th>ShardQueries</th>
 				<th>RowsAffected</th>
 				<th>Error</th>
@@ -74,6 +75,7 @@ var (
 			<td>{{.CommitTime.Seconds}}</td>
 			<td>{{.StmtType}}</td>
 			<td>{{.SQL | .Parser.TruncateForUI | unquote | cssWrappable}}</td>
+			<td>{{.Fingerprint}}</td>
 			<td>{{.ShardQueries}}</td>
 			<td>{{.RowsAffected}}</td>
 			<td>{{.ErrorStr}}</td>
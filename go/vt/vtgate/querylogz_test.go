@@ -38,6 +38,7 @@ func TestQuerylogzHandlerFormatting(t *testing.T) {
 	logStats := logstats.NewLogStats(context.Background(), "Execute",
 		"select name, 'inject <script>alert();</script>' from test_table limit 1000", "suuid", nil, streamlog.NewQueryLogConfigForTest())
 	logStats.StmtType = "select"
+	logStats.Fingerprint = "0123456789abcdef"
 	logStats.RowsAffected = 1000
 	logStats.ShardQueries = 1
 	logStats.StartTime, _ = time.Parse("Jan 2 15:04:05", "Nov 29 13:33:09")
@@ -66,6 +67,7 @@ func TestQuerylogzHandlerFormatting(t *testing.T) {
 		`<td>0.003</td>`,
 		`<td>select</td>`,
 		regexp.QuoteMeta("<td>select name,\u200b &#39;inject &lt;script&gt;alert()\u200b;&lt;/script&gt;&#39; from test_table limit 1000</td>"),
+		`<td>0123456789abcdef</td>`,
 		`<td>1</td>`,
 		`<td>1000</td>`,
 		`<td></td>`,
@@ -96,6 +98,7 @@ func TestQuerylogzHandlerFormatting(t *testing.T) {
 		`<td>0.003</td>`,
 		`<td>select</td>`,
 		regexp.QuoteMeta("<td>select name,\u200b &#39;inject &lt;script&gt;alert()\u200b;&lt;/script&gt;&#39; from test_table limit 1000</td>"),
+		`<td>0123456789abcdef</td>`,
 		`<td>1</td>`,
 		`<td>1000</td>`,
 		`<td></td>`,
@@ -126,6 +129,7 @@ func TestQuerylogzHandlerFormatting(t *testing.T) {
 		`<td>0.003</td>`,
 		`<td>select</td>`,
 		regexp.QuoteMeta("<td>select name,\u200b &#39;inject &lt;script&gt;alert()\u200b;&lt;/script&gt;&#39; from test_table limit 1000</td>"),
+		`<td>0123456789abcdef</td>`,
 		`<td>1</td>`,
 		`<td>1000</td>`,
 		`<td></td>`,
@@ -31,6 +31,7 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/querydenylist"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
@@ -87,6 +88,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern1 := []string{
 		`<tr class="low">`,
 		"<td>select id from `user` where id = 1</td>",
+		"<td>" + querydenylist.Fingerprint(plan1.Original) + "</td>",
 		`<td>1</td>`,
 		`<td>0.001000</td>`,
 		`<td>1</td>`,
@@ -104,6 +106,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern2 := []string{
 		`<tr class="high">`,
 		"<td>select id from `user`</td>",
+		"<td>" + querydenylist.Fingerprint(plan2.Original) + "</td>",
 		`<td>1</td>`,
 		`<td>1.000000</td>`,
 		`<td>8</td>`,
@@ -121,6 +124,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern3 := []string{
 		`<tr class="medium">`,
 		"<td>insert into `user`.*</td>",
+		"<td>" + querydenylist.Fingerprint(plan3.Original) + "</td>",
 		`<td>2</td>`,
 		`<td>0.100000</td>`,
 		`<td>2</td>`,
@@ -138,6 +142,7 @@ func TestQueryzHandler(t *testing.T) {
 	planPattern4 := []string{
 		`<tr class="high">`,
 		`<td>insert into name_user_map.*</td>`,
+		"<td>" + querydenylist.Fingerprint(plan4.Original) + "</td>",
 		`<td>2</td>`,
 		`<td>0.200000</td>`,
 		`<td>2</td>`,
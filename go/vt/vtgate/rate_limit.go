@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"strings"
+
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/ratelimiter"
+)
+
+// checkRateLimit returns an error if the tenant the query belongs to has exceeded its rate limit.
+func (e *Executor) checkRateLimit(ctx context.Context, safeSession *econtext.SafeSession, plan *engine.Plan, vcursor *econtext.VCursorImpl, bindVars map[string]*querypb.BindVariable) error {
+	rl := e.config.RateLimiter
+	if rl == nil {
+		return nil
+	}
+
+	var key string
+	switch rl.Dimension() {
+	case ratelimiter.DimensionUsername:
+		key = callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx))
+	case ratelimiter.DimensionWorkload:
+		key = safeSession.GetOptions().GetWorkloadName()
+	case ratelimiter.DimensionVindex:
+		key = e.primaryVindexKey(ctx, plan, vcursor, bindVars)
+	}
+	return rl.Allow(key)
+}
+
+// primaryVindexKey returns the value of the primary vindex column for queries that target a single
+// primary vindex value, or an empty string for any other query.
+func (e *Executor) primaryVindexKey(ctx context.Context, plan *engine.Plan, vcursor *econtext.VCursorImpl, bindVars map[string]*querypb.BindVariable) string {
+	var expr evalengine.Expr
+	switch prim := plan.Instructions.(type) {
+	case *engine.Insert:
+		// VindexValues[0] holds the values of the primary vindex; only single-column, single-row inserts are keyed
+		if len(prim.VindexValues) > 0 && len(prim.VindexValues[0]) == 1 && len(prim.VindexValues[0][0]) == 1 {
+			expr = prim.VindexValues[0][0][0]
+		}
+	case *engine.Update:
+		expr = e.primaryVindexRouteValue(prim.RoutingParameters, plan.TablesUsed)
+	case *engine.Delete:
+		expr = e.primaryVindexRouteValue(prim.RoutingParameters, plan.TablesUsed)
+	case *engine.Route:
+		expr = e.primaryVindexRouteValue(prim.RoutingParameters, plan.TablesUsed)
+	}
+	if expr == nil {
+		return ""
+	}
+
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	value, err := env.Evaluate(expr)
+	if err != nil {
+		return ""
+	}
+	return value.Value(vcursor.ConnCollation()).ToString()
+}
+
+// primaryVindexRouteValue returns the vindex value of a route by equality, provided the vindex is
+// the primary vindex of one of the given tables.
+func (e *Executor) primaryVindexRouteValue(rp *engine.RoutingParameters, tablesUsed []string) evalengine.Expr {
+	if rp == nil || len(rp.Values) != 1 || rp.Vindex == nil || rp.Keyspace == nil {
+		return nil
+	}
+	if rp.Opcode != engine.Equal && rp.Opcode != engine.EqualUnique {
+		return nil
+	}
+	vschema := e.VSchema()
+	if vschema == nil {
+		return nil
+	}
+	for _, tableUsed := range tablesUsed {
+		keyspace, tableName, ok := strings.Cut(tableUsed, ".")
+		if !ok {
+			continue
+		}
+		table, err := vschema.FindTable(keyspace, tableName)
+		if err != nil || table == nil || len(table.ColumnVindexes) == 0 {
+			continue
+		}
+		// vindex names are unique within a keyspace
+		if table.Keyspace.Name == rp.Keyspace.Name && table.ColumnVindexes[0].Name == rp.Vindex.String() {
+			return rp.Values[0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/ratelimiter"
+)
+
+func TestExecutorRateLimit(t *testing.T) {
+	oneQuery := ratelimiter.Limit{QPS: 0.001, Burst: 1}
+
+	t.Run("username", func(t *testing.T) {
+		eConfig := createExecutorConfig()
+		eConfig.RateLimiter = ratelimiter.New(&ratelimiter.Config{
+			Dimension: ratelimiter.DimensionUsername,
+			Keys:      map[string]ratelimiter.Limit{"noisy": oneQuery, "other": oneQuery},
+		})
+		executor, _, _, sbclookup, ctx := createExecutorEnvWithConfig(t, eConfig)
+		noisyCtx := callerid.NewContext(ctx, &vtrpcpb.CallerID{}, &querypb.VTGateCallerID{Username: "noisy"})
+		quietCtx := callerid.NewContext(ctx, &vtrpcpb.CallerID{}, &querypb.VTGateCallerID{Username: "quiet"})
+		otherCtx := callerid.NewContext(ctx, &vtrpcpb.CallerID{}, &querypb.VTGateCallerID{Username: "other"})
+		session := &vtgatepb.Session{TargetString: "@primary"}
+
+		_, err := executorExec(noisyCtx, executor, session, "select id from main1", nil)
+		require.NoError(t, err)
+		_, err = executorExec(noisyCtx, executor, session, "select id from main1", nil)
+		require.Error(t, err)
+		assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+		assert.Equal(t, sqlerror.ERUserLimitReached, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Number())
+
+		for range 3 {
+			_, err = executorExec(quietCtx, executor, session, "select id from main1", nil)
+			require.NoError(t, err)
+		}
+
+		// a retried query takes a single token
+		vschemaWaitTimeout = 10 * time.Millisecond
+		defer func() { vschemaWaitTimeout = 30 * time.Second }()
+		sbclookup.EphemeralShardErr = errors.New("enforce denied tables")
+		autocommitSession := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+		_, err = executorExec(otherCtx, executor, autocommitSession, "select id from main1", nil)
+		require.NoError(t, err)
+		_, err = executorExec(otherCtx, executor, autocommitSession, "select id from main1", nil)
+		require.ErrorContains(t, err, "rate limit exceeded")
+
+		// transaction control statements are never limited
+		_, err = executorExec(noisyCtx, executor, session, "begin", nil)
+		require.NoError(t, err)
+		_, err = executorExec(noisyCtx, executor, session, "rollback", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("workload", func(t *testing.T) {
+		eConfig := createExecutorConfig()
+		eConfig.RateLimiter = ratelimiter.New(&ratelimiter.Config{
+			Dimension: ratelimiter.DimensionWorkload,
+			Keys:      map[string]ratelimiter.Limit{"reports": oneQuery},
+		})
+		executor, _, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+		session := &vtgatepb.Session{TargetString: "@primary"}
+
+		_, err := executorExec(ctx, executor, session, "select /*vt+ WORKLOAD_NAME=reports */ id from main1", nil)
+		require.NoError(t, err)
+		_, err = executorExec(ctx, executor, session, "select /*vt+ WORKLOAD_NAME=reports */ id from main1", nil)
+		require.ErrorContains(t, err, "rate limit exceeded for workload 'reports'")
+		_, err = executorExec(ctx, executor, &vtgatepb.Session{TargetString: "@primary"}, "select /*vt+ WORKLOAD_NAME=app */ id from main1", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("vindex", func(t *testing.T) {
+		eConfig := createExecutorConfig()
+		eConfig.RateLimiter = ratelimiter.New(&ratelimiter.Config{
+			Dimension: ratelimiter.DimensionVindex,
+			Default:   &oneQuery,
+		})
+		executor, _, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+		session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+
+		_, err := executorExec(ctx, executor, session, "select id from `user` where id = 1", nil)
+		require.NoError(t, err)
+		_, err = executorExec(ctx, executor, session, "update `user` set a = 2 where id = 1", nil)
+		require.ErrorContains(t, err, "rate limit exceeded for vindex '1'")
+		_, err = executorExec(ctx, executor, session, "select id from `user` where id = 2", nil)
+		require.NoError(t, err)
+		_, err = executorExec(ctx, executor, session, "insert into user_extra(user_id) values (2)", nil)
+		require.ErrorContains(t, err, "rate limit exceeded for vindex '2'")
+
+		// queries that do not target a single primary vindex value are not limited
+		for range 3 {
+			_, err = executorExec(ctx, executor, session, "select id from `user`", nil)
+			require.NoError(t, err)
+		}
+	})
+}
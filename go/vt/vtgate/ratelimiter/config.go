@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Dimension is the property of a query that identifies the tenant a rate limit applies to.
+type Dimension string
+
+const (
+	// DimensionUsername keys queries by the username of the immediate caller.
+	DimensionUsername Dimension = "username"
+	// DimensionWorkload keys queries by the WORKLOAD_NAME comment directive.
+	DimensionWorkload Dimension = "workload"
+	// DimensionVindex keys queries by the value of the primary vindex column, for queries that
+	// target a single primary vindex value.
+	DimensionVindex Dimension = "vindex"
+)
+
+// Limit is a token bucket limit.
+type Limit struct {
+	// QPS is the rate at which the bucket refills. A zero or negative QPS means no limit.
+	QPS float64 `json:"qps"`
+	// Burst is the size of the bucket. Defaults to QPS, and never less than 1.
+	Burst int `json:"burst,omitempty"`
+}
+
+func (l Limit) unlimited() bool {
+	return l.QPS <= 0
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return max(1, int(l.QPS))
+}
+
+// Config is the rate limiter configuration, as read from a JSON file, e.g.:
+//
+//	{
+//	  "dimension": "username",
+//	  "default": {"qps": 100, "burst": 200},
+//	  "keys": {
+//	    "batch_user": {"qps": 10},
+//	    "admin": {"qps": 0}
+//	  }
+//	}
+type Config struct {
+	// Dimension is the query property queries are keyed by.
+	Dimension Dimension `json:"dimension"`
+	// Default is the limit applied to each key that is not listed in Keys. When nil, such keys are not limited.
+	Default *Limit `json:"default,omitempty"`
+	// Keys holds per-key limits.
+	Keys map[string]Limit `json:"keys,omitempty"`
+}
+
+// Validate checks the configuration for errors.
+func (c *Config) Validate() error {
+	switch c.Dimension {
+	case DimensionUsername, DimensionWorkload, DimensionVindex:
+	default:
+		return fmt.Errorf("unknown rate limiter dimension %q, expected one of %s, %s, %s", c.Dimension, DimensionUsername, DimensionWorkload, DimensionVindex)
+	}
+	if c.Default != nil && c.Default.Burst < 0 {
+		return fmt.Errorf("negative default burst: %d", c.Default.Burst)
+	}
+	for key, limit := range c.Keys {
+		if limit.Burst < 0 {
+			return fmt.Errorf("negative burst for key %q: %d", key, limit.Burst)
+		}
+	}
+	return nil
+}
+
+// limitFor returns the limit applying to the given key, and whether that limit was explicitly configured for it.
+func (c *Config) limitFor(key string) (limit Limit, explicit bool, ok bool) {
+	if limit, ok := c.Keys[key]; ok {
+		return limit, true, true
+	}
+	if c.Default != nil {
+		return *c.Default, false, true
+	}
+	return Limit{}, false, false
+}
+
+// LoadConfig reads and validates a configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing rate limiter config %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limiter config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
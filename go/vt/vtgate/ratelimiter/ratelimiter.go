@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimiter implements per-tenant token bucket rate limiting of queries in vtgate.
+package ratelimiter
+
+import (
+	"path"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/time/rate"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	// defaultKeyLabel is the stats label used for all keys that are limited by the default limit,
+	// so that high cardinality dimensions, such as vindex values, do not explode the stats.
+	defaultKeyLabel = "default"
+
+	// maxBuckets is the number of buckets above which idle buckets are discarded.
+	maxBuckets = 10000
+)
+
+var (
+	statsAllowed  = stats.NewCountersWithSingleLabel("VtgateRateLimitAllowed", "Queries allowed by the rate limiter", "Key")
+	statsRejected = stats.NewCountersWithSingleLabel("VtgateRateLimitRejected", "Queries rejected by the rate limiter", "Key")
+)
+
+// RateLimiter limits the rate of queries per key. A nil *RateLimiter allows all queries.
+type RateLimiter struct {
+	path string
+
+	mu      sync.Mutex
+	cfg     *Config
+	buckets map[string]*rate.Limiter
+	watcher *fsnotify.Watcher
+}
+
+// New creates a RateLimiter with the given configuration.
+func New(cfg *Config) *RateLimiter {
+	rl := &RateLimiter{}
+	rl.SetConfig(cfg)
+	return rl
+}
+
+// NewFromFile creates a RateLimiter from a configuration file, which can later be reloaded with Reload or Watch.
+func NewFromFile(path string) (*RateLimiter, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	rl := New(cfg)
+	rl.path = path
+	return rl, nil
+}
+
+// Dimension returns the dimension queries are keyed by.
+func (rl *RateLimiter) Dimension() Dimension {
+	if rl == nil {
+		return ""
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.cfg.Dimension
+}
+
+// SetConfig replaces the configuration. All buckets are reset.
+func (rl *RateLimiter) SetConfig(cfg *Config) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.cfg = cfg
+	rl.buckets = make(map[string]*rate.Limiter)
+}
+
+// Reload reads the configuration file again. On error, the current configuration is kept.
+func (rl *RateLimiter) Reload() error {
+	cfg, err := LoadConfig(rl.path)
+	if err != nil {
+		return err
+	}
+	rl.SetConfig(cfg)
+	return nil
+}
+
+// Watch sets up a watch on the configuration file and reloads it whenever it changes.
+func (rl *RateLimiter) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(path.Dir(rl.path)); err != nil {
+		watcher.Close()
+		return err
+	}
+	rl.mu.Lock()
+	rl.watcher = watcher
+	rl.mu.Unlock()
+
+	fileName := path.Base(rl.path)
+	go func() {
+		for {
+			select {
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if path.Base(evt.Name) != fileName {
+					continue
+				}
+				if err := rl.Reload(); err != nil {
+					log.Errorf("Failed to reload rate limiter config from %q: %v", rl.path, err)
+				} else {
+					log.Infof("Reloaded rate limiter config from %q", rl.path)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorf("Error watching %v: %v", rl.path, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops watching the configuration file.
+func (rl *RateLimiter) Close() {
+	if rl == nil {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.watcher != nil {
+		rl.watcher.Close()
+		rl.watcher = nil
+	}
+}
+
+// Allow takes a token from the bucket of the given key, and returns a RESOURCE_EXHAUSTED error,
+// which vtgate reports as MySQL error 1226 (ER_USER_LIMIT_REACHED), if the bucket is empty.
+// Queries without a key are never limited.
+func (rl *RateLimiter) Allow(key string) error {
+	if rl == nil || key == "" {
+		return nil
+	}
+
+	rl.mu.Lock()
+	limit, explicit, ok := rl.cfg.limitFor(key)
+	if !ok || limit.unlimited() {
+		rl.mu.Unlock()
+		return nil
+	}
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		if len(rl.buckets) >= maxBuckets {
+			rl.discardIdleBuckets()
+		}
+		bucket = rate.NewLimiter(rate.Limit(limit.QPS), limit.burst())
+		rl.buckets[key] = bucket
+	}
+	dimension := rl.cfg.Dimension
+	allowed := bucket.Allow()
+	rl.mu.Unlock()
+
+	label := key
+	if !explicit {
+		label = defaultKeyLabel
+	}
+	if allowed {
+		statsAllowed.Add(label, 1)
+		return nil
+	}
+	statsRejected.Add(label, 1)
+	return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "rate limit exceeded for %s '%s'", dimension, key)
+}
+
+// discardIdleBuckets removes the buckets that are full, which are indistinguishable from new buckets.
+// It must be called with rl.mu held.
+func (rl *RateLimiter) discardIdleBuckets() {
+	for key, bucket := range rl.buckets {
+		if bucket.Tokens() >= float64(bucket.Burst()) {
+			delete(rl.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func TestAllow(t *testing.T) {
+	rl := New(&Config{
+		Dimension: DimensionUsername,
+		Default:   &Limit{QPS: 0.001, Burst: 2},
+		Keys: map[string]Limit{
+			"limited":   {QPS: 0.001},
+			"unlimited": {QPS: 0},
+		},
+	})
+
+	// explicit limit, burst defaults to 1
+	require.NoError(t, rl.Allow("limited"))
+	err := rl.Allow("limited")
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "rate limit exceeded for username 'limited'")
+	assert.Equal(t, sqlerror.ERUserLimitReached, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Number())
+
+	// explicitly unlimited
+	for range 10 {
+		require.NoError(t, rl.Allow("unlimited"))
+	}
+
+	// default limit, with a bucket per key
+	for _, key := range []string{"a", "b"} {
+		require.NoError(t, rl.Allow(key))
+		require.NoError(t, rl.Allow(key))
+		require.Error(t, rl.Allow(key))
+	}
+
+	// queries without a key are never limited
+	for range 10 {
+		require.NoError(t, rl.Allow(""))
+	}
+
+	assert.EqualValues(t, 1, statsRejected.Counts()["limited"])
+	assert.EqualValues(t, 2, statsRejected.Counts()[defaultKeyLabel])
+
+	// a nil rate limiter allows everything
+	var nilLimiter *RateLimiter
+	require.NoError(t, nilLimiter.Allow("limited"))
+}
+
+func TestAllowRefill(t *testing.T) {
+	rl := New(&Config{
+		Dimension: DimensionWorkload,
+		Keys:      map[string]Limit{"olap": {QPS: 100}},
+	})
+	allowed := 0
+	for range 200 {
+		if rl.Allow("olap") == nil {
+			allowed++
+		}
+	}
+	assert.Equal(t, 100, allowed)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(t, rl.Allow("olap"))
+
+	// keys not listed are not limited when there is no default
+	for range 200 {
+		require.NoError(t, rl.Allow("oltp"))
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{Dimension: DimensionVindex}).Validate())
+	assert.ErrorContains(t, (&Config{Dimension: "tenant"}).Validate(), "unknown rate limiter dimension")
+	assert.ErrorContains(t, (&Config{Dimension: DimensionVindex, Default: &Limit{Burst: -1}}).Validate(), "negative default burst")
+	assert.ErrorContains(t, (&Config{Dimension: DimensionVindex, Keys: map[string]Limit{"x": {Burst: -1}}}).Validate(), "negative burst")
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimiter.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"dimension": "username", "keys": {"u1": {"qps": 0.001}}}`), 0o644))
+
+	rl, err := NewFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, DimensionUsername, rl.Dimension())
+	require.NoError(t, rl.Allow("u1"))
+	require.Error(t, rl.Allow("u1"))
+
+	require.NoError(t, rl.Watch())
+	defer rl.Close()
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"dimension": "username", "keys": {"u1": {"qps": 0}}}`), 0o644))
+	assert.Eventually(t, func() bool {
+		return rl.Allow("u1") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid config is rejected and the current one is kept
+	require.NoError(t, os.WriteFile(path, []byte(`{"dimension": "tenant"}`), 0o644))
+	assert.Error(t, rl.Reload())
+	assert.Equal(t, DimensionUsername, rl.Dimension())
+
+	_, err = NewFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/resultcache"
+)
+
+var (
+	resultCacheHits          = stats.NewCounter("ResultCacheHits", "Number of read-only queries answered from the result cache")
+	resultCacheMisses        = stats.NewCounter("ResultCacheMisses", "Number of cacheable read-only queries which were not found in the result cache")
+	resultCacheInvalidations = stats.NewCountersWithSingleLabel("ResultCacheInvalidations", "Number of result cache invalidations", "Source", "Write", "VStream")
+
+	// resultCacheVStreamRetryDelay is how long to wait before restarting the vstream invalidating the result cache after it failed.
+	resultCacheVStreamRetryDelay = 5 * time.Second
+)
+
+// initResultCache creates the result cache if enabled.
+func initResultCache() *resultcache.Cache {
+	if resultCacheMemory <= 0 {
+		return nil
+	}
+	cache := resultcache.New(resultCacheMemory)
+	stats.NewGaugeFunc("ResultCacheLength", "Number of results in the result cache", func() int64 {
+		return int64(cache.Len())
+	})
+	stats.NewGaugeFunc("ResultCacheSize", "Memory used by the results in the result cache, in bytes", func() int64 {
+		return int64(cache.UsedCapacity())
+	})
+	servenv.OnTerm(cache.Close)
+	return cache
+}
+
+// resultCacheTTL returns how long the result of a read-only query may be cached, 0 if it
+// must not be. The CACHE_TTL directive of the query takes precedence over the result cache
+// TTL of its tables in the vschema, in which case all of them must have one.
+func (e *Executor) resultCacheTTL(plan *engine.Plan) time.Duration {
+	if e.config.ResultCache == nil {
+		return 0
+	}
+	if plan.QueryHints.CacheTTL > 0 {
+		return plan.QueryHints.CacheTTL
+	}
+	if len(plan.TablesUsed) == 0 {
+		return 0
+	}
+	vschema := e.VSchema()
+	if vschema == nil {
+		return 0
+	}
+	var ttl time.Duration
+	for _, name := range plan.TablesUsed {
+		ksName, tblName, ok := strings.Cut(name, ".")
+		if !ok {
+			return 0
+		}
+		ks := vschema.Keyspaces[ksName]
+		if ks == nil {
+			return 0
+		}
+		tbl := ks.Tables[tblName]
+		if tbl == nil || tbl.ResultCacheTTL <= 0 {
+			return 0
+		}
+		if ttl == 0 || tbl.ResultCacheTTL < ttl {
+			ttl = tbl.ResultCacheTTL
+		}
+	}
+	return ttl
+}
+
+// executeCached answers a read-only query from the result cache, executing it and caching
+// its result for ttl if it is not found.
+func (e *Executor) executeCached(
+	ctx context.Context,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+	key string,
+	ttl time.Duration,
+) (*sqltypes.Result, error) {
+	cache := e.config.ResultCache
+	if qr, ok := cache.Get(key); ok {
+		resultCacheHits.Add(1)
+		return qr.ShallowCopy(), nil
+	}
+	resultCacheMisses.Add(1)
+
+	// The version must be taken before executing the query, so that a write happening
+	// concurrently keeps its result out of the cache.
+	version := cache.Version(plan.TablesUsed)
+	qr, shared, err := e.executeConsolidated(ctx, plan, vcursor, bindVars, key)
+	if err != nil {
+		return nil, err
+	}
+	// A shared result comes from an execution that started before the version was taken,
+	// possibly before a write, so only the caller which executed the query caches it.
+	if !shared {
+		// The cache keeps its own copy, so that it never sees changes the caller makes to the result.
+		cache.Set(key, plan.TablesUsed, version, qr.ShallowCopy(), ttl)
+	}
+	return qr, nil
+}
+
+// invalidateResultCache invalidates the cached results reading the tables written by the plan.
+// Writes made in a transaction are also invalidated when it commits, as their results can be
+// cached again in the meantime by other sessions.
+func (e *Executor) invalidateResultCache(safeSession *econtext.SafeSession, plan *engine.Plan) {
+	if e.config.ResultCache == nil || plan.QueryType == sqlparser.StmtSelect || len(plan.TablesUsed) == 0 {
+		return
+	}
+	e.config.ResultCache.Invalidate(plan.TablesUsed...)
+	resultCacheInvalidations.Add("Write", 1)
+	if safeSession.InTransaction() {
+		safeSession.RecordWrittenTables(plan.TablesUsed)
+	}
+}
+
+// streamResultCacheInvalidations invalidates the cached results from the row events of the
+// keyspaces, so that writes made through other vtgates are seen as well. It runs until ctx is done.
+func streamResultCacheInvalidations(ctx context.Context, vsm *vstreamManager, cache *resultcache.Cache, keyspaces string) {
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: keyspaces,
+			Gtid:     "current",
+		}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match: "/.*",
+		}},
+	}
+	for {
+		err := vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, filter, nil, func(events []*binlogdatapb.VEvent) error {
+			for _, event := range events {
+				switch event.Type {
+				case binlogdatapb.VEventType_ROW:
+					// The table name is qualified with its keyspace by the vstream manager.
+					cache.Invalidate(event.RowEvent.TableName)
+					resultCacheInvalidations.Add("VStream", 1)
+				case binlogdatapb.VEventType_DDL:
+					cache.InvalidateAll()
+					resultCacheInvalidations.Add("VStream", 1)
+				}
+			}
+			return nil
+		})
+		// Writes may have been missed while the stream was down.
+		cache.InvalidateAll()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("Result cache invalidation vstream for keyspaces %s failed, retrying in %v: %v", keyspaces, resultCacheVStreamRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resultCacheVStreamRetryDelay):
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sync2"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	"vitess.io/vitess/go/vt/vtgate/resultcache"
+)
+
+func TestExecutorResultCache(t *testing.T) {
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.ResultCache = resultcache.New(1024 * 1024)
+	defer eConfig.ResultCache.Close()
+	consolidator := &recordingConsolidator{Consolidator: sync2.NewConsolidator()}
+	eConfig.Consolidator = consolidator
+	executor, _, _, sbclookup, ctx := createExecutorEnvWithConfig(t, eConfig)
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+
+	// exec runs the query and returns how many times it reached the tablet.
+	exec := func(t *testing.T, session *vtgatepb.Session, query string) int64 {
+		t.Helper()
+		before := sbclookup.ExecCount.Load()
+		_, err := executorExec(ctx, executor, session, query, nil)
+		require.NoError(t, err, query)
+		return sbclookup.ExecCount.Load() - before
+	}
+
+	t.Run("directive", func(t *testing.T) {
+		const query = "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 1"
+		hits := resultCacheHits.Get()
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 0, exec(t, session, query))
+		assert.EqualValues(t, 1, resultCacheHits.Get()-hits)
+
+		// a different bind variable is a different query
+		assert.EqualValues(t, 1, exec(t, session, "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 2"))
+	})
+
+	t.Run("not cached without a ttl", func(t *testing.T) {
+		const query = "select id from main1 where id = 3"
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 1, exec(t, session, query))
+	})
+
+	t.Run("not cached in a transaction", func(t *testing.T) {
+		const query = "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 4"
+		assert.EqualValues(t, 1, exec(t, session, query))
+		txSession := &vtgatepb.Session{TargetString: "@primary", InTransaction: true}
+		assert.EqualValues(t, 1, exec(t, txSession, query))
+	})
+
+	t.Run("invalidated by writes", func(t *testing.T) {
+		const query = "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 5"
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 0, exec(t, session, query))
+
+		exec(t, session, "update main1 set id = 6 where id = 5")
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 0, exec(t, session, query))
+	})
+
+	t.Run("vschema ttl", func(t *testing.T) {
+		const query = "select id from main1 where id = 7"
+		executor.VSchema().Keyspaces[KsTestUnsharded].Tables["main1"].ResultCacheTTL = time.Hour
+		defer func() {
+			executor.VSchema().Keyspaces[KsTestUnsharded].Tables["main1"].ResultCacheTTL = 0
+		}()
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 0, exec(t, session, query))
+
+		// every table must have a ttl
+		const join = "select main1.id from main1 join music_user_map on main1.id = music_user_map.music_id"
+		assert.EqualValues(t, 1, exec(t, session, join))
+		assert.EqualValues(t, 1, exec(t, session, join))
+	})
+
+	t.Run("invalidated when a transaction commits", func(t *testing.T) {
+		const query = "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 8"
+		txSession := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+		exec(t, txSession, "begin")
+		exec(t, txSession, "update main1 set id = 9 where id = 8")
+
+		// other sessions cache the result from before the commit
+		assert.EqualValues(t, 1, exec(t, session, query))
+		assert.EqualValues(t, 0, exec(t, session, query))
+
+		exec(t, txSession, "commit")
+		assert.Empty(t, txSession.WrittenTables)
+		assert.EqualValues(t, 1, exec(t, session, query))
+	})
+
+	t.Run("shared results are not cached", func(t *testing.T) {
+		const query = "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 10"
+		consolidator.takeKeys()
+		assert.EqualValues(t, 1, exec(t, session, query))
+		keys := consolidator.takeKeys()
+		require.Len(t, keys, 1)
+		exec(t, session, "update main1 set id = 11 where id = 10")
+
+		// act as an in-flight query which started before the write
+		q, original := consolidator.Create(keys[0])
+		require.True(t, original)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := executorExec(ctx, executor, session, query, nil)
+			assert.NoError(t, err)
+		}()
+		require.Eventually(t, func() bool {
+			return *q.AddWaiterCounter(0) == 1
+		}, 5*time.Second, time.Millisecond)
+		q.SetResult(sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "10"))
+		q.Broadcast()
+		wg.Wait()
+
+		assert.EqualValues(t, 1, exec(t, session, query))
+	})
+}
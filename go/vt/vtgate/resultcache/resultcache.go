@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resultcache caches the results of read-only queries in vtgate. Cached
+// results expire after their TTL, and are invalidated when one of the tables
+// they read is written to.
+package resultcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/cache/theine"
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+// Version is the state of the tables of a query when it started executing.
+type Version struct {
+	epoch       uint32
+	generations []uint64
+}
+
+// entry is a cached result, along with the version of its tables.
+type entry struct {
+	result  *sqltypes.Result
+	expires time.Time
+	tables  []string
+	version Version
+}
+
+// CachedSize implements the theine cache value interface.
+func (e *entry) CachedSize(alloc bool) int64 {
+	size := e.result.CachedSize(true)
+	if alloc {
+		size += int64(80)
+	}
+	size += hack.RuntimeAllocSize(int64(cap(e.tables)) * int64(16))
+	for _, table := range e.tables {
+		size += hack.RuntimeAllocSize(int64(len(table)))
+	}
+	size += hack.RuntimeAllocSize(int64(cap(e.version.generations)) * int64(8))
+	return size
+}
+
+// Cache is a memory bounded cache of query results. It is safe for concurrent use.
+type Cache struct {
+	store *theine.Store[theine.StringKey, *entry]
+	// epoch is increased to invalidate all the cached results at once.
+	epoch atomic.Uint32
+
+	mu          sync.RWMutex
+	generations map[string]uint64
+}
+
+// New returns a Cache holding up to maxMemory bytes of results.
+func New(maxMemory int64) *Cache {
+	return &Cache{
+		store:       theine.NewStore[theine.StringKey, *entry](maxMemory, false),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Version returns the current version of tables. It must be taken before the
+// query is executed, and passed to Set along with its result.
+func (c *Cache) Version(tables []string) Version {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	version := Version{
+		epoch:       c.epoch.Load(),
+		generations: make([]uint64, len(tables)),
+	}
+	for i, table := range tables {
+		version.generations[i] = c.generations[table]
+	}
+	return version
+}
+
+// Get returns the cached result of a query, if it has neither expired nor been
+// invalidated. The result must not be modified.
+func (c *Cache) Get(key string) (*sqltypes.Result, bool) {
+	e, ok := c.store.Get(theine.StringKey(key), c.epoch.Load())
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) || !c.isCurrent(e) {
+		c.store.Delete(theine.StringKey(key))
+		return nil, false
+	}
+	return e.result, true
+}
+
+func (c *Cache) isCurrent(e *entry) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.epoch.Load() != e.version.epoch {
+		return false
+	}
+	for i, table := range e.tables {
+		if c.generations[table] != e.version.generations[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Set caches the result of a query reading tables for ttl. The version is the
+// one of the tables before the query was executed, so that the result of a
+// query that ran concurrently with a write is never served.
+func (c *Cache) Set(key string, tables []string, version Version, result *sqltypes.Result, ttl time.Duration) {
+	e := &entry{
+		result:  result,
+		expires: time.Now().Add(ttl),
+		tables:  tables,
+		version: version,
+	}
+	if !c.isCurrent(e) {
+		return
+	}
+	c.store.Set(theine.StringKey(key), e, 0, version.epoch)
+}
+
+// Invalidate invalidates the cached results reading any of the tables.
+func (c *Cache) Invalidate(tables ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, table := range tables {
+		c.generations[table]++
+	}
+}
+
+// InvalidateAll invalidates all the cached results.
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch.Add(1)
+}
+
+// Len returns the number of cached results, including the ones which expired
+// or were invalidated but have not been evicted yet.
+func (c *Cache) Len() int {
+	return c.store.Len()
+}
+
+// UsedCapacity returns the memory used by the cached results, in bytes.
+func (c *Cache) UsedCapacity() int {
+	return c.store.UsedCapacity()
+}
+
+// Close releases the resources of the cache.
+func (c *Cache) Close() {
+	c.store.Close()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestCache(t *testing.T) {
+	c := New(1024 * 1024)
+	defer c.Close()
+
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2")
+	tables := []string{"ks.t1", "ks.t2"}
+
+	_, ok := c.Get("q1")
+	assert.False(t, ok)
+
+	c.Set("q1", tables, c.Version(tables), result, time.Minute)
+	got, ok := c.Get("q1")
+	require.True(t, ok)
+	assert.Equal(t, result, got)
+
+	// writes to other tables do not invalidate the result
+	c.Invalidate("ks.t3")
+	_, ok = c.Get("q1")
+	assert.True(t, ok)
+
+	c.Invalidate("ks.t2")
+	_, ok = c.Get("q1")
+	assert.False(t, ok)
+
+	c.Set("q1", tables, c.Version(tables), result, time.Minute)
+	_, ok = c.Get("q1")
+	assert.True(t, ok)
+	c.InvalidateAll()
+	_, ok = c.Get("q1")
+	assert.False(t, ok)
+
+	// the result of a query which ran concurrently with a write is not cached
+	version := c.Version(tables)
+	c.Invalidate("ks.t1")
+	c.Set("q1", tables, version, result, time.Minute)
+	_, ok = c.Get("q1")
+	assert.False(t, ok)
+
+	version = c.Version(tables)
+	c.InvalidateAll()
+	c.Set("q1", tables, version, result, time.Minute)
+	_, ok = c.Get("q1")
+	assert.False(t, ok)
+
+	// expired results are not served
+	c.Set("q2", nil, c.Version(nil), result, -time.Second)
+	_, ok = c.Get("q2")
+	assert.False(t, ok)
+}
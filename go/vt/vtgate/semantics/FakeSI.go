@@ -90,3 +90,8 @@ func (s *FakeSI) GetAggregateUDFs() []string {
 func (s *FakeSI) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error) {
 	return nil, nil
 }
+
+// FindWeightedRoutingRule implements SchemaInformation.
+func (s *FakeSI) FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	return nil, nil
+}
@@ -1675,3 +1675,8 @@ func (i *infoSchemaWithColumns) GetAggregateUDFs() []string {
 func (i *infoSchemaWithColumns) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error) {
 	return i.inner.FindMirrorRule(tablename)
 }
+
+// FindWeightedRoutingRule implements SchemaInformation.
+func (i *infoSchemaWithColumns) FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error) {
+	return i.inner.FindWeightedRoutingRule(tablename)
+}
@@ -36,9 +36,12 @@ type RealTable struct {
 	CTE               *CTE
 	VindexHint        *sqlparser.IndexHint
 	MirrorRule        *vindexes.MirrorRule
-	isInfSchema       bool
-	collationEnv      *collations.Environment
-	cache             map[string]dependencies
+	// WeightedRoutingRule is the rule routing a percentage of the reads of
+	// this table to another keyspace, if any.
+	WeightedRoutingRule *vindexes.WeightedRoutingRule
+	isInfSchema         bool
+	collationEnv        *collations.Environment
+	cache               map[string]dependencies
 }
 
 var _ TableInfo = (*RealTable)(nil)
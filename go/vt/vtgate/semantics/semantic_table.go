@@ -18,6 +18,8 @@ package semantics
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
@@ -97,6 +99,15 @@ type (
 		Percent float32
 	}
 
+	// WeightedRoutingInfo stores information used to produce weighted route
+	// operators.
+	WeightedRoutingInfo struct {
+		Percent         float32
+		MaxErrorPercent float32
+		// Rules describes the weighted routing rules used by the query.
+		Rules string
+	}
+
 	// SemTable contains semantic analysis information about the query.
 	SemTable struct {
 		// Tables stores information about the tables in the query, including derived tables
@@ -173,6 +184,7 @@ type (
 		KeyspaceError(keyspace string) error
 		GetAggregateUDFs() []string
 		FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error)
+		FindWeightedRoutingRule(tablename sqlparser.TableName) (*vindexes.WeightedRoutingRule, error)
 	}
 
 	shortCut = int
@@ -987,6 +999,9 @@ func canTakeSelectUnshardedShortcut(tableInfos []TableInfo) (*vindexes.Keyspace,
 	if mi := mirrorInfo(tableInfos); mi.Percent > 0 {
 		return nil, false
 	}
+	if wi := weightedRoutingInfo(tableInfos); wi.Percent > 0 {
+		return nil, false
+	}
 	return canTakeUnshardedShortcut(tableInfos)
 }
 
@@ -994,6 +1009,10 @@ func (st *SemTable) GetMirrorInfo() MirrorInfo {
 	return mirrorInfo(st.Tables)
 }
 
+func (st *SemTable) GetWeightedRoutingInfo() WeightedRoutingInfo {
+	return weightedRoutingInfo(st.Tables)
+}
+
 func (st *SemTable) ShouldFetchLastInsertID() bool {
 	if st == nil {
 		return false
@@ -1026,3 +1045,35 @@ func mirrorInfo(tableInfos []TableInfo) MirrorInfo {
 	}
 	return mi
 }
+
+// weightedRoutingInfo returns the WeightedRoutingInfo of a query. A query is
+// only routed by weight when all of its tables have a weighted routing rule,
+// since the tables without one are still served by the from keyspace. Like
+// mirrorInfo, the lowest percentage found across all rules is used.
+func weightedRoutingInfo(tableInfos []TableInfo) WeightedRoutingInfo {
+	wi := WeightedRoutingInfo{}
+	var rules []string
+	for _, t := range tableInfos {
+		rt, ok := t.(*RealTable)
+		if !ok || rt.IsInfSchema() || rt.CTE != nil {
+			continue
+		}
+		wr := rt.WeightedRoutingRule
+		if wr == nil {
+			if vtbl := rt.GetVindexTable(); vtbl != nil && vtbl.Type == vindexes.TypeReference && vtbl.Name.String() == "dual" {
+				continue
+			}
+			return WeightedRoutingInfo{}
+		}
+		if wi.Percent == 0 || wr.Percent < wi.Percent {
+			wi.Percent = wr.Percent
+		}
+		if wr.MaxErrorPercent > 0 && (wi.MaxErrorPercent == 0 || wr.MaxErrorPercent < wi.MaxErrorPercent) {
+			wi.MaxErrorPercent = wr.MaxErrorPercent
+		}
+		rules = append(rules, wr.FromTable+"->"+wr.Table.Keyspace.Name+"."+wr.Table.Name.String())
+	}
+	slices.Sort(rules)
+	wi.Rules = strings.Join(slices.Compact(rules), ",")
+	return wi
+}
@@ -564,15 +564,22 @@ func (etc *earlyTableCollector) createTable(
 		// because of an issue with mirroring.
 		mr = nil
 	}
+	wr, err := etc.si.FindWeightedRoutingRule(tblName)
+	if err != nil {
+		// Like mirroring, weighted routing is best effort: an invalid rule
+		// routes all the reads to the from table.
+		wr = nil
+	}
 
 	table := &RealTable{
-		tableName:    alias.As.String(),
-		ASTNode:      alias,
-		Table:        tbl,
-		VindexHint:   hint,
-		MirrorRule:   mr,
-		isInfSchema:  isInfSchema,
-		collationEnv: etc.si.Environment().CollationEnv(),
+		tableName:           alias.As.String(),
+		ASTNode:             alias,
+		Table:               tbl,
+		VindexHint:          hint,
+		MirrorRule:          mr,
+		WeightedRoutingRule: wr,
+		isInfSchema:         isInfSchema,
+		collationEnv:        etc.si.Environment().CollationEnv(),
 	}
 
 	if alias.As.IsEmpty() {
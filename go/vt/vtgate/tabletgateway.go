@@ -74,7 +74,7 @@ func registerTabletGatewayFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&retryCount, "retry-count", 2, "retry count")
 	fs.BoolVar(&balancerEnabled, "enable-balancer", false, "(DEPRECATED: use --vtgate-balancer-mode instead) Enable the tablet balancer to evenly spread query load for a given tablet type")
 	fs.StringVar(&balancerModeFlag, "vtgate-balancer-mode", "", fmt.Sprintf("Tablet balancer mode (options: %s). Defaults to 'cell' which shuffles tablets in the local cell.", strings.Join(balancer.GetAvailableModeNames(), ", ")))
-	fs.StringSliceVar(&balancerVtgateCells, "balancer-vtgate-cells", []string{}, "Comma-separated list of cells that contain vttablets. For 'prefer-cell' mode, this is required. For 'random' mode, this is optional and filters tablets to those cells.")
+	fs.StringSliceVar(&balancerVtgateCells, "balancer-vtgate-cells", []string{}, "Comma-separated list of cells that contain vttablets. For 'prefer-cell' mode, this is required. For 'random' and 'peak-ewma' modes, this is optional and filters tablets to those cells.")
 	fs.StringSliceVar(&balancerKeyspaces, "balancer-keyspaces", []string{}, "Comma-separated list of keyspaces for which to use the balancer (optional). If empty, applies to all keyspaces.")
 }
 
@@ -206,7 +206,7 @@ func (gw *TabletGateway) setupBalancer() {
 		log.Exitf("--balancer-vtgate-cells is required when using --vtgate-balancer-mode=prefer-cell")
 	}
 
-	// Create the balancer for prefer-cell or random modes
+	// Create the balancer for prefer-cell, random or peak-ewma modes
 	var err error
 	gw.balancer, err = balancer.NewTabletBalancer(mode, gw.localCell, balancerVtgateCells)
 	if err != nil {
@@ -322,7 +322,7 @@ func (gw *TabletGateway) DebugBalancerHandler(w http.ResponseWriter, r *http.Req
 // withRetry also adds shard information to errors returned from the inner QueryService, so
 // withShardError should not be combined with withRetry.
 func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target, _ queryservice.QueryService,
-	_ string, inTransaction bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error)) error {
+	name string, inTransaction bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error)) error {
 	// for transactions, we connect to a specific tablet instead of letting gateway choose one
 	if inTransaction && target.TabletType != topodatapb.TabletType_PRIMARY {
 		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "tabletGateway's query service can only be used for non-transactional queries on replicas")
@@ -449,10 +449,18 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 
 		gw.updateDefaultConnCollation(tabletLastUsed)
 
+		var queryDone func(error)
+		if observer, ok := gw.balancer.(balancer.QueryObserver); ok && useBalancer && !streamingMethods[name] {
+			queryDone = observer.QueryStarted(th)
+		}
+
 		startTime := time.Now()
 		var canRetry bool
 		canRetry, err = inner(ctx, target, th.Conn)
 		gw.updateStats(target, startTime, err)
+		if queryDone != nil {
+			queryDone(err)
+		}
 		if canRetry {
 			invalidTablets[topoproto.TabletAliasString(tabletLastUsed.Alias)] = true
 			continue
@@ -462,6 +470,22 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 	return NewShardError(err, target)
 }
 
+// streamingMethods are the QueryService methods that stream their results.
+// They are not reported to balancers that observe query latency, since the
+// duration of a stream says nothing about how fast the tablet is.
+var streamingMethods = map[string]bool{
+	"StreamExecute":             true,
+	"BeginStreamExecute":        true,
+	"ReserveStreamExecute":      true,
+	"ReserveBeginStreamExecute": true,
+	"MessageStream":             true,
+	"VStream":                   true,
+	"VStreamRows":               true,
+	"VStreamTables":             true,
+	"VStreamResults":            true,
+	"StreamHealth":              true,
+}
+
 // withShardError adds shard information to errors returned from the inner QueryService.
 func (gw *TabletGateway) withShardError(ctx context.Context, target *querypb.Target, conn queryservice.QueryService,
 	_ string, _ bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error)) error {
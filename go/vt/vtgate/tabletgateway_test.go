@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -169,6 +170,48 @@ func TestTabletGatewayShuffleTablets(t *testing.T) {
 	}
 }
 
+// observingBalancer picks the first tablet and counts the queries it observes.
+type observingBalancer struct {
+	observed int
+}
+
+func (b *observingBalancer) Pick(target *querypb.Target, tablets []*discovery.TabletHealth) *discovery.TabletHealth {
+	return tablets[0]
+}
+
+func (b *observingBalancer) DebugHandler(w http.ResponseWriter, r *http.Request) {}
+
+func (b *observingBalancer) QueryStarted(th *discovery.TabletHealth) func(err error) {
+	b.observed++
+	return func(err error) {}
+}
+
+func TestTabletGatewayObserverSkipsStreams(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	ts := &econtext.FakeTopoServer{}
+	tg := NewTabletGateway(ctx, hc, ts, "cell")
+	defer tg.Close(ctx)
+
+	observer := &observingBalancer{}
+	tg.balancer = observer
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc.AddTestTablet("cell", "1.1.1.1", 1001, target.Keyspace, target.Shard, target.TabletType, true, 10, nil)
+
+	_, err := tg.Execute(ctx, target, "query", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, observer.observed)
+
+	// The duration of a stream says nothing about the latency of the tablet.
+	err = tg.StreamExecute(ctx, target, "query", nil, 0, 0, nil, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, observer.observed)
+}
+
 func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -205,6 +248,13 @@ func testTabletGatewayGeneric(t *testing.T, ctx context.Context, f func(ctx cont
 	balancerVtgateCells = []string{"cell", "cell2"}
 	testTabletGatewayGenericHelper(t, ctx, f, verifyExpectedCount)
 	balancerEnabled = false
+
+	// and with the peak-ewma balancer, which also prefers the local cell as
+	// long as no tablet is known to be slower than the others
+	balancerModeFlag = "peak-ewma"
+	testTabletGatewayGenericHelper(t, ctx, f, verifyExpectedCount)
+	balancerModeFlag = ""
+	balancerVtgateCells = []string{}
 }
 
 func testTabletGatewayGenericHelper(t *testing.T, ctx context.Context, f func(ctx context.Context, tg *TabletGateway, target *querypb.Target) error, verifyExpectedCount func(t *testing.T, sc *sandboxconn.SandboxConn, want int64)) {
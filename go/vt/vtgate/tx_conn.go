@@ -46,6 +46,10 @@ const nonAtomicCommitWarnMaxShards = 16
 type TxConn struct {
 	tabletGateway *TabletGateway
 	txMode        dynamicconfig.TxMode
+
+	// onCommit, if set, is called with the session of a transaction once it
+	// is committed, even partially, before the session is reset.
+	onCommit func(session *econtext.SafeSession)
 }
 
 // NewTxConn builds a new TxConn.
@@ -132,6 +136,9 @@ func (txc *TxConn) Commit(ctx context.Context, session *econtext.SafeSession) er
 	if !session.InTransaction() {
 		return nil
 	}
+	if txc.onCommit != nil {
+		defer txc.onCommit(session)
+	}
 
 	twopc := false
 	switch session.TransactionMode {
@@ -31,6 +31,7 @@ var (
 	_ Hashing         = (*Binary)(nil)
 	_ ParamValidating = (*Binary)(nil)
 	_ Sequential      = (*Binary)(nil)
+	_ RangeMapper     = (*Binary)(nil)
 )
 
 // Binary is a vindex that converts binary bits to a keyspace id.
@@ -123,6 +124,13 @@ func (vind *Binary) RangeMap(ctx context.Context, vcursor VCursor, startId sqlty
 	return out, nil
 }
 
+// MapRange implements RangeMapper. The ids from start to end, inclusive, map
+// to a single key range. The ids are compared byte by byte, so the planner only
+// uses this for binary strings: other collations may order them differently.
+func (vind *Binary) MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) ([]key.ShardDestination, error) {
+	return mapKeyRange(vind.Hash, start, end), nil
+}
+
 // UnknownParams implements the ParamValidating interface.
 func (vind *Binary) UnknownParams() []string {
 	return vind.unknownParams
@@ -159,3 +159,40 @@ func TestBinaryRangeMap(t *testing.T) {
 	want := "DestinationKeyRange(01-10)"
 	assert.Equal(t, want, got[0].String())
 }
+
+func TestBinaryMapRange(t *testing.T) {
+	tcs := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       string
+	}{{
+		name:  "closed range includes its end",
+		start: sqltypes.NewVarBinary("\x01"),
+		end:   sqltypes.NewVarBinary("\x10"),
+		want:  "DestinationKeyRange(01-1000)",
+	}, {
+		name:  "lower bound only",
+		start: sqltypes.NewVarBinary("\x40"),
+		end:   sqltypes.NULL,
+		want:  "DestinationKeyRange(40-)",
+	}, {
+		name:  "upper bound only",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewVarBinary("\x80"),
+		want:  "DestinationKeyRange(-8000)",
+	}, {
+		name:  "empty range",
+		start: sqltypes.NewVarBinary("\x80"),
+		end:   sqltypes.NewVarBinary("\x40"),
+		want:  "DestinationNone()",
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := binOnlyVindex.(RangeMapper).MapRange(context.Background(), nil, tc.start, tc.end)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, tc.want, got[0].String())
+		})
+	}
+}
@@ -46,11 +46,13 @@ var (
 	_ WantOwnerInfo   = (*ConsistentLookupUnique)(nil)
 	_ LookupPlanable  = (*ConsistentLookupUnique)(nil)
 	_ ParamValidating = (*ConsistentLookupUnique)(nil)
+	_ LookupCaching   = (*ConsistentLookupUnique)(nil)
 	_ SingleColumn    = (*ConsistentLookup)(nil)
 	_ Lookup          = (*ConsistentLookup)(nil)
 	_ WantOwnerInfo   = (*ConsistentLookup)(nil)
 	_ LookupPlanable  = (*ConsistentLookup)(nil)
 	_ ParamValidating = (*ConsistentLookup)(nil)
+	_ LookupCaching   = (*ConsistentLookup)(nil)
 
 	consistentLookupParams = append(
 		append(make([]string, 0), lookupInternalParams...),
@@ -168,6 +170,11 @@ func (lu *ConsistentLookup) AutoCommitEnabled() bool {
 	return lu.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCaching interface.
+func (lu *ConsistentLookup) LookupCache() *LookupCache {
+	return lu.lkp.cache
+}
+
 // UnknownParams implements the ParamValidating interface.
 func (lu *ConsistentLookup) UnknownParams() []string {
 	return lu.unknownParams
@@ -273,6 +280,11 @@ func (lu *ConsistentLookupUnique) AutoCommitEnabled() bool {
 	return lu.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCaching interface.
+func (lu *ConsistentLookupUnique) LookupCache() *LookupCache {
+	return lu.lkp.cache
+}
+
 // ====================================================================
 
 // clCommon defines a vindex that uses a lookup table.
@@ -301,7 +313,7 @@ func newCLCommon(name string, m map[string]string) (*clCommon, error) {
 		return nil, err
 	}
 
-	if err := lu.lkp.Init(m, false /* autocommit */, false /* upsert */, false /* multiShardAutocommit */); err != nil {
+	if err := lu.lkp.Init(name, m, false /* autocommit */, false /* upsert */, false /* multiShardAutocommit */); err != nil {
 		return nil, err
 	}
 	return lu, nil
@@ -556,6 +556,13 @@ func (vc *loggingVCursor) LookupRowLockShardSession() vtgatepb.CommitOrder {
 	return vtgatepb.CommitOrder_PRE
 }
 
+func (vc *loggingVCursor) InTransaction() bool {
+	return false
+}
+
+func (vc *loggingVCursor) InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value) {
+}
+
 func (vc *loggingVCursor) InTransactionAndIsDML() bool {
 	return false
 }
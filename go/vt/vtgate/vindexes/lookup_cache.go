@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	lookupCacheParamSize = "cache_size"
+	lookupCacheParamTTL  = "cache_ttl"
+
+	lookupCacheDefaultSize = 10000
+)
+
+var (
+	lookupCacheHits          = stats.NewCountersWithSingleLabel("VindexLookupCacheHits", "Lookup vindex ids served from the vtgate lookup cache", "Vindex")
+	lookupCacheMisses        = stats.NewCountersWithSingleLabel("VindexLookupCacheMisses", "Lookup vindex ids not found in the vtgate lookup cache", "Vindex")
+	lookupCacheInvalidations = stats.NewCountersWithSingleLabel("VindexLookupCacheInvalidations", "Lookup vindex ids removed from the vtgate lookup cache by DMLs", "Vindex")
+)
+
+// LookupCache is a bounded, TTL-based cache of the rows returned by the
+// lookup query of a lookup vindex, keyed by the id that was looked up.
+//
+// It is enabled per vindex by setting the "cache_ttl" param to a duration, and
+// optionally "cache_size" to the maximum number of cached ids. Entries are
+// invalidated when the vindex creates or deletes rows in its lookup table, and
+// again when the transaction doing so commits, but changes made by other
+// vtgates are only picked up once entries expire, so the TTL bounds how stale
+// routing can be. Ids that are not found in the lookup
+// table are never cached, so that rows inserted elsewhere are routed correctly
+// right away.
+type LookupCache struct {
+	vindex string
+	table  string
+	ttl    time.Duration
+	now    func() time.Time
+
+	// lru is shared by all the plans that use the vindex, so it is held in
+	// an atomic.Pointer to keep it out of the cached size of plans.
+	lru atomic.Pointer[cache.LRUCache[lookupCacheEntry]]
+}
+
+type lookupCacheEntry struct {
+	rows    []sqltypes.Row
+	expires time.Time
+}
+
+// newLookupCache creates the cache of a lookup vindex from its params. It
+// returns nil if caching is not enabled.
+func newLookupCache(vindex string, params map[string]string) (*LookupCache, error) {
+	ttlStr, ok := params[lookupCacheParamTTL]
+	if !ok {
+		if _, ok := params[lookupCacheParamSize]; ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s requires %s to be set", lookupCacheParamSize, lookupCacheParamTTL)
+		}
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil || ttl <= 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s value: %s", lookupCacheParamTTL, ttlStr)
+	}
+
+	size := int64(lookupCacheDefaultSize)
+	if sizeStr, ok := params[lookupCacheParamSize]; ok {
+		size, err = strconv.ParseInt(sizeStr, 10, 64)
+		if err != nil || size <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s value: %s", lookupCacheParamSize, sizeStr)
+		}
+	}
+
+	lc := &LookupCache{
+		vindex: vindex,
+		table:  params[lookupInternalParamTable],
+		ttl:    ttl,
+		now:    time.Now,
+	}
+	lc.lru.Store(cache.NewLRUCache[lookupCacheEntry](size))
+	return lc, nil
+}
+
+// Lookup returns the lookup results of ids. The results of the ids that are
+// cached are served from the cache, the others are obtained with a single call
+// to lookup and cached. A nil cache calls lookup with all the ids.
+func (lc *LookupCache) Lookup(ids []sqltypes.Value, lookup func(ids []sqltypes.Value) ([]*sqltypes.Result, error)) ([]*sqltypes.Result, error) {
+	if lc == nil {
+		return lookup(ids)
+	}
+
+	now := lc.now()
+	lru := lc.lru.Load()
+	results := make([]*sqltypes.Result, len(ids))
+	var missing []sqltypes.Value
+	var missingIdx []int
+	for i, id := range ids {
+		if e, ok := lru.Get(lookupCacheKey(id)); ok && now.Before(e.expires) {
+			results[i] = &sqltypes.Result{Rows: e.rows}
+			continue
+		}
+		missing = append(missing, id)
+		missingIdx = append(missingIdx, i)
+	}
+	lookupCacheHits.Add(lc.vindex, int64(len(ids)-len(missing)))
+	lookupCacheMisses.Add(lc.vindex, int64(len(missing)))
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	fetched, err := lookup(missing)
+	if err != nil {
+		return nil, err
+	}
+	expires := now.Add(lc.ttl)
+	for i, result := range fetched {
+		results[missingIdx[i]] = result
+		if len(result.Rows) == 0 || missing[i].IsNull() {
+			continue
+		}
+		lru.Set(lookupCacheKey(missing[i]), lookupCacheEntry{rows: result.Rows, expires: expires})
+	}
+	return results, nil
+}
+
+// Invalidate removes ids from the cache.
+func (lc *LookupCache) Invalidate(ids []sqltypes.Value) {
+	if lc == nil {
+		return
+	}
+	lru := lc.lru.Load()
+	for _, id := range ids {
+		lru.Delete(lookupCacheKey(id))
+	}
+	lookupCacheInvalidations.Add(lc.vindex, int64(len(ids)))
+}
+
+// Table returns the lookup table of the vindex.
+func (lc *LookupCache) Table() string {
+	return lc.table
+}
+
+// Len returns the number of cached ids.
+func (lc *LookupCache) Len() int {
+	return lc.lru.Load().Len()
+}
+
+// lookupCacheKey returns the cache key of id. Ids are keyed by their string
+// representation, like the results of batched lookup queries are matched to
+// their ids.
+func lookupCacheKey(id sqltypes.Value) string {
+	return id.ToString()
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+)
+
+func createCachedLookup(t *testing.T, name string, params map[string]string) (SingleColumn, *LookupCache, *time.Time) {
+	t.Helper()
+	m := map[string]string{
+		"table": "t",
+		"from":  "fromc",
+		"to":    "toc",
+	}
+	for k, v := range params {
+		m[k] = v
+	}
+	l, err := CreateVindex("lookup", name, m)
+	require.NoError(t, err)
+	require.Empty(t, l.(ParamValidating).UnknownParams())
+
+	lc := l.(LookupCaching).LookupCache()
+	require.NotNil(t, lc)
+	now := time.Unix(1700000000, 0)
+	lc.now = func() time.Time { return now }
+	return l.(SingleColumn), lc, &now
+}
+
+func TestLookupCacheParams(t *testing.T) {
+	params := func(extra map[string]string) map[string]string {
+		m := map[string]string{"table": "t", "from": "fromc", "to": "toc"}
+		for k, v := range extra {
+			m[k] = v
+		}
+		return m
+	}
+
+	l, err := CreateVindex("lookup", "lookup", params(nil))
+	require.NoError(t, err)
+	assert.Nil(t, l.(LookupCaching).LookupCache())
+
+	l, err = CreateVindex("consistent_lookup_unique", "lookup", params(map[string]string{"cache_ttl": "1m", "cache_size": "10"}))
+	require.NoError(t, err)
+	assert.Empty(t, l.(ParamValidating).UnknownParams())
+	require.NotNil(t, l.(LookupCaching).LookupCache())
+	assert.EqualValues(t, 10, l.(LookupCaching).LookupCache().lru.Load().MaxCapacity())
+
+	_, err = CreateVindex("lookup", "lookup", params(map[string]string{"cache_ttl": "soon"}))
+	assert.EqualError(t, err, "invalid cache_ttl value: soon")
+
+	_, err = CreateVindex("lookup", "lookup", params(map[string]string{"cache_ttl": "1m", "cache_size": "0"}))
+	assert.EqualError(t, err, "invalid cache_size value: 0")
+
+	_, err = CreateVindex("lookup_hash", "lookup", params(map[string]string{"cache_size": "10"}))
+	assert.EqualError(t, err, "cache_size requires cache_ttl to be set")
+}
+
+func TestLookupCacheMap(t *testing.T) {
+	lnu, lc, now := createCachedLookup(t, "lookup_cache_map", map[string]string{"cache_ttl": "10s"})
+	vc := &vcursor{numRows: 1}
+	ctx := context.Background()
+	want := []key.ShardDestination{key.DestinationKeyspaceIDs([][]byte{[]byte("1")})}
+
+	got, err := lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Len(t, vc.queries, 1)
+	assert.Equal(t, 1, lc.Len())
+
+	// Served from the cache.
+	got, err = lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Len(t, vc.queries, 1)
+	assert.EqualValues(t, 1, lookupCacheHits.Counts()["lookup_cache_map"])
+	assert.EqualValues(t, 1, lookupCacheMisses.Counts()["lookup_cache_map"])
+
+	// Only the ids that are not cached are looked up, and ids that are not
+	// found are not cached.
+	vc.keys = []sqltypes.Value{sqltypes.NewInt64(2)}
+	got, err = lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(3)})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{want[0], key.DestinationNone{}}, got)
+	require.Len(t, vc.queries, 2)
+	wantVars, err := sqltypes.BuildBindVariable([]any{sqltypes.NewInt64(3)})
+	require.NoError(t, err)
+	assert.Equal(t, wantVars, vc.queries[1].BindVariables["fromc"])
+	assert.Equal(t, 1, lc.Len())
+
+	// Expired entries are looked up again.
+	vc.keys = nil
+	*now = now.Add(11 * time.Second)
+	_, err = lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 3)
+
+	// Transactions bypass the cache.
+	vc.inTx = true
+	_, err = lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 4)
+}
+
+func TestLookupCacheInvalidation(t *testing.T) {
+	lnu, lc, _ := createCachedLookup(t, "lookup_cache_invalidation", map[string]string{"cache_ttl": "1h"})
+	vc := &vcursor{numRows: 2}
+	ctx := context.Background()
+
+	_, err := lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lc.Len())
+
+	err = lnu.(Lookup).Delete(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, []byte("1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, lc.Len())
+
+	err = lnu.(Lookup).Create(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(2)}}, [][]byte{[]byte("2")}, false /* ignoreMode */)
+	require.NoError(t, err)
+	assert.Equal(t, 0, lc.Len())
+	assert.EqualValues(t, 2, lookupCacheInvalidations.Counts()["lookup_cache_invalidation"])
+
+	vc.queries = nil
+	_, err = lnu.Map(ctx, vc, []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 1)
+}
+
+func TestLookupCacheInvalidationOnCommit(t *testing.T) {
+	lnu, lc, _ := createCachedLookup(t, "lookup_cache_invalidation_on_commit", map[string]string{"cache_ttl": "1h"})
+	ctx := context.Background()
+
+	// Outside of a transaction, the writes are committed right away.
+	vc := &vcursor{numRows: 1}
+	err := lnu.(Lookup).Delete(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, []byte("1"))
+	require.NoError(t, err)
+	assert.Empty(t, vc.lookupWrites)
+
+	vc = &vcursor{numRows: 1, inTx: true}
+	err = lnu.(Lookup).Delete(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, []byte("1"))
+	require.NoError(t, err)
+	err = lnu.(Lookup).Create(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(2)}}, [][]byte{[]byte("2")}, false /* ignoreMode */)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]sqltypes.Value{"t": {sqltypes.NewInt64(1), sqltypes.NewInt64(2)}}, vc.lookupWrites)
+	assert.Equal(t, "t", lc.Table())
+
+	// Autocommit lookup rows are committed right away even in a transaction.
+	lnu, _, _ = createCachedLookup(t, "lookup_cache_invalidation_autocommit", map[string]string{"cache_ttl": "1h", "autocommit": "true"})
+	vc = &vcursor{numRows: 1, inTx: true}
+	err = lnu.(Lookup).Create(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(2)}}, [][]byte{[]byte("2")}, false /* ignoreMode */)
+	require.NoError(t, err)
+	assert.Empty(t, vc.lookupWrites)
+}
@@ -71,6 +71,8 @@ var (
 		lookupInternalParamIgnoreNulls,
 		lookupInternalParamBatchLookup,
 		lookupInternalParamReadLock,
+		lookupCacheParamSize,
+		lookupCacheParamTTL,
 	}
 )
 
@@ -86,9 +88,10 @@ type lookupInternal struct {
 	BatchLookup             bool     `json:"batch_lookup,omitempty"`
 	ReadLock                string   `json:"read_lock,omitempty"`
 	sel, selTxDml, ver, del string   // sel: map query, ver: verify query, del: delete query
+	cache                   *LookupCache
 }
 
-func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit, upsert, multiShardAutocommit bool) error {
+func (lkp *lookupInternal) Init(name string, lookupQueryParams map[string]string, autocommit, upsert, multiShardAutocommit bool) error {
 	lkp.Table = lookupQueryParams[lookupInternalParamTable]
 	lkp.To = lookupQueryParams[lookupInternalParamTo]
 	var fromColumns []string
@@ -112,6 +115,10 @@ func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit,
 		}
 		lkp.ReadLock = readLock
 	}
+	lkp.cache, err = newLookupCache(name, lookupQueryParams)
+	if err != nil {
+		return err
+	}
 
 	lkp.Autocommit = autocommit
 	lkp.Upsert = upsert
@@ -138,11 +145,23 @@ func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit,
 	return nil
 }
 
-// Lookup performs a lookup for the ids.
+// Lookup performs a lookup for the ids. Outside of transactions, the results
+// are served from the lookup cache if the vindex has one.
 func (lkp *lookupInternal) Lookup(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, co vtgatepb.CommitOrder) ([]*sqltypes.Result, error) {
 	if vcursor == nil {
 		return nil, vterrors.VT13001("cannot perform lookup: no vcursor provided")
 	}
+	// Transactions may see uncommitted changes to the lookup table, and DMLs
+	// need the lookup rows to be locked, so they always query the table.
+	if vcursor.InTransaction() {
+		return lkp.lookup(ctx, vcursor, ids, co)
+	}
+	return lkp.cache.Lookup(ids, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		return lkp.lookup(ctx, vcursor, ids, co)
+	})
+}
+
+func (lkp *lookupInternal) lookup(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, co vtgatepb.CommitOrder) ([]*sqltypes.Result, error) {
 	results := make([]*sqltypes.Result, 0, len(ids))
 	if lkp.Autocommit {
 		co = vtgatepb.CommitOrder_AUTOCOMMIT
@@ -306,6 +325,7 @@ nextRow:
 		return vterrors.VT03030(lkp.FromColumns, len(trimmedRowsCols[0]))
 	}
 	sort.Sort(&sorter{rowsColValues: trimmedRowsCols, toValues: trimmedToValues})
+	lkp.invalidateCache(vcursor, firstColsOnly(trimmedRowsCols), co)
 
 	insStmt := "insert"
 	if lkp.MultiShardAutocommit {
@@ -352,6 +372,20 @@ nextRow:
 	return nil
 }
 
+// invalidateCache removes the ids whose lookup rows are written from the lookup
+// cache. Rows written in a transaction are only visible to other sessions once
+// it commits, and may be cached again by them in the meantime, so the ids are
+// also removed when it commits.
+func (lkp *lookupInternal) invalidateCache(vcursor VCursor, ids []sqltypes.Value, co vtgatepb.CommitOrder) {
+	if lkp.cache == nil {
+		return
+	}
+	lkp.cache.Invalidate(ids)
+	if co != vtgatepb.CommitOrder_AUTOCOMMIT && vcursor.InTransaction() {
+		vcursor.InvalidateLookupCacheOnCommit(lkp.Table, ids)
+	}
+}
+
 // Delete deletes the association between ids and value.
 // rowsColValues contains all the rows that are being deleted.
 // For each row, we store the value of each column defined in the vindex.
@@ -381,6 +415,7 @@ func (lkp *lookupInternal) Delete(ctx context.Context, vcursor VCursor, rowsColV
 	if len(rowsColValues[0]) != len(lkp.FromColumns) {
 		return vterrors.VT03030(lkp.FromColumns, len(rowsColValues[0]))
 	}
+	lkp.invalidateCache(vcursor, firstColsOnly(rowsColValues), co)
 	for _, column := range rowsColValues {
 		bindVars := make(map[string]*querypb.BindVariable, len(rowsColValues))
 		for colIdx, columnValue := range column {
@@ -52,12 +52,26 @@ type vcursor struct {
 	autocommits int
 	pre, post   int
 	keys        []sqltypes.Value
+	inTx        bool
+	// lookupWrites are the ids to remove from the lookup caches on commit, by lookup table.
+	lookupWrites map[string][]sqltypes.Value
 }
 
 func (vc *vcursor) LookupRowLockShardSession() vtgatepb.CommitOrder {
 	panic("implement me")
 }
 
+func (vc *vcursor) InTransaction() bool {
+	return vc.inTx
+}
+
+func (vc *vcursor) InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value) {
+	if vc.lookupWrites == nil {
+		vc.lookupWrites = make(map[string][]sqltypes.Value)
+	}
+	vc.lookupWrites[table] = append(vc.lookupWrites[table], ids...)
+}
+
 func (vc *vcursor) InTransactionAndIsDML() bool {
 	return false
 }
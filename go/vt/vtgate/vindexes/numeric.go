@@ -32,6 +32,7 @@ var (
 	_ Hashing         = (*Numeric)(nil)
 	_ ParamValidating = (*Numeric)(nil)
 	_ Sequential      = (*Numeric)(nil)
+	_ RangeMapper     = (*Numeric)(nil)
 )
 
 // Numeric defines a bit-pattern mapping of a uint64 to the KeyspaceId.
@@ -123,6 +124,12 @@ func (vind *Numeric) RangeMap(ctx context.Context, vcursor VCursor, startId sqlt
 	return out, nil
 }
 
+// MapRange implements RangeMapper. The ids from start to end, inclusive, map
+// to a single key range.
+func (vind *Numeric) MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) ([]key.ShardDestination, error) {
+	return mapKeyRange(vind.Hash, start, end), nil
+}
+
 // UnknownParams implements the ParamValidating interface.
 func (vind *Numeric) UnknownParams() []string {
 	return vind.unknownParams
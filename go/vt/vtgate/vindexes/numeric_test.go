@@ -146,3 +146,58 @@ func TestNumericReverseMapBadData(t *testing.T) {
 		t.Errorf("numeric.Map: %v, want %v", err, want)
 	}
 }
+
+func TestNumericMapRange(t *testing.T) {
+	tcs := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       key.ShardDestination
+	}{{
+		name:  "closed range includes its end",
+		start: sqltypes.NewInt64(1),
+		end:   sqltypes.NewInt64(2),
+		want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange(
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 2, 0},
+		)},
+	}, {
+		name:  "lower bound only",
+		start: sqltypes.NewUint64(0x8000000000000000),
+		end:   sqltypes.NULL,
+		want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange(
+			[]byte{0x80, 0, 0, 0, 0, 0, 0, 0},
+			nil,
+		)},
+	}, {
+		name:  "upper bound only",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewVarChar("10"),
+		want: &key.DestinationKeyRange{KeyRange: key.NewKeyRange(
+			nil,
+			[]byte{0, 0, 0, 0, 0, 0, 0, 10, 0},
+		)},
+	}, {
+		name:  "empty range",
+		start: sqltypes.NewInt64(2),
+		end:   sqltypes.NewInt64(1),
+		want:  key.DestinationNone{},
+	}, {
+		name:  "negative values map to all shards",
+		start: sqltypes.NewInt64(-1),
+		end:   sqltypes.NewInt64(10),
+		want:  key.DestinationAllShards{},
+	}, {
+		name:  "non-integral values map to all shards",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewFloat64(1.5),
+		want:  key.DestinationAllShards{},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := numeric.(RangeMapper).MapRange(context.Background(), nil, tc.start, tc.end)
+			require.NoError(t, err)
+			require.Equal(t, []key.ShardDestination{tc.want}, got)
+		})
+	}
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"slices"
+	"time"
+
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	timeRangeParamBucket        = "bucket"
+	timeRangeParamBucketMap     = "bucket_map"
+	timeRangeParamBucketMapPath = "bucket_map_path"
+	timeRangeParamIntegerFormat = "integer_format"
+	timeRangeParamTimeZone      = "time_zone"
+)
+
+var (
+	_ SingleColumn    = (*TimeRange)(nil)
+	_ Hashing         = (*TimeRange)(nil)
+	_ Sequential      = (*TimeRange)(nil)
+	_ RangeMapper     = (*TimeRange)(nil)
+	_ ParamValidating = (*TimeRange)(nil)
+
+	timeRangeParams = []string{
+		timeRangeParamBucket,
+		timeRangeParamBucketMap,
+		timeRangeParamBucketMapPath,
+		timeRangeParamIntegerFormat,
+		timeRangeParamTimeZone,
+	}
+)
+
+// TimeRangeBucket is the granularity at which a TimeRange vindex maps time
+// values to keyspace ids.
+type TimeRangeBucket string
+
+const (
+	TimeRangeBucketDay   TimeRangeBucket = "day"
+	TimeRangeBucketMonth TimeRangeBucket = "month"
+	TimeRangeBucketYear  TimeRangeBucket = "year"
+)
+
+// layout returns the time layout of bucket names.
+func (b TimeRangeBucket) layout() (string, bool) {
+	switch b {
+	case TimeRangeBucketDay:
+		return "2006-01-02", true
+	case TimeRangeBucketMonth:
+		return "2006-01", true
+	case TimeRangeBucketYear:
+		return "2006", true
+	}
+	return "", false
+}
+
+// truncate returns the start of the bucket t falls in.
+func (b TimeRangeBucket) truncate(year, month, day int) time.Time {
+	switch b {
+	case TimeRangeBucketYear:
+		month, day = 1, 1
+	case TimeRangeBucketMonth:
+		day = 1
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// TimeRangeIntegerFormat is how a TimeRange vindex converts integer values to
+// dates.
+type TimeRangeIntegerFormat string
+
+const (
+	// TimeRangeIntegerFormatMySQLDate parses integers like MySQL parses integer
+	// dates: YYYYMMDDhhmmss, YYMMDDhhmmss, YYYYMMDD or YYMMDD.
+	TimeRangeIntegerFormatMySQLDate TimeRangeIntegerFormat = "mysql_date"
+	// TimeRangeIntegerFormatUnixSeconds reads integers as unix epochs in seconds.
+	TimeRangeIntegerFormatUnixSeconds TimeRangeIntegerFormat = "unix_seconds"
+)
+
+// timeRangeEntry maps all buckets starting at start, up to the start of the
+// next entry, to ksid.
+type timeRangeEntry struct {
+	start time.Time
+	ksid  []byte
+}
+
+// TimeRange is a functional unique vindex for time-partitioned sharding. It
+// maps DATETIME, TIMESTAMP and DATE values to the keyspace id of the time bucket
+// (day, month or year) they fall in. Strings are converted like MySQL does.
+//
+// Integers are converted according to the "integer_format" param. With
+// "mysql_date", the default, they are integer dates like MySQL converts them,
+// so 20240115 and '2024-01-15' are the same date. With "unix_seconds" they are
+// unix epochs in seconds, which fall in the buckets of the "time_zone" param,
+// UTC by default.
+//
+// The bucket map is a JSON object from bucket names to hex-encoded keyspace
+// ids, provided inline with "bucket_map" or as a file with "bucket_map_path":
+//
+//	{"2024-01": "40", "2024-07": "80", "2025-01": "c0"}
+//
+// Buckets that are not in the map use the keyspace id of the closest preceding
+// bucket, so every entry starts a range of buckets. Values before the first
+// bucket in the map do not map to any keyspace id.
+//
+// Since consecutive values map to consecutive ranges of buckets, range
+// predicates like BETWEEN are routed to the shards of the buckets in the range
+// only.
+type TimeRange struct {
+	name          string
+	bucket        TimeRangeBucket
+	integerFormat TimeRangeIntegerFormat
+	location      *time.Location
+	entries       []timeRangeEntry
+	unknownParams []string
+}
+
+func init() {
+	Register("time_range", newTimeRange)
+}
+
+// newTimeRange creates a TimeRange vindex.
+func newTimeRange(name string, params map[string]string) (Vindex, error) {
+	bucket := TimeRangeBucketMonth
+	if b, ok := params[timeRangeParamBucket]; ok {
+		bucket = TimeRangeBucket(b)
+	}
+	layout, ok := bucket.layout()
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid %s %q, expected one of day, month, year", timeRangeParamBucket, bucket)
+	}
+
+	integerFormat := TimeRangeIntegerFormatMySQLDate
+	if f, ok := params[timeRangeParamIntegerFormat]; ok {
+		integerFormat = TimeRangeIntegerFormat(f)
+	}
+	switch integerFormat {
+	case TimeRangeIntegerFormatMySQLDate, TimeRangeIntegerFormatUnixSeconds:
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid %s %q, expected one of mysql_date, unix_seconds", timeRangeParamIntegerFormat, integerFormat)
+	}
+
+	location := time.UTC
+	if tz, ok := params[timeRangeParamTimeZone]; ok {
+		if integerFormat != TimeRangeIntegerFormatUnixSeconds {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: %s only applies to %s %s", timeRangeParamTimeZone, timeRangeParamIntegerFormat, TimeRangeIntegerFormatUnixSeconds)
+		}
+		var err error
+		if location, err = datetime.ParseTimeZone(tz); err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid %s %q", timeRangeParamTimeZone, tz)
+		}
+	}
+
+	jsonStr, jsok := params[timeRangeParamBucketMap]
+	jsonPath, jpok := params[timeRangeParamBucketMapPath]
+	if jsok == jpok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: exactly one of `%s` or `%s` params must be set in vschema", timeRangeParamBucketMap, timeRangeParamBucketMapPath)
+	}
+
+	data := []byte(jsonStr)
+	if jpok {
+		var err error
+		if data, err = os.ReadFile(jsonPath); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := parseTimeRangeBucketMap(data, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimeRange{
+		name:          name,
+		bucket:        bucket,
+		integerFormat: integerFormat,
+		location:      location,
+		entries:       entries,
+		unknownParams: FindUnknownParams(params, timeRangeParams),
+	}, nil
+}
+
+func parseTimeRangeBucketMap(data []byte, layout string) ([]timeRangeEntry, error) {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid bucket map: %v", err)
+	}
+	if len(m) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: bucket map is empty")
+	}
+
+	entries := make([]timeRangeEntry, 0, len(m))
+	for bucket, ksid := range m {
+		start, err := time.Parse(layout, bucket)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid bucket %q, expected format %s", bucket, layout)
+		}
+		b, err := hex.DecodeString(ksid)
+		if err != nil || len(b) == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: invalid keyspace id %q for bucket %q", ksid, bucket)
+		}
+		entries = append(entries, timeRangeEntry{start: start, ksid: b})
+	}
+	slices.SortFunc(entries, func(a, b timeRangeEntry) int {
+		return a.start.Compare(b.start)
+	})
+	return entries, nil
+}
+
+// String returns the name of the vindex.
+func (vind *TimeRange) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*TimeRange) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (*TimeRange) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*TimeRange) NeedsVCursor() bool {
+	return false
+}
+
+// Verify returns true if ids and ksids match.
+func (vind *TimeRange) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	for i, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bytes.Equal(ksid, ksids[i]))
+	}
+	return out, nil
+}
+
+// Map can map ids to key.ShardDestination objects. NULL values and values
+// before the first bucket cannot match any row and map to no keyspace id;
+// values that cannot be converted to a date are an error.
+func (vind *TimeRange) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, id := range ids {
+		if id.IsNull() {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		start, err := vind.bucketStart(id)
+		if err != nil {
+			return nil, err
+		}
+		i := vind.entryIndex(start)
+		if i < 0 {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		out = append(out, key.DestinationKeyspaceID(vind.entries[i].ksid))
+	}
+	return out, nil
+}
+
+// Hash returns the keyspace id of the bucket id falls in.
+func (vind *TimeRange) Hash(id sqltypes.Value) ([]byte, error) {
+	start, err := vind.bucketStart(id)
+	if err != nil {
+		return nil, err
+	}
+	i := vind.entryIndex(start)
+	if i < 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: %v is before the first bucket", id)
+	}
+	return vind.entries[i].ksid, nil
+}
+
+// RangeMap implements Between. It returns the keyspace ids of all the buckets
+// between startId and endId, inclusive.
+func (vind *TimeRange) RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
+	start, err := vind.bucketStart(startId)
+	if err != nil {
+		return nil, err
+	}
+	end, err := vind.bucketStart(endId)
+	if err != nil {
+		return nil, err
+	}
+	return []key.ShardDestination{vind.keyspaceIDsBetween(start, end)}, nil
+}
+
+// MapRange implements RangeMapper. It returns the keyspace ids of all the
+// buckets between start and end, inclusive. A NULL start or end extends the
+// range to the first or last bucket.
+func (vind *TimeRange) MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) ([]key.ShardDestination, error) {
+	first := vind.entries[0].start
+	if !start.IsNull() {
+		var err error
+		if first, err = vind.bucketStart(start); err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+	}
+	// All buckets from the last entry on map to it.
+	last := vind.entries[len(vind.entries)-1].start
+	if last.Before(first) {
+		last = first
+	}
+	if !end.IsNull() {
+		var err error
+		if last, err = vind.bucketStart(end); err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}, nil
+		}
+	}
+	return []key.ShardDestination{vind.keyspaceIDsBetween(first, last)}, nil
+}
+
+// keyspaceIDsBetween returns the distinct keyspace ids of the buckets from
+// start to end, inclusive.
+func (vind *TimeRange) keyspaceIDsBetween(start, end time.Time) key.ShardDestination {
+	if end.Before(start) {
+		return key.DestinationNone{}
+	}
+	first := max(vind.entryIndex(start), 0)
+	last := vind.entryIndex(end)
+	if last < 0 {
+		return key.DestinationNone{}
+	}
+
+	var ksids key.DestinationKeyspaceIDs
+	for _, e := range vind.entries[first : last+1] {
+		if !slices.ContainsFunc(ksids, func(ksid []byte) bool { return bytes.Equal(ksid, e.ksid) }) {
+			ksids = append(ksids, e.ksid)
+		}
+	}
+	return ksids
+}
+
+// entryIndex returns the index of the entry that the bucket starting at start
+// maps to, or -1 if start is before the first entry.
+func (vind *TimeRange) entryIndex(start time.Time) int {
+	i, found := slices.BinarySearchFunc(vind.entries, start, func(e timeRangeEntry, t time.Time) int {
+		return e.start.Compare(t)
+	})
+	if found {
+		return i
+	}
+	return i - 1
+}
+
+// bucketStart returns the start of the bucket that id falls in. Integer
+// values are converted according to the integer format of the vindex, all
+// others are parsed as DATETIME or DATE strings.
+func (vind *TimeRange) bucketStart(id sqltypes.Value) (time.Time, error) {
+	if id.IsIntegral() {
+		if i, err := id.ToInt64(); err == nil {
+			if vind.integerFormat == TimeRangeIntegerFormatUnixSeconds {
+				t := time.Unix(i, 0).In(vind.location)
+				return vind.bucket.truncate(t.Year(), int(t.Month()), t.Day()), nil
+			}
+			if dt, ok := datetime.ParseDateTimeInt64(i); ok && !dt.Date.IsZero() {
+				return vind.bucket.truncate(dt.Date.Year(), dt.Date.Month(), dt.Date.Day()), nil
+			}
+			if d, ok := datetime.ParseDateInt64(i); ok && !d.IsZero() {
+				return vind.bucket.truncate(d.Year(), d.Month(), d.Day()), nil
+			}
+		}
+		return time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: cannot convert %v to a date and time", id)
+	}
+
+	s := id.ToString()
+	if dt, _, ok := datetime.ParseDateTime(s, -1); ok && !dt.Date.IsZero() {
+		return vind.bucket.truncate(dt.Date.Year(), dt.Date.Month(), dt.Date.Day()), nil
+	}
+	if d, ok := datetime.ParseDate(s); ok && !d.IsZero() {
+		return vind.bucket.truncate(d.Year(), d.Month(), d.Day()), nil
+	}
+	return time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TimeRange: cannot convert %v to a date and time", id)
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (vind *TimeRange) UnknownParams() []string {
+	return vind.unknownParams
+}
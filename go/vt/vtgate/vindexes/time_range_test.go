@@ -0,0 +1,417 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const timeRangeTestBucketMap = `{"2024-01": "10", "2024-03": "50", "2024-06": "90", "2025-01": "10"}`
+
+func createTimeRangeVindex(t *testing.T, params map[string]string) *TimeRange {
+	t.Helper()
+	vindex, err := CreateVindex("time_range", "time_range", params)
+	require.NoError(t, err)
+	return vindex.(*TimeRange)
+}
+
+func timeRangeCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "time_range",
+		vindexName:   "time_range",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "time_range",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestTimeRangeCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		timeRangeCreateVindexTestCase(
+			"no bucket map",
+			map[string]string{},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: exactly one of `bucket_map` or `bucket_map_path` params must be set in vschema"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"bucket_map and bucket_map_path mutually exclusive",
+			map[string]string{
+				"bucket_map":      timeRangeTestBucketMap,
+				"bucket_map_path": "/path/to/map.json",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: exactly one of `bucket_map` or `bucket_map_path` params must be set in vschema"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"bucket_map_path must exist",
+			map[string]string{
+				"bucket_map_path": "/path/to/map.json",
+			},
+			errors.New("open /path/to/map.json: no such file or directory"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"bucket_map ok",
+			map[string]string{
+				"bucket_map": timeRangeTestBucketMap,
+			},
+			nil,
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"bucket_map must not be empty",
+			map[string]string{
+				"bucket_map": "{}",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: bucket map is empty"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"bucket names must match the bucket",
+			map[string]string{
+				"bucket":     "day",
+				"bucket_map": `{"2024-01": "10"}`,
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: invalid bucket \"2024-01\", expected format 2006-01-02"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"keyspace ids must be hex",
+			map[string]string{
+				"bucket_map": `{"2024-01": "zz"}`,
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: invalid keyspace id \"zz\" for bucket \"2024-01\""),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"invalid bucket",
+			map[string]string{
+				"bucket":     "week",
+				"bucket_map": timeRangeTestBucketMap,
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: invalid bucket \"week\", expected one of day, month, year"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"invalid integer_format",
+			map[string]string{
+				"bucket_map":     timeRangeTestBucketMap,
+				"integer_format": "unix_millis",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: invalid integer_format \"unix_millis\", expected one of mysql_date, unix_seconds"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"time_zone requires unix_seconds",
+			map[string]string{
+				"bucket_map": timeRangeTestBucketMap,
+				"time_zone":  "+02:00",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: time_zone only applies to integer_format unix_seconds"),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"invalid time_zone",
+			map[string]string{
+				"bucket_map":     timeRangeTestBucketMap,
+				"integer_format": "unix_seconds",
+				"time_zone":      "Mars/Olympus_Mons",
+			},
+			vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TimeRange: invalid time_zone \"Mars/Olympus_Mons\""),
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"unix_seconds ok",
+			map[string]string{
+				"bucket_map":     timeRangeTestBucketMap,
+				"integer_format": "unix_seconds",
+				"time_zone":      "America/New_York",
+			},
+			nil,
+			nil,
+		),
+		timeRangeCreateVindexTestCase(
+			"unknown params",
+			map[string]string{
+				"bucket_map": timeRangeTestBucketMap,
+				"hello":      "world",
+			},
+			nil,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func TestTimeRangeMap(t *testing.T) {
+	vindex := createTimeRangeVindex(t, map[string]string{"bucket_map": timeRangeTestBucketMap})
+
+	got, err := vindex.Map(context.Background(), nil, []sqltypes.Value{
+		sqltypes.NewDatetime("2024-01-15 10:00:00"),
+		sqltypes.NewVarChar("2024-02-29"),
+		sqltypes.NewTimestamp("2024-03-01 00:00:00"),
+		sqltypes.NewDate("2024-12-31"),
+		sqltypes.NewVarChar("2025-06-01 12:34:56.789"),
+		sqltypes.NewInt64(20240601123456),
+		sqltypes.NewInt64(20240301),
+		sqltypes.NewUint64(240115),
+		sqltypes.NewVarChar("2023-12-31 23:59:59"),
+		sqltypes.NULL,
+	})
+	require.NoError(t, err)
+
+	want := []key.ShardDestination{
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationKeyspaceID([]byte{0x50}),
+		key.DestinationKeyspaceID([]byte{0x90}),
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationKeyspaceID([]byte{0x90}),
+		key.DestinationKeyspaceID([]byte{0x50}),
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+
+	_, err = vindex.Map(context.Background(), nil, []sqltypes.Value{sqltypes.NewVarChar("not a date")})
+	require.EqualError(t, err, "TimeRange: cannot convert VARCHAR(\"not a date\") to a date and time")
+
+	// Integers are MySQL integer dates by default, not unix epochs.
+	_, err = vindex.Map(context.Background(), nil, []sqltypes.Value{sqltypes.NewInt64(1717200000)})
+	require.EqualError(t, err, "TimeRange: cannot convert INT64(1717200000) to a date and time")
+}
+
+func TestTimeRangeUnixSeconds(t *testing.T) {
+	vindex := createTimeRangeVindex(t, map[string]string{
+		"bucket_map":     timeRangeTestBucketMap,
+		"integer_format": "unix_seconds",
+	})
+
+	got, err := vindex.Map(context.Background(), nil, []sqltypes.Value{
+		sqltypes.NewInt64(1705312800),  // 2024-01-15 10:00:00 UTC
+		sqltypes.NewUint64(1717200000), // 2024-06-01 00:00:00 UTC
+		sqltypes.NewInt64(1709251199),  // 2024-02-29 23:59:59 UTC
+		sqltypes.NewInt64(0),           // 1970-01-01 00:00:00 UTC
+		sqltypes.NewDatetime("2024-03-01 00:00:00"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationKeyspaceID([]byte{0x90}),
+		key.DestinationKeyspaceID([]byte{0x10}),
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte{0x50}),
+	}, got)
+
+	// Integer dates are epochs in this format.
+	_, err = vindex.Hash(sqltypes.NewInt64(20240301))
+	require.EqualError(t, err, "TimeRange: INT64(20240301) is before the first bucket")
+
+	got, err = vindex.RangeMap(context.Background(), nil, sqltypes.NewInt64(1706745600), sqltypes.NewInt64(1717199999))
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{key.DestinationKeyspaceIDs{{0x10}, {0x50}}}, got)
+
+	// Epochs fall in the buckets of the time zone of the vindex.
+	vindex = createTimeRangeVindex(t, map[string]string{
+		"bucket_map":     timeRangeTestBucketMap,
+		"integer_format": "unix_seconds",
+		"time_zone":      "+02:00",
+	})
+	ksid, err := vindex.Hash(sqltypes.NewInt64(1709251199)) // 2024-03-01 01:59:59 +02:00
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x50}, ksid)
+}
+
+func TestTimeRangeBuckets(t *testing.T) {
+	tcs := []struct {
+		bucket    string
+		bucketMap string
+		value     sqltypes.Value
+		ksid      []byte
+	}{{
+		bucket:    "day",
+		bucketMap: `{"2024-01-01": "10", "2024-01-02": "20"}`,
+		value:     sqltypes.NewDatetime("2024-01-01 23:59:59"),
+		ksid:      []byte{0x10},
+	}, {
+		bucket:    "day",
+		bucketMap: `{"2024-01-01": "10", "2024-01-02": "20"}`,
+		value:     sqltypes.NewDatetime("2024-01-02 00:00:00"),
+		ksid:      []byte{0x20},
+	}, {
+		bucket:    "year",
+		bucketMap: `{"2023": "10", "2024": "20"}`,
+		value:     sqltypes.NewDate("2023-12-31"),
+		ksid:      []byte{0x10},
+	}, {
+		bucket:    "year",
+		bucketMap: `{"2023": "10", "2024": "20"}`,
+		value:     sqltypes.NewDate("2030-01-01"),
+		ksid:      []byte{0x20},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.bucket+"/"+tc.value.ToString(), func(t *testing.T) {
+			vindex := createTimeRangeVindex(t, map[string]string{"bucket": tc.bucket, "bucket_map": tc.bucketMap})
+			ksid, err := vindex.Hash(tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ksid, ksid)
+		})
+	}
+}
+
+func TestTimeRangeVerify(t *testing.T) {
+	vindex := createTimeRangeVindex(t, map[string]string{"bucket_map": timeRangeTestBucketMap})
+
+	got, err := vindex.Verify(context.Background(), nil,
+		[]sqltypes.Value{sqltypes.NewVarChar("2024-01-15"), sqltypes.NewVarChar("2024-04-15")},
+		[][]byte{{0x10}, {0x10}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, got)
+
+	_, err = vindex.Verify(context.Background(), nil, []sqltypes.Value{sqltypes.NewVarChar("2000-01-01")}, [][]byte{{0x10}})
+	require.EqualError(t, err, "TimeRange: VARCHAR(\"2000-01-01\") is before the first bucket")
+}
+
+func TestTimeRangeRangeMap(t *testing.T) {
+	vindex := createTimeRangeVindex(t, map[string]string{"bucket_map": timeRangeTestBucketMap})
+
+	tcs := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       key.ShardDestination
+	}{{
+		name:  "single bucket",
+		start: sqltypes.NewVarChar("2024-01-01"),
+		end:   sqltypes.NewVarChar("2024-01-31 23:59:59"),
+		want:  key.DestinationKeyspaceIDs{{0x10}},
+	}, {
+		name:  "buckets mapped to the same entry",
+		start: sqltypes.NewVarChar("2024-03-01"),
+		end:   sqltypes.NewVarChar("2024-05-31"),
+		want:  key.DestinationKeyspaceIDs{{0x50}},
+	}, {
+		name:  "multiple entries",
+		start: sqltypes.NewVarChar("2024-02-15"),
+		end:   sqltypes.NewVarChar("2024-06-15"),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}, {0x90}},
+	}, {
+		name:  "duplicate keyspace ids are removed",
+		start: sqltypes.NewVarChar("2024-01-01"),
+		end:   sqltypes.NewVarChar("2025-12-31"),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}, {0x90}},
+	}, {
+		name:  "start before the first bucket",
+		start: sqltypes.NewVarChar("2000-01-01"),
+		end:   sqltypes.NewVarChar("2024-03-01"),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}},
+	}, {
+		name:  "end before the first bucket",
+		start: sqltypes.NewVarChar("2000-01-01"),
+		end:   sqltypes.NewVarChar("2001-01-01"),
+		want:  key.DestinationNone{},
+	}, {
+		name:  "empty range",
+		start: sqltypes.NewVarChar("2024-06-01"),
+		end:   sqltypes.NewVarChar("2024-01-01"),
+		want:  key.DestinationNone{},
+	}, {
+		name:  "integer dates",
+		start: sqltypes.NewInt64(20240101000000),
+		end:   sqltypes.NewInt64(20240301),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := vindex.RangeMap(context.Background(), nil, tc.start, tc.end)
+			require.NoError(t, err)
+			assert.Equal(t, []key.ShardDestination{tc.want}, got)
+		})
+	}
+
+	_, err := vindex.RangeMap(context.Background(), nil, sqltypes.NewVarChar("yesterday"), sqltypes.NewVarChar("2024-01-01"))
+	require.EqualError(t, err, "TimeRange: cannot convert VARCHAR(\"yesterday\") to a date and time")
+}
+
+func TestTimeRangeMapRange(t *testing.T) {
+	vindex := createTimeRangeVindex(t, map[string]string{"bucket_map": timeRangeTestBucketMap})
+
+	tcs := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       key.ShardDestination
+	}{{
+		name:  "closed range",
+		start: sqltypes.NewVarChar("2024-02-15"),
+		end:   sqltypes.NewVarChar("2024-03-15"),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}},
+	}, {
+		name:  "lower bound only",
+		start: sqltypes.NewVarChar("2024-05-01"),
+		end:   sqltypes.NULL,
+		want:  key.DestinationKeyspaceIDs{{0x50}, {0x90}, {0x10}},
+	}, {
+		name:  "lower bound after the last bucket",
+		start: sqltypes.NewVarChar("2030-01-01"),
+		end:   sqltypes.NULL,
+		want:  key.DestinationKeyspaceIDs{{0x10}},
+	}, {
+		name:  "upper bound only",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewVarChar("2024-04-01"),
+		want:  key.DestinationKeyspaceIDs{{0x10}, {0x50}},
+	}, {
+		name:  "invalid bound maps to all shards",
+		start: sqltypes.NewVarChar("yesterday"),
+		end:   sqltypes.NULL,
+		want:  key.DestinationAllShards{},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := vindex.MapRange(context.Background(), nil, tc.start, tc.end)
+			require.NoError(t, err)
+			assert.Equal(t, []key.ShardDestination{tc.want}, got)
+		})
+	}
+}
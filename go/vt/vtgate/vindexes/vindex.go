@@ -17,6 +17,7 @@ limitations under the License.
 package vindexes
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
@@ -42,7 +43,11 @@ type (
 	VCursor interface {
 		Execute(ctx context.Context, method string, query string, bindvars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		ExecuteKeyspaceID(ctx context.Context, keyspace string, ksid []byte, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError, autocommit bool) (*sqltypes.Result, error)
+		InTransaction() bool
 		InTransactionAndIsDML() bool
+		// InvalidateLookupCacheOnCommit removes ids written to the lookup table from
+		// the lookup caches when the current transaction commits.
+		InvalidateLookupCacheOnCommit(table string, ids []sqltypes.Value)
 		LookupRowLockShardSession() vtgatepb.CommitOrder
 		ConnCollation() collations.ID
 		Environment() *vtenv.Environment
@@ -137,6 +142,18 @@ type (
 		RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error)
 	}
 
+	// A RangeMapper is a Sequential vindex whose keyspace ids preserve the order
+	// of its ids, so that range predicates like '<', '>=' and 'BETWEEN' can be
+	// routed to the shards covering the range only.
+	//
+	// MapRange maps the ids from start to end, both inclusive. A NULL start or
+	// end leaves that side of the range unbounded. Ids that cannot be mapped
+	// must not fail the query, they should map to all shards instead.
+	RangeMapper interface {
+		Sequential
+		MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) ([]key.ShardDestination, error)
+	}
+
 	// A Prefixable vindex is one that maps the prefix of a id to a keyspace range
 	// instead of a single keyspace id. It's being used to reduced the fan out for
 	// 'LIKE' expressions.
@@ -174,6 +191,14 @@ type (
 		AutoCommitEnabled() bool
 	}
 
+	// LookupCaching is implemented by lookup vindexes that can cache the
+	// results of their lookup query in vtgate.
+	LookupCaching interface {
+		// LookupCache returns the cache of the vindex, or nil if caching is
+		// not enabled.
+		LookupCache() *LookupCache
+	}
+
 	// LookupBackfill interfaces all lookup vindexes that can backfill rows, such as LookupUnique.
 	LookupBackfill interface {
 		IsBackfilling() bool
@@ -245,6 +270,33 @@ func firstColsOnly(rowsColValues [][]sqltypes.Value) []sqltypes.Value {
 	return firstCols
 }
 
+// mapKeyRange implements MapRange for vindexes whose keyspace ids are
+// computed by an order-preserving hash. NULL bounds leave the key range open
+// on that side, and ids that cannot be hashed map to all shards.
+func mapKeyRange(hash func(sqltypes.Value) ([]byte, error), start, end sqltypes.Value) []key.ShardDestination {
+	var startKsID, endKsID []byte
+	if !start.IsNull() {
+		ksid, err := hash(start)
+		if err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}
+		}
+		startKsID = ksid
+	}
+	if !end.IsNull() {
+		ksid, err := hash(end)
+		if err != nil {
+			return []key.ShardDestination{key.DestinationAllShards{}}
+		}
+		// Key ranges exclude their end, so use the smallest keyspace id after
+		// the one of end to include it.
+		endKsID = append(ksid[:len(ksid):len(ksid)], 0)
+	}
+	if startKsID != nil && endKsID != nil && bytes.Compare(startKsID, endKsID) >= 0 {
+		return []key.ShardDestination{key.DestinationNone{}}
+	}
+	return []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(startKsID, endKsID)}}
+}
+
 // FindUnknownParams a sorted slice of keys in params that are not present in knownParams.
 func FindUnknownParams(params map[string]string, knownParams []string) []string {
 	var unknownParams []string
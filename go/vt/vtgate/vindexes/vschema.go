@@ -30,6 +30,7 @@ import (
 	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
@@ -62,8 +63,11 @@ const (
 // VSchema represents the denormalized version of SrvVSchema,
 // used for building routing plans.
 type VSchema struct {
-	MirrorRules  map[string]*MirrorRule  `json:"mirror_rules"`
-	RoutingRules map[string]*RoutingRule `json:"routing_rules"`
+	MirrorRules               map[string]*MirrorRule               `json:"mirror_rules"`
+	RoutingRules              map[string]*RoutingRule              `json:"routing_rules"`
+	WeightedRoutingRules      map[string]*WeightedRoutingRule      `json:"weighted_routing_rules,omitempty"`
+	WeightedShardRoutingRules map[string]*WeightedShardRoutingRule `json:"weighted_shard_routing_rules,omitempty"`
+	QueryDenylist             *vschemapb.QueryDenylist             `json:"query_denylist,omitempty"`
 
 	// globalTables contains the name of all tables in all keyspaces. If the
 	// table is uniquely named, the value will be the qualified Table object
@@ -100,6 +104,64 @@ func (mr *MirrorRule) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// WeightedRoutingRule represents one weighted routing rule: a percentage of
+// the reads of FromTable are routed to Table instead.
+type WeightedRoutingRule struct {
+	Error           error
+	FromTable       string     `json:"from_table,omitempty"`
+	Percent         float32    `json:"percent,omitempty"`
+	MaxErrorPercent float32    `json:"max_error_percent,omitempty"`
+	Table           *BaseTable `json:"table,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of WeightedRoutingRule.
+func (wr *WeightedRoutingRule) MarshalJSON() ([]byte, error) {
+	if wr.Error != nil {
+		return json.Marshal(wr.Error.Error())
+	}
+	return json.Marshal(struct {
+		Percent         float32
+		MaxErrorPercent float32 `json:",omitempty"`
+		Table           *BaseTable
+	}{
+		Percent:         wr.Percent,
+		MaxErrorPercent: wr.MaxErrorPercent,
+		Table:           wr.Table,
+	})
+}
+
+// WeightedShardRoutingRule represents one weighted shard routing rule: a
+// percentage of the reads of FromKeyspace are resolved against Shards instead
+// of the shards serving them.
+type WeightedShardRoutingRule struct {
+	Error           error
+	FromKeyspace    string                       `json:"from_keyspace,omitempty"`
+	Keyspace        string                       `json:"keyspace,omitempty"`
+	Percent         float32                      `json:"percent,omitempty"`
+	MaxErrorPercent float32                      `json:"max_error_percent,omitempty"`
+	Shards          []*topodatapb.ShardReference `json:"shards,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of WeightedShardRoutingRule.
+func (wr *WeightedShardRoutingRule) MarshalJSON() ([]byte, error) {
+	if wr.Error != nil {
+		return json.Marshal(wr.Error.Error())
+	}
+	shards := make([]string, 0, len(wr.Shards))
+	for _, shard := range wr.Shards {
+		shards = append(shards, shard.Name)
+	}
+	return json.Marshal(struct {
+		Percent         float32
+		MaxErrorPercent float32 `json:",omitempty"`
+		Shards          []string
+	}{
+		Percent:         wr.Percent,
+		MaxErrorPercent: wr.MaxErrorPercent,
+		Shards:          shards,
+	})
+}
+
 // RoutingRule represents one routing rule.
 type RoutingRule struct {
 	Tables []*BaseTable
@@ -138,6 +200,8 @@ type BaseTable struct {
 	Columns                 []Column               `json:"columns,omitempty"`
 	Pinned                  []byte                 `json:"pinned,omitempty"`
 	ColumnListAuthoritative bool                   `json:"column_list_authoritative,omitempty"`
+	// ResultCacheTTL is how long vtgate caches the results of read-only queries on this table, 0 means no caching.
+	ResultCacheTTL time.Duration `json:"result_cache_ttl,omitempty"`
 	// ReferencedBy is an inverse mapping of tables in other keyspaces that
 	// reference this table via Source.
 	//
@@ -368,6 +432,9 @@ func BuildVSchema(source *vschemapb.SrvVSchema, parser *sqlparser.Parser) (vsche
 	buildShardRoutingRule(source, vschema)
 	buildKeyspaceRoutingRule(source, vschema)
 	buildMirrorRule(source, vschema, parser)
+	buildWeightedRoutingRule(source, vschema, parser)
+	buildWeightedShardRoutingRule(source, vschema)
+	vschema.QueryDenylist = source.QueryDenylist
 	// Resolve auto-increments after routing rules are built since sequence tables also obey routing rules.
 	resolveAutoIncrement(source, vschema, parser)
 	return vschema
@@ -735,6 +802,18 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			}
 			t.Pinned = decoded
 		}
+		if table.ResultCacheTtl != "" {
+			ttl, err := time.ParseDuration(table.ResultCacheTtl)
+			if err != nil || ttl < 0 {
+				return vterrors.Errorf(
+					vtrpcpb.Code_INVALID_ARGUMENT,
+					"invalid result cache ttl %q for table: %s",
+					table.ResultCacheTtl,
+					tname,
+				)
+			}
+			t.ResultCacheTTL = ttl
+		}
 
 		// If keyspace is sharded, then any table that's not a reference or pinned must have vindexes.
 		if keyspace.Sharded && t.Type != TypeReference && table.Pinned == "" && len(table.ColumnVindexes) == 0 {
@@ -1315,6 +1394,156 @@ func buildMirrorRule(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sql
 	}
 }
 
+func buildWeightedRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sqlparser.Parser) {
+	sourceRules := source.GetWeightedRoutingRules().GetRules()
+	if len(sourceRules) == 0 {
+		return
+	}
+	vschema.WeightedRoutingRules = make(map[string]*WeightedRoutingRule, len(sourceRules))
+	for _, rule := range sourceRules {
+		if _, ok := vschema.WeightedRoutingRules[rule.FromTable]; ok {
+			vschema.WeightedRoutingRules[rule.FromTable] = &WeightedRoutingRule{
+				Error: vterrors.Errorf(
+					vtrpcpb.Code_ALREADY_EXISTS,
+					"from table: duplicate rule for entry '%s'",
+					rule.FromTable,
+				),
+			}
+			continue
+		}
+		t, err := findWeightedRoutingRuleTable(rule, vschema, parser)
+		if err != nil {
+			vschema.WeightedRoutingRules[rule.FromTable] = &WeightedRoutingRule{Error: err}
+			continue
+		}
+		vschema.WeightedRoutingRules[rule.FromTable] = &WeightedRoutingRule{
+			FromTable:       rule.FromTable,
+			Percent:         rule.Percent,
+			MaxErrorPercent: rule.MaxErrorPercent,
+			Table:           t,
+		}
+	}
+}
+
+func buildWeightedShardRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	sourceRules := source.GetWeightedRoutingRules().GetShardRules()
+	if len(sourceRules) == 0 {
+		return
+	}
+	vschema.WeightedShardRoutingRules = make(map[string]*WeightedShardRoutingRule, len(sourceRules))
+	for _, rule := range sourceRules {
+		if _, ok := vschema.WeightedShardRoutingRules[rule.FromKeyspace]; ok {
+			vschema.WeightedShardRoutingRules[rule.FromKeyspace] = &WeightedShardRoutingRule{
+				Error: vterrors.Errorf(
+					vtrpcpb.Code_ALREADY_EXISTS,
+					"from keyspace: duplicate rule for entry '%s'",
+					rule.FromKeyspace,
+				),
+			}
+			continue
+		}
+		keyspace, err := validateWeightedShardRoutingRule(rule, vschema)
+		if err != nil {
+			vschema.WeightedShardRoutingRules[rule.FromKeyspace] = &WeightedShardRoutingRule{Error: err}
+			continue
+		}
+		vschema.WeightedShardRoutingRules[rule.FromKeyspace] = &WeightedShardRoutingRule{
+			FromKeyspace:    rule.FromKeyspace,
+			Keyspace:        keyspace,
+			Percent:         rule.Percent,
+			MaxErrorPercent: rule.MaxErrorPercent,
+			Shards:          rule.ToShards,
+		}
+	}
+}
+
+// validateWeightedShardRoutingRule validates a weighted shard routing rule
+// and returns the keyspace it routes. Weighted routing only splits reads, so
+// the from keyspace must name a replica or rdonly tablet type, and the shards
+// must cover the whole keyspace so that every keyspace id resolves to one of
+// them.
+func validateWeightedShardRoutingRule(rule *vschemapb.WeightedShardRoutingRule, vschema *VSchema) (string, error) {
+	if rule.Percent <= 0 || rule.Percent > 100 {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent must be between 0 and 100: %v", rule.Percent)
+	}
+	if rule.MaxErrorPercent < 0 || rule.MaxErrorPercent > 100 {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "max error percent must be between 0 and 100: %v", rule.MaxErrorPercent)
+	}
+
+	keyspace, tabletTypeSuffix, ok := strings.Cut(rule.FromKeyspace, "@")
+	if !ok || ("@"+tabletTypeSuffix != TabletTypeSuffix[topodatapb.TabletType_REPLICA] && "@"+tabletTypeSuffix != TabletTypeSuffix[topodatapb.TabletType_RDONLY]) {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from keyspace: must specify a replica or rdonly tablet type: '%s'", rule.FromKeyspace)
+	}
+	if _, ok := vschema.Keyspaces[keyspace]; !ok {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from keyspace: keyspace '%s' not found in vschema", keyspace)
+	}
+
+	if len(rule.ToShards) == 0 {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to shards: no shards specified")
+	}
+	shards := append([]*topodatapb.ShardReference(nil), rule.ToShards...)
+	sort.Slice(shards, func(i, j int) bool {
+		return key.KeyRangeLess(shards[i].KeyRange, shards[j].KeyRange)
+	})
+	if len(shards[0].GetKeyRange().GetStart()) > 0 || len(shards[len(shards)-1].GetKeyRange().GetEnd()) > 0 {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to shards: must cover the whole keyspace")
+	}
+	for i := 1; i < len(shards); i++ {
+		if !key.KeyRangeContiguous(shards[i-1].KeyRange, shards[i].KeyRange) {
+			return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to shards: must cover the whole keyspace: %s and %s are not contiguous", shards[i-1].Name, shards[i].Name)
+		}
+	}
+	return keyspace, nil
+}
+
+// findWeightedRoutingRuleTable validates a weighted routing rule and returns
+// the table it routes to. Weighted routing only splits reads, so the from
+// table must name a replica or rdonly tablet type.
+func findWeightedRoutingRuleTable(rule *vschemapb.WeightedRoutingRule, vschema *VSchema, parser *sqlparser.Parser) (*BaseTable, error) {
+	if rule.Percent <= 0 || rule.Percent > 100 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "percent must be between 0 and 100: %v", rule.Percent)
+	}
+	if rule.MaxErrorPercent < 0 || rule.MaxErrorPercent > 100 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "max error percent must be between 0 and 100: %v", rule.MaxErrorPercent)
+	}
+
+	fromTable, tabletTypeSuffix, ok := strings.Cut(rule.FromTable, "@")
+	if !ok || ("@"+tabletTypeSuffix != TabletTypeSuffix[topodatapb.TabletType_REPLICA] && "@"+tabletTypeSuffix != TabletTypeSuffix[topodatapb.TabletType_RDONLY]) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from table: must specify a replica or rdonly tablet type: '%s'", rule.FromTable)
+	}
+	fromTable, err := escapeQualifiedTable(fromTable)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from table: %s", err.Error())
+	}
+	fromKeyspace, fromTableName, err := parser.ParseTable(fromTable)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from table: invalid table name: '%s'", err.Error())
+	}
+	if _, err := vschema.FindTable(fromKeyspace, fromTableName); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "from table: %s", err.Error())
+	}
+
+	if strings.Contains(rule.ToTable, "@") {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to table: tablet type may not be specified: '%s'", rule.ToTable)
+	}
+	toTable, err := escapeQualifiedTable(rule.ToTable)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to table: %s", err.Error())
+	}
+	toKeyspace, toTableName, err := parser.ParseTable(toTable)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to table: invalid table name: '%s'", rule.ToTable)
+	}
+	if fromKeyspace == toKeyspace {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to table: cannot reside in same keyspace as from table")
+	}
+	t, err := vschema.FindTable(toKeyspace, toTableName)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "to table: %s", err.Error())
+	}
+	return t, nil
+}
+
 // FindTable returns a pointer to the Table. If a keyspace is specified, only tables
 // from that keyspace are searched. If the specified keyspace is unsharded
 // and no tables matched, it's considered valid: FindTable will construct a table
@@ -1629,6 +1858,42 @@ func (vschema *VSchema) FindMirrorRule(keyspace, tablename string, tabletType to
 	return nil, nil
 }
 
+// FindWeightedRoutingRule finds a weighted routing rule from the keyspace,
+// table name and tablet type.
+func (vschema *VSchema) FindWeightedRoutingRule(keyspace, tablename string, tabletType topodatapb.TabletType) (*WeightedRoutingRule, error) {
+	if len(vschema.WeightedRoutingRules) == 0 {
+		return nil, nil
+	}
+	qualified := tablename
+	if keyspace != "" {
+		qualified = keyspace + "." + tablename
+	}
+	wr, ok := vschema.WeightedRoutingRules[qualified+TabletTypeSuffix[tabletType]]
+	if !ok {
+		return nil, nil
+	}
+	if wr.Error != nil {
+		return nil, wr.Error
+	}
+	return wr, nil
+}
+
+// FindWeightedShardRoutingRule finds a weighted shard routing rule from the
+// keyspace and tablet type.
+func (vschema *VSchema) FindWeightedShardRoutingRule(keyspace string, tabletType topodatapb.TabletType) (*WeightedShardRoutingRule, error) {
+	if len(vschema.WeightedShardRoutingRules) == 0 {
+		return nil, nil
+	}
+	wr, ok := vschema.WeightedShardRoutingRules[keyspace+TabletTypeSuffix[tabletType]]
+	if !ok {
+		return nil, nil
+	}
+	if wr.Error != nil {
+		return nil, wr.Error
+	}
+	return wr, nil
+}
+
 // ByCost provides the interface needed for ColumnVindexes to
 // be sorted by cost order.
 type ByCost []*ColumnVindex
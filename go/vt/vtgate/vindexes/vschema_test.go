@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -612,6 +613,25 @@ func TestVSchemaPinned(t *testing.T) {
 	assert.Equal(t, "\x80", string(t1.Pinned))
 }
 
+func TestVSchemaResultCacheTTL(t *testing.T) {
+	good := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"unsharded": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ResultCacheTtl: "5s"}}}}}
+
+	got := BuildVSchema(&good, sqlparser.NewTestParser())
+	require.NoError(t, got.Keyspaces["unsharded"].Error)
+	t1, err := got.FindTable("unsharded", "t1")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, t1.ResultCacheTTL)
+
+	good.Keyspaces["unsharded"].Tables["t1"].ResultCacheTtl = "5"
+	got = BuildVSchema(&good, sqlparser.NewTestParser())
+	require.EqualError(t, got.Keyspaces["unsharded"].Error, `invalid result cache ttl "5" for table: t1`)
+}
+
 func TestShardedVSchemaOwned(t *testing.T) {
 	good := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -1022,6 +1042,140 @@ func TestVSchemaMirrorRules(t *testing.T) {
 	assert.Equal(t, string(wantb), string(gotb), string(gotb))
 }
 
+func TestVSchemaWeightedRoutingRules(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{
+			Rules: []*vschemapb.WeightedRoutingRule{
+				// OK, unsharded@replica => unsharded.
+				{
+					FromTable:       "ks1.t1@replica",
+					ToTable:         "ks2.t1",
+					Percent:         10,
+					MaxErrorPercent: 5,
+				},
+				// Weighted routing only splits reads.
+				{
+					FromTable: "ks1.t2",
+					ToTable:   "ks2.t2",
+					Percent:   10,
+				},
+				{
+					FromTable: "ks1.t3@primary",
+					ToTable:   "ks2.t3",
+					Percent:   10,
+				},
+				// Percent must be set.
+				{
+					FromTable: "ks1.t4@replica",
+					ToTable:   "ks2.t4",
+				},
+				// Invalid ToTable, needs to be <keyspace>.<table>.
+				{
+					FromTable: "ks1.t5@rdonly",
+					ToTable:   "ks2.t5@replica",
+					Percent:   10,
+				},
+				// ToTable must be in another keyspace.
+				{
+					FromTable: "ks1.t6@rdonly",
+					ToTable:   "ks1.t6",
+					Percent:   10,
+				},
+			},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks1": {},
+			"ks2": {},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	wr, err := vschema.FindWeightedRoutingRule("ks1", "t1", topodatapb.TabletType_REPLICA)
+	require.NoError(t, err)
+	require.NotNil(t, wr)
+	assert.Equal(t, "ks2", wr.Table.Keyspace.Name)
+	assert.Equal(t, "t1", wr.Table.Name.String())
+	assert.EqualValues(t, 10, wr.Percent)
+	assert.EqualValues(t, 5, wr.MaxErrorPercent)
+
+	// No rule for the other tablet types.
+	wr, err = vschema.FindWeightedRoutingRule("ks1", "t1", topodatapb.TabletType_RDONLY)
+	require.NoError(t, err)
+	assert.Nil(t, wr)
+
+	for fromTable, wantErr := range map[string]string{
+		"ks1.t2":         "from table: must specify a replica or rdonly tablet type: 'ks1.t2'",
+		"ks1.t3@primary": "from table: must specify a replica or rdonly tablet type: 'ks1.t3@primary'",
+		"ks1.t4@replica": "percent must be between 0 and 100: 0",
+		"ks1.t5@rdonly":  "to table: tablet type may not be specified: 'ks2.t5@replica'",
+		"ks1.t6@rdonly":  "to table: cannot reside in same keyspace as from table",
+	} {
+		require.Contains(t, vschema.WeightedRoutingRules, fromTable)
+		assert.EqualError(t, vschema.WeightedRoutingRules[fromTable].Error, wantErr, fromTable)
+	}
+	_, err = vschema.FindWeightedRoutingRule("ks1", "t4", topodatapb.TabletType_REPLICA)
+	assert.EqualError(t, err, "percent must be between 0 and 100: 0")
+}
+
+func TestVSchemaWeightedShardRoutingRules(t *testing.T) {
+	lower := &topodatapb.ShardReference{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}}
+	upper := &topodatapb.ShardReference{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}}
+	input := vschemapb.SrvVSchema{
+		WeightedRoutingRules: &vschemapb.WeightedRoutingRules{
+			ShardRules: []*vschemapb.WeightedShardRoutingRule{
+				// OK, in any order.
+				{
+					FromKeyspace:    "ks1@replica",
+					ToShards:        []*topodatapb.ShardReference{upper, lower},
+					Percent:         10,
+					MaxErrorPercent: 5,
+				},
+				// Weighted routing only splits reads.
+				{
+					FromKeyspace: "ks1@primary",
+					ToShards:     []*topodatapb.ShardReference{lower, upper},
+					Percent:      10,
+				},
+				// The shards must cover the whole keyspace.
+				{
+					FromKeyspace: "ks1@rdonly",
+					ToShards:     []*topodatapb.ShardReference{lower},
+					Percent:      10,
+				},
+				// The keyspace must exist.
+				{
+					FromKeyspace: "ks2@replica",
+					ToShards:     []*topodatapb.ShardReference{lower, upper},
+					Percent:      10,
+				},
+			},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks1": {Sharded: true},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	wr, err := vschema.FindWeightedShardRoutingRule("ks1", topodatapb.TabletType_REPLICA)
+	require.NoError(t, err)
+	require.NotNil(t, wr)
+	assert.Equal(t, "ks1", wr.Keyspace)
+	assert.Equal(t, []*topodatapb.ShardReference{upper, lower}, wr.Shards)
+	assert.EqualValues(t, 10, wr.Percent)
+	assert.EqualValues(t, 5, wr.MaxErrorPercent)
+
+	for fromKeyspace, wantErr := range map[string]string{
+		"ks1@primary": "from keyspace: must specify a replica or rdonly tablet type: 'ks1@primary'",
+		"ks1@rdonly":  "to shards: must cover the whole keyspace",
+		"ks2@replica": "from keyspace: keyspace 'ks2' not found in vschema",
+	} {
+		require.Contains(t, vschema.WeightedShardRoutingRules, fromKeyspace)
+		assert.EqualError(t, vschema.WeightedShardRoutingRules[fromKeyspace].Error, wantErr, fromKeyspace)
+	}
+	_, err = vschema.FindWeightedShardRoutingRule("ks1", topodatapb.TabletType_RDONLY)
+	assert.EqualError(t, err, "to shards: must cover the whole keyspace")
+}
+
 func TestChooseVindexForType(t *testing.T) {
 	testcases := []struct {
 		in  querypb.Type
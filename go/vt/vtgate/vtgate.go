@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/tb"
 	"vitess.io/vitess/go/viperutil"
 	"vitess.io/vitess/go/vt/discovery"
@@ -57,8 +58,10 @@ import (
 	"vitess.io/vitess/go/vt/utils"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/ratelimiter"
 	vtschema "vitess.io/vitess/go/vt/vtgate/schema"
 	"vitess.io/vitess/go/vt/vtgate/txresolver"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
@@ -81,6 +84,12 @@ var (
 	maxPayloadSize  int
 	warnPayloadSize int
 
+	// maxMemoryBytes is the maximum size of the intermediate results a query holds in memory, 0 means no limit
+	maxMemoryBytes int64
+	// spillToDisk lets the primitives which support it write their intermediate results to spillDir instead of failing
+	spillToDisk bool
+	spillDir    string
+
 	noScatter          bool
 	enableShardRouting bool
 
@@ -168,6 +177,24 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	// rateLimiterConfigFile is the path of the per-tenant rate limiter configuration, reloaded when changed
+	rateLimiterConfigFile string
+
+	// queryDigestsMax is the maximum number of query digests kept in memory, 0 disables query digests
+	queryDigestsMax int
+	// queryDigestsSnapshotFile is the path of the file the query digests are periodically saved to and loaded from at startup
+	queryDigestsSnapshotFile     string
+	queryDigestsSnapshotInterval = 1 * time.Minute
+
+	// enableConsolidator merges identical in-flight read-only queries of non-transactional sessions
+	enableConsolidator         bool
+	consolidatorQueryWaiterCap int64
+
+	// resultCacheMemory is the maximum memory used by the result cache, 0 disables it
+	resultCacheMemory int64
+	// resultCacheVStreamKeyspaces are the keyspaces whose row events invalidate the result cache
+	resultCacheVStreamKeyspaces string
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -178,6 +205,9 @@ func registerFlags(fs *pflag.FlagSet) {
 	utils.SetFlagIntVar(fs, &streamBufferSize, "stream-buffer-size", streamBufferSize, "the number of bytes sent from vtgate for each stream call. It's recommended to keep this value in sync with vttablet's query-server-config-stream-buffer-size.")
 	utils.SetFlagInt64Var(fs, &queryPlanCacheMemory, "gate-query-cache-memory", queryPlanCacheMemory, "gate server query cache size in bytes, maximum amount of memory to be cached. vtgate analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
 	utils.SetFlagIntVar(fs, &maxMemoryRows, "max-memory-rows", maxMemoryRows, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
+	fs.Int64Var(&maxMemoryBytes, "max-memory-bytes", maxMemoryBytes, "Maximum number of bytes of intermediate results a query holds in memory in sort, hash join, distinct and group_concat operations, for both streaming and non-streaming queries. 0 means no limit.")
+	fs.BoolVar(&spillToDisk, "spill-to-disk", spillToDisk, "Let sort, hash join and distinct operations of streaming queries spill their intermediate results to temporary files instead of failing when the query exceeds --max-memory-bytes. Sorts also spill instead of failing on --max-memory-rows.")
+	fs.StringVar(&spillDir, "spill-dir", spillDir, "Directory of the temporary files of operations spilling to disk. Defaults to the temporary directory of the system.")
 	utils.SetFlagIntVar(fs, &warnMemoryRows, "warn-memory-rows", warnMemoryRows, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
 	utils.SetFlagStringVar(fs, &defaultDDLStrategy, "ddl-strategy", defaultDDLStrategy, "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
 	utils.SetFlagStringVar(fs, &dbDDLPlugin, "dbddl-plugin", dbDDLPlugin, "controls how to handle CREATE/DROP DATABASE. use it if you are using your own database provisioning service")
@@ -205,6 +235,15 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.DurationVar(&engine.WeightedRoutingRollbackDuration, "weighted-routing-rollback-duration", engine.WeightedRoutingRollbackDuration, "How long reads are routed back to the source of weighted routing rules whose target exceeded its maximum error rate, before the target is tried again")
+	fs.StringVar(&rateLimiterConfigFile, "rate-limiter-config-file", rateLimiterConfigFile, "Path of a JSON file configuring per-tenant query rate limits. The file is watched and reloaded when it changes.")
+	fs.IntVar(&queryDigestsMax, "query-digests-max", queryDigestsMax, "Maximum number of normalized queries to keep statistics for in the query digests, served by SHOW VITESS_QUERY_DIGESTS and /debug/query_digests. Once it is reached, the normalized query with the lowest total time is evicted to make room for a new one. 0 disables query digests.")
+	fs.StringVar(&queryDigestsSnapshotFile, "query-digests-snapshot-file", queryDigestsSnapshotFile, "Path of a file the query digests are periodically saved to, and loaded from at startup so that they survive restarts.")
+	fs.DurationVar(&queryDigestsSnapshotInterval, "query-digests-snapshot-interval", queryDigestsSnapshotInterval, "How often the query digests are saved to --query-digests-snapshot-file.")
+	fs.BoolVar(&enableConsolidator, "enable-vtgate-consolidator", enableConsolidator, "Merge identical in-flight read-only queries of sessions outside of transactions, so that they share a single execution and result. Streaming queries are not consolidated. Queries can opt out with the SKIP_VTGATE_CONSOLIDATOR directive.")
+	fs.Int64Var(&consolidatorQueryWaiterCap, "vtgate-consolidator-query-waiter-cap", consolidatorQueryWaiterCap, "Maximum number of queries waiting for an identical in-flight query in the vtgate consolidator. Queries beyond this number are executed on their own. 0 means unlimited.")
+	fs.Int64Var(&resultCacheMemory, "result-cache-memory", resultCacheMemory, "Maximum memory in bytes used to cache the results of read-only queries opting in with the CACHE_TTL directive or the result_cache_ttl of their tables in the vschema. Cached results are invalidated by writes through this vtgate. 0 disables the result cache.")
+	fs.StringVar(&resultCacheVStreamKeyspaces, "result-cache-vstream-keyspaces", resultCacheVStreamKeyspaces, "Keyspace, or /regexp matching keyspaces, whose row events are streamed to invalidate the result cache, so that writes not made through this vtgate are seen as well.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -375,12 +414,38 @@ func Init(
 
 	plans := DefaultPlanCache()
 
+	var rl *ratelimiter.RateLimiter
+	if rateLimiterConfigFile != "" {
+		rl, err = ratelimiter.NewFromFile(rateLimiterConfigFile)
+		if err != nil {
+			log.Fatalf("Unable to load rate limiter config: %v", err)
+		}
+		if err := rl.Watch(); err != nil {
+			log.Fatalf("Unable to watch rate limiter config: %v", err)
+		}
+		servenv.OnTerm(rl.Close)
+	}
+
+	queryDigests := initQueryDigests()
+
+	var consolidator sync2.Consolidator
+	if enableConsolidator {
+		consolidator = sync2.NewConsolidator()
+	}
+
+	resultCache := initResultCache()
+
 	eConfig := ExecutorConfig{
-		Normalize:           normalizeQueries,
-		StreamSize:          streamBufferSize,
-		AllowScatter:        !noScatter,
-		WarmingReadsPercent: warmingReadsPercent,
-		QueryLogToFile:      queryLogToFile,
+		Normalize:                  normalizeQueries,
+		StreamSize:                 streamBufferSize,
+		AllowScatter:               !noScatter,
+		WarmingReadsPercent:        warmingReadsPercent,
+		QueryLogToFile:             queryLogToFile,
+		RateLimiter:                rl,
+		QueryDigests:               queryDigests,
+		Consolidator:               consolidator,
+		ConsolidatorQueryWaiterCap: consolidatorQueryWaiterCap,
+		ResultCache:                resultCache,
 	}
 
 	executor := NewExecutor(ctx, env, serv, cell, resolver, eConfig, warnShardedOnly, plans, si, pv, dynamicConfig)
@@ -424,6 +489,13 @@ func Init(
 		}
 		tr.Stop()
 	})
+	if resultCache != nil && resultCacheVStreamKeyspaces != "" {
+		streamCtx, cancel := context.WithCancel(ctx)
+		servenv.OnRun(func() {
+			go streamResultCacheInvalidations(streamCtx, vsm, resultCache, resultCacheVStreamKeyspaces)
+		})
+		servenv.OnTerm(cancel)
+	}
 	vtgateInst.registerDebugHealthHandler()
 	vtgateInst.registerDebugEnvHandler()
 	vtgateInst.registerDebugBalancerHandler()
@@ -46,6 +46,7 @@ import (
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/dbconnpool"
+	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -96,6 +97,7 @@ var (
 	migrationCheckInterval  = 1 * time.Minute
 	retainOnlineDDLTables   = 24 * time.Hour
 	maxConcurrentOnlineDDLs = 256
+	onlineDDLLintConfigFile string
 
 	migrationNextCheckIntervals = []time.Duration{1 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second}
 	cutoverIntervals            = []time.Duration{0, 1 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
@@ -116,6 +118,7 @@ func registerOnlineDDLFlags(fs *pflag.FlagSet) {
 	utils.SetFlagDurationVar(fs, &migrationCheckInterval, "migration-check-interval", migrationCheckInterval, "Interval between migration checks")
 	utils.SetFlagDurationVar(fs, &retainOnlineDDLTables, "retain-online-ddl-tables", retainOnlineDDLTables, "How long should vttablet keep an old migrated table before purging it")
 	utils.SetFlagIntVar(fs, &maxConcurrentOnlineDDLs, "max-concurrent-online-ddl", maxConcurrentOnlineDDLs, "Maximum number of online DDL changes that may run concurrently")
+	utils.SetFlagStringVar(fs, &onlineDDLLintConfigFile, "online-ddl-lint-config", onlineDDLLintConfigFile, "Path to a JSON schema lint config, applied to migrations submitted with the --lint DDL strategy flag. By default all built-in lint rules apply")
 }
 
 const (
@@ -457,6 +460,78 @@ func (e *Executor) showCreateTable(ctx context.Context, tableName string) (strin
 	return row[1].ToString(), nil
 }
 
+// routineExists checks if the stored procedure, function, trigger or event that the given migration
+// creates or drops, exists.
+func (e *Executor) routineExists(ctx context.Context, onlineDDL *schema.OnlineDDL) (bool, error) {
+	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
+	if err != nil {
+		return false, err
+	}
+	name := sqltypes.StringBindVariable(ddlStmt.GetTable().Name.String())
+	dbName := sqltypes.StringBindVariable(e.dbName)
+	var query string
+	switch ddlStmt.(type) {
+	case *sqlparser.CreateProcedure, *sqlparser.DropProcedure:
+		query, err = sqlparser.ParseAndBind(sqlSelectRoutineExists, dbName, sqltypes.StringBindVariable("PROCEDURE"), name)
+	case *sqlparser.CreateFunction, *sqlparser.DropFunction:
+		query, err = sqlparser.ParseAndBind(sqlSelectRoutineExists, dbName, sqltypes.StringBindVariable("FUNCTION"), name)
+	case *sqlparser.CreateTrigger, *sqlparser.DropTrigger:
+		query, err = sqlparser.ParseAndBind(sqlSelectTriggerExists, dbName, name)
+	case *sqlparser.CreateEvent, *sqlparser.DropEvent:
+		query, err = sqlparser.ParseAndBind(sqlSelectEventExists, dbName, name)
+	default:
+		return false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "expected a routine statement in online DDL statement: %v", onlineDDL.SQL)
+	}
+	if err != nil {
+		return false, err
+	}
+	rs, err := e.execQuery(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	return len(rs.Rows) > 0, nil
+}
+
+// migrationEntityExists checks if the table, view or routine the given migration operates on, exists.
+func (e *Executor) migrationEntityExists(ctx context.Context, onlineDDL *schema.OnlineDDL) (bool, error) {
+	if onlineDDL.IsRoutine(e.env.Environment().Parser()) {
+		return e.routineExists(ctx, onlineDDL)
+	}
+	return e.tableExists(ctx, onlineDDL.Table)
+}
+
+// showCreateRoutine returns the SHOW CREATE statement for the stored procedure, function, trigger or event
+// that the given migration operates on.
+func (e *Executor) showCreateRoutine(ctx context.Context, onlineDDL *schema.OnlineDDL) (string, error) {
+	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
+	if err != nil {
+		return "", err
+	}
+	var parsed *sqlparser.ParsedQuery
+	var createColumn string
+	switch ddlStmt.(type) {
+	case *sqlparser.CreateProcedure, *sqlparser.DropProcedure:
+		parsed, createColumn = sqlparser.BuildParsedQuery(sqlShowCreateProcedure, onlineDDL.Table), "Create Procedure"
+	case *sqlparser.CreateFunction, *sqlparser.DropFunction:
+		parsed, createColumn = sqlparser.BuildParsedQuery(sqlShowCreateFunction, onlineDDL.Table), "Create Function"
+	case *sqlparser.CreateTrigger, *sqlparser.DropTrigger:
+		parsed, createColumn = sqlparser.BuildParsedQuery(sqlShowCreateTrigger, onlineDDL.Table), "SQL Original Statement"
+	case *sqlparser.CreateEvent, *sqlparser.DropEvent:
+		parsed, createColumn = sqlparser.BuildParsedQuery(sqlShowCreateEvent, onlineDDL.Table), "Create Event"
+	default:
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "expected a routine statement in online DDL statement: %v", onlineDDL.SQL)
+	}
+	rs, err := e.execQuery(ctx, parsed.Query)
+	if err != nil {
+		return "", err
+	}
+	row := rs.Named().Row()
+	if row == nil {
+		return "", nil
+	}
+	return row.AsString(createColumn, ""), nil
+}
+
 // getCreateTableStatement gets a formal AlterTable representation of the given table
 func (e *Executor) getCreateTableStatement(ctx context.Context, tableName string) (*sqlparser.CreateTable, error) {
 	showCreateTable, err := e.showCreateTable(ctx, tableName)
@@ -1968,6 +2043,9 @@ func (e *Executor) validateMigrationRevertible(ctx context.Context, revertMigrat
 	default:
 		return fmt.Errorf("cannot revert migration %s: unexpected action %s", revertMigration.UUID, actionStr)
 	}
+	if revertMigration.IsRoutine(e.env.Environment().Parser()) {
+		return fmt.Errorf("cannot revert migration %s: migrations on procedures, functions, triggers and events are not revertible", revertMigration.UUID)
+	}
 	if revertMigration.Status != schema.OnlineDDLStatusComplete {
 		return fmt.Errorf("can only revert a migration in a '%s' state. Migration %s is in '%s' state", schema.OnlineDDLStatusComplete, revertMigration.UUID, revertMigration.Status)
 	}
@@ -2131,13 +2209,16 @@ func (e *Executor) executeRevert(ctx context.Context, onlineDDL *schema.OnlineDD
 
 // evaluateDeclarativeDiff is called for -declarative CREATE statements, where the table already exists. The function generates a SQL diff, which can be:
 // - empty, in which case the migration is noop and implicitly successful, or
-// - non-empty, in which case the migration turns to be an ALTER
+// - non-empty, in which case the migration turns to be an ALTER (or, for routines, a DROP followed by a CREATE)
 func (e *Executor) evaluateDeclarativeDiff(ctx context.Context, onlineDDL *schema.OnlineDDL) (diff schemadiff.EntityDiff, err error) {
 	// Modify the CREATE TABLE statement to indicate a different, made up table name, known as the "comparison table"
 	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
 	if err != nil {
 		return nil, err
 	}
+	if onlineDDL.IsRoutine(e.env.Environment().Parser()) {
+		return e.evaluateDeclarativeRoutineDiff(ctx, onlineDDL, ddlStmt)
+	}
 	// Is this CREATE TABLE or CREATE VIEW?
 	comparisonTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
 	if err != nil {
@@ -2206,6 +2287,61 @@ func (e *Executor) evaluateDeclarativeDiff(ctx context.Context, onlineDDL *schem
 	return diff, nil
 }
 
+// evaluateDeclarativeRoutineDiff is the routine counterpart of evaluateDeclarativeDiff. Routine definitions do
+// not depend on server defaults the way table definitions do, and so there is no comparison routine: the declared
+// routine is diffed directly against the existing one.
+func (e *Executor) evaluateDeclarativeRoutineDiff(ctx context.Context, onlineDDL *schema.OnlineDDL, ddlStmt sqlparser.DDLStatement) (diff schemadiff.EntityDiff, err error) {
+	existingShowCreateRoutine, err := e.showCreateRoutine(ctx, onlineDDL)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "in evaluateDeclarativeRoutineDiff(), for onlineDDL.Table")
+	}
+	if existingShowCreateRoutine == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "unexpected: cannot find routine %v", onlineDDL.Table)
+	}
+	existingStmt, err := e.env.Environment().Parser().ParseStrictDDL(existingShowCreateRoutine)
+	if err != nil {
+		return nil, err
+	}
+	// The migration's comments are not part of the routine's definition
+	declaredStmt := sqlparser.Clone(ddlStmt)
+	declaredStmt.SetComments(nil)
+	ignoreImplicitRoutineAttributes(existingStmt, declaredStmt)
+
+	senv := schemadiff.NewEnv(e.env.Environment(), e.env.Environment().CollationEnv().DefaultConnectionCharset())
+	return schemadiff.DiffRoutines(senv, existingStmt, declaredStmt, schemadiff.EmptyDiffHints())
+}
+
+// ignoreImplicitRoutineAttributes clears, from an existing routine's definition, those attributes that MySQL assigns
+// implicitly when not declared: the DEFINER of any routine, and the STARTS time of a recurring event. Without
+// this, a routine declared without these attributes would always seem to differ from its existing counterpart.
+func ignoreImplicitRoutineAttributes(existingStmt sqlparser.Statement, declaredStmt sqlparser.Statement) {
+	switch declared := declaredStmt.(type) {
+	case *sqlparser.CreateProcedure:
+		if existing, ok := existingStmt.(*sqlparser.CreateProcedure); ok && declared.Definer == nil {
+			existing.Definer = nil
+		}
+	case *sqlparser.CreateFunction:
+		if existing, ok := existingStmt.(*sqlparser.CreateFunction); ok && declared.Definer == nil {
+			existing.Definer = nil
+		}
+	case *sqlparser.CreateTrigger:
+		if existing, ok := existingStmt.(*sqlparser.CreateTrigger); ok && declared.Definer == nil {
+			existing.Definer = nil
+		}
+	case *sqlparser.CreateEvent:
+		existing, ok := existingStmt.(*sqlparser.CreateEvent)
+		if !ok {
+			return
+		}
+		if declared.Definer == nil {
+			existing.Definer = nil
+		}
+		if declared.Schedule != nil && declared.Schedule.Starts == nil && existing.Schedule != nil {
+			existing.Schedule.Starts = nil
+		}
+	}
+}
+
 // getCompletedMigrationByContextAndSQL checks if there exists a completed migration with exact same
 // context and SQL as given migration. If so, it returns its UUID.
 func (e *Executor) getCompletedMigrationByContextAndSQL(ctx context.Context, onlineDDL *schema.OnlineDDL) (completedUUID string, err error) {
@@ -2343,6 +2479,14 @@ func (e *Executor) executeDropDDLActionMigration(ctx context.Context, onlineDDL
 		return nil
 	}
 
+	if onlineDDL.IsRoutine(e.env.Environment().Parser()) {
+		// Routines hold no data, and so do not go through the GC lifecycle. We just drop them.
+		if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+			return failMigration(err)
+		}
+		return nil
+	}
+
 	// We transform a DROP TABLE into a RENAME TABLE statement, so as to remove the table safely and asynchronously.
 
 	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
@@ -2392,6 +2536,13 @@ func (e *Executor) executeCreateDDLActionMigration(ctx context.Context, onlineDD
 	if err != nil {
 		return failMigration(err)
 	}
+	if onlineDDL.IsRoutine(e.env.Environment().Parser()) {
+		// Routines are created directly. Routine migrations are not revertible, and so there is no need for a sentry artifact.
+		if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+			return failMigration(err)
+		}
+		return nil
+	}
 	if _, isCreateView := ddlStmt.(*sqlparser.CreateView); isCreateView {
 		if ddlStmt.GetIsReplace() {
 			// This is a CREATE OR REPLACE VIEW
@@ -2454,6 +2605,101 @@ func (e *Executor) executeCreateDDLActionMigration(ctx context.Context, onlineDD
 	return nil
 }
 
+// executeReplaceRoutineMigration applies a declarative routine diff: it drops the existing routine, then creates
+// the declared one. MySQL cannot modify a routine's definition in place, and so to keep the routine from going
+// missing, the declared definition is first validated by creating it under a temporary name. This does not work
+// for triggers, which would then fire twice, so a trigger's table is instead locked for writes while its trigger
+// is replaced. Either way, should the declared routine fail to create, the existing definition is restored.
+func (e *Executor) executeReplaceRoutineMigration(ctx context.Context, onlineDDL *schema.OnlineDDL, diff schemadiff.EntityDiff) error {
+	failMigration := func(err error) error {
+		return e.failMigration(ctx, onlineDDL, err)
+	}
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	createDiff := diff.SubsequentDiff()
+	if createDiff == nil {
+		return failMigration(vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected: routine diff does not create routine %v", onlineDDL.Table))
+	}
+	createStmt, ok := createDiff.Statement().(sqlparser.DDLStatement)
+	if !ok {
+		return failMigration(vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected: routine diff does not create routine %v", onlineDDL.Table))
+	}
+	existingShowCreateRoutine, err := e.showCreateRoutine(ctx, onlineDDL)
+	if err != nil {
+		return failMigration(err)
+	}
+	if existingShowCreateRoutine == "" {
+		return failMigration(vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "unexpected: cannot find routine %v", onlineDDL.Table))
+	}
+
+	conn, err := dbconnpool.NewDBConnection(ctx, e.env.Config().DB.DbaWithDB())
+	if err != nil {
+		return failMigration(err)
+	}
+	defer conn.Close()
+
+	restoreSQLModeFunc, err := e.initMigrationSQLMode(ctx, onlineDDL, conn)
+	defer restoreSQLModeFunc()
+	if err != nil {
+		return failMigration(err)
+	}
+
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusRunning, false, progressPctStarted, etaSecondsUnknown, rowsCopiedUnknown, emptyHint)
+	if createTrigger, isCreateTrigger := createStmt.(*sqlparser.CreateTrigger); isCreateTrigger {
+		lockTableQuery := sqlparser.BuildParsedQuery(sqlLockTableWrite, createTrigger.Table.Name.String())
+		if _, err := conn.ExecuteFetch(lockTableQuery.Query, 0, false); err != nil {
+			return failMigration(vterrors.Wrapf(err, "failed locking table"))
+		}
+		defer conn.ExecuteFetch(sqlUnlockTables, 0, false)
+	} else if err := e.validateRoutineDefinition(conn, createStmt); err != nil {
+		return failMigration(err)
+	}
+
+	if _, err := conn.ExecuteFetch(diff.StatementString(), 0, false); err != nil {
+		return failMigration(err)
+	}
+	if _, err := conn.ExecuteFetch(createDiff.StatementString(), 0, false); err != nil {
+		if _, restoreErr := conn.ExecuteFetch(existingShowCreateRoutine, 0, false); restoreErr != nil {
+			return failMigration(vterrors.Wrapf(err, "failed restoring routine %v: %v", onlineDDL.Table, restoreErr))
+		}
+		return failMigration(err)
+	}
+	defer e.reloadSchema(ctx)
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusComplete, false, progressPctFull, etaSecondsNow, rowsCopiedUnknown, emptyHint)
+	return nil
+}
+
+// validateRoutineDefinition creates the given routine under a temporary name, and drops it right away. An event
+// is created disabled, so that it does not run.
+func (e *Executor) validateRoutineDefinition(conn *dbconnpool.DBConnection, createStmt sqlparser.DDLStatement) error {
+	validationRoutineName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+	if err != nil {
+		return err
+	}
+	validationStmt := sqlparser.Clone(createStmt)
+	validationStmt.SetTable("", validationRoutineName)
+	var dropStmt sqlparser.DDLStatement
+	switch stmt := validationStmt.(type) {
+	case *sqlparser.CreateProcedure:
+		dropStmt = &sqlparser.DropProcedure{Name: stmt.Name}
+	case *sqlparser.CreateFunction:
+		dropStmt = &sqlparser.DropFunction{Name: stmt.Name}
+	case *sqlparser.CreateEvent:
+		stmt.Status = sqlparser.DisableEventStatus
+		dropStmt = &sqlparser.DropEvent{Name: stmt.Name}
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected: cannot validate routine statement: %v", sqlparser.String(createStmt))
+	}
+	if _, err := conn.ExecuteFetch(sqlparser.String(validationStmt), 0, false); err != nil {
+		return vterrors.Wrapf(err, "invalid routine definition")
+	}
+	if _, err := conn.ExecuteFetch(sqlparser.String(dropStmt), 0, false); err != nil {
+		return vterrors.Wrapf(err, "failed dropping validation routine %v", validationRoutineName)
+	}
+	return nil
+}
+
 // generateSwapTablesStatement creates a RENAME statement that swaps two tables, with assistance
 // of temporary third table. It returns the name of generated third table, though normally
 // that table should not exist before & after operation, only _during_ operation time.
@@ -2750,7 +2996,7 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 					return failMigration(vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "strategy is declarative. IF EXISTS does not work in declarative mode for migration %v", onlineDDL.UUID))
 				}
 			}
-			exists, err := e.tableExists(ctx, onlineDDL.Table)
+			exists, err := e.migrationEntityExists(ctx, onlineDDL)
 			if err != nil {
 				return failMigration(err)
 			}
@@ -2780,7 +3026,7 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 				return failMigration(vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "strategy is declarative. OR REPLACE does not work in declarative mode for migration %v", onlineDDL.UUID))
 			}
 
-			exists, err := e.tableExists(ctx, onlineDDL.Table)
+			exists, err := e.migrationEntityExists(ctx, onlineDDL)
 			if err != nil {
 				return failMigration(err)
 			}
@@ -2795,6 +3041,18 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 					_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, "no change")
 					return nil
 				}
+				if onlineDDL.IsRoutine(e.env.Environment().Parser()) {
+					// A routine cannot be altered. The diff drops the existing routine and creates the declared one.
+					var statements []string
+					for _, subDiff := range schemadiff.AllSubsequent(diff) {
+						statements = append(statements, subDiff.CanonicalStatementString())
+					}
+					_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, strings.Join(statements, "; "))
+					go func() error {
+						return e.executeReplaceRoutineMigration(ctx, onlineDDL, diff)
+					}()
+					return nil
+				}
 				// alterClause is non empty. We convert this migration into an ALTER
 				if err := e.updateDDLAction(ctx, onlineDDL.UUID, sqlparser.AlterStr); err != nil {
 					return failMigration(err)
@@ -3147,6 +3405,12 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 		if errForceCutOverAfter != nil {
 			forceCutOverAfter = 0
 		}
+		// Likewise, --cutover-window is validated when DDL strategy is first parsed. On error we
+		// choose to not restrict the cut-over to any window.
+		cutOverWindow, errCutOverWindow := strategySetting.CutOverWindow()
+		if errCutOverWindow != nil {
+			cutOverWindow = nil
+		}
 
 		uuidsFoundRunning[uuid] = true
 
@@ -3258,6 +3522,15 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 						return nil
 					}
 				}
+				if cutOverWindow != nil && !shouldForceCutOver {
+					// The migration may only cut-over within its --cutover-window. An explicit user
+					// request to force the cut-over overrides the window.
+					if now := time.Now(); !cutOverWindow.Contains(now) {
+						_ = e.updateMigrationNextCutOverWindow(ctx, uuid, cutOverWindow.NextOpening(now))
+						return nil
+					}
+					_ = e.clearMigrationNextCutOverWindow(ctx, uuid)
+				}
 				shouldCutOver, shouldForceCutOver := shouldCutOverAccordingToBackoff(
 					shouldForceCutOver, forceCutOverAfter, sinceReadyToComplete, sinceLastCutoverAttempt, cutoverAttempts,
 				)
@@ -3936,6 +4209,34 @@ func (e *Executor) updateMigrationLastThrottled(
 	return err
 }
 
+// updateMigrationNextCutOverWindow sets the time at which the --cutover-window of a ready migration next opens.
+// The migration is only written to when that time changes.
+func (e *Executor) updateMigrationNextCutOverWindow(ctx context.Context, uuid string, nextOpening time.Time) error {
+	nextOpeningTimestamp := nextOpening.UTC().Format(sqltypes.TimestampFormat)
+	query, err := sqlparser.ParseAndBind(sqlUpdateNextCutOverWindow,
+		sqltypes.StringBindVariable(nextOpeningTimestamp),
+		sqltypes.StringBindVariable(uuid),
+		sqltypes.StringBindVariable(nextOpeningTimestamp),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = e.execQuery(ctx, query)
+	return err
+}
+
+// clearMigrationNextCutOverWindow clears the next_cutover_window_timestamp of a migration, once its --cutover-window is open.
+func (e *Executor) clearMigrationNextCutOverWindow(ctx context.Context, uuid string) error {
+	query, err := sqlparser.ParseAndBind(sqlClearNextCutOverWindow,
+		sqltypes.StringBindVariable(uuid),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = e.execQuery(ctx, query)
+	return err
+}
+
 func (e *Executor) updateMigrationTableRows(ctx context.Context, uuid string, tableRows int64) error {
 	query, err := sqlparser.ParseAndBind(sqlUpdateMigrationTableRows,
 		sqltypes.Int64BindVariable(tableRows),
@@ -4577,6 +4878,12 @@ func (e *Executor) SubmitMigration(
 	}
 	log.Infof("SubmitMigration: request to submit migration %s; action=%s, table=%s", onlineDDL.UUID, actionStr, onlineDDL.Table)
 
+	if onlineDDL.StrategySetting().IsLintFlag() {
+		if err := e.lintMigration(ctx, onlineDDL); err != nil {
+			return nil, vterrors.Wrapf(err, "migration %v rejected", onlineDDL.UUID)
+		}
+	}
+
 	revertedUUID, _ := onlineDDL.GetRevertUUID(e.env.Environment().Parser()) // Empty value if the migration is not actually a REVERT. Safe to ignore error.
 	retainArtifactsSeconds := int64((retainOnlineDDLTables).Seconds())
 	if retainArtifacts, _ := onlineDDL.StrategySetting().RetainArtifactsDuration(); retainArtifacts != 0 {
@@ -4630,6 +4937,57 @@ func (e *Executor) SubmitMigration(
 	return result, nil
 }
 
+// lintMigration checks the table resulting from the given migration against the schema lint rules
+// configured by --online-ddl-lint-config, and returns an error if it violates any rule.
+func (e *Executor) lintMigration(ctx context.Context, onlineDDL *schema.OnlineDDL) error {
+	stmt, err := e.env.Environment().Parser().ParseStrictDDL(onlineDDL.SQL)
+	if err != nil {
+		return err
+	}
+	diff := schemadiff.EntityDiffByStatement(stmt)
+	if diff == nil {
+		// e.g. REVERT
+		return nil
+	}
+	config := schemadiff.DefaultLintConfig()
+	if onlineDDLLintConfigFile != "" {
+		if config, err = schemadiff.LoadLintConfig(onlineDDLLintConfigFile); err != nil {
+			return vterrors.Wrapf(err, "loading lint config")
+		}
+	}
+	if _, keyRange, err := topo.ValidateShardName(e.shard); err == nil {
+		config.Sharded = key.KeyRangeIsPartial(keyRange)
+	}
+	senv := schemadiff.NewEnv(e.env.Environment(), e.env.Environment().CollationEnv().DefaultConnectionCharset())
+	linter, err := schemadiff.NewLinter(senv, config)
+	if err != nil {
+		return err
+	}
+	var queries []string
+	if _, ok := stmt.(*sqlparser.AlterTable); ok {
+		showCreateTable, err := e.showCreateTable(ctx, onlineDDL.Table)
+		if err != nil {
+			return vterrors.Wrapf(err, "in lintMigration(), for %v", onlineDDL.Table)
+		}
+		if showCreateTable == "" {
+			return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "cannot find table %v", onlineDDL.Table)
+		}
+		queries = append(queries, showCreateTable)
+	}
+	currentSchema, err := schemadiff.NewSchemaFromQueries(senv, queries)
+	if err != nil {
+		return err
+	}
+	violations, err := linter.LintApply(currentSchema, []schemadiff.EntityDiff{diff})
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, (&schemadiff.LintViolationsError{Violations: violations}).Error())
+	}
+	return nil
+}
+
 // ShowMigrations shows migrations, optionally filtered by a condition
 func (e *Executor) ShowMigrations(ctx context.Context, show *sqlparser.Show) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
@@ -26,6 +26,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
 )
 
 func TestShouldCutOverAccordingToBackoff(t *testing.T) {
@@ -229,3 +231,54 @@ func TestSafeMigrationCutOverThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestIgnoreImplicitRoutineAttributes(t *testing.T) {
+	tcases := []struct {
+		name     string
+		existing string
+		declared string
+		expect   string
+	}{
+		{
+			name:     "procedure without definer",
+			existing: "CREATE DEFINER=`root`@`localhost` PROCEDURE `p1`() BEGIN SELECT 1; END",
+			declared: "create procedure p1() begin select 1; end",
+			expect:   "create procedure p1 () begin select 1 from dual; end;",
+		},
+		{
+			name:     "procedure with definer",
+			existing: "CREATE DEFINER=`root`@`localhost` PROCEDURE `p1`() BEGIN SELECT 1; END",
+			declared: "create definer=`app`@`%` procedure p1() begin select 1; end",
+			expect:   "create definer = root@localhost procedure p1 () begin select 1 from dual; end;",
+		},
+		{
+			name:     "trigger without definer",
+			existing: "CREATE DEFINER=`root`@`localhost` TRIGGER `tr1` BEFORE INSERT ON `t1` FOR EACH ROW DELETE FROM t2",
+			declared: "create trigger tr1 before insert on t1 for each row delete from t2",
+			expect:   "create trigger tr1 before insert on t1 for each row delete from t2;",
+		},
+		{
+			name:     "event without starts",
+			existing: "CREATE DEFINER=`root`@`localhost` EVENT `ev1` ON SCHEDULE EVERY 1 DAY STARTS '2026-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO DELETE FROM t1",
+			declared: "create event ev1 on schedule every 1 day do delete from t1",
+			expect:   "create event ev1 on schedule every 1 day do delete from t1;",
+		},
+		{
+			name:     "event with starts",
+			existing: "CREATE DEFINER=`root`@`localhost` EVENT `ev1` ON SCHEDULE EVERY 1 DAY STARTS '2026-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO DELETE FROM t1",
+			declared: "create event ev1 on schedule every 1 day starts '2026-01-01 00:00:00' do delete from t1",
+			expect:   "create event ev1 on schedule every 1 day starts '2026-01-01 00:00:00' do delete from t1;",
+		},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			existing, err := parser.ParseStrictDDL(tcase.existing)
+			require.NoError(t, err)
+			declared, err := parser.ParseStrictDDL(tcase.declared)
+			require.NoError(t, err)
+			ignoreImplicitRoutineAttributes(existing, declared)
+			assert.Equal(t, tcase.expect, sqlparser.String(existing))
+		})
+	}
+}
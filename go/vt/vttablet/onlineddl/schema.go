@@ -192,6 +192,18 @@ const (
 		WHERE
 			migration_uuid=%a
 	`
+	sqlUpdateNextCutOverWindow = `UPDATE _vt.schema_migrations
+			SET next_cutover_window_timestamp=%a
+		WHERE
+			migration_uuid=%a
+			AND NOT next_cutover_window_timestamp <=> %a
+	`
+	sqlClearNextCutOverWindow = `UPDATE _vt.schema_migrations
+			SET next_cutover_window_timestamp=NULL
+		WHERE
+			migration_uuid=%a
+			AND next_cutover_window_timestamp IS NOT NULL
+	`
 	sqlUpdateLaunchMigration = `UPDATE _vt.schema_migrations
 			SET postpone_launch=0
 		WHERE
@@ -287,6 +299,7 @@ const (
 			cancelled_timestamp=NULL,
 			completed_timestamp=NULL,
 			last_cutover_attempt_timestamp=NULL,
+			next_cutover_window_timestamp=NULL,
 			shadow_analyzed_timestamp=NULL,
 			cleanup_timestamp=NULL
 		WHERE
@@ -309,6 +322,7 @@ const (
 			cancelled_timestamp=NULL,
 			completed_timestamp=NULL,
 			last_cutover_attempt_timestamp=NULL,
+			next_cutover_window_timestamp=NULL,
 			shadow_analyzed_timestamp=NULL,
 			cleanup_timestamp=NULL
 		WHERE
@@ -491,6 +505,27 @@ const (
 			TABLE_SCHEMA=%a AND TABLE_NAME=%a
 			AND REFERENCED_TABLE_NAME IS NOT NULL
 		`
+	sqlSelectRoutineExists = `
+		SELECT
+			ROUTINE_NAME
+		FROM INFORMATION_SCHEMA.ROUTINES
+		WHERE
+			ROUTINE_SCHEMA=%a AND ROUTINE_TYPE=%a AND ROUTINE_NAME=%a
+		`
+	sqlSelectTriggerExists = `
+		SELECT
+			TRIGGER_NAME
+		FROM INFORMATION_SCHEMA.TRIGGERS
+		WHERE
+			TRIGGER_SCHEMA=%a AND TRIGGER_NAME=%a
+		`
+	sqlSelectEventExists = `
+		SELECT
+			EVENT_NAME
+		FROM INFORMATION_SCHEMA.EVENTS
+		WHERE
+			EVENT_SCHEMA=%a AND EVENT_NAME=%a
+		`
 	sqlShowTablesLike                      = "SHOW TABLES LIKE '%a'"
 	sqlDropTable                           = "DROP TABLE `%a`"
 	sqlDropTableIfExists                   = "DROP TABLE IF EXISTS `%a`"
@@ -498,6 +533,10 @@ const (
 	sqlAnalyzeTableLocal                   = "ANALYZE NO_WRITE_TO_BINLOG TABLE `%a`"
 	sqlAnalyzeTable                        = "ANALYZE TABLE `%a`"
 	sqlShowCreateTable                     = "SHOW CREATE TABLE `%a`"
+	sqlShowCreateProcedure                 = "SHOW CREATE PROCEDURE `%a`"
+	sqlShowCreateFunction                  = "SHOW CREATE FUNCTION `%a`"
+	sqlShowCreateTrigger                   = "SHOW CREATE TRIGGER `%a`"
+	sqlShowCreateEvent                     = "SHOW CREATE EVENT `%a`"
 	sqlShowVariablesLikePreserveForeignKey = "show global variables like 'rename_table_preserve_foreign_key'"
 	sqlShowVariablesLikeFastAnalyzeTable   = "show global variables like 'fast_analyze_table'"
 	sqlEnableFastAnalyzeTable              = "set @@fast_analyze_table = 1"
@@ -549,6 +588,7 @@ const (
 		`
 	sqlSwapTables              = "RENAME TABLE `%a` TO `%a`, `%a` TO `%a`, `%a` TO `%a`"
 	sqlRenameTable             = "RENAME TABLE `%a` TO `%a`"
+	sqlLockTableWrite          = "LOCK TABLES `%a` WRITE"
 	sqlLockTwoTablesWrite      = "LOCK TABLES `%a` WRITE, `%a` WRITE"
 	sqlUnlockTables            = "UNLOCK TABLES"
 	sqlCreateSentryTable       = "CREATE TABLE IF NOT EXISTS `%a` (id INT PRIMARY KEY)"
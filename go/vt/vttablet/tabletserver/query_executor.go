@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -67,6 +69,8 @@ type QueryExecutor struct {
 	// The target type we requested might be different from tsv's tablet type, if we had a change to the tablet type recently.
 	targetTabletType topodatapb.TabletType
 	setting          *smartconnpool.Setting
+	// softActions are the soft actions of the query rules that match the query.
+	softActions *rules.SoftActions
 }
 
 const (
@@ -158,6 +162,12 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return nil, err
 	}
 
+	release, err := qre.applySoftActions()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	if reqThrottledErr := qre.tsv.queryThrottler.Throttle(qre.ctx, qre.targetTabletType, qre.plan.FullQuery, qre.connID, qre.options); reqThrottledErr != nil {
 		return nil, reqThrottledErr
 	}
@@ -191,7 +201,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	switch qre.plan.PlanID {
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanShow:
 		maxrows := qre.getSelectLimit()
-		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
+		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(qre.getMaxLimit(maxrows))
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
 			qre.bindVars[sqltypes.BvSchemaName] = sqltypes.StringBindVariable(qre.tsv.config.DB.DBName)
 		}
@@ -199,6 +209,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if err != nil {
 			return nil, err
 		}
+		qr = qre.applyRowLimit(qr)
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
@@ -316,7 +327,7 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		return qre.txFetch(conn, false)
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanShow, p.PlanSelectLockFunc:
 		maxrows := qre.getSelectLimit()
-		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
+		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(qre.getMaxLimit(maxrows))
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
 			qre.bindVars[sqltypes.BvSchemaName] = sqltypes.StringBindVariable(qre.tsv.config.DB.DBName)
 		}
@@ -324,6 +335,7 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		if err != nil {
 			return nil, err
 		}
+		qr = qre.applyRowLimit(qr)
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
@@ -354,6 +366,13 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		return err
 	}
 
+	release, err := qre.applySoftActions()
+	if err != nil {
+		return err
+	}
+	defer release()
+	callback = qre.limitStreamRows(callback)
+
 	if reqThrottledErr := qre.tsv.queryThrottler.Throttle(qre.ctx, qre.targetTabletType, qre.plan.FullQuery, qre.connID, qre.options); reqThrottledErr != nil {
 		return reqThrottledErr
 	}
@@ -505,6 +524,8 @@ func (qre *QueryExecutor) checkPermissions() error {
 	default:
 		// no rules against this query. Good to proceed
 	}
+	qre.softActions = qre.plan.Rules.GetSoftActions(remoteAddr, username, qre.bindVars, qre.marginComments)
+
 	// Skip ACL check for queries against the dummy dual table
 	if qre.plan.TableName().String() == "dual" {
 		return nil
@@ -807,6 +828,90 @@ func (qre *QueryExecutor) execDMLLimit(conn *StatefulConnection) (*sqltypes.Resu
 	return result, nil
 }
 
+// applySoftActions applies the soft actions of the matching query rules that take
+// effect before the query runs: it accounts for them, logs a sampled query, and waits
+// for a slot under every concurrency limit. The returned function releases the slots.
+func (qre *QueryExecutor) applySoftActions() (release func(), err error) {
+	release = func() {}
+	sa := qre.softActions
+	if sa == nil {
+		return release, nil
+	}
+	for _, triggered := range sa.Triggered {
+		qre.tsv.stats.QueryRuleSoftActions.Add([]string{triggered.Name, triggered.Action.String()}, 1)
+	}
+	if sa.Sampled {
+		log.Infof("Query sampled by query rule: %s", queryAsString(qre.query, qre.bindVars, qre.tsv.Config().SanitizeLogMessages, true, qre.tsv.env.Parser()))
+	}
+	if len(sa.Limiters) == 0 {
+		return release, nil
+	}
+
+	startTime := time.Now()
+	defer qre.tsv.stats.WaitTimings.Record("QueryRuleConcurrency", startTime)
+	for i, limiter := range sa.Limiters {
+		if err := limiter.Acquire(qre.ctx); err != nil {
+			for _, acquired := range sa.Limiters[:i] {
+				acquired.Release()
+			}
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "timed out waiting under query rule concurrency limit of %d: %v", limiter.MaxConcurrency(), err)
+		}
+	}
+	return func() {
+		for _, limiter := range sa.Limiters {
+			limiter.Release()
+		}
+	}, nil
+}
+
+// getMaxLimit returns the value of the #maxLimit bind variable of a SELECT. It is one more
+// than maxrows, so that verifyRowCount fails results that exceed maxrows, unless a ROW_LIMIT
+// query rule lowers it.
+func (qre *QueryExecutor) getMaxLimit(maxrows int64) int64 {
+	if sa := qre.softActions; sa != nil && sa.RowLimit > 0 && sa.RowLimit <= maxrows {
+		return sa.RowLimit
+	}
+	return maxrows + 1
+}
+
+// applyRowLimit truncates the result of a SELECT to the ROW_LIMIT of the query rules. This
+// covers the LIMITs that addRowLimit cannot lower in the SQL.
+func (qre *QueryExecutor) applyRowLimit(qr *sqltypes.Result) *sqltypes.Result {
+	sa := qre.softActions
+	if sa == nil || sa.RowLimit <= 0 || int64(len(qr.Rows)) <= sa.RowLimit {
+		return qr
+	}
+	// The result may be shared with consolidated queries.
+	truncated := qr.ShallowCopy()
+	truncated.Rows = qr.Rows[:sa.RowLimit]
+	return truncated
+}
+
+// limitStreamRows wraps the callback of a streaming query to truncate the stream to the
+// ROW_LIMIT of the query rules. generateFinalSQL already adds the limit to the SQL of a
+// SELECT, so this only drops rows for the statements it could not add it to.
+func (qre *QueryExecutor) limitStreamRows(callback StreamCallback) StreamCallback {
+	sa := qre.softActions
+	if sa == nil || sa.RowLimit <= 0 {
+		return callback
+	}
+	remaining := sa.RowLimit
+	return func(result *sqltypes.Result) error {
+		if int64(len(result.Rows)) <= remaining {
+			remaining -= int64(len(result.Rows))
+			return callback(result)
+		}
+		if remaining == 0 && len(result.Fields) == 0 {
+			return nil
+		}
+		// The result may be shared with consolidated streams.
+		truncated := result.ShallowCopy()
+		truncated.Rows = result.Rows[:remaining]
+		remaining = 0
+		return callback(truncated)
+	}
+}
+
 func (qre *QueryExecutor) verifyRowCount(count, maxrows int64) error {
 	if count > maxrows {
 		callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
@@ -911,6 +1016,12 @@ func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, b
 	if err != nil {
 		return "", "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s", err)
 	}
+	if sa := qre.softActions; sa != nil && (sa.MaxExecutionTime > 0 || sa.RowLimit > 0) {
+		query, err = applySoftActionsToSQL(qre.tsv.env.Parser(), query, sa)
+		if err != nil {
+			return "", "", err
+		}
+	}
 	if qre.tsv.config.AnnotateQueries {
 		username := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(qre.ctx))
 		if username == "" {
@@ -940,6 +1051,69 @@ func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, b
 	return buf.String(), query, nil
 }
 
+// applySoftActionsToSQL adds the MAX_EXECUTION_TIME and ROW_LIMIT soft actions of the query
+// rules to a generated SELECT, so that MySQL stops executing it at the limits. Other statements
+// are returned as is.
+func applySoftActionsToSQL(parser *sqlparser.Parser, query string, sa *rules.SoftActions) (string, error) {
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s", err)
+	}
+	sel, ok := stmt.(sqlparser.SelectStatement)
+	if !ok {
+		return query, nil
+	}
+	changed := false
+	if s, ok := sel.(*sqlparser.Select); ok && sa.MaxExecutionTime > 0 {
+		addMaxExecutionTimeHint(s, sa.MaxExecutionTime)
+		changed = true
+	}
+	if sa.RowLimit > 0 && addRowLimit(sel, sa.RowLimit) {
+		changed = true
+	}
+	if !changed {
+		return query, nil
+	}
+	return sqlparser.String(sel), nil
+}
+
+// addMaxExecutionTimeHint adds a MAX_EXECUTION_TIME optimizer hint to a SELECT. MySQL only
+// honors the hint on a top-level SELECT, so it is not added to a UNION. MySQL also only honors
+// the first optimizer hint comment of a SELECT, and the first of duplicate hints in it, so the
+// hint goes first in any existing optimizer hint comment.
+func addMaxExecutionTimeHint(sel *sqlparser.Select, maxExecutionTime time.Duration) {
+	const hintPrefix = "/*+"
+	hint := fmt.Sprintf("MAX_EXECUTION_TIME(%d)", maxExecutionTime.Milliseconds())
+	comments := slices.Clone(sel.GetParsedComments().GetComments())
+	if i := slices.IndexFunc(comments, func(comment string) bool { return strings.HasPrefix(comment, hintPrefix) }); i >= 0 {
+		comments[i] = hintPrefix + " " + hint + " " + strings.TrimLeft(comments[i][len(hintPrefix):], " ")
+	} else {
+		comments = slices.Insert(comments, 0, hintPrefix+" "+hint+" */")
+	}
+	sel.SetComments(comments)
+}
+
+// addRowLimit lowers the LIMIT of a SELECT or UNION to rowLimit, or adds one, and reports
+// whether it changed the statement. A LIMIT that is not a plain number is left as is, and the
+// rows past the ROW_LIMIT are then dropped by applyRowLimit or limitStreamRows.
+func addRowLimit(sel sqlparser.SelectStatement, rowLimit int64) bool {
+	rowcount := sqlparser.NewIntLiteral(strconv.FormatInt(rowLimit, 10))
+	limit := sel.GetLimit()
+	if limit == nil {
+		sel.SetLimit(&sqlparser.Limit{Rowcount: rowcount})
+		return true
+	}
+	lit, ok := limit.Rowcount.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.IntVal {
+		return false
+	}
+	if n, err := strconv.ParseInt(lit.Val, 10, 64); err != nil || n <= rowLimit {
+		return false
+	}
+	limit.Rowcount = rowcount
+	return true
+}
+
 func rewriteOUTParamError(err error) error {
 	sqlErr, ok := err.(*sqlerror.SQLError)
 	if !ok {
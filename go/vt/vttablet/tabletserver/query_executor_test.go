@@ -1270,6 +1270,152 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorSoftActions(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	// The ROW_LIMIT lowers the LIMIT of the query, so MySQL stops at it. The result has an
+	// extra row to check that the rows past the limit are dropped anyway.
+	hintedQuery := "select /*+ MAX_EXECUTION_TIME(500) */ * from test_table where `name` = 1 limit 2"
+	fields := getTestTableFields()
+	db.AddQuery(hintedQuery, sqltypes.MakeTestResult(fields, "1|1|1", "2|2|2", "3|3|3"))
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: fields,
+	})
+
+	timeRule := rules.NewQueryRule("max execution time", "max execution time", rules.QRMaxExecutionTime)
+	timeRule.SetMaxExecutionTime(500 * time.Millisecond)
+	timeRule.AddTableCond("test_table")
+	limitRule := rules.NewQueryRule("row limit", "row limit", rules.QRRowLimit)
+	limitRule.SetRowLimit(2)
+	limitRule.AddTableCond("test_table")
+
+	rulesName := "softActionRules"
+	qrs := rules.New()
+	qrs.Add(timeRule)
+	qrs.Add(limitRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	qr, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 2)
+	assert.EqualValues(t, 1, tsv.stats.QueryRuleSoftActions.Counts()["max execution time.MAX_EXECUTION_TIME"])
+	assert.EqualValues(t, 1, tsv.stats.QueryRuleSoftActions.Counts()["row limit.ROW_LIMIT"])
+
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	var streamed []sqltypes.Row
+	err = qre.Stream(func(result *sqltypes.Result) error {
+		streamed = append(streamed, result.Rows...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, streamed, 2)
+	assert.EqualValues(t, 2, tsv.stats.QueryRuleSoftActions.Counts()["row limit.ROW_LIMIT"])
+}
+
+func TestQueryExecutorSoftActionConcurrencyLimit(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	limitRule := rules.NewQueryRule("concurrency limit", "concurrency limit", rules.QRConcurrencyLimit)
+	limitRule.SetMaxConcurrency(1)
+	limitRule.AddTableCond("test_table")
+
+	rulesName := "softActionConcurrencyRules"
+	qrs := rules.New()
+	qrs.Add(limitRule)
+
+	tsv := newTestTabletServer(context.Background(), noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+
+	qre := newTestQueryExecutor(context.Background(), tsv, query, 0)
+	_, err := qre.Execute()
+	require.NoError(t, err)
+
+	// Hold the only slot so that the next query times out waiting for it.
+	holder := newTestQueryExecutor(context.Background(), tsv, query, 0)
+	require.NoError(t, holder.checkPermissions())
+	release, err := holder.applySoftActions()
+	require.NoError(t, err)
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+}
+
+func TestApplySoftActionsToSQL(t *testing.T) {
+	testcases := []struct {
+		query       string
+		softActions rules.SoftActions
+		want        string
+	}{{
+		query:       "select a from t",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond},
+		want:        "select /*+ MAX_EXECUTION_TIME(1500) */ a from t",
+	}, {
+		query:       "select /*+ SET_VAR(sort_buffer_size = 16M) */ a from t",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond},
+		want:        "select /*+ MAX_EXECUTION_TIME(1500) SET_VAR(sort_buffer_size = 16M) */ a from t",
+	}, {
+		query:       "select /* comment */ a from t",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond},
+		want:        "select /*+ MAX_EXECUTION_TIME(1500) */ /* comment */ a from t",
+	}, {
+		query:       "(select a from t) union (select b from u)",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond},
+		want:        "(select a from t) union (select b from u)",
+	}, {
+		query:       "update t set a = 1",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond, RowLimit: 10},
+		want:        "update t set a = 1",
+	}, {
+		query:       "select a from t",
+		softActions: rules.SoftActions{RowLimit: 10},
+		want:        "select a from t limit 10",
+	}, {
+		query:       "select a from t limit 5, 100",
+		softActions: rules.SoftActions{RowLimit: 10},
+		want:        "select a from t limit 5, 10",
+	}, {
+		query:       "select a from t limit 3",
+		softActions: rules.SoftActions{RowLimit: 10},
+		want:        "select a from t limit 3",
+	}, {
+		query:       "(select a from t) union (select b from u)",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond, RowLimit: 10},
+		want:        "select a from t union select b from u limit 10",
+	}, {
+		query:       "select a from t for update",
+		softActions: rules.SoftActions{MaxExecutionTime: 1500 * time.Millisecond, RowLimit: 10},
+		want:        "select /*+ MAX_EXECUTION_TIME(1500) */ a from t limit 10 for update",
+	}}
+	parser := sqlparser.NewTestParser()
+	for _, tc := range testcases {
+		got, err := applySoftActionsToSQL(parser, tc.query, &tc.softActions)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.query)
+	}
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -34,9 +34,57 @@ type Config struct {
 
 	// Strategy selects which throttling strategy should be used.
 	Strategy registry.ThrottlingStrategy `json:"strategy"`
+
+	// TabletThrottler configures the TabletThrottler strategy.
+	TabletThrottler TabletThrottlerConfig `json:"tablet_throttler"`
 }
 
 // GetStrategy implements registry.StrategyConfig interface
 func (c Config) GetStrategy() registry.ThrottlingStrategy {
 	return c.Strategy
 }
+
+// TabletThrottlerConfig configures the TabletThrottler strategy, which throttles queries
+// based on the metrics collected by the tablet throttler.
+type TabletThrottlerConfig struct {
+	// Metrics lists the tablet throttler metrics to consult, e.g. "lag", "threads_running" or
+	// "history_list_length". When empty, the metrics assigned to the query throttler app in the
+	// tablet throttler are used.
+	Metrics []MetricRule `json:"metrics"`
+
+	// TabletTypes restricts throttling to queries that target the given tablet types, e.g. "PRIMARY".
+	// When empty, queries to all tablet types are subject to throttling.
+	TabletTypes []string `json:"tablet_types"`
+
+	// DefaultQuota applies to all workloads that don't have a quota in Workloads.
+	DefaultQuota WorkloadQuota `json:"default_quota"`
+
+	// Workloads holds per-workload quotas, keyed by the workload name given with the
+	// WORKLOAD_NAME query directive.
+	Workloads map[string]WorkloadQuota `json:"workloads"`
+}
+
+// MetricRule is a tablet throttler metric consulted by the TabletThrottler strategy.
+type MetricRule struct {
+	// Name is the name of the metric in the tablet throttler.
+	Name string `json:"name"`
+
+	// Threshold overrides the threshold configured for the metric in the tablet throttler.
+	// When zero, the metric is considered exceeded whenever the tablet throttler check fails.
+	Threshold float64 `json:"threshold"`
+}
+
+// WorkloadQuota defines how the queries of a workload are throttled while a metric is exceeded.
+type WorkloadQuota struct {
+	// Exempt disables throttling for the workload.
+	Exempt bool `json:"exempt"`
+
+	// ThrottleRatio is the fraction (0.0-1.0) of the workload's lowest priority queries that are
+	// throttled. The ratio is scaled by the query's priority, so queries with PRIORITY=0 are never
+	// throttled. When zero, all of the lowest priority queries are throttled.
+	ThrottleRatio float64 `json:"throttle_ratio"`
+
+	// Delay, when set, makes throttled queries wait for the given duration (e.g. "250ms") for the
+	// metrics to recover before they get rejected.
+	Delay string `json:"delay"`
+}
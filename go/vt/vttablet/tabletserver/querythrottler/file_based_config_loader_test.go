@@ -102,6 +102,39 @@ func TestFileBasedConfigLoader_Load(t *testing.T) {
 				DryRun:   true,
 			},
 		},
+		{
+			name:       "successful config load with tablet throttler strategy config",
+			configPath: "/config/throttler-config.json",
+			mockReadFile: func(filename string) ([]byte, error) {
+				require.Equal(t, "/config/throttler-config.json", filename)
+				return []byte(`{
+					"enabled": true,
+					"strategy": "TabletThrottler",
+					"tablet_throttler": {
+						"metrics": [{"name": "lag", "threshold": 5}, {"name": "threads_running"}],
+						"tablet_types": ["PRIMARY"],
+						"default_quota": {"throttle_ratio": 0.5},
+						"workloads": {"oltp": {"exempt": true}, "batch": {"delay": "250ms"}}
+					}
+				}`), nil
+			},
+			mockJsonUnmarshal: func(data []byte, v interface{}) error {
+				return json.Unmarshal(data, v)
+			},
+			expectedConfig: Config{
+				Enabled:  true,
+				Strategy: registry.ThrottlingStrategyTabletThrottler,
+				TabletThrottler: TabletThrottlerConfig{
+					Metrics:      []MetricRule{{Name: "lag", Threshold: 5}, {Name: "threads_running"}},
+					TabletTypes:  []string{"PRIMARY"},
+					DefaultQuota: WorkloadQuota{ThrottleRatio: 0.5},
+					Workloads: map[string]WorkloadQuota{
+						"oltp":  {Exempt: true},
+						"batch": {Delay: "250ms"},
+					},
+				},
+			},
+		},
 		{
 			name:       "file read error - permission denied",
 			configPath: "/config/throttler-config.json",
@@ -121,6 +121,22 @@ func (qt *QueryThrottler) Throttle(ctx context.Context, tabletType topodatapb.Ta
 		return nil
 	}
 
+	// The strategy asked to hold the query for a while: give it a second chance before rejecting it.
+	// Only the load is checked again, so that the delay doesn't change the throttling probability.
+	if reevaluator, ok := qt.strategy.(registry.ReevaluatingStrategy); ok && decision.Delay > 0 {
+		timer := time.NewTimer(decision.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return vterrors.New(vtrpcpb.Code_RESOURCE_EXHAUSTED, decision.Message)
+		case <-timer.C:
+		}
+		decision = reevaluator.Reevaluate(decision)
+		if !decision.Throttle {
+			return nil
+		}
+	}
+
 	// Normal throttling: return an error to reject the query
 	return vterrors.New(vtrpcpb.Code_RESOURCE_EXHAUSTED, decision.Message)
 }
@@ -204,6 +220,11 @@ func (qt *QueryThrottler) startConfigRefreshLoop() {
 					if qt.strategy != nil {
 						qt.strategy.Start()
 					}
+				} else if configurable, ok := qt.strategy.(registry.ConfigurableStrategy); ok {
+					// Same strategy: apply the new configuration (e.g. workload quotas) in place
+					if err := configurable.UpdateConfig(newCfg); err != nil {
+						log.Errorf("Failed to update the configuration of strategy %s: %v", newCfg.Strategy, err)
+					}
 				}
 
 				// Always update the configuration
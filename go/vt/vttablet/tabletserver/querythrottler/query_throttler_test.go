@@ -19,6 +19,7 @@ package querythrottler
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -303,6 +304,108 @@ func TestIncomingQueryThrottler_DryRunMode(t *testing.T) {
 	}
 }
 
+// TestQueryThrottler_Delay tests that queries are given a second chance when the strategy asks for a delay.
+func TestQueryThrottler_Delay(t *testing.T) {
+	delayed := registry.ThrottleDecision{
+		Throttle: true,
+		Message:  "Query throttled: metric=lag value=10.0 threshold=5.0",
+		Delay:    10 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name        string
+		decisions   []registry.ThrottleDecision
+		expectError bool
+	}{
+		{
+			name:      "allowed after the delay",
+			decisions: []registry.ThrottleDecision{delayed, {Throttle: false}},
+		},
+		{
+			name:        "rejected after the delay",
+			decisions:   []registry.ThrottleDecision{delayed, delayed},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := &sequenceThrottlingStrategy{decisions: tt.decisions}
+			iqt := &QueryThrottler{
+				ctx:      context.Background(),
+				cfg:      Config{Enabled: true},
+				strategy: strategy,
+			}
+
+			start := time.Now()
+			err := iqt.Throttle(context.Background(), topodatapb.TabletType_PRIMARY, &sqlparser.ParsedQuery{Query: "SELECT 1"}, 0, nil)
+			require.GreaterOrEqual(t, time.Since(start), delayed.Delay)
+			require.Equal(t, 2, strategy.evaluations)
+			if tt.expectError {
+				require.EqualError(t, err, delayed.Message)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestQueryThrottler_DelayKeepsThrottleRatio tests that delaying throttled queries doesn't change the
+// share of queries that get rejected while the load stays high.
+func TestQueryThrottler_DelayKeepsThrottleRatio(t *testing.T) {
+	const (
+		queries       = 2000
+		throttleRatio = 0.5
+	)
+
+	strategy := newTestTabletThrottlerStrategy(t, TabletThrottlerConfig{
+		DefaultQuota: WorkloadQuota{ThrottleRatio: throttleRatio, Delay: "1us"},
+	}, map[string]*throttle.MetricResult{"lag": metricResult(10, 5)}, 0)
+	random := rand.New(rand.NewPCG(1, 2))
+	strategy.random = random.Float64
+
+	iqt := &QueryThrottler{
+		ctx:      context.Background(),
+		cfg:      Config{Enabled: true},
+		strategy: strategy,
+	}
+
+	rejected := 0
+	for range queries {
+		if err := iqt.Throttle(context.Background(), topodatapb.TabletType_PRIMARY, &sqlparser.ParsedQuery{Query: "SELECT 1"}, 0, nil); err != nil {
+			rejected++
+		}
+	}
+	require.InDelta(t, throttleRatio, float64(rejected)/queries, 0.05)
+
+	// Once the load is gone, delayed queries are let through.
+	strategy.lastCheck.Store(&throttle.CheckResult{Metrics: map[string]*throttle.MetricResult{"lag": metricResult(1, 5)}})
+	decision := strategy.Reevaluate(registry.ThrottleDecision{Throttle: true, Delay: time.Microsecond})
+	require.False(t, decision.Throttle)
+}
+
+// sequenceThrottlingStrategy is a test strategy that returns the given decisions in order
+type sequenceThrottlingStrategy struct {
+	decisions   []registry.ThrottleDecision
+	evaluations int
+}
+
+func (s *sequenceThrottlingStrategy) Evaluate(ctx context.Context, targetTabletType topodatapb.TabletType, parsedQuery *sqlparser.ParsedQuery, transactionID int64, attrs registry.QueryAttributes) registry.ThrottleDecision {
+	decision := s.decisions[s.evaluations]
+	s.evaluations++
+	return decision
+}
+
+func (s *sequenceThrottlingStrategy) Reevaluate(decision registry.ThrottleDecision) registry.ThrottleDecision {
+	decision = s.decisions[s.evaluations]
+	s.evaluations++
+	return decision
+}
+
+func (s *sequenceThrottlingStrategy) Start() {}
+
+func (s *sequenceThrottlingStrategy) Stop() {}
+
 // mockThrottlingStrategy is a test strategy that allows us to control throttling decisions
 type mockThrottlingStrategy struct {
 	decision registry.ThrottleDecision
@@ -17,6 +17,8 @@ limitations under the License.
 package registry
 
 import (
+	"time"
+
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
 )
@@ -52,6 +54,10 @@ type ThrottleDecision struct {
 
 	// ThrottlePercentage contains the percentage chance this query was throttled (0.0-1.0).
 	ThrottlePercentage float64
+
+	// Delay, when non-zero, asks for the query to be held for the given duration and evaluated
+	// once more before it gets rejected, instead of being rejected right away.
+	Delay time.Duration
 }
 
 // StrategyConfig defines the configuration interface that strategy implementations
@@ -66,6 +72,21 @@ type Deps struct {
 	TabletConfig   *tabletenv.TabletConfig
 }
 
+// ConfigurableStrategy is implemented by strategies that can apply a new configuration in place.
+// Strategies that don't implement it keep the configuration they were created with until the
+// QueryThrottler switches to a different strategy.
+type ConfigurableStrategy interface {
+	UpdateConfig(cfg StrategyConfig) error
+}
+
+// ReevaluatingStrategy is implemented by strategies that ask for throttled queries to be delayed.
+// Once the delay is over, Reevaluate re-checks the load signals behind a decision to throttle,
+// without drawing the throttling probability again: the query is only let through if the load
+// that throttled it has gone away. Strategies that don't implement it keep their first decision.
+type ReevaluatingStrategy interface {
+	Reevaluate(decision ThrottleDecision) ThrottleDecision
+}
+
 // StrategyFactory creates a new strategy instance with the given dependencies and configuration.
 type StrategyFactory interface {
 	New(deps Deps, cfg StrategyConfig) (ThrottlingStrategyHandler, error)
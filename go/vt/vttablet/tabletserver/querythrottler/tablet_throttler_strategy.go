@@ -0,0 +1,318 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querythrottler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/querythrottler/registry"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+)
+
+// tabletThrottlerCheckInterval is how often the TabletThrottler strategy refreshes the metrics.
+// Queries are evaluated against the latest metrics, so that the hot path never checks the throttler.
+const tabletThrottlerCheckInterval = 250 * time.Millisecond
+
+func init() {
+	registry.Register(registry.ThrottlingStrategyTabletThrottler, tabletThrottlerStrategyFactory{})
+}
+
+type tabletThrottlerStrategyFactory struct{}
+
+// New implements registry.StrategyFactory.
+func (tabletThrottlerStrategyFactory) New(deps registry.Deps, cfg registry.StrategyConfig) (registry.ThrottlingStrategyHandler, error) {
+	strategy := &TabletThrottlerStrategy{client: deps.ThrottleClient}
+	if err := strategy.UpdateConfig(cfg); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+var _ registry.ThrottlingStrategyHandler = (*TabletThrottlerStrategy)(nil)
+var _ registry.ConfigurableStrategy = (*TabletThrottlerStrategy)(nil)
+var _ registry.ReevaluatingStrategy = (*TabletThrottlerStrategy)(nil)
+
+// TabletThrottlerStrategy throttles queries while any of the tablet throttler metrics it
+// consults is above its threshold. The queries of each workload are throttled according to
+// the workload quota: low priority queries are throttled first, and high priority queries
+// (PRIORITY=0) are never throttled.
+type TabletThrottlerStrategy struct {
+	client *throttle.Client
+
+	cfg       atomic.Pointer[tabletThrottlerRules]
+	lastCheck atomic.Pointer[throttle.CheckResult]
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+
+	// random returns a number in [0.0, 1.0), and can be replaced in tests.
+	random func() float64
+}
+
+// tabletThrottlerRules is the validated form of TabletThrottlerConfig.
+type tabletThrottlerRules struct {
+	metrics      []MetricRule
+	metricNames  base.MetricNames
+	tabletTypes  map[topodatapb.TabletType]bool
+	defaultQuota workloadRules
+	workloads    map[string]workloadRules
+}
+
+type workloadRules struct {
+	exempt        bool
+	throttleRatio float64
+	delay         time.Duration
+}
+
+func newWorkloadRules(name string, quota WorkloadQuota) (workloadRules, error) {
+	rules := workloadRules{
+		exempt:        quota.Exempt,
+		throttleRatio: quota.ThrottleRatio,
+	}
+	if rules.throttleRatio < 0 || rules.throttleRatio > 1 {
+		return workloadRules{}, fmt.Errorf("invalid throttle_ratio %v for workload %s: must be between 0 and 1", quota.ThrottleRatio, name)
+	}
+	if rules.throttleRatio == 0 {
+		rules.throttleRatio = 1
+	}
+	if quota.Delay != "" {
+		delay, err := time.ParseDuration(quota.Delay)
+		if err != nil || delay < 0 {
+			return workloadRules{}, fmt.Errorf("invalid delay %q for workload %s", quota.Delay, name)
+		}
+		rules.delay = delay
+	}
+	return rules, nil
+}
+
+func newTabletThrottlerRules(cfg TabletThrottlerConfig) (*tabletThrottlerRules, error) {
+	rules := &tabletThrottlerRules{
+		metrics:   cfg.Metrics,
+		workloads: make(map[string]workloadRules, len(cfg.Workloads)),
+	}
+
+	for _, metric := range cfg.Metrics {
+		if metric.Name == "" {
+			return nil, fmt.Errorf("metric name cannot be empty")
+		}
+		if metric.Threshold < 0 {
+			return nil, fmt.Errorf("invalid threshold %v for metric %s", metric.Threshold, metric.Name)
+		}
+		rules.metricNames = append(rules.metricNames, base.MetricName(metric.Name))
+	}
+
+	if len(cfg.TabletTypes) > 0 {
+		rules.tabletTypes = make(map[topodatapb.TabletType]bool, len(cfg.TabletTypes))
+		for _, tt := range cfg.TabletTypes {
+			tabletType, err := topoproto.ParseTabletType(tt)
+			if err != nil {
+				return nil, err
+			}
+			rules.tabletTypes[tabletType] = true
+		}
+	}
+
+	var err error
+	rules.defaultQuota, err = newWorkloadRules("default", cfg.DefaultQuota)
+	if err != nil {
+		return nil, err
+	}
+	for name, quota := range cfg.Workloads {
+		rules.workloads[name], err = newWorkloadRules(name, quota)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func (r *tabletThrottlerRules) workload(name string) workloadRules {
+	if rules, ok := r.workloads[name]; ok {
+		return rules
+	}
+	return r.defaultQuota
+}
+
+// exceededMetric returns the first consulted metric that is above its threshold in the given check result.
+func (r *tabletThrottlerRules) exceededMetric(check *throttle.CheckResult) (name string, value float64, threshold float64, exceeded bool) {
+	if len(r.metrics) == 0 {
+		for name, metric := range check.Metrics {
+			if !metric.IsOK() {
+				return name, metric.Value, metric.Threshold, true
+			}
+		}
+		return "", 0, 0, false
+	}
+
+	for _, rule := range r.metrics {
+		metric, ok := check.Metrics[rule.Name]
+		if !ok {
+			continue
+		}
+		if rule.Threshold > 0 {
+			if metric.Error == nil && metric.Value >= rule.Threshold {
+				return rule.Name, metric.Value, rule.Threshold, true
+			}
+			continue
+		}
+		if !metric.IsOK() {
+			return rule.Name, metric.Value, metric.Threshold, true
+		}
+	}
+	return "", 0, 0, false
+}
+
+// UpdateConfig implements registry.ConfigurableStrategy.
+func (s *TabletThrottlerStrategy) UpdateConfig(cfg registry.StrategyConfig) error {
+	c, ok := cfg.(Config)
+	if !ok {
+		return fmt.Errorf("unexpected config type %T for strategy %s", cfg, registry.ThrottlingStrategyTabletThrottler)
+	}
+	rules, err := newTabletThrottlerRules(c.TabletThrottler)
+	if err != nil {
+		return err
+	}
+	s.cfg.Store(rules)
+	return nil
+}
+
+// Evaluate implements registry.ThrottlingStrategyHandler.
+func (s *TabletThrottlerStrategy) Evaluate(ctx context.Context, targetTabletType topodatapb.TabletType, parsedQuery *sqlparser.ParsedQuery, transactionID int64, attrs registry.QueryAttributes) registry.ThrottleDecision {
+	rules := s.cfg.Load()
+	check := s.lastCheck.Load()
+	if rules == nil || check == nil {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: no throttler metrics available"}
+	}
+	if rules.tabletTypes != nil && !rules.tabletTypes[targetTabletType] {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: tablet type not throttled"}
+	}
+
+	workload := rules.workload(attrs.WorkloadName)
+	if workload.exempt {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: workload exempt from throttling"}
+	}
+
+	metricName, value, threshold, exceeded := rules.exceededMetric(check)
+	if !exceeded {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: all metrics below threshold"}
+	}
+
+	percentage := workload.throttleRatio * float64(attrs.Priority) / defaultPriority
+	decision := registry.ThrottleDecision{
+		MetricName:         metricName,
+		MetricValue:        value,
+		Threshold:          threshold,
+		ThrottlePercentage: percentage,
+		Delay:              workload.delay,
+	}
+	if percentage <= 0 || s.randomFloat() >= percentage {
+		decision.Message = fmt.Sprintf("TabletThrottlerStrategy: query allowed for workload %s with priority %d", attrs.WorkloadName, attrs.Priority)
+		return decision
+	}
+
+	decision.Throttle = true
+	decision.Message = fmt.Sprintf("[VTTabletThrottler] Query throttled: workload=%s priority=%d metric=%s value=%.2f threshold=%.2f throttle=%.1f%%",
+		attrs.WorkloadName, attrs.Priority, metricName, value, threshold, percentage*100)
+	return decision
+}
+
+// Reevaluate implements registry.ReevaluatingStrategy. The query keeps being throttled while any
+// of the consulted metrics is above its threshold in the latest throttler check.
+func (s *TabletThrottlerStrategy) Reevaluate(decision registry.ThrottleDecision) registry.ThrottleDecision {
+	rules := s.cfg.Load()
+	check := s.lastCheck.Load()
+	if rules == nil || check == nil {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: no throttler metrics available"}
+	}
+
+	metricName, value, threshold, exceeded := rules.exceededMetric(check)
+	if !exceeded {
+		return registry.ThrottleDecision{Message: "TabletThrottlerStrategy: all metrics below threshold"}
+	}
+
+	decision.MetricName = metricName
+	decision.MetricValue = value
+	decision.Threshold = threshold
+	return decision
+}
+
+func (s *TabletThrottlerStrategy) randomFloat() float64 {
+	if s.random != nil {
+		return s.random()
+	}
+	return rand.Float64()
+}
+
+// refresh checks the tablet throttler and stores the result for Evaluate to use.
+func (s *TabletThrottlerStrategy) refresh(ctx context.Context) {
+	rules := s.cfg.Load()
+	if rules == nil {
+		return
+	}
+	check := s.client.CheckMetrics(ctx, rules.metricNames)
+	if check.Error != nil && len(check.Metrics) == 0 {
+		log.Warningf("TabletThrottlerStrategy: throttler check failed: %v", check.Error)
+	}
+	s.lastCheck.Store(check)
+}
+
+// Start implements registry.ThrottlingStrategyHandler. It starts refreshing the
+// throttler metrics in the background.
+func (s *TabletThrottlerStrategy) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(tabletThrottlerCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			s.refresh(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop implements registry.ThrottlingStrategyHandler.
+func (s *TabletThrottlerStrategy) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querythrottler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/querythrottler/registry"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+)
+
+func newTestTabletThrottlerStrategy(t *testing.T, cfg TabletThrottlerConfig, metrics map[string]*throttle.MetricResult, random float64) *TabletThrottlerStrategy {
+	strategy, err := tabletThrottlerStrategyFactory{}.New(registry.Deps{}, Config{
+		Enabled:         true,
+		Strategy:        registry.ThrottlingStrategyTabletThrottler,
+		TabletThrottler: cfg,
+	})
+	require.NoError(t, err)
+
+	s := strategy.(*TabletThrottlerStrategy)
+	s.lastCheck.Store(&throttle.CheckResult{Metrics: metrics})
+	s.random = func() float64 { return random }
+	return s
+}
+
+func metricResult(value, threshold float64) *throttle.MetricResult {
+	code := tabletmanagerdatapb.CheckThrottlerResponseCode_OK
+	if value >= threshold {
+		code = tabletmanagerdatapb.CheckThrottlerResponseCode_THRESHOLD_EXCEEDED
+	}
+	return &throttle.MetricResult{ResponseCode: code, Value: value, Threshold: threshold}
+}
+
+func TestTabletThrottlerStrategy_Registered(t *testing.T) {
+	factory, ok := registry.Get(registry.ThrottlingStrategyTabletThrottler)
+	require.True(t, ok)
+	require.IsType(t, tabletThrottlerStrategyFactory{}, factory)
+
+	strategy := registry.CreateStrategy(Config{Strategy: registry.ThrottlingStrategyTabletThrottler}, registry.Deps{})
+	require.IsType(t, &TabletThrottlerStrategy{}, strategy)
+
+	// invalid configurations fall back to the NoOp strategy
+	strategy = registry.CreateStrategy(Config{
+		Strategy:        registry.ThrottlingStrategyTabletThrottler,
+		TabletThrottler: TabletThrottlerConfig{TabletTypes: []string{"NOT_A_TABLET_TYPE"}},
+	}, registry.Deps{})
+	require.IsType(t, &registry.NoOpStrategy{}, strategy)
+}
+
+func TestTabletThrottlerStrategy_Config(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           TabletThrottlerConfig
+		expectedError string
+	}{
+		{
+			name: "empty config",
+		},
+		{
+			name: "full config",
+			cfg: TabletThrottlerConfig{
+				Metrics:      []MetricRule{{Name: "lag", Threshold: 5}, {Name: "threads_running"}},
+				TabletTypes:  []string{"PRIMARY", "replica"},
+				DefaultQuota: WorkloadQuota{ThrottleRatio: 0.5},
+				Workloads: map[string]WorkloadQuota{
+					"oltp":  {Exempt: true},
+					"batch": {Delay: "100ms"},
+				},
+			},
+		},
+		{
+			name:          "empty metric name",
+			cfg:           TabletThrottlerConfig{Metrics: []MetricRule{{Threshold: 5}}},
+			expectedError: "metric name cannot be empty",
+		},
+		{
+			name:          "negative threshold",
+			cfg:           TabletThrottlerConfig{Metrics: []MetricRule{{Name: "lag", Threshold: -1}}},
+			expectedError: "invalid threshold -1 for metric lag",
+		},
+		{
+			name:          "invalid throttle ratio",
+			cfg:           TabletThrottlerConfig{Workloads: map[string]WorkloadQuota{"batch": {ThrottleRatio: 1.5}}},
+			expectedError: "invalid throttle_ratio 1.5 for workload batch: must be between 0 and 1",
+		},
+		{
+			name:          "invalid delay",
+			cfg:           TabletThrottlerConfig{DefaultQuota: WorkloadQuota{Delay: "soon"}},
+			expectedError: `invalid delay "soon" for workload default`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTabletThrottlerRules(tt.cfg)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTabletThrottlerStrategy_Evaluate(t *testing.T) {
+	cfg := TabletThrottlerConfig{
+		Metrics:      []MetricRule{{Name: "lag", Threshold: 5}, {Name: "threads_running"}},
+		TabletTypes:  []string{"PRIMARY"},
+		DefaultQuota: WorkloadQuota{ThrottleRatio: 0.5},
+		Workloads: map[string]WorkloadQuota{
+			"oltp":  {Exempt: true},
+			"batch": {Delay: "100ms"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		metrics          map[string]*throttle.MetricResult
+		tabletType       topodatapb.TabletType
+		attrs            registry.QueryAttributes
+		random           float64
+		expectedThrottle bool
+		expectedMetric   string
+		expectedPercent  float64
+		expectedDelay    time.Duration
+	}{
+		{
+			name:       "metrics below threshold",
+			metrics:    map[string]*throttle.MetricResult{"lag": metricResult(1, 10), "threads_running": metricResult(10, 100)},
+			tabletType: topodatapb.TabletType_PRIMARY,
+			attrs:      registry.QueryAttributes{WorkloadName: "batch", Priority: 100},
+		},
+		{
+			name:             "lag above the configured threshold",
+			metrics:          map[string]*throttle.MetricResult{"lag": metricResult(6, 10), "threads_running": metricResult(10, 100)},
+			tabletType:       topodatapb.TabletType_PRIMARY,
+			attrs:            registry.QueryAttributes{WorkloadName: "batch", Priority: 100},
+			expectedThrottle: true,
+			expectedMetric:   "lag",
+			expectedPercent:  1,
+			expectedDelay:    100 * time.Millisecond,
+		},
+		{
+			name:             "threads_running above the throttler threshold",
+			metrics:          map[string]*throttle.MetricResult{"lag": metricResult(1, 10), "threads_running": metricResult(200, 100)},
+			tabletType:       topodatapb.TabletType_PRIMARY,
+			attrs:            registry.QueryAttributes{WorkloadName: "batch", Priority: 100},
+			expectedThrottle: true,
+			expectedMetric:   "threads_running",
+			expectedPercent:  1,
+			expectedDelay:    100 * time.Millisecond,
+		},
+		{
+			name:       "unconsulted metrics are ignored",
+			metrics:    map[string]*throttle.MetricResult{"loadavg": metricResult(20, 1)},
+			tabletType: topodatapb.TabletType_PRIMARY,
+			attrs:      registry.QueryAttributes{WorkloadName: "batch", Priority: 100},
+		},
+		{
+			name:       "tablet type not throttled",
+			metrics:    map[string]*throttle.MetricResult{"lag": metricResult(20, 10)},
+			tabletType: topodatapb.TabletType_REPLICA,
+			attrs:      registry.QueryAttributes{WorkloadName: "batch", Priority: 100},
+		},
+		{
+			name:       "exempt workload",
+			metrics:    map[string]*throttle.MetricResult{"lag": metricResult(20, 10)},
+			tabletType: topodatapb.TabletType_PRIMARY,
+			attrs:      registry.QueryAttributes{WorkloadName: "oltp", Priority: 100},
+		},
+		{
+			name:            "highest priority is never throttled",
+			metrics:         map[string]*throttle.MetricResult{"lag": metricResult(20, 10)},
+			tabletType:      topodatapb.TabletType_PRIMARY,
+			attrs:           registry.QueryAttributes{WorkloadName: "batch", Priority: 0},
+			expectedMetric:  "lag",
+			expectedPercent: 0,
+			expectedDelay:   100 * time.Millisecond,
+		},
+		{
+			name:             "default quota is scaled by priority",
+			metrics:          map[string]*throttle.MetricResult{"lag": metricResult(20, 10)},
+			tabletType:       topodatapb.TabletType_PRIMARY,
+			attrs:            registry.QueryAttributes{WorkloadName: "default", Priority: 50},
+			random:           0.2,
+			expectedThrottle: true,
+			expectedMetric:   "lag",
+			expectedPercent:  0.25,
+		},
+		{
+			name:            "query allowed by chance",
+			metrics:         map[string]*throttle.MetricResult{"lag": metricResult(20, 10)},
+			tabletType:      topodatapb.TabletType_PRIMARY,
+			attrs:           registry.QueryAttributes{WorkloadName: "default", Priority: 50},
+			random:          0.3,
+			expectedMetric:  "lag",
+			expectedPercent: 0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestTabletThrottlerStrategy(t, cfg, tt.metrics, tt.random)
+			decision := s.Evaluate(context.Background(), tt.tabletType, &sqlparser.ParsedQuery{Query: "select 1"}, 0, tt.attrs)
+
+			require.Equal(t, tt.expectedThrottle, decision.Throttle, decision.Message)
+			require.Equal(t, tt.expectedMetric, decision.MetricName)
+			require.InDelta(t, tt.expectedPercent, decision.ThrottlePercentage, 0.0001)
+			require.Equal(t, tt.expectedDelay, decision.Delay)
+		})
+	}
+}
+
+func TestTabletThrottlerStrategy_ThrottlerMetrics(t *testing.T) {
+	// without configured metrics, the checks of the tablet throttler are used as is
+	s := newTestTabletThrottlerStrategy(t, TabletThrottlerConfig{}, map[string]*throttle.MetricResult{
+		"lag":                 metricResult(1, 10),
+		"history_list_length": metricResult(6000, 5000),
+	}, 0)
+
+	decision := s.Evaluate(context.Background(), topodatapb.TabletType_PRIMARY, nil, 0, registry.QueryAttributes{WorkloadName: "default", Priority: 100})
+	require.True(t, decision.Throttle)
+	require.Equal(t, "history_list_length", decision.MetricName)
+	require.Equal(t, float64(6000), decision.MetricValue)
+	require.Equal(t, float64(5000), decision.Threshold)
+	require.Equal(t, "[VTTabletThrottler] Query throttled: workload=default priority=100 metric=history_list_length value=6000.00 threshold=5000.00 throttle=100.0%", decision.Message)
+}
+
+func TestTabletThrottlerStrategy_UpdateConfig(t *testing.T) {
+	metrics := map[string]*throttle.MetricResult{"lag": metricResult(20, 10)}
+	s := newTestTabletThrottlerStrategy(t, TabletThrottlerConfig{}, metrics, 0)
+	attrs := registry.QueryAttributes{WorkloadName: "batch", Priority: 100}
+
+	require.True(t, s.Evaluate(context.Background(), topodatapb.TabletType_PRIMARY, nil, 0, attrs).Throttle)
+
+	err := s.UpdateConfig(Config{TabletThrottler: TabletThrottlerConfig{
+		Workloads: map[string]WorkloadQuota{"batch": {Exempt: true}},
+	}})
+	require.NoError(t, err)
+	require.False(t, s.Evaluate(context.Background(), topodatapb.TabletType_PRIMARY, nil, 0, attrs).Throttle)
+
+	// invalid configurations are rejected and the current one is kept
+	err = s.UpdateConfig(Config{TabletThrottler: TabletThrottlerConfig{TabletTypes: []string{"NOT_A_TABLET_TYPE"}}})
+	require.Error(t, err)
+	require.False(t, s.Evaluate(context.Background(), topodatapb.TabletType_PRIMARY, nil, 0, attrs).Throttle)
+}
+
+func TestTabletThrottlerStrategy_StartStop(t *testing.T) {
+	s := newTestTabletThrottlerStrategy(t, TabletThrottlerConfig{}, nil, 0)
+	s.lastCheck.Store(nil)
+	s.client = &throttle.Client{}
+
+	s.Start()
+	s.Start()
+	require.Eventually(t, func() bool {
+		return s.lastCheck.Load() != nil
+	}, time.Second, 10*time.Millisecond)
+
+	s.Stop()
+	s.Stop()
+}
@@ -39,13 +39,28 @@ func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *ConcurrencyLimiter) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field sem *golang.org/x/sync/semaphore.Weighted
+	if cached.sem != nil {
+		// WARNING: size of external type golang.org/x/sync/semaphore.Weighted cannot be fully calculated
+		size += hack.RuntimeAllocSize(int64(72))
+	}
+	return size
+}
 func (cached *Rule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(288)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -79,6 +94,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field limiter *vitess.io/vitess/go/vt/vttablet/tabletserver/rules.ConcurrencyLimiter
+	size += cached.limiter.CachedSize(true)
 	return size
 }
 func (cached *Rules) CachedSize(alloc bool) int64 {
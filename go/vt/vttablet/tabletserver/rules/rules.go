@@ -21,11 +21,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"reflect"
 	"regexp"
 	"strconv"
 	"time"
 
+	"golang.org/x/sync/semaphore"
+
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -181,13 +185,105 @@ func (qrs *Rules) GetAction(
 	timeout time.Duration,
 	desc string) {
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
+		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue && !act.IsSoft() {
 			return act, qr.cancelCtx, qr.timeout, qr.Description
 		}
 	}
 	return QRContinue, nil, 0, ""
 }
 
+// GetSoftActions runs the input against the rules engine and returns the soft actions
+// of all the matching rules, or nil if no rule with a soft action matches.
+func (qrs *Rules) GetSoftActions(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) *SoftActions {
+	var sa *SoftActions
+	for _, qr := range qrs.rules {
+		act := qr.GetAction(ip, user, bindVars, marginComments)
+		if !act.IsSoft() {
+			continue
+		}
+		if sa == nil {
+			sa = &SoftActions{}
+		}
+		switch act {
+		case QRMaxExecutionTime:
+			if sa.MaxExecutionTime == 0 || qr.maxExecutionTime < sa.MaxExecutionTime {
+				sa.MaxExecutionTime = qr.maxExecutionTime
+			}
+		case QRRowLimit:
+			if sa.RowLimit == 0 || qr.rowLimit < sa.RowLimit {
+				sa.RowLimit = qr.rowLimit
+			}
+		case QRConcurrencyLimit:
+			sa.Limiters = append(sa.Limiters, qr.limiter)
+		case QRSampleLog:
+			if rand.Float64() >= qr.sampleRate {
+				continue
+			}
+			sa.Sampled = true
+		}
+		sa.Triggered = append(sa.Triggered, TriggeredRule{Name: qr.Name, Description: qr.Description, Action: act})
+	}
+	return sa
+}
+
+// SoftActions are the soft actions of the rules that match a query. Unlike the other
+// actions, soft actions let the query run, in a restricted form.
+type SoftActions struct {
+	// MaxExecutionTime is the smallest MAX_EXECUTION_TIME of the matching rules.
+	MaxExecutionTime time.Duration
+	// RowLimit is the smallest ROW_LIMIT of the matching rules.
+	RowLimit int64
+	// Limiters are the concurrency limiters of the matching CONCURRENCY_LIMIT rules,
+	// in rule order.
+	Limiters []*ConcurrencyLimiter
+	// Sampled is true if a matching SAMPLE_LOG rule sampled the query.
+	Sampled bool
+	// Triggered are the matching rules whose soft action applies to the query.
+	Triggered []TriggeredRule
+}
+
+// TriggeredRule identifies a rule whose soft action applies to a query.
+type TriggeredRule struct {
+	Name        string
+	Description string
+	Action      Action
+}
+
+// ConcurrencyLimiter bounds the number of queries that run concurrently under a
+// CONCURRENCY_LIMIT rule. All the copies of a rule share its limiter.
+type ConcurrencyLimiter struct {
+	maxConcurrency int64
+	sem            *semaphore.Weighted
+}
+
+// NewConcurrencyLimiter creates a ConcurrencyLimiter that lets maxConcurrency queries run at once.
+func NewConcurrencyLimiter(maxConcurrency int64) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		maxConcurrency: maxConcurrency,
+		sem:            semaphore.NewWeighted(maxConcurrency),
+	}
+}
+
+// MaxConcurrency returns the number of queries that can run at once.
+func (cl *ConcurrencyLimiter) MaxConcurrency() int64 {
+	return cl.maxConcurrency
+}
+
+// Acquire waits for a slot, until ctx is done.
+func (cl *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	return cl.sem.Acquire(ctx, 1)
+}
+
+// Release returns a slot acquired with Acquire.
+func (cl *ConcurrencyLimiter) Release() {
+	cl.sem.Release(1)
+}
+
 // -----------------------------------------------
 
 // Rule represents one rule (conditions-action).
@@ -224,6 +320,12 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// Parameters of the soft actions.
+	maxExecutionTime time.Duration
+	rowLimit         int64
+	limiter          *ConcurrencyLimiter
+	sampleRate       float64
 }
 
 type namedRegexp struct {
@@ -269,6 +371,10 @@ func (qr *Rule) Equal(other *Rule) bool {
 		qr.leadingComment.Equal(other.leadingComment) &&
 		qr.trailingComment.Equal(other.trailingComment) &&
 		qr.timeout == other.timeout &&
+		qr.maxExecutionTime == other.maxExecutionTime &&
+		qr.rowLimit == other.rowLimit &&
+		qr.MaxConcurrency() == other.MaxConcurrency() &&
+		qr.sampleRate == other.sampleRate &&
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
@@ -278,16 +384,20 @@ func (qr *Rule) Equal(other *Rule) bool {
 // Copy performs a deep copy of a Rule.
 func (qr *Rule) Copy() (newqr *Rule) {
 	newqr = &Rule{
-		Description:     qr.Description,
-		Name:            qr.Name,
-		requestIP:       qr.requestIP,
-		user:            qr.user,
-		query:           qr.query,
-		leadingComment:  qr.leadingComment,
-		trailingComment: qr.trailingComment,
-		act:             qr.act,
-		cancelCtx:       qr.cancelCtx,
-		timeout:         qr.timeout,
+		Description:      qr.Description,
+		Name:             qr.Name,
+		requestIP:        qr.requestIP,
+		user:             qr.user,
+		query:            qr.query,
+		leadingComment:   qr.leadingComment,
+		trailingComment:  qr.trailingComment,
+		act:              qr.act,
+		cancelCtx:        qr.cancelCtx,
+		timeout:          qr.timeout,
+		maxExecutionTime: qr.maxExecutionTime,
+		rowLimit:         qr.rowLimit,
+		limiter:          qr.limiter,
+		sampleRate:       qr.sampleRate,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -339,10 +449,54 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.maxExecutionTime != 0 {
+		safeEncode(b, `,"MaxExecutionTime":`, qr.maxExecutionTime.Milliseconds())
+	}
+	if qr.rowLimit != 0 {
+		safeEncode(b, `,"RowLimit":`, qr.rowLimit)
+	}
+	if qr.limiter != nil {
+		safeEncode(b, `,"MaxConcurrency":`, qr.limiter.MaxConcurrency())
+	}
+	if qr.sampleRate != 0 {
+		safeEncode(b, `,"SampleRate":`, qr.sampleRate)
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
 
+// SetMaxExecutionTime sets the MAX_EXECUTION_TIME optimizer hint that a
+// MAX_EXECUTION_TIME rule adds to matching SELECT queries.
+func (qr *Rule) SetMaxExecutionTime(maxExecutionTime time.Duration) {
+	qr.maxExecutionTime = maxExecutionTime
+}
+
+// SetRowLimit sets the maximum number of rows that matching queries return
+// under a ROW_LIMIT rule.
+func (qr *Rule) SetRowLimit(rowLimit int64) {
+	qr.rowLimit = rowLimit
+}
+
+// SetMaxConcurrency sets the number of matching queries that can run at once
+// under a CONCURRENCY_LIMIT rule.
+func (qr *Rule) SetMaxConcurrency(maxConcurrency int64) {
+	qr.limiter = NewConcurrencyLimiter(maxConcurrency)
+}
+
+// MaxConcurrency returns the number of matching queries that can run at once
+// under a CONCURRENCY_LIMIT rule, or zero if there is no limit.
+func (qr *Rule) MaxConcurrency() int64 {
+	if qr.limiter == nil {
+		return 0
+	}
+	return qr.limiter.MaxConcurrency()
+}
+
+// SetSampleRate sets the fraction of matching queries that a SAMPLE_LOG rule logs.
+func (qr *Rule) SetSampleRate(sampleRate float64) {
+	qr.sampleRate = sampleRate
+}
+
 // SetIPCond adds a regular expression condition for the client IP.
 // It has to be a full match (not substring).
 func (qr *Rule) SetIPCond(pattern string) (err error) {
@@ -573,23 +727,47 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	// The following are soft actions: the query still runs, in a restricted form.
+	QRMaxExecutionTime
+	QRRowLimit
+	QRConcurrencyLimit
+	QRSampleLog
 )
 
-// MarshalJSON marshals to JSON.
-func (act Action) MarshalJSON() ([]byte, error) {
-	// If we add more actions, we'll need to use a map.
-	var str string
+// IsSoft returns true if the action lets the query run.
+func (act Action) IsSoft() bool {
+	switch act {
+	case QRMaxExecutionTime, QRRowLimit, QRConcurrencyLimit, QRSampleLog:
+		return true
+	}
+	return false
+}
+
+// String returns the name of the action in the rule JSON format.
+func (act Action) String() string {
 	switch act {
 	case QRFail:
-		str = "FAIL"
+		return "FAIL"
 	case QRFailRetry:
-		str = "FAIL_RETRY"
+		return "FAIL_RETRY"
 	case QRBuffer:
-		str = "BUFFER"
+		return "BUFFER"
+	case QRMaxExecutionTime:
+		return "MAX_EXECUTION_TIME"
+	case QRRowLimit:
+		return "ROW_LIMIT"
+	case QRConcurrencyLimit:
+		return "CONCURRENCY_LIMIT"
+	case QRSampleLog:
+		return "SAMPLE_LOG"
 	default:
-		str = "INVALID"
+		return "INVALID"
 	}
-	return json.Marshal(str)
+}
+
+// MarshalJSON marshals to JSON.
+func (act Action) MarshalJSON() ([]byte, error) {
+	return json.Marshal(act.String())
 }
 
 // BindVarCond represents a bind var condition.
@@ -874,6 +1052,7 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 	for k, v := range ruleInfo {
 		var sv string
 		var lv []any
+		var nv float64
 		var ok bool
 		switch k {
 		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
@@ -886,6 +1065,11 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "MaxExecutionTime", "RowLimit", "MaxConcurrency", "SampleRate":
+			nv, ok = getfloat64(v)
+			if !ok || nv <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive number for %s", k)
+			}
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -958,14 +1142,66 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "MAX_EXECUTION_TIME":
+				qr.act = QRMaxExecutionTime
+			case "ROW_LIMIT":
+				qr.act = QRRowLimit
+			case "CONCURRENCY_LIMIT":
+				qr.act = QRConcurrencyLimit
+			case "SAMPLE_LOG":
+				qr.act = QRSampleLog
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+		case "MaxExecutionTime":
+			qr.SetMaxExecutionTime(time.Duration(nv) * time.Millisecond)
+		case "RowLimit":
+			if nv != math.Trunc(nv) || nv < 1 || nv > math.MaxInt64 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive integer for RowLimit, got %v", nv)
+			}
+			qr.SetRowLimit(int64(nv))
+		case "MaxConcurrency":
+			if nv != math.Trunc(nv) || nv < 1 || nv > math.MaxInt64 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive integer for MaxConcurrency, got %v", nv)
+			}
+			qr.SetMaxConcurrency(int64(nv))
+		case "SampleRate":
+			if nv > 1 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want SampleRate between 0 and 1, got %v", nv)
+			}
+			qr.SetSampleRate(nv)
 		}
 	}
+	if err := validateSoftAction(qr); err != nil {
+		return nil, err
+	}
 	return qr, nil
 }
 
+// validateSoftAction checks that a rule has the parameter of its soft action,
+// and no parameter of another soft action.
+func validateSoftAction(qr *Rule) error {
+	params := []struct {
+		name string
+		act  Action
+		set  bool
+	}{
+		{"MaxExecutionTime", QRMaxExecutionTime, qr.maxExecutionTime != 0},
+		{"RowLimit", QRRowLimit, qr.rowLimit != 0},
+		{"MaxConcurrency", QRConcurrencyLimit, qr.limiter != nil},
+		{"SampleRate", QRSampleLog, qr.sampleRate != 0},
+	}
+	for _, param := range params {
+		if param.set && qr.act != param.act {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s requires Action %v", param.name, param.act)
+		}
+		if !param.set && qr.act == param.act {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Action %v requires %s", param.act, param.name)
+		}
+	}
+	return nil
+}
+
 func buildBindVarCondition(bvc any) (name string, onAbsent, onMismatch bool, op Operator, value any, err error) {
 	bvcinfo, ok := bvc.(map[string]any)
 	if !ok {
@@ -1058,6 +1294,22 @@ func buildBindVarCondition(bvc any) (name string, onAbsent, onMismatch bool, op
 	return
 }
 
+// getfloat64 converts a number decoded from the rule JSON format to a float64.
+func getfloat64(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		fv, err := v.Float64()
+		return fv, err == nil
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
 func safeEncode(b *bytes.Buffer, prefix string, v any) {
 	enc := json.NewEncoder(b)
 	_, _ = b.WriteString(prefix)
@@ -18,6 +18,7 @@ package rules
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"reflect"
 	"regexp"
@@ -733,6 +734,15 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Action": "ROW_LIMIT" }]`, "Action ROW_LIMIT requires RowLimit"},
+	{`[{"Action": "FAIL", "RowLimit": 10 }]`, "RowLimit requires Action ROW_LIMIT"},
+	{`[{"Action": "MAX_EXECUTION_TIME", "MaxExecutionTime": "1s" }]`, "want positive number for MaxExecutionTime"},
+	{`[{"Action": "CONCURRENCY_LIMIT", "MaxConcurrency": 0 }]`, "want positive number for MaxConcurrency"},
+	{`[{"Action": "CONCURRENCY_LIMIT", "MaxConcurrency": 0.5 }]`, "want positive integer for MaxConcurrency, got 0.5"},
+	{`[{"Action": "CONCURRENCY_LIMIT", "MaxConcurrency": 2.5 }]`, "want positive integer for MaxConcurrency, got 2.5"},
+	{`[{"Action": "ROW_LIMIT", "RowLimit": 0.9 }]`, "want positive integer for RowLimit, got 0.9"},
+	{`[{"Action": "ROW_LIMIT", "RowLimit": 1e30 }]`, "want positive integer for RowLimit, got 1e+30"},
+	{`[{"Action": "SAMPLE_LOG", "SampleRate": 2 }]`, "want SampleRate between 0 and 1, got 2"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -755,6 +765,82 @@ func TestInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestSoftActions(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[
+		{"Name": "r1", "Action": "MAX_EXECUTION_TIME", "MaxExecutionTime": 2000},
+		{"Name": "r2", "Action": "MAX_EXECUTION_TIME", "MaxExecutionTime": 500, "User": "u1"},
+		{"Name": "r3", "Action": "ROW_LIMIT", "RowLimit": 100},
+		{"Name": "r4", "Action": "CONCURRENCY_LIMIT", "MaxConcurrency": 2},
+		{"Name": "r5", "Action": "SAMPLE_LOG", "SampleRate": 1},
+		{"Name": "r6", "Action": "FAIL", "User": "u2"}
+	]`))
+	assert.NoError(t, err)
+
+	// Soft actions don't stop the query.
+	act, _, _, _ := qrs.GetAction("", "u1", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, act)
+	act, _, _, _ = qrs.GetAction("", "u2", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, act)
+
+	sa := qrs.GetSoftActions("", "u1", nil, sqlparser.MarginComments{})
+	assert.Equal(t, 500*time.Millisecond, sa.MaxExecutionTime)
+	assert.EqualValues(t, 100, sa.RowLimit)
+	assert.Len(t, sa.Limiters, 1)
+	assert.EqualValues(t, 2, sa.Limiters[0].MaxConcurrency())
+	assert.True(t, sa.Sampled)
+	var triggered []string
+	for _, tr := range sa.Triggered {
+		triggered = append(triggered, tr.Name+":"+tr.Action.String())
+	}
+	assert.Equal(t, []string{"r1:MAX_EXECUTION_TIME", "r2:MAX_EXECUTION_TIME", "r3:ROW_LIMIT", "r4:CONCURRENCY_LIMIT", "r5:SAMPLE_LOG"}, triggered)
+
+	sa = qrs.GetSoftActions("", "u3", nil, sqlparser.MarginComments{})
+	assert.Equal(t, 2*time.Second, sa.MaxExecutionTime)
+
+	// Copies of a rule share its concurrency limiter.
+	filtered := qrs.FilterByPlan("select * from t", planbuilder.PlanSelect, "t")
+	assert.Same(t, sa.Limiters[0], filtered.GetSoftActions("", "u3", nil, sqlparser.MarginComments{}).Limiters[0])
+	assert.True(t, qrs.Equal(qrs.Copy()))
+
+	// Rules without soft actions have none.
+	assert.Nil(t, New().GetSoftActions("", "u1", nil, sqlparser.MarginComments{}))
+
+	// Soft action parameters survive a JSON round trip.
+	b, err := json.Marshal(qrs)
+	assert.NoError(t, err)
+	qrs2 := New()
+	assert.NoError(t, qrs2.UnmarshalJSON(b))
+	assert.True(t, qrs.Equal(qrs2))
+}
+
+func TestSoftActionSampleRate(t *testing.T) {
+	qr := NewQueryRule("sample", "sample", QRSampleLog)
+	qr.SetSampleRate(0.000001)
+	qrs := New()
+	qrs.Add(qr)
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if sa := qrs.GetSoftActions("", "", nil, sqlparser.MarginComments{}); sa != nil && sa.Sampled {
+			sampled++
+		}
+	}
+	assert.Less(t, sampled, 10)
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	cl := NewConcurrencyLimiter(1)
+	assert.NoError(t, cl.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, cl.Acquire(ctx))
+
+	cl.Release()
+	assert.NoError(t, cl.Acquire(context.Background()))
+	cl.Release()
+}
+
 func TestBuildQueryRuleActionFail(t *testing.T) {
 	var ruleInfo map[string]any
 	err := json.Unmarshal([]byte(`{"Action": "FAIL" }`), &ruleInfo)
@@ -70,6 +70,7 @@ const (
 	HistoryListLengthMetricName      MetricName = "history_list_length"
 	MysqldLoadAvgMetricName          MetricName = "mysqld-loadavg"
 	MysqldDatadirUsedRatioMetricName MetricName = "mysqld-datadir-used-ratio"
+	PrometheusMetricName             MetricName = "prometheus"
 )
 
 func (metric MetricName) DefaultScope() Scope {
@@ -0,0 +1,395 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	prometheusHTTPTimeout   = 5 * time.Second
+	prometheusCacheDuration = 1 * time.Second
+)
+
+// PrometheusAggregation is the function used to aggregate all the series of a prometheus metric that
+// match the configured labels into a single value.
+type PrometheusAggregation string
+
+const (
+	PrometheusAggregationSum PrometheusAggregation = "sum"
+	PrometheusAggregationAvg PrometheusAggregation = "avg"
+	PrometheusAggregationMin PrometheusAggregation = "min"
+	PrometheusAggregationMax PrometheusAggregation = "max"
+)
+
+// PrometheusMetricConfig describes where and how to read the prometheus self metric.
+type PrometheusMetricConfig struct {
+	// URL of a local HTTP endpoint exposing metrics in the prometheus text format, e.g. node_exporter's
+	// http://localhost:9100/metrics
+	URL string
+	// Metric is the name of the prometheus metric to read, e.g. node_disk_io_time_seconds_total
+	Metric string
+	// Labels only selects the series that have all of the given label values, e.g. device=nvme0n1
+	Labels map[string]string
+	// Aggregation aggregates all selected series into a single value. Defaults to sum.
+	Aggregation PrometheusAggregation
+	// Rate, when true, makes the metric value the per-second rate of increase of the aggregated value
+	// between two consecutive reads. This is useful for counters, such as CPU or disk time.
+	Rate bool
+}
+
+// Equal returns true when both configurations read the same metric in the same way.
+func (c *PrometheusMetricConfig) Equal(other *PrometheusMetricConfig) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.URL == other.URL &&
+		c.Metric == other.Metric &&
+		maps.Equal(c.Labels, other.Labels) &&
+		c.Aggregation == other.Aggregation &&
+		c.Rate == other.Rate
+}
+
+// ParsePrometheusLabels parses a comma separated list of name=value label selectors.
+func ParsePrometheusLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, selector := range strings.Split(s, ",") {
+		selector = strings.TrimSpace(selector)
+		if selector == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(selector, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid prometheus label selector %q, expected name=value", selector)
+		}
+		labels[strings.TrimSpace(name)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return labels, nil
+}
+
+// ParsePrometheusAggregation validates the name of an aggregation. An empty name stands for sum.
+func ParsePrometheusAggregation(s string) (PrometheusAggregation, error) {
+	switch aggregation := PrometheusAggregation(strings.ToLower(s)); aggregation {
+	case "":
+		return PrometheusAggregationSum, nil
+	case PrometheusAggregationSum, PrometheusAggregationAvg, PrometheusAggregationMin, PrometheusAggregationMax:
+		return aggregation, nil
+	default:
+		return "", fmt.Errorf("unknown prometheus aggregation %q, expected one of sum, avg, min, max", s)
+	}
+}
+
+var _ SelfMetric = registerSelfMetric(&PrometheusSelfMetric{})
+
+// PrometheusSelfMetric reads a metric from a local HTTP endpoint that exposes metrics in the prometheus
+// text format. This allows the throttler to react to host signals that are not visible to MySQL, such
+// as I/O wait or disk latency reported by node_exporter.
+type PrometheusSelfMetric struct {
+	mu sync.Mutex
+	// config is the configuration the cached and last values were read with
+	config *PrometheusMetricConfig
+	// cached is the latest read metric, which is reused for prometheusCacheDuration
+	cached   *ThrottleMetric
+	cachedAt time.Time
+	// lastValue and lastValueAt are used to compute rates
+	lastValue   float64
+	lastValueAt time.Time
+}
+
+func (m *PrometheusSelfMetric) Name() MetricName {
+	return PrometheusMetricName
+}
+
+func (m *PrometheusSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *PrometheusSelfMetric) DefaultThreshold() float64 {
+	return 0
+}
+
+func (m *PrometheusSelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *PrometheusSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	metric := &ThrottleMetric{
+		Scope: SelfScope,
+	}
+	cfg := params.Throttler.GetPrometheusMetricConfig()
+	if cfg == nil || cfg.URL == "" || cfg.Metric == "" {
+		return metric
+	}
+
+	m.mu.Lock()
+	if cfg != m.config {
+		// the throttler config changed, and values read with the previous configuration do not apply
+		m.config = cfg
+		m.cached = nil
+		m.lastValue, m.lastValueAt = 0, time.Time{}
+	}
+	if m.cached != nil && time.Since(m.cachedAt) < prometheusCacheDuration {
+		cached := m.cached
+		m.mu.Unlock()
+		return cached
+	}
+	m.mu.Unlock()
+
+	// The scrape can take up to prometheusHTTPTimeout, so it happens without holding the lock.
+	value, err := readPrometheusMetric(ctx, cfg)
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cfg != m.config {
+		// the throttler config changed while reading
+		return metric.WithError(errors.New("prometheus metric configuration changed"))
+	}
+	m.cached, m.cachedAt = metric, now
+	if err != nil {
+		return metric.WithError(err)
+	}
+	if !cfg.Rate {
+		metric.Value = value
+		return metric
+	}
+
+	lastValue, lastValueAt := m.lastValue, m.lastValueAt
+	if now.Before(lastValueAt) {
+		// a concurrent read already stored a more recent sample
+		return metric.WithError(errors.New("stale prometheus sample"))
+	}
+	m.lastValue, m.lastValueAt = value, now
+	if lastValueAt.IsZero() {
+		return metric.WithError(errors.New("no previous sample to compute the rate of the prometheus metric"))
+	}
+	if value < lastValue {
+		// the counter was reset, e.g. because the exporter restarted
+		return metric.WithError(errors.New("prometheus counter reset"))
+	}
+	metric.Value = (value - lastValue) / now.Sub(lastValueAt).Seconds()
+	return metric
+}
+
+// ValidatePrometheusURL checks that the URL of the prometheus metric is an HTTP endpoint on the
+// local host. The throttler only reads host signals exposed by local exporters, and must not be
+// turned into a client for arbitrary hosts.
+func ValidatePrometheusURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid prometheus URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid prometheus URL %q: scheme must be http or https", rawURL)
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("invalid prometheus URL %q: host must be localhost or a loopback address", rawURL)
+}
+
+// prometheusHTTPClient only follows redirects to local addresses.
+var prometheusHTTPClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return ValidatePrometheusURL(req.URL.String())
+	},
+}
+
+// readPrometheusMetric scrapes the configured endpoint and returns the aggregated value of all the
+// series of the configured metric that match the configured labels.
+func readPrometheusMetric(ctx context.Context, cfg *PrometheusMetricConfig) (float64, error) {
+	if err := ValidatePrometheusURL(cfg.URL); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, prometheusHTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "text/plain")
+	resp, err := prometheusHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s reading prometheus metrics from %s", resp.Status, cfg.URL)
+	}
+	return aggregatePrometheusMetric(resp.Body, cfg)
+}
+
+func aggregatePrometheusMetric(r io.Reader, cfg *PrometheusMetricConfig) (float64, error) {
+	aggregation, err := ParsePrometheusAggregation(string(cfg.Aggregation))
+	if err != nil {
+		return 0, err
+	}
+
+	var result float64
+	var count int
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		sample, ok, err := parsePrometheusSample(scanner.Text(), cfg.Metric)
+		if err != nil {
+			return 0, err
+		}
+		if !ok || !sample.matches(cfg.Labels) {
+			continue
+		}
+		if count == 0 {
+			result = sample.value
+		} else {
+			switch aggregation {
+			case PrometheusAggregationSum, PrometheusAggregationAvg:
+				result += sample.value
+			case PrometheusAggregationMin:
+				result = math.Min(result, sample.value)
+			case PrometheusAggregationMax:
+				result = math.Max(result, sample.value)
+			}
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no series found for prometheus metric %s", cfg.Metric)
+	}
+	if aggregation == PrometheusAggregationAvg {
+		result /= float64(count)
+	}
+	return result, nil
+}
+
+type prometheusSample struct {
+	labels map[string]string
+	value  float64
+}
+
+func (s *prometheusSample) matches(labels map[string]string) bool {
+	for name, value := range labels {
+		if s.labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePrometheusSample parses a single line in the prometheus text format, which looks like:
+//
+//	metric_name{label="value",...} 1.5 [timestamp]
+//
+// It returns false for comments, empty lines and samples of other metrics.
+func parsePrometheusSample(line string, metric string) (sample prometheusSample, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return sample, false, nil
+	}
+	rest, found := strings.CutPrefix(line, metric)
+	if !found {
+		return sample, false, nil
+	}
+	if rest == "" {
+		return sample, false, fmt.Errorf("invalid prometheus sample %q: missing value", line)
+	}
+	if rest[0] != '{' && rest[0] != ' ' && rest[0] != '\t' {
+		// a different metric sharing the same prefix
+		return sample, false, nil
+	}
+
+	if rest[0] == '{' {
+		sample.labels, rest, err = parsePrometheusLabels(rest[1:])
+		if err != nil {
+			return sample, false, fmt.Errorf("invalid prometheus sample %q: %w", line, err)
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, false, fmt.Errorf("invalid prometheus sample %q: missing value", line)
+	}
+	sample.value, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, false, fmt.Errorf("invalid prometheus sample %q: %w", line, err)
+	}
+	return sample, true, nil
+}
+
+// parsePrometheusLabels parses the labels of a sample, right after the opening brace,
+// and returns the rest of the line after the closing brace.
+func parsePrometheusLabels(s string) (map[string]string, string, error) {
+	labels := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return nil, "", errors.New("unterminated labels")
+		}
+		if s[0] == '}' {
+			return labels, s[1:], nil
+		}
+
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok || len(rest) == 0 || rest[0] != '"' {
+			return nil, "", errors.New("invalid label")
+		}
+
+		var value strings.Builder
+		i := 1
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+				switch rest[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(rest[i])
+				}
+				continue
+			}
+			value.WriteByte(rest[i])
+		}
+		if i == len(rest) {
+			return nil, "", errors.New("unterminated label value")
+		}
+		labels[strings.TrimSpace(name)] = value.String()
+		s = rest[i+1:]
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrometheusMetrics = `# HELP node_disk_io_time_seconds_total Total seconds spent doing I/Os.
+# TYPE node_disk_io_time_seconds_total counter
+node_disk_io_time_seconds_total{device="nvme0n1"} 10.5
+node_disk_io_time_seconds_total{device="nvme1n1"} 4.5
+node_disk_io_time_seconds_total{device="sda",path="a \"quoted\", value"} 1 1700000000000
+node_disk_io_time_seconds_total_other{device="nvme0n1"} 100
+# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1 2.25
+`
+
+type testPrometheusPublisher struct {
+	cfg *PrometheusMetricConfig
+}
+
+func (p *testPrometheusPublisher) GetCustomMetricsQuery() string {
+	return ""
+}
+
+func (p *testPrometheusPublisher) GetPrometheusMetricConfig() *PrometheusMetricConfig {
+	return p.cfg
+}
+
+func TestAggregatePrometheusMetric(t *testing.T) {
+	tcases := []struct {
+		metric      string
+		labels      map[string]string
+		aggregation PrometheusAggregation
+		expect      float64
+		expectErr   string
+	}{
+		{metric: "node_load1", expect: 2.25},
+		{metric: "node_disk_io_time_seconds_total", expect: 16},
+		{metric: "node_disk_io_time_seconds_total", aggregation: PrometheusAggregationAvg, expect: 16.0 / 3},
+		{metric: "node_disk_io_time_seconds_total", aggregation: PrometheusAggregationMin, expect: 1},
+		{metric: "node_disk_io_time_seconds_total", aggregation: PrometheusAggregationMax, expect: 10.5},
+		{metric: "node_disk_io_time_seconds_total", labels: map[string]string{"device": "nvme1n1"}, expect: 4.5},
+		{metric: "node_disk_io_time_seconds_total", labels: map[string]string{"path": `a "quoted", value`}, expect: 1},
+		{metric: "node_disk_io_time_seconds_total", labels: map[string]string{"device": "sdb"}, expectErr: "no series found"},
+		{metric: "node_load5", expectErr: "no series found"},
+		{metric: "node_load1", aggregation: "median", expectErr: "unknown prometheus aggregation"},
+	}
+	for _, tcase := range tcases {
+		t.Run(fmt.Sprintf("%s/%v/%s", tcase.metric, tcase.labels, tcase.aggregation), func(t *testing.T) {
+			cfg := &PrometheusMetricConfig{
+				Metric:      tcase.metric,
+				Labels:      tcase.labels,
+				Aggregation: tcase.aggregation,
+			}
+			value, err := aggregatePrometheusMetric(strings.NewReader(testPrometheusMetrics), cfg)
+			if tcase.expectErr != "" {
+				assert.ErrorContains(t, err, tcase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tcase.expect, value, 0.0001)
+		})
+	}
+}
+
+func TestParsePrometheusSampleErrors(t *testing.T) {
+	for _, line := range []string{
+		`node_load1`,
+		`node_load1 abc`,
+		`node_load1{device="sda" 1`,
+		`node_load1{device=sda} 1`,
+	} {
+		t.Run(line, func(t *testing.T) {
+			_, _, err := parsePrometheusSample(line, "node_load1")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParsePrometheusLabels(t *testing.T) {
+	labels, err := ParsePrometheusLabels("device=nvme0n1, mode=\"idle\"")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"device": "nvme0n1", "mode": "idle"}, labels)
+
+	labels, err = ParsePrometheusLabels("")
+	require.NoError(t, err)
+	assert.Empty(t, labels)
+
+	_, err = ParsePrometheusLabels("device")
+	assert.Error(t, err)
+}
+
+func TestValidatePrometheusURL(t *testing.T) {
+	for _, u := range []string{
+		"http://localhost:9100/metrics",
+		"http://127.0.0.1:9100/metrics",
+		"https://[::1]:9100/metrics",
+	} {
+		assert.NoError(t, ValidatePrometheusURL(u), u)
+	}
+	for _, u := range []string{
+		"http://10.0.0.1:9100/metrics",
+		"http://example.com/metrics",
+		"file:///etc/passwd",
+		"localhost:9100/metrics",
+		"://",
+	} {
+		assert.Error(t, ValidatePrometheusURL(u), u)
+	}
+}
+
+func TestPrometheusSelfMetricRead(t *testing.T) {
+	defer func(d time.Duration) { prometheusCacheDuration = d }(prometheusCacheDuration)
+	prometheusCacheDuration = 0
+
+	var counter atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "node_cpu_seconds_total{mode=\"iowait\"} %d\n", counter.Add(10))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	t.Run("unconfigured", func(t *testing.T) {
+		m := &PrometheusSelfMetric{}
+		metric := m.Read(ctx, &SelfMetricReadParams{Throttler: &testPrometheusPublisher{}})
+		assert.NoError(t, metric.Err)
+		assert.Zero(t, metric.Value)
+	})
+	t.Run("gauge", func(t *testing.T) {
+		m := &PrometheusSelfMetric{}
+		cfg := &PrometheusMetricConfig{URL: server.URL, Metric: "node_cpu_seconds_total"}
+		metric := m.Read(ctx, &SelfMetricReadParams{Throttler: &testPrometheusPublisher{cfg: cfg}})
+		require.NoError(t, metric.Err)
+		assert.Positive(t, metric.Value)
+		assert.Equal(t, SelfScope, metric.Scope)
+	})
+	t.Run("rate", func(t *testing.T) {
+		m := &PrometheusSelfMetric{}
+		cfg := &PrometheusMetricConfig{URL: server.URL, Metric: "node_cpu_seconds_total", Rate: true}
+		params := &SelfMetricReadParams{Throttler: &testPrometheusPublisher{cfg: cfg}}
+		metric := m.Read(ctx, params)
+		assert.Error(t, metric.Err)
+
+		time.Sleep(10 * time.Millisecond)
+		metric = m.Read(ctx, params)
+		require.NoError(t, metric.Err)
+		assert.Positive(t, metric.Value)
+
+		// a new configuration does not compute the rate from a value read with the previous one
+		params.Throttler = &testPrometheusPublisher{cfg: &PrometheusMetricConfig{URL: server.URL, Metric: "node_cpu_seconds_total", Rate: true}}
+		metric = m.Read(ctx, params)
+		assert.Error(t, metric.Err)
+	})
+	t.Run("bad status", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+		m := &PrometheusSelfMetric{}
+		cfg := &PrometheusMetricConfig{URL: server.URL, Metric: "node_cpu_seconds_total"}
+		metric := m.Read(ctx, &SelfMetricReadParams{Throttler: &testPrometheusPublisher{cfg: cfg}})
+		assert.ErrorContains(t, metric.Err, "unexpected status")
+	})
+}
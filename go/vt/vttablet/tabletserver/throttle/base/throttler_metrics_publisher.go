@@ -20,4 +20,5 @@ package base
 // implementations to query the throttler.
 type metricsPublisher interface {
 	GetCustomMetricsQuery() string
+	GetPrometheusMetricConfig() *PrometheusMetricConfig
 }
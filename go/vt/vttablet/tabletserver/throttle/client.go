@@ -122,6 +122,17 @@ func (c *Client) ThrottleCheckOK(ctx context.Context, overrideAppName throttlera
 	return checkResult, true
 }
 
+// CheckMetrics checks the throttler for the given metrics and returns the full check result, including
+// the value and threshold of every metric. When no metric names are given, the metrics assigned to the
+// client's app are checked. Unlike ThrottleCheckOK, the result is never served from the cache of recent
+// successful checks, so callers are expected to call this function periodically rather than per request.
+func (c *Client) CheckMetrics(ctx context.Context, metricNames base.MetricNames) *CheckResult {
+	if c == nil || c.throttler == nil {
+		return emptyCheckResult
+	}
+	return c.throttler.Check(ctx, c.appName.String(), metricNames, &c.flags)
+}
+
 // ThrottleCheckOKOrWait checks the throttler; if throttler is satisfied, the function returns 'true' immediately,
 // otherwise it briefly sleeps and returns 'false'.
 // Non-empty appName overrides the default appName.
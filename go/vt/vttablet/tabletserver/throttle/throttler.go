@@ -45,6 +45,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/rand/v2"
 	"strings"
@@ -175,8 +176,9 @@ type Throttler struct {
 
 	inventory *base.Inventory
 
-	customMetricsQuery atomic.Value
-	MetricsThreshold   atomic.Uint64
+	customMetricsQuery     atomic.Value
+	prometheusMetricConfig atomic.Pointer[base.PrometheusMetricConfig]
+	MetricsThreshold       atomic.Uint64
 
 	metricThresholds  *cache.Cache
 	aggregatedMetrics *cache.Cache
@@ -322,6 +324,38 @@ func (throttler *Throttler) GetCustomMetricsQuery() string {
 	return val.(string)
 }
 
+// GetPrometheusMetricConfig returns the configuration of the prometheus self metric, or nil if not configured
+func (throttler *Throttler) GetPrometheusMetricConfig() *base.PrometheusMetricConfig {
+	return throttler.prometheusMetricConfig.Load()
+}
+
+// storePrometheusMetricConfig applies the prometheus metric configuration of the throttler config.
+// An invalid configuration is logged and leaves the prometheus metric unconfigured. An unchanged
+// configuration keeps the existing one, so that the metric keeps its cached value and rate state.
+func (throttler *Throttler) storePrometheusMetricConfig(prometheusMetric *topodatapb.ThrottlerConfig_PrometheusMetric) {
+	if prometheusMetric.GetUrl() == "" {
+		throttler.prometheusMetricConfig.Store(nil)
+		return
+	}
+	aggregation, err := base.ParsePrometheusAggregation(prometheusMetric.Aggregation)
+	if err != nil {
+		log.Errorf("Throttler: invalid prometheus metric config: %v", err)
+		throttler.prometheusMetricConfig.Store(nil)
+		return
+	}
+	config := &base.PrometheusMetricConfig{
+		URL:         prometheusMetric.Url,
+		Metric:      prometheusMetric.Metric,
+		Labels:      maps.Clone(prometheusMetric.Labels),
+		Aggregation: aggregation,
+		Rate:        prometheusMetric.Rate,
+	}
+	if config.Equal(throttler.prometheusMetricConfig.Load()) {
+		return
+	}
+	throttler.prometheusMetricConfig.Store(config)
+}
+
 func (throttler *Throttler) GetMetricsThreshold() float64 {
 	return math.Float64frombits(throttler.MetricsThreshold.Load())
 }
@@ -427,6 +461,7 @@ func (throttler *Throttler) convergeMetricThresholds() {
 func (throttler *Throttler) applyThrottlerConfig(ctx context.Context, throttlerConfig *topodatapb.ThrottlerConfig) {
 	log.Infof("Throttler: applying topo config: %+v", throttlerConfig)
 	throttler.customMetricsQuery.Store(throttlerConfig.CustomQuery)
+	throttler.storePrometheusMetricConfig(throttlerConfig.PrometheusMetric)
 	if throttlerConfig.Threshold > 0 || throttlerConfig.CustomQuery != "" {
 		// We do not allow Threshold=0, unless there is a custom query.
 		// Without a custom query, the theshold applies to replication lag,
@@ -625,6 +660,7 @@ func (throttler *Throttler) Open() error {
 	var ctx context.Context
 	ctx, throttler.cancelOpenContext = context.WithCancel(context.Background())
 	throttler.customMetricsQuery.Store("")
+	throttler.prometheusMetricConfig.Store(nil)
 	throttler.initConfig()
 	throttler.pool.Open(throttler.env.Config().DB.AppWithDB(), throttler.env.Config().DB.DbaWithDB(), throttler.env.Config().DB.AppDebugWithDB())
 
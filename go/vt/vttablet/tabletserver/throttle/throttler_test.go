@@ -95,6 +95,12 @@ var (
 			Value: 0.85,
 			Err:   nil,
 		},
+		base.PrometheusMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0,
+			Err:   nil,
+		},
 	}
 	replicaMetrics = map[string]*MetricResult{
 		base.LagMetricName.String(): {
@@ -125,6 +131,10 @@ var (
 			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
 			Value:        0.87,
 		},
+		base.PrometheusMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0,
+		},
 	}
 	nonPrimaryTabletType atomic.Int32
 )
@@ -531,6 +541,65 @@ func TestApplyThrottlerConfigMetricThresholds(t *testing.T) {
 	})
 }
 
+func TestApplyThrottlerConfigPrometheusMetric(t *testing.T) {
+	ctx := context.Background()
+	throttler := newTestThrottler()
+	assert.Nil(t, throttler.GetPrometheusMetricConfig())
+
+	throttler.applyThrottlerConfig(ctx, &topodatapb.ThrottlerConfig{
+		Enabled: true,
+		PrometheusMetric: &topodatapb.ThrottlerConfig_PrometheusMetric{
+			Url:         "http://localhost:9100/metrics",
+			Metric:      "node_pressure_io_waiting_seconds_total",
+			Labels:      map[string]string{"device": "nvme0n1"},
+			Aggregation: "MAX",
+			Rate:        true,
+		},
+	})
+	assert.Equal(t, &base.PrometheusMetricConfig{
+		URL:         "http://localhost:9100/metrics",
+		Metric:      "node_pressure_io_waiting_seconds_total",
+		Labels:      map[string]string{"device": "nvme0n1"},
+		Aggregation: base.PrometheusAggregationMax,
+		Rate:        true,
+	}, throttler.GetPrometheusMetricConfig())
+
+	throttler.applyThrottlerConfig(ctx, &topodatapb.ThrottlerConfig{
+		Enabled: true,
+		PrometheusMetric: &topodatapb.ThrottlerConfig_PrometheusMetric{
+			Url:         "http://localhost:9100/metrics",
+			Metric:      "node_pressure_io_waiting_seconds_total",
+			Aggregation: "median",
+		},
+	})
+	assert.Nil(t, throttler.GetPrometheusMetricConfig())
+
+	throttler.applyThrottlerConfig(ctx, &topodatapb.ThrottlerConfig{
+		Enabled: true,
+		PrometheusMetric: &topodatapb.ThrottlerConfig_PrometheusMetric{
+			Url:    "http://localhost:9100/metrics",
+			Metric: "node_pressure_io_waiting_seconds_total",
+		},
+	})
+	prometheusMetricConfig := throttler.GetPrometheusMetricConfig()
+	require.NotNil(t, prometheusMetricConfig)
+	assert.Equal(t, base.PrometheusAggregationSum, prometheusMetricConfig.Aggregation)
+
+	// re-applying the same configuration keeps the existing one
+	throttler.applyThrottlerConfig(ctx, &topodatapb.ThrottlerConfig{
+		Enabled: true,
+		PrometheusMetric: &topodatapb.ThrottlerConfig_PrometheusMetric{
+			Url:         "http://localhost:9100/metrics",
+			Metric:      "node_pressure_io_waiting_seconds_total",
+			Aggregation: "sum",
+		},
+	})
+	assert.Same(t, prometheusMetricConfig, throttler.GetPrometheusMetricConfig())
+
+	throttler.applyThrottlerConfig(ctx, &topodatapb.ThrottlerConfig{Enabled: true})
+	assert.Nil(t, throttler.GetPrometheusMetricConfig())
+}
+
 // TestApplyThrottlerConfigAppCheckedMetrics applies different metrics to the "test" app and checks the result
 func TestApplyThrottlerConfigAppCheckedMetrics(t *testing.T) {
 	ctx := context.Background() // for development, replace with	ctx := utils.LeakCheckContext(t)
@@ -2230,7 +2299,8 @@ func TestReplica(t *testing.T) {
 					case base.ThreadsRunningMetricName,
 						base.HistoryListLengthMetricName,
 						base.MysqldLoadAvgMetricName,
-						base.MysqldDatadirUsedRatioMetricName:
+						base.MysqldDatadirUsedRatioMetricName,
+						base.PrometheusMetricName:
 						assert.NoError(t, metricResult.Error, "metricName=%v, value=%v, threshold=%v", metricName, metricResult.Value, metricResult.Threshold)
 					default:
 						assert.Fail(t, "unexpected metric", "name=%v", metricName)
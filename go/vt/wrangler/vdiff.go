@@ -1433,6 +1433,10 @@ func (vc *contextVCursor) SQLMode() string {
 	return config.DefaultSQLMode
 }
 
+func (vc *contextVCursor) BlockEncryptionMode() string {
+	return config.DefaultBlockEncryptionMode
+}
+
 func (vc *contextVCursor) Environment() *vtenv.Environment {
 	return vc.env
 }
@@ -681,6 +681,25 @@ export const validateSchemaKeyspace = async ({ clusterID, keyspace }: ValidateSc
     return vtctldata.ValidateSchemaKeyspaceResponse.create(result);
 };
 
+export interface GetSchemaDriftParams {
+    clusterID: string;
+    keyspace: string;
+    referenceShard?: string;
+    includeViews?: boolean;
+}
+
+export const getSchemaDrift = async ({ clusterID, keyspace, referenceShard, includeViews }: GetSchemaDriftParams) => {
+    const req = new URLSearchParams();
+    if (referenceShard) req.append('reference_shard', referenceShard);
+    if (includeViews) req.append('include_views', 'true');
+
+    const { result } = await vtfetch(`/api/keyspace/${clusterID}/${keyspace}/schema_drift?${req}`);
+    const err = vtctldata.GetSchemaDriftResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.GetSchemaDriftResponse.create(result);
+};
+
 export interface ValidateVersionKeyspaceParams {
     clusterID: string;
     keyspace: string;
@@ -979,6 +998,46 @@ export const getTopologyPath = async (params: GetTopologyPathParams) => {
 
     return vtctldata.GetTopologyPathResponse.create(result);
 };
+
+// TopologyAuditEntry is a write to the topology recorded in the topo audit
+// log. It is not a protobuf message, so its fields are declared here.
+export interface TopologyAuditEntry {
+    time: string;
+    cell: string;
+    path: string;
+    operation: string;
+    binary: string;
+    hostname: string;
+    pid: number;
+    user?: string;
+    caller?: string;
+    old_value?: string;
+    new_value?: string;
+    diff?: string;
+    values_omitted?: boolean;
+    dropped?: number;
+}
+
+export interface GetTopologyAuditLogParams {
+    clusterID: string;
+    cell?: string;
+    path?: string;
+    limit?: number;
+}
+
+export const getTopologyAuditLog = async (params: GetTopologyAuditLogParams) => {
+    const req = new URLSearchParams();
+    if (params.cell) req.append('cell', params.cell);
+    if (params.path) req.append('path', params.path);
+    if (params.limit) req.append('limit', params.limit.toString());
+
+    return vtfetchEntities({
+        endpoint: `/api/cluster/${params.clusterID}/topology/audit?${req}`,
+        extract: (res) => res.result,
+        transform: (e) => e as TopologyAuditEntry,
+    });
+};
+
 export interface ValidateParams {
     clusterID: string;
     pingTablets: boolean;
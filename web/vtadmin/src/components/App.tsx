@@ -41,6 +41,7 @@ import { isReadOnlyMode } from '../util/env';
 import { CreateKeyspace } from './routes/createKeyspace/CreateKeyspace';
 import { Topology } from './routes/topology/Topology';
 import { ClusterTopology } from './routes/topology/ClusterTopology';
+import { ClusterTopologyAudit } from './routes/topology/ClusterTopologyAudit';
 import { CreateMoveTables } from './routes/createWorkflow/CreateMoveTables';
 import { Transactions } from './routes/Transactions';
 import { Transaction } from './routes/transaction/Transaction';
@@ -166,6 +167,10 @@ export const App = () => {
                             <Transaction />
                         </Route>
 
+                        <Route path="/topology/:clusterID/audit">
+                            <ClusterTopologyAudit />
+                        </Route>
+
                         <Route path="/topology/:clusterID">
                             <ClusterTopology />
                         </Route>
@@ -29,6 +29,7 @@ import { Tab } from '../../tabs/Tab';
 import { TabContainer } from '../../tabs/TabContainer';
 import { Advanced } from './Advanced';
 import style from './Keyspace.module.scss';
+import { KeyspaceSchemaDrift } from './KeyspaceSchemaDrift';
 import { KeyspaceShards } from './KeyspaceShards';
 import { KeyspaceVSchema } from './KeyspaceVSchema';
 import JSONViewTree from '../../jsonViewTree/JSONViewTree';
@@ -94,6 +95,7 @@ export const Keyspace = () => {
                 <TabContainer>
                     <Tab text="Shards" to={`${url}/shards`} />
                     <Tab text="VSchema" to={`${url}/vschema`} />
+                    <Tab text="Schema Drift" to={`${url}/schema_drift`} />
                     <Tab text="JSON" to={`${url}/json`} />
                     <Tab text="JSON Tree" to={`${url}/json_tree`} />
 
@@ -111,6 +113,10 @@ export const Keyspace = () => {
                         <KeyspaceVSchema clusterID={clusterID} name={name} />
                     </Route>
 
+                    <Route path={`${path}/schema_drift`}>
+                        <KeyspaceSchemaDrift clusterID={clusterID} name={name} />
+                    </Route>
+
                     <Route path={`${path}/json`}>
                         <QueryLoadingPlaceholder query={kq} />
                         <Code code={JSON.stringify(keyspace, null, 2)} />
@@ -0,0 +1,73 @@
+/**
+ * Copyright 2025 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { useSchemaDrift } from '../../../hooks/api';
+import { vtctldata } from '../../../proto/vtadmin';
+import { formatAlias } from '../../../util/tablets';
+import { Code } from '../../Code';
+import { DataCell } from '../../dataTable/DataCell';
+import { DataTable } from '../../dataTable/DataTable';
+import { QueryErrorPlaceholder } from '../../placeholders/QueryErrorPlaceholder';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+
+interface Props {
+    clusterID: string;
+    name: string;
+}
+
+export const KeyspaceSchemaDrift = ({ clusterID, name }: Props) => {
+    const query = useSchemaDrift({ clusterID, keyspace: name });
+    const { data: drift } = query;
+
+    const renderRows = (rows: vtctldata.IShardSchemaDrift[]) => {
+        return rows.map((row) => {
+            const diffs = row.diffs || [];
+            let status = 'In sync';
+            if (row.error) {
+                status = 'Unknown';
+            } else if (row.shard === drift?.reference_shard) {
+                status = 'Reference';
+            } else if (diffs.length > 0) {
+                status = `Drifted (${diffs.length} ${diffs.length === 1 ? 'diff' : 'diffs'})`;
+            }
+
+            return (
+                <tr key={row.shard} className="align-top">
+                    <DataCell>
+                        <div className="font-bold">{row.shard}</div>
+                        {row.primary_alias && (
+                            <div className="font-mono text-sm text-secondary">{formatAlias(row.primary_alias)}</div>
+                        )}
+                    </DataCell>
+                    <DataCell className="whitespace-nowrap">{status}</DataCell>
+                    <DataCell>
+                        {row.error && <div className="text-danger">{row.error}</div>}
+                        {diffs.length > 0 && <Code code={diffs.map((d) => `${d.statement};`).join('\n')} />}
+                    </DataCell>
+                </tr>
+            );
+        });
+    };
+
+    return (
+        <div>
+            <QueryLoadingPlaceholder query={query} />
+            <QueryErrorPlaceholder query={query} title="Couldn't load schema drift" />
+            {query.isSuccess && (
+                <DataTable columns={['Shard', 'Status', 'Diffs']} data={drift?.shards || []} renderRows={renderRows} />
+            )}
+        </div>
+    );
+};
@@ -112,6 +112,7 @@ export const ClusterTopology = () => {
                 </NavCrumbs>
 
                 <WorkspaceTitle className="font-mono">{clusterID}</WorkspaceTitle>
+                <Link to={`/topology/${clusterID}/audit`}>Audit log</Link>
             </WorkspaceHeader>
 
             <ContentContainer className="lg:w-[1400px] lg:h-[1200px] md:w-[900px] md:h-[800px]">
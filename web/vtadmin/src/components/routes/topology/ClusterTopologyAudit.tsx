@@ -0,0 +1,99 @@
+/**
+ * Copyright 2025 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+import { Link, useParams } from 'react-router-dom';
+
+import { TopologyAuditEntry } from '../../../api/http';
+import { useTopologyAuditLog } from '../../../hooks/api';
+import { useDocumentTitle } from '../../../hooks/useDocumentTitle';
+import { useSyncedURLParam } from '../../../hooks/useSyncedURLParam';
+import { DataCell } from '../../dataTable/DataCell';
+import { DataFilter } from '../../dataTable/DataFilter';
+import { DataTable } from '../../dataTable/DataTable';
+import { ContentContainer } from '../../layout/ContentContainer';
+import { NavCrumbs } from '../../layout/NavCrumbs';
+import { WorkspaceHeader } from '../../layout/WorkspaceHeader';
+import { WorkspaceTitle } from '../../layout/WorkspaceTitle';
+import { QueryLoadingPlaceholder } from '../../placeholders/QueryLoadingPlaceholder';
+
+interface RouteParams {
+    clusterID: string;
+}
+
+export const ClusterTopologyAudit = () => {
+    const { clusterID } = useParams<RouteParams>();
+    useDocumentTitle(`${clusterID} Topology Audit Log`);
+
+    const { value: path, updateValue: updatePath } = useSyncedURLParam('path');
+    const auditQuery = useTopologyAuditLog({ clusterID, path: path || undefined });
+    const { data: entries = [] } = auditQuery;
+
+    const renderRows = (rows: TopologyAuditEntry[]) => {
+        return rows.map((row, idx) => (
+            <tr key={`${row.time}-${row.cell}-${row.path}-${idx}`} className="align-top">
+                <DataCell className="whitespace-nowrap">{new Date(row.time).toLocaleString()}</DataCell>
+                <DataCell>
+                    <div className="font-bold">{row.operation}</div>
+                    <div className="font-mono text-sm">{row.path}</div>
+                    <div className="text-sm text-secondary">{row.cell}</div>
+                </DataCell>
+                <DataCell>
+                    <div>{row.binary}</div>
+                    <div className="text-sm text-secondary">
+                        {row.hostname} (pid {row.pid})
+                    </div>
+                    {row.user && <div className="text-sm text-secondary">user: {row.user}</div>}
+                    {row.caller && <div className="text-sm text-secondary">caller: {row.caller}</div>}
+                </DataCell>
+                <DataCell>
+                    {!!row.dropped && (
+                        <div className="text-sm text-danger">
+                            {row.dropped} entries were dropped, the audit log could not keep up with the writes
+                        </div>
+                    )}
+                    {row.values_omitted && (
+                        <div className="text-sm text-secondary">The values were too large to be stored</div>
+                    )}
+                    <pre className="text-sm whitespace-pre-wrap">{row.diff}</pre>
+                </DataCell>
+            </tr>
+        ));
+    };
+
+    return (
+        <div>
+            <WorkspaceHeader>
+                <NavCrumbs>
+                    <Link to="/topology">Topology</Link>
+                    <Link to={`/topology/${clusterID}`}>{clusterID}</Link>
+                </NavCrumbs>
+
+                <WorkspaceTitle>Audit log</WorkspaceTitle>
+            </WorkspaceHeader>
+
+            <ContentContainer>
+                <DataFilter
+                    autoFocus
+                    onChange={(e) => updatePath(e.target.value)}
+                    onClear={() => updatePath('')}
+                    placeholder="Filter by path prefix, e.g. /keyspaces/commerce/"
+                    value={path || ''}
+                />
+                <DataTable columns={['Time', 'Write', 'Writer', 'Diff']} data={entries} renderRows={renderRows} />
+                <QueryLoadingPlaceholder query={auditQuery} />
+            </ContentContainer>
+        </div>
+    );
+};
@@ -59,6 +59,8 @@ import {
     ValidateSchemaKeyspaceParams,
     ValidateVersionKeyspaceParams,
     validateVersionKeyspace,
+    GetSchemaDriftParams,
+    getSchemaDrift,
     fetchShardReplicationPositions,
     createKeyspace,
     reloadSchema,
@@ -72,6 +74,9 @@ import {
     createShard,
     GetTopologyPathParams,
     getTopologyPath,
+    GetTopologyAuditLogParams,
+    getTopologyAuditLog,
+    TopologyAuditEntry,
     validate,
     ValidateParams,
     validateShard,
@@ -422,6 +427,17 @@ export const useVSchema = (params: FetchVSchemaParams, options?: UseQueryOptions
     return useQuery(['vschema', params], () => fetchVSchema(params));
 };
 
+/**
+ * useSchemaDrift is a query hook that compares the schema of each shard in a keyspace against the
+ * canonical schema of the keyspace.
+ */
+export const useSchemaDrift = (
+    params: GetSchemaDriftParams,
+    options?: UseQueryOptions<vtctldata.GetSchemaDriftResponse, Error> | undefined
+) => {
+    return useQuery(['schema-drift', params], () => getSchemaDrift(params), options);
+};
+
 /**
  * useTransactions is a query hook that fetches unresolved transactions for the given keyspace.
  */
@@ -739,6 +755,16 @@ export const useTopologyPath = (
 ) => {
     return useQuery(['topology-path', params], () => getTopologyPath(params), options);
 };
+/**
+ * useTopologyAuditLog is a query hook that fetches the most recent writes to the topology server
+ * recorded in the topo audit log.
+ */
+export const useTopologyAuditLog = (
+    params: GetTopologyAuditLogParams,
+    options?: UseQueryOptions<TopologyAuditEntry[], Error> | undefined
+) => {
+    return useQuery(['topology-audit-log', params], () => getTopologyAuditLog(params), options);
+};
 /**
  * useValidate is a mutate hook that validates that all nodes reachable from the global replication graph,
  * as well as all tablets in discoverable cells, are consistent.